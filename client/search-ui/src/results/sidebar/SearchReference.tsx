@@ -246,6 +246,9 @@ To use this filter, the search query must contain \`type:diff\` or \`type:commit
 - \`select:repo\`
 - \`select:commit.diff.added\`
 - \`select:commit.diff.removed\`
+- \`select:commit.author\`
+- \`select:commit.message\`
+- \`select:commit.files\`
 - \`select:file\`
 - \`select:file.directory\`
 - \`select:file.path\`
@@ -384,6 +384,7 @@ describe('getCompletionItems()', () => {
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
               "has.tag(\${1}) ",
+              "has.language(\${1:go}) ",
               "has(\${1:key}:\${2:value}) ",
               "^repo/with\\\\ a\\\\ space$ "
             ]
@@ -408,6 +409,7 @@ describe('getCompletionItems()', () => {
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
               "has.tag(\${1}) ",
+              "has.language(\${1:go}) ",
               "has(\${1:key}:\${2:value}) "
             ]
         `)
@@ -174,6 +174,8 @@ const toPredicateHover = (token: MetaPredicate): string => {
             return '**Built-in predicate**. Search only inside repositories that have a **description** matching the given regular expression'
         case 'has.tag':
             return '**Built-in predicate**. Search only inside repositories that are tagged with the given tag'
+        case 'has.language':
+            return '**Built-in predicate**. Search only inside repositories that contain code written in the given **language**, optionally making up more than a share of the code like `>50%`'
         case 'has':
             return '**Built-in predicate**. Search only inside repositories that are associated with the given key:value pair'
     }
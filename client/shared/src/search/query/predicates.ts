@@ -39,6 +39,7 @@ export const PREDICATES: Access[] = [
                     },
                     { name: 'description' },
                     { name: 'tag' },
+                    { name: 'language' },
                 ],
             },
         ],
@@ -52,7 +53,7 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }],
+                fields: [{ name: 'content' }, { name: 'owner' }, { name: 'author' }],
             },
         ],
     },
@@ -203,6 +204,11 @@ export const predicateCompletion = (field: string): Completion[] => {
                 insertText: 'has.tag(${1})',
                 asSnippet: true,
             },
+            {
+                label: 'has.language(...)',
+                insertText: 'has.language(${1:go})',
+                asSnippet: true,
+            },
             {
                 label: 'has(...)',
                 insertText: 'has(${1:key}:${2:value})',
@@ -54,9 +54,12 @@ describe('selectorCompletion', () => {
     test('suggest depth 2 commit.diff completions', () => {
         expect(selectorCompletion(create('commit.diff.'))).toMatchInlineSnapshot(`
             commit,
+            commit.author,
             commit.diff,
             commit.diff.added,
-            commit.diff.removed
+            commit.diff.removed,
+            commit.files,
+            commit.message
         `)
     })
 })
@@ -11,7 +11,7 @@ export const SELECTORS: Access[] = [
     },
     {
         name: 'file',
-        fields: [{ name: 'directory' }, { name: 'path' }],
+        fields: [{ name: 'directory' }, { name: 'path' }, { name: 'owners' }],
     },
     {
         name: 'content',
@@ -49,7 +49,12 @@ export const SELECTORS: Access[] = [
     },
     {
         name: 'commit',
-        fields: [{ name: 'diff', fields: [{ name: 'added' }, { name: 'removed' }] }],
+        fields: [
+            { name: 'author' },
+            { name: 'diff', fields: [{ name: 'added' }, { name: 'removed' }] },
+            { name: 'files' },
+            { name: 'message' },
+        ],
     },
 ]
 
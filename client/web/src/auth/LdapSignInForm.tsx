@@ -0,0 +1,126 @@
+import React, { useCallback, useState } from 'react'
+
+import classNames from 'classnames'
+import { useLocation } from 'react-router-dom-v5-compat'
+
+import { Form } from '@sourcegraph/branded/src/components/Form'
+import { asError, logger } from '@sourcegraph/common'
+import { Label, Button, LoadingSpinner, Text, Input } from '@sourcegraph/wildcard'
+
+import { AuthProvider, SourcegraphContext } from '../jscontext'
+import { eventLogger } from '../tracking/eventLogger'
+
+import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
+
+interface Props {
+    provider: AuthProvider
+    onAuthError: (error: Error | null) => void
+    className?: string
+    context: Pick<SourcegraphContext, 'xhrHeaders'>
+}
+
+/**
+ * The form for signing in with the username and password of an LDAP directory account.
+ */
+export const LdapSignInForm: React.FunctionComponent<React.PropsWithChildren<Props>> = ({
+    provider,
+    onAuthError,
+    className,
+    context,
+}) => {
+    const location = useLocation()
+    const [username, setUsername] = useState('')
+    const [password, setPassword] = useState('')
+    const [loading, setLoading] = useState(false)
+
+    const onUsernameFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setUsername(event.target.value)
+    }, [])
+
+    const onPasswordFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
+        setPassword(event.target.value)
+    }, [])
+
+    const handleSubmit = useCallback(
+        (event: React.FormEvent<HTMLFormElement>): void => {
+            event.preventDefault()
+            if (loading) {
+                return
+            }
+
+            setLoading(true)
+            eventLogger.log('InitiateSignIn')
+            fetch(provider.authenticationURL, {
+                credentials: 'same-origin',
+                method: 'POST',
+                headers: {
+                    ...context.xhrHeaders,
+                    Accept: 'application/json',
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({ username, password }),
+            })
+                .then(response => {
+                    if (response.status === 200) {
+                        if (new URLSearchParams(location.search).get('close') === 'true') {
+                            window.close()
+                        } else {
+                            const returnTo = getReturnTo(location)
+                            window.location.replace(returnTo)
+                        }
+                    } else if (response.status === 401) {
+                        throw new Error('User or password was incorrect')
+                    } else if (response.status === 422) {
+                        throw new Error('The account has been locked out')
+                    } else {
+                        throw new Error('Unknown Error')
+                    }
+                })
+                .catch(error => {
+                    logger.error('Auth error:', error)
+                    setLoading(false)
+                    onAuthError(asError(error))
+                })
+        },
+        [provider, username, loading, location, password, onAuthError, context]
+    )
+
+    // The ID makes the inputs unique when there are multiple LDAP providers or a builtin provider.
+    const id = `ldap-${provider.serviceID}-${provider.displayName}`.replace(/\W+/g, '-')
+
+    return (
+        <Form onSubmit={handleSubmit} className={classNames('test-ldap-signin-form', className)}>
+            <Text weight="medium">Sign in with {provider.displayName}</Text>
+            <Input
+                id={`${id}-username`}
+                label={<Text alignment="left">Username</Text>}
+                onChange={onUsernameFieldChange}
+                required={true}
+                value={username}
+                disabled={loading}
+                autoCapitalize="off"
+                className="form-group"
+                autoComplete="username"
+            />
+
+            <div className="form-group d-flex flex-column align-content-start">
+                <Label htmlFor={`${id}-password`} className="align-self-start">
+                    Password
+                </Label>
+                <PasswordInput
+                    id={`${id}-password`}
+                    onChange={onPasswordFieldChange}
+                    value={password}
+                    required={true}
+                    disabled={loading}
+                    autoComplete="current-password"
+                    placeholder=" "
+                />
+            </div>
+
+            <Button display="block" type="submit" disabled={loading} variant="primary">
+                {loading ? <LoadingSpinner /> : 'Sign in'}
+            </Button>
+        </Form>
+    )
+}
@@ -140,6 +140,43 @@ describe('SignInPage', () => {
         ).toMatchSnapshot()
     })
 
+    it('renders sign in form for LDAP providers', () => {
+        const rendered = renderWithBrandedContext(
+            <Routes>
+                <Route
+                    path="/sign-in"
+                    element={
+                        <SignInPage
+                            authenticatedUser={null}
+                            context={{
+                                allowSignup: true,
+                                sourcegraphDotComMode: false,
+                                authProviders: [
+                                    ...authProviders,
+                                    {
+                                        serviceType: 'ldap',
+                                        displayName: 'Example LDAP',
+                                        isBuiltin: false,
+                                        authenticationURL: '/.auth/ldap/login?pc=f00bar',
+                                        serviceID: 'ldaps://ldap.example.com',
+                                    },
+                                ],
+                                resetPasswordEnabled: true,
+                                xhrHeaders: {},
+                                experimentalFeatures: {},
+                            }}
+                        />
+                    }
+                />
+            </Routes>,
+            { route: '/sign-in' }
+        )
+
+        expect(within(rendered.baseElement).getByText('Sign in with Example LDAP')).toBeInTheDocument()
+        expect(within(rendered.baseElement).queryByText('Continue with Example LDAP')).not.toBeInTheDocument()
+        expect(within(rendered.baseElement).getByText('Continue with GitHub')).toBeInTheDocument()
+    })
+
     it('renders redirect when user is authenticated', () => {
         // eslint-disable-next-line @typescript-eslint/consistent-type-assertions
         const mockUser = {
@@ -15,6 +15,7 @@ import { AuthProvider, SourcegraphContext } from '../jscontext'
 import { eventLogger } from '../tracking/eventLogger'
 
 import { SourcegraphIcon } from './icons'
+import { LdapSignInForm } from './LdapSignInForm'
 import { OrDivider } from './OrDivider'
 import { getReturnTo } from './SignInSignUpCommon'
 import { UsernamePasswordSignInForm } from './UsernamePasswordSignInForm'
@@ -62,6 +63,13 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
         provider => showSourcegraphOperatorLogin || !isSourcegraphOperatorProvider(provider)
     )
 
+    // LDAP providers are signed in with a username and password, so they get a form instead of a
+    // link to the provider.
+    const [ldapAuthProviders, redirectAuthProviders] = partition(
+        thirdPartyAuthProviders,
+        provider => provider.serviceType === 'ldap'
+    )
+
     const body =
         !builtInAuthProvider && thirdPartyAuthProviders.length === 0 ? (
             <Alert className="mt-3" variant="info">
@@ -84,8 +92,24 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                             noThirdPartyProviders={thirdPartyAuthProviders.length === 0}
                         />
                     )}
-                    {builtInAuthProvider && thirdPartyAuthProviders.length > 0 && <OrDivider className="mb-3 py-1" />}
-                    {thirdPartyAuthProviders.map((provider, index) => (
+                    {ldapAuthProviders.map((provider, index) => (
+                        // Use index as key because display name may not be unique. This is OK
+                        // here because this list will not be updated during this component's lifetime.
+                        /* eslint-disable react/no-array-index-key */
+                        <React.Fragment key={index}>
+                            {(builtInAuthProvider || index > 0) && <OrDivider className="mb-3 py-1" />}
+                            <LdapSignInForm
+                                {...props}
+                                provider={provider}
+                                onAuthError={setError}
+                                className={classNames({ 'mb-3': redirectAuthProviders.length > 0 })}
+                            />
+                        </React.Fragment>
+                    ))}
+                    {(builtInAuthProvider || ldapAuthProviders.length > 0) && redirectAuthProviders.length > 0 && (
+                        <OrDivider className="mb-3 py-1" />
+                    )}
+                    {redirectAuthProviders.map((provider, index) => (
                         // Use index as key because display name may not be unique. This is OK
                         // here because this list will not be updated during this component's lifetime.
                         /* eslint-disable react/no-array-index-key */
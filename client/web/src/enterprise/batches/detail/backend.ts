@@ -44,6 +44,8 @@ import {
     CloseChangesetsVariables,
     PublishChangesetsResult,
     PublishChangesetsVariables,
+    AssignChangesetsResult,
+    AssignChangesetsVariables,
     AvailableBulkOperationsVariables,
     AvailableBulkOperationsResult,
     BulkOperationType,
@@ -820,6 +822,38 @@ export async function publishChangesets(
     dataOrThrowErrors(result)
 }
 
+export async function assignChangesets(
+    batchChange: Scalars['ID'],
+    changesets: Scalars['ID'][],
+    reviewers: string[],
+    assignees: string[],
+    labels: string[]
+): Promise<void> {
+    const result = await requestGraphQL<AssignChangesetsResult, AssignChangesetsVariables>(
+        gql`
+            mutation AssignChangesets(
+                $batchChange: ID!
+                $changesets: [ID!]!
+                $reviewers: [String!]!
+                $assignees: [String!]!
+                $labels: [String!]!
+            ) {
+                assignChangesets(
+                    batchChange: $batchChange
+                    changesets: $changesets
+                    reviewers: $reviewers
+                    assignees: $assignees
+                    labels: $labels
+                ) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets, reviewers, assignees, labels }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
 export const BULK_OPERATIONS = gql`
     query BatchChangeBulkOperations($batchChange: ID!, $first: Int, $after: String) {
         node(id: $batchChange) {
@@ -0,0 +1,36 @@
+import { action } from '@storybook/addon-actions'
+import { Meta, Story, DecoratorFn } from '@storybook/react'
+import { noop } from 'lodash'
+
+import { WebStory } from '../../../../components/WebStory'
+
+import { AssignChangesetsModal } from './AssignChangesetsModal'
+
+const decorator: DecoratorFn = story => <div className="p-3 container">{story()}</div>
+
+const config: Meta = {
+    title: 'web/batches/details/AssignChangesetsModal',
+    decorators: [decorator],
+}
+
+export default config
+
+const assignChangesetsAction = () => {
+    action('AssignChangesets')
+    return Promise.resolve()
+}
+
+export const Confirmation: Story = () => (
+    <WebStory>
+        {props => (
+            <AssignChangesetsModal
+                {...props}
+                afterCreate={noop}
+                batchChangeID="test-123"
+                changesetIDs={['test-123', 'test-234']}
+                onCancel={noop}
+                assignChangesets={assignChangesetsAction}
+            />
+        )}
+    </WebStory>
+)
@@ -0,0 +1,133 @@
+import React, { useCallback, useState } from 'react'
+
+import { ErrorAlert } from '@sourcegraph/branded/src/components/alerts'
+import { Form } from '@sourcegraph/branded/src/components/Form'
+import { asError, isErrorLike } from '@sourcegraph/common'
+import { Button, Input, Modal, H3, Text } from '@sourcegraph/wildcard'
+
+import { LoaderButton } from '../../../../components/LoaderButton'
+import { Scalars } from '../../../../graphql-operations'
+import { assignChangesets as _assignChangesets } from '../backend'
+
+export interface AssignChangesetsModalProps {
+    onCancel: () => void
+    afterCreate: () => void
+    batchChangeID: Scalars['ID']
+    changesetIDs: Scalars['ID'][]
+
+    /** For testing only. */
+    assignChangesets?: typeof _assignChangesets
+}
+
+export const AssignChangesetsModal: React.FunctionComponent<React.PropsWithChildren<AssignChangesetsModalProps>> = ({
+    onCancel,
+    afterCreate,
+    batchChangeID,
+    changesetIDs,
+    assignChangesets = _assignChangesets,
+}) => {
+    const [isLoading, setIsLoading] = useState<boolean | Error>(false)
+    const [reviewers, setReviewers] = useState<string>('')
+    const [assignees, setAssignees] = useState<string>('')
+    const [labels, setLabels] = useState<string>('')
+
+    const onChangeReviewers = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setReviewers(event.target.value)
+    }, [])
+    const onChangeAssignees = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setAssignees(event.target.value)
+    }, [])
+    const onChangeLabels = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setLabels(event.target.value)
+    }, [])
+
+    const isEmpty = [reviewers, assignees, labels].every(value => splitList(value).length === 0)
+
+    const onSubmit = useCallback<React.FormEventHandler>(
+        async event => {
+            event.preventDefault()
+            setIsLoading(true)
+            try {
+                await assignChangesets(
+                    batchChangeID,
+                    changesetIDs,
+                    splitList(reviewers),
+                    splitList(assignees),
+                    splitList(labels)
+                )
+                afterCreate()
+            } catch (error) {
+                setIsLoading(asError(error))
+            }
+        },
+        [afterCreate, assignChangesets, assignees, batchChangeID, changesetIDs, labels, reviewers]
+    )
+
+    return (
+        <Modal onDismiss={onCancel} aria-labelledby={LABEL_ID}>
+            <H3 id={LABEL_ID}>Add reviewers, assignees and labels to changesets</H3>
+            <Text className="mb-4">
+                Separate multiple usernames or labels with commas. Existing reviewers, assignees and labels are kept,
+                and the ones not supported by a code host are ignored.
+            </Text>
+            {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
+            <Form onSubmit={onSubmit}>
+                <div className="form-group">
+                    <Input
+                        id="reviewers"
+                        name="reviewers"
+                        className="mb-2"
+                        spellCheck="false"
+                        value={reviewers}
+                        onChange={onChangeReviewers}
+                        label="Reviewers"
+                    />
+                    <Input
+                        id="assignees"
+                        name="assignees"
+                        className="mb-2"
+                        spellCheck="false"
+                        value={assignees}
+                        onChange={onChangeAssignees}
+                        label="Assignees"
+                    />
+                    <Input
+                        id="labels"
+                        name="labels"
+                        spellCheck="false"
+                        value={labels}
+                        onChange={onChangeLabels}
+                        label="Labels"
+                    />
+                </div>
+                <div className="d-flex justify-content-end">
+                    <Button
+                        disabled={isLoading === true}
+                        className="mr-2"
+                        onClick={onCancel}
+                        outline={true}
+                        variant="secondary"
+                    >
+                        Cancel
+                    </Button>
+                    <LoaderButton
+                        type="submit"
+                        disabled={isLoading === true || isEmpty}
+                        variant="primary"
+                        loading={isLoading === true}
+                        alwaysShowLabel={true}
+                        label="Assign"
+                    />
+                </div>
+            </Form>
+        </Modal>
+    )
+}
+
+const LABEL_ID = 'assign-changesets-modal-id'
+
+const splitList = (value: string): string[] =>
+    value
+        .split(',')
+        .map(item => item.trim())
+        .filter(item => item !== '')
@@ -15,6 +15,7 @@ import {
     queryAvailableBulkOperations as _queryAvailableBulkOperations,
 } from '../backend'
 
+import { AssignChangesetsModal } from './AssignChangesetsModal'
 import { CloseChangesetsModal } from './CloseChangesetsModal'
 import { CreateCommentModal } from './CreateCommentModal'
 import { DetachChangesetsModal } from './DetachChangesetsModal'
@@ -43,6 +44,24 @@ interface ChangesetListAction extends Omit<Action, 'onTrigger'> {
  * Ensure the order (alphabetical) is preserved when adding a new bulk action.
  */
 const AVAILABLE_ACTIONS: Record<BulkOperationType, ChangesetListAction> = {
+    [BulkOperationType.ASSIGN]: {
+        type: 'assign',
+        buttonLabel: 'Assign changesets',
+        dropdownTitle: 'Assign changesets',
+        dropdownDescription:
+            'Request reviews from, assign and label all selected changesets on the code hosts that support it.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_assign:clicked')
+            return (
+                <AssignChangesetsModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                />
+            )
+        },
+    },
     [BulkOperationType.CLOSE]: {
         type: 'close',
         buttonLabel: 'Close changesets',
@@ -88,6 +88,14 @@ describe('FormTriggerArea', () => {
             repoChecked: false,
             validChecked: true,
         },
+        {
+            query: 'test type:file',
+            isSourcegraphDotCom: true,
+            patternTypeChecked: true,
+            typeChecked: true,
+            repoChecked: false,
+            validChecked: true,
+        },
         {
             query: 'test repo:test',
             isSourcegraphDotCom: true,
@@ -196,4 +204,24 @@ describe('FormTriggerArea', () => {
 
         sinon.assert.calledOnceWithExactly(onQueryChange, 'test patternType:regexp type:diff repo:test')
     })
+
+    test('Allow type:file queries to monitor file contents', () => {
+        const onQueryChange = sinon.spy()
+        renderWithBrandedContext(
+            <FormTriggerArea
+                query="test type:file repo:test"
+                triggerCompleted={false}
+                onQueryChange={onQueryChange}
+                setTriggerCompleted={sinon.spy()}
+                startExpanded={false}
+                isLightTheme={true}
+                isSourcegraphDotCom={false}
+            />
+        )
+        userEvent.click(screen.getByTestId('trigger-button'))
+        expect(screen.getByTestId('submit-trigger')).toBeEnabled()
+        userEvent.click(screen.getByTestId('submit-trigger'))
+
+        sinon.assert.calledOnceWithExactly(onQueryChange, 'test type:file repo:test patternType:literal')
+    })
 })
@@ -29,7 +29,8 @@ interface TriggerAreaProps extends ThemeProps {
     isSourcegraphDotCom: boolean
 }
 
-const isDiffOrCommit = (value: string): boolean => value === 'diff' || value === 'commit'
+// type:diff and type:commit monitors fire on new commits, type:file monitors fire on newly matching file contents.
+const isSupportedType = (value: string): boolean => value === 'diff' || value === 'commit' || value === 'file'
 const isLiteralOrRegexp = (value: string): boolean => value === 'literal' || value === 'regexp'
 
 const ValidQueryChecklistItem: React.FunctionComponent<
@@ -112,17 +113,17 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
     }, [])
 
     const [isValidQuery, setIsValidQuery] = useState(false)
-    const [hasTypeDiffOrCommitFilter, setHasTypeDiffOrCommitFilter] = useState(false)
+    const [hasSupportedTypeFilter, setHasSupportedTypeFilter] = useState(false)
     const [hasRepoFilter, setHasRepoFilter] = useState(false)
     const [hasPatternTypeFilter, setHasPatternTypeFilter] = useState(false)
     const [hasValidPatternTypeFilter, setHasValidPatternTypeFilter] = useState(true)
     const isTriggerQueryComplete = useMemo(
         () =>
             isValidQuery &&
-            hasTypeDiffOrCommitFilter &&
+            hasSupportedTypeFilter &&
             (!isSourcegraphDotCom || hasRepoFilter) &&
             hasValidPatternTypeFilter,
-        [hasRepoFilter, hasTypeDiffOrCommitFilter, hasValidPatternTypeFilter, isValidQuery, isSourcegraphDotCom]
+        [hasRepoFilter, hasSupportedTypeFilter, hasValidPatternTypeFilter, isValidQuery, isSourcegraphDotCom]
     )
 
     const [queryState, setQueryState] = useState<QueryState>({ query: query || '' })
@@ -138,19 +139,19 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
         const isValidQuery = !!value && tokens.type === 'success'
         setIsValidQuery(isValidQuery)
 
-        let hasTypeDiffOrCommitFilter = false
+        let hasSupportedTypeFilter = false
         let hasRepoFilter = false
         let hasPatternTypeFilter = false
         let hasValidPatternTypeFilter = true
 
         if (tokens.type === 'success') {
             const filters = tokens.term.filter(token => token.type === 'filter')
-            hasTypeDiffOrCommitFilter = filters.some(
+            hasSupportedTypeFilter = filters.some(
                 filter =>
                     filter.type === 'filter' &&
                     resolveFilter(filter.field.value)?.type === FilterType.type &&
                     filter.value &&
-                    isDiffOrCommit(filter.value.value)
+                    isSupportedType(filter.value.value)
             )
 
             hasRepoFilter = filters.some(
@@ -181,7 +182,7 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                 )
         }
 
-        setHasTypeDiffOrCommitFilter(hasTypeDiffOrCommitFilter)
+        setHasSupportedTypeFilter(hasSupportedTypeFilter)
         setHasRepoFilter(hasRepoFilter)
         setHasPatternTypeFilter(hasPatternTypeFilter)
         setHasValidPatternTypeFilter(hasValidPatternTypeFilter)
@@ -284,11 +285,12 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                             </li>
                             <li>
                                 <ValidQueryChecklistItem
-                                    checked={hasTypeDiffOrCommitFilter}
-                                    hint="type:diff targets code present in new commits, while type:commit targets commit messages"
+                                    checked={hasSupportedTypeFilter}
+                                    hint="type:diff targets code present in new commits, type:commit targets commit messages, and type:file targets files whose contents newly match"
                                     dataTestid="type-checkbox"
                                 >
-                                    Contains a <Code>type:diff</Code> or <Code>type:commit</Code> filter
+                                    Contains a <Code>type:diff</Code>, <Code>type:commit</Code> or{' '}
+                                    <Code>type:file</Code> filter
                                 </ValidQueryChecklistItem>
                             </li>
                             {/* Enforce repo filter on sourcegraph.com because otherwise it's too easy to generate a lot of load */}
@@ -57,7 +57,7 @@ export const CreateActionsMenu: React.FunctionComponent<CreateActionsMenuProps>
                         <Tooltip
                             content={
                                 authenticatedUser && !canCreateMonitor
-                                    ? 'Code monitors only support type:diff or type:commit searches.'
+                                    ? 'Code monitors only support type:diff, type:commit or type:file searches.'
                                     : undefined
                             }
                         >
@@ -83,10 +83,10 @@ export const SearchResultsInfoBar: React.FunctionComponent<
     )
 
     const canCreateMonitorFromQuery = useMemo(() => {
-        if (globalTypeFilter) {
+        if (!globalTypeFilter) {
             return false
         }
-        return globalTypeFilter === 'diff' || globalTypeFilter === 'commit'
+        return globalTypeFilter === 'diff' || globalTypeFilter === 'commit' || globalTypeFilter === 'file'
     }, [globalTypeFilter])
 
     const canCreateBatchChangeFromQuery = useMemo(() => {
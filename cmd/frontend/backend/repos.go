@@ -15,7 +15,6 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/api"
-	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -231,25 +230,21 @@ func (s *repos) GetInventory(ctx context.Context, repo *types.Repo, commitID api
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
 	defer cancel()
 
-	invCtx, err := InventoryContext(s.logger, repo.Name, s.gitserverClient, commitID, forceEnhancedLanguageDetection)
-	if err != nil {
-		return nil, err
-	}
+	return inventory.ComputeInventory(ctx, s.logger, s.gitserverClient, repo.Name, commitID, forceEnhancedLanguageDetection)
+}
 
-	root, err := s.gitserverClient.Stat(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, commitID, "")
-	if err != nil {
-		return nil, err
+// GetCachedInventory returns the inventory of the repository at the given commit if it has already
+// been computed and cached, and false otherwise. Unlike GetInventory, it never reads the contents
+// of the repository.
+func (s *repos) GetCachedInventory(ctx context.Context, repo *types.Repo, commitID api.CommitID) (res *inventory.Inventory, ok bool, err error) {
+	if Mocks.Repos.GetCachedInventory != nil {
+		return Mocks.Repos.GetCachedInventory(ctx, repo, commitID)
 	}
 
-	// In computing the inventory, sub-tree inventories are cached based on the OID of the Git
-	// tree. Compared to per-blob caching, this creates many fewer cache entries, which means fewer
-	// stores, fewer lookups, and less cache storage overhead. Compared to per-commit caching, this
-	// yields a higher cache hit rate because most trees are unchanged across commits.
-	inv, err := invCtx.Entries(ctx, root)
-	if err != nil {
-		return nil, err
-	}
-	return &inv, nil
+	ctx, done := trace(ctx, "Repos", "GetCachedInventory", map[string]any{"repo": repo.Name, "commitID": commitID}, &err)
+	defer done()
+
+	return inventory.CachedInventory(ctx, s.logger, s.gitserverClient, repo.Name, commitID)
 }
 
 func (s *repos) DeleteRepositoryFromDisk(ctx context.Context, repoID api.RepoID) (err error) {
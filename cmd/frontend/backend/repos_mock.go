@@ -19,6 +19,7 @@ type MockRepos struct {
 	List                     func(v0 context.Context, v1 database.ReposListOptions) ([]*types.Repo, error)
 	ResolveRev               func(v0 context.Context, repo *types.Repo, rev string) (api.CommitID, error)
 	GetInventory             func(v0 context.Context, repo *types.Repo, commitID api.CommitID) (*inventory.Inventory, error)
+	GetCachedInventory       func(v0 context.Context, repo *types.Repo, commitID api.CommitID) (*inventory.Inventory, bool, error)
 	DeleteRepositoryFromDisk func(v0 context.Context, name api.RepoID) error
 }
 
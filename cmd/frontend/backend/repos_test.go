@@ -225,9 +225,15 @@ func TestReposGetInventory(t *testing.T) {
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("useEnhancedLanguageDetection=%v", test.useEnhancedLanguageDetection), func(t *testing.T) {
 			rcache.SetupForTest(t)
-			orig := useEnhancedLanguageDetection
-			useEnhancedLanguageDetection = test.useEnhancedLanguageDetection
-			defer func() { useEnhancedLanguageDetection = orig }() // reset
+			orig := inventory.UseEnhancedLanguageDetection
+			inventory.UseEnhancedLanguageDetection = test.useEnhancedLanguageDetection
+			defer func() { inventory.UseEnhancedLanguageDetection = orig }() // reset
+
+			if _, ok, err := s.GetCachedInventory(ctx, &types.Repo{Name: wantRepo}, wantCommitID); err != nil {
+				t.Fatal(err)
+			} else if ok {
+				t.Fatal("expected no cached inventory before computing it")
+			}
 
 			inv, err := s.GetInventory(ctx, &types.Repo{Name: wantRepo}, wantCommitID, false)
 			if err != nil {
@@ -236,6 +242,17 @@ func TestReposGetInventory(t *testing.T) {
 			if diff := cmp.Diff(test.want, inv); diff != "" {
 				t.Error(diff)
 			}
+
+			cached, ok, err := s.GetCachedInventory(ctx, &types.Repo{Name: wantRepo}, wantCommitID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("expected cached inventory after computing it")
+			}
+			if diff := cmp.Diff(test.want, cached); diff != "" {
+				t.Error(diff)
+			}
 		})
 	}
 }
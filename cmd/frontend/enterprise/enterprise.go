@@ -33,6 +33,7 @@ type Services struct {
 	NewExecutorProxyHandler     NewExecutorProxyHandler
 	NewGitHubAppSetupHandler    NewGitHubAppSetupHandler
 	NewComputeStreamHandler     NewComputeStreamHandler
+	NewSCIMHandler              NewSCIMHandler
 	AuthzResolver               graphqlbackend.AuthzResolver
 	BatchChangesResolver        graphqlbackend.BatchChangesResolver
 	CodeIntelResolver           graphqlbackend.CodeIntelResolver
@@ -70,6 +71,10 @@ type NewGitHubAppSetupHandler func() http.Handler
 // NewComputeStreamHandler creates a new handler for the Sourcegraph Compute streaming endpoint.
 type NewComputeStreamHandler func() http.Handler
 
+// NewSCIMHandler creates a new handler for the SCIM 2.0 user and group provisioning API. This
+// handler is protected via a bearer token configured in the site configuration.
+type NewSCIMHandler func() http.Handler
+
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
@@ -86,6 +91,7 @@ func DefaultServices() Services {
 		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
 		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		NewSCIMHandler:                  func() http.Handler { return makeNotFoundHandler("SCIM provisioning") },
 	}
 }
 
@@ -229,6 +229,13 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type AssignChangesetsArgs struct {
+	BulkOperationBaseArgs
+	Reviewers []string
+	Assignees []string
+	Labels    []string
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
@@ -279,6 +286,7 @@ type BatchChangesResolver interface {
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	AssignChangesets(ctx context.Context, args *AssignChangesetsArgs) (BulkOperationResolver, error)
 
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
@@ -788,6 +796,7 @@ type ExternalChangesetResolver interface {
 
 	Error() *string
 	SyncerError() *string
+	AutoMergeBlockedReason(ctx context.Context) (*string, error)
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
@@ -0,0 +1,36 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// CodeOwnerSearchResultResolver is a resolver for the GraphQL type `CodeOwnerSearchResult`
+type CodeOwnerSearchResultResolver struct {
+	result.OwnerMatch
+
+	RepoResolver *RepositoryResolver
+}
+
+func (r *CodeOwnerSearchResultResolver) Handle() string {
+	return r.OwnerMatch.Handle
+}
+
+func (r *CodeOwnerSearchResultResolver) Repository() *RepositoryResolver {
+	return r.RepoResolver
+}
+
+func (r *CodeOwnerSearchResultResolver) URL() string {
+	return r.OwnerMatch.URL().String()
+}
+
+func (r *CodeOwnerSearchResultResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
+func (r *CodeOwnerSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)   { return nil, false }
+func (r *CodeOwnerSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *CodeOwnerSearchResultResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return r, true
+}
+func (r *CodeOwnerSearchResultResolver) ToCommitAuthorSearchResult() (*CommitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
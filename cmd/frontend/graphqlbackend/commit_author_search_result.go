@@ -0,0 +1,42 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// CommitAuthorSearchResultResolver is a resolver for the GraphQL type `CommitAuthorSearchResult`
+type CommitAuthorSearchResultResolver struct {
+	db database.DB
+	result.CommitAuthorMatch
+
+	RepoResolver *RepositoryResolver
+}
+
+func (r *CommitAuthorSearchResultResolver) Author() *PersonResolver {
+	return NewPersonResolver(r.db, r.CommitAuthorMatch.Author.Name, r.CommitAuthorMatch.Author.Email, true)
+}
+
+func (r *CommitAuthorSearchResultResolver) Repository() *RepositoryResolver {
+	return r.RepoResolver
+}
+
+func (r *CommitAuthorSearchResultResolver) URL() string {
+	return r.CommitAuthorMatch.URL().String()
+}
+
+func (r *CommitAuthorSearchResultResolver) ToRepository() (*RepositoryResolver, bool) {
+	return nil, false
+}
+func (r *CommitAuthorSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool) {
+	return nil, false
+}
+func (r *CommitAuthorSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *CommitAuthorSearchResultResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *CommitAuthorSearchResultResolver) ToCommitAuthorSearchResult() (*CommitAuthorSearchResultResolver, bool) {
+	return r, true
+}
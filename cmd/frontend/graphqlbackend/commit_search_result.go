@@ -96,3 +96,9 @@ func (r *CommitSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)
 func (r *CommitSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return r, true
 }
+func (r *CommitSearchResultResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *CommitSearchResultResolver) ToCommitAuthorSearchResult() (*CommitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
@@ -82,6 +82,12 @@ func (fm *FileMatchResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (fm *FileMatchResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (fm *FileMatchResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return nil, false
+}
+func (fm *FileMatchResolver) ToCommitAuthorSearchResult() (*CommitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
 
 type lineMatchResolver struct {
 	*result.LineMatch
@@ -1,9 +1,14 @@
 package graphqlbackend
 
-import "context"
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
 
 type InsightsAggregationResolver interface {
 	SearchQueryAggregate(ctx context.Context, args SearchQueryArgs) (SearchQueryAggregateResolver, error)
+	FileChurn(ctx context.Context, args FileChurnArgs) ([]FileChurnResolver, error)
 }
 
 type SearchQueryArgs struct {
@@ -60,4 +65,24 @@ type AggregationsArgs struct {
 	Mode            *string `json:"mode"` //enum
 	Limit           int32   `json:"limit"`
 	ExtendedTimeout bool    `json:"extendedTimeout"`
+	DateInterval    string  `json:"dateInterval"` //enum
+	DirectoryDepth  int32   `json:"directoryDepth"`
+}
+
+type FileChurnArgs struct {
+	Repository string            `json:"repository"`
+	Revision   *string           `json:"revision"`
+	After      *gqlutil.DateTime `json:"after"`
+	Before     *gqlutil.DateTime `json:"before"`
+	Paths      *[]string         `json:"paths"`
+	First      int32             `json:"first"`
+}
+
+type FileChurnResolver interface {
+	Path() string
+	Commits() int32
+	LinesAdded() int32
+	LinesDeleted() int32
+	Authors() int32
+	Query() string
 }
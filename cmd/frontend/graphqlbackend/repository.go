@@ -319,6 +319,12 @@ func (r *RepositoryResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (r *RepositoryResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (r *RepositoryResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *RepositoryResolver) ToCommitAuthorSearchResult() (*CommitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (r *RepositoryResolver) Type(ctx context.Context) (*types.Repo, error) {
 	return r.repo(ctx)
@@ -27,6 +27,10 @@ type SearchImplementer interface {
 	Stats(context.Context) (*searchResultsStats, error)
 }
 
+// mockSearchClient is used by batch searches instead of the default search
+// client when set.
+var mockSearchClient client.SearchClient
+
 // NewBatchSearchImplementer returns a SearchImplementer that provides search results and suggestions.
 func NewBatchSearchImplementer(ctx context.Context, logger log.Logger, db database.DB, args *SearchArgs) (_ SearchImplementer, err error) {
 	settings, err := DecodedViewerFinalSettings(ctx, db)
@@ -34,7 +38,10 @@ func NewBatchSearchImplementer(ctx context.Context, logger log.Logger, db databa
 		return nil, err
 	}
 
-	cli := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	var cli client.SearchClient = mockSearchClient
+	if cli == nil {
+		cli = client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	}
 	inputs, err := cli.Plan(
 		ctx,
 		args.Version,
@@ -167,6 +167,21 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.OwnerMatch:
+			rev := ""
+			if v.InputRev != nil {
+				rev = *v.InputRev
+			}
+			resolvers = append(resolvers, &CodeOwnerSearchResultResolver{
+				OwnerMatch:   *v,
+				RepoResolver: getRepoResolver(v.Repo, rev),
+			})
+		case *result.CommitAuthorMatch:
+			resolvers = append(resolvers, &CommitAuthorSearchResultResolver{
+				db:                db,
+				CommitAuthorMatch: *v,
+				RepoResolver:      getRepoResolver(v.Repo, ""),
+			})
 		}
 	}
 	return resolvers
@@ -666,10 +681,14 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 //   - *RepositoryResolver         // repo name match
 //   - *fileMatchResolver          // text match
 //   - *commitSearchResultResolver // diff or commit match
+//   - *CodeOwnerSearchResultResolver // owner match
+//   - *CommitAuthorSearchResultResolver // commit author match
 //
 // Note: Any new result types added here also need to be handled properly in search_results.go:301 (sparklines)
 type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool)
+	ToCommitAuthorSearchResult() (*CommitAuthorSearchResultResolver, bool)
 }
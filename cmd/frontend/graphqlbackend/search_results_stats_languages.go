@@ -160,7 +160,7 @@ func searchResultsStatsLanguages(ctx context.Context, logger log.Logger, db data
 		goroutine.Go(func() {
 			defer run.Release()
 
-			invCtx, err := backend.InventoryContext(logger, repos[key.repo].Name, gsClient, key.commitID, true)
+			invCtx, err := inventory.GitserverContext(logger, repos[key.repo].Name, gsClient, key.commitID, true)
 			if err != nil {
 				run.Error(err)
 				return
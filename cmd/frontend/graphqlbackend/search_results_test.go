@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
@@ -703,3 +704,145 @@ func TestSubRepoFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchResultsGraphQL(t *testing.T) {
+	MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { MockDecodedViewerFinalSettings = nil })
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	rev := "main"
+
+	cli := client.NewMockSearchClient()
+	cli.PlanFunc.SetDefaultReturn(&search.Inputs{}, nil)
+	cli.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			&result.OwnerMatch{Handle: "@sourcegraph/search", Repo: repo, CommitID: "deadbeef", InputRev: &rev},
+		}})
+		return nil, nil
+	})
+	mockSearchClient = cli
+	t.Cleanup(func() { mockSearchClient = nil })
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchema(t, database.NewMockDB()),
+		Query: `
+			{
+				search(query: "select:file.owners", version: V2) {
+					results {
+						results {
+							__typename
+							... on CodeOwnerSearchResult {
+								handle
+								url
+								repository {
+									name
+								}
+							}
+						}
+					}
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"search": {
+					"results": {
+						"results": [
+							{
+								"__typename": "CodeOwnerSearchResult",
+								"handle": "@sourcegraph/search",
+								"url": "/github.com/sourcegraph/sourcegraph@main",
+								"repository": {
+									"name": "github.com/sourcegraph/sourcegraph"
+								}
+							}
+						]
+					}
+				}
+			}
+		`,
+	})
+}
+
+func TestSearchResultsGraphQLCommitSelect(t *testing.T) {
+	MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { MockDecodedViewerFinalSettings = nil })
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	cli := client.NewMockSearchClient()
+	cli.PlanFunc.SetDefaultReturn(&search.Inputs{}, nil)
+	cli.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			&result.CommitAuthorMatch{
+				Author: gitdomain.Signature{Name: "Alice", Email: "alice@example.com"},
+				Repo:   repo,
+				Commit: "deadbeef",
+			},
+			&result.CommitMatch{
+				Commit:         gitdomain.Commit{ID: "deadbeef", Message: "fix bug"},
+				Repo:           repo,
+				MessagePreview: &result.MatchedString{Content: "fix bug"},
+				MessageOnly:    true,
+			},
+		}})
+		return nil, nil
+	})
+	mockSearchClient = cli
+	t.Cleanup(func() { mockSearchClient = nil })
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchema(t, database.NewMockDB()),
+		Query: `
+			{
+				search(query: "type:commit select:commit.author", version: V2) {
+					results {
+						results {
+							__typename
+							... on CommitAuthorSearchResult {
+								author {
+									email
+								}
+								url
+								repository {
+									name
+								}
+							}
+							... on CommitSearchResult {
+								messagePreview {
+									value
+								}
+							}
+						}
+					}
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"search": {
+					"results": {
+						"results": [
+							{
+								"__typename": "CommitAuthorSearchResult",
+								"author": {
+									"email": "alice@example.com"
+								},
+								"url": "/github.com/sourcegraph/sourcegraph/-/commit/deadbeef",
+								"repository": {
+									"name": "github.com/sourcegraph/sourcegraph"
+								}
+							},
+							{
+								"__typename": "CommitSearchResult",
+								"messagePreview": {
+									"value": "fix bug"
+								}
+							}
+						]
+					}
+				}
+			}
+		`,
+	})
+}
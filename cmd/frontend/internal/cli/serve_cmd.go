@@ -341,6 +341,7 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
+		enterprise.NewSCIMHandler,
 	)
 	httpServer := &http.Server{
 		Handler:      externalHandler,
@@ -301,6 +301,10 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
+	case *result.CommitAuthorMatch:
+		return fromCommitAuthor(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -487,6 +491,34 @@ func fromRepository(rm *result.RepoMatch, repoCache map[api.RepoID]*types.Search
 	return repoEvent
 }
 
+func fromOwner(om *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	var branches []string
+	if om.InputRev != nil {
+		branches = []string{*om.InputRev}
+	}
+
+	return &streamhttp.EventOwnerMatch{
+		Type:         streamhttp.OwnerMatchType,
+		Handle:       om.Handle,
+		RepositoryID: int32(om.Repo.ID),
+		Repository:   string(om.Repo.Name),
+		Branches:     branches,
+		Commit:       string(om.CommitID),
+	}
+}
+
+func fromCommitAuthor(am *result.CommitAuthorMatch) *streamhttp.EventCommitAuthorMatch {
+	return &streamhttp.EventCommitAuthorMatch{
+		Type:         streamhttp.CommitAuthorMatchType,
+		AuthorName:   am.Author.Name,
+		AuthorEmail:  am.Author.Email,
+		URL:          am.URL().String(),
+		RepositoryID: int32(am.Repo.ID),
+		Repository:   string(am.Repo.Name),
+		OID:          string(am.Commit),
+	}
+}
+
 func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCommitMatch {
 	hls := commit.Body().ToHighlightedString()
 	ranges := make([][3]int32, len(hls.Highlights))
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (s *Server) handleChurn(w http.ResponseWriter, r *http.Request) {
+	// 🚨 SECURITY: Only allow POST requests.
+	if strings.ToUpper(r.Method) != http.MethodPost {
+		http.Error(w, "", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req protocol.ChurnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var churnErr error
+	ctx, logger, endObservation := s.ensureOperations().churn.With(r.Context(), &churnErr, observation.Args{
+		LogFields: req.LogFields(),
+	})
+	defer endObservation(1, observation.Args{})
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	statsBuf := streamhttp.NewJSONArrayBuf(8*1024, func(data []byte) error {
+		return eventWriter.EventBytes("stats", data)
+	})
+
+	churnErr = s.churn(ctx, &req, func(stat protocol.ChurnStat) error {
+		return statsBuf.Append(stat)
+	})
+	if churnErr == nil {
+		churnErr = statsBuf.Flush()
+	}
+	if writeErr := eventWriter.Event("done", protocol.NewSearchEventDone(false, churnErr)); writeErr != nil {
+		if !errors.Is(writeErr, syscall.EPIPE) {
+			logger.Error("failed to send done event", log.Error(writeErr))
+		}
+	}
+}
+
+// churn computes the churn statistics of the paths in the history of a
+// repository and calls onStat for each path, ordered by decreasing number of
+// commits.
+func (s *Server) churn(ctx context.Context, req *protocol.ChurnRequest, onStat func(protocol.ChurnStat) error) error {
+	req.Repo = protocol.NormalizeRepo(req.Repo)
+
+	dir := s.dir(req.Repo)
+	if !repoCloned(dir) {
+		cloneProgress, cloneInProgress := s.locker.Status(dir)
+		return &gitdomain.RepoNotExistError{
+			Repo:            req.Repo,
+			CloneInProgress: cloneInProgress,
+			CloneProgress:   cloneProgress,
+		}
+	}
+
+	revision := req.Revision
+	if revision == "" {
+		revision = "HEAD"
+	}
+	// make sure revision is not an arg
+	if strings.HasPrefix(revision, "-") {
+		return errors.Errorf("invalid revision %q", revision)
+	}
+
+	args := []string{"-c", "core.quotePath=false", "log", "--numstat", "--no-merges", "--no-renames", "--format=format:" + churnCommitMarker + "%aE"}
+	if req.After != "" {
+		args = append(args, "--after="+req.After)
+	}
+	if req.Before != "" {
+		args = append(args, "--before="+req.Before)
+	}
+	args = append(args, revision, "--")
+	args = append(args, req.Paths...)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	cmd.Stderr = &stderr
+
+	// Parse the output of git log while it runs. The pipe is closed with the
+	// error of the command, which is returned by the parser.
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	go func() {
+		_, err := runCommand(ctx, cmd)
+		if err != nil {
+			err = errors.Wrapf(err, "git log --numstat failed (output: %q)", stderr.String())
+		}
+		pw.CloseWithError(err)
+	}()
+
+	stats, err := parseChurn(pr)
+	// Unblock the command if parsing stopped early.
+	_ = pr.Close()
+	if err != nil {
+		return err
+	}
+
+	if req.Limit > 0 && len(stats) > req.Limit {
+		stats = stats[:req.Limit]
+	}
+	for _, stat := range stats {
+		if err := onStat(stat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// churnCommitMarker starts the line with the author email of each commit in
+// the output of the git log command run by churn.
+const churnCommitMarker = "\x1e"
+
+type churnCounts struct {
+	commits      int
+	linesAdded   int
+	linesDeleted int
+	authors      map[string]struct{}
+}
+
+// parseChurn parses the output of `git log --numstat` where each commit is
+// formatted as churnCommitMarker followed by the author email. It returns the
+// stats of every path ordered by decreasing number of commits, and then by
+// path.
+func parseChurn(r io.Reader) ([]protocol.ChurnStat, error) {
+	counts := make(map[string]*churnCounts)
+
+	var author string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, churnCommitMarker) {
+			author = strings.ToLower(strings.TrimPrefix(line, churnCommitMarker))
+			continue
+		}
+
+		// example lines: "10\t2\tcmd/main.go" or "-\t-\tlogo.png" for binary files
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			return nil, errors.Errorf("invalid git log --numstat line: %q", line)
+		}
+		path := fields[2]
+		c, ok := counts[path]
+		if !ok {
+			c = &churnCounts{authors: make(map[string]struct{})}
+			counts[path] = c
+		}
+		c.commits++
+		c.authors[author] = struct{}{}
+		if added, err := strconv.Atoi(fields[0]); err == nil {
+			c.linesAdded += added
+		}
+		if deleted, err := strconv.Atoi(fields[1]); err == nil {
+			c.linesDeleted += deleted
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := make([]protocol.ChurnStat, 0, len(counts))
+	for path, c := range counts {
+		stats = append(stats, protocol.ChurnStat{
+			Path:         path,
+			Commits:      c.commits,
+			LinesAdded:   c.linesAdded,
+			LinesDeleted: c.linesDeleted,
+			Authors:      len(c.authors),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Commits != stats[j].Commits {
+			return stats[i].Commits > stats[j].Commits
+		}
+		return stats[i].Path < stats[j].Path
+	})
+	return stats, nil
+}
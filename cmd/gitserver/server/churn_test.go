@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestParseChurn(t *testing.T) {
+	out := strings.Join([]string{
+		"\x1ealice@example.com",
+		"10\t2\tcmd/main.go",
+		"-\t-\tlogo.png",
+		"",
+		"\x1eBob@example.com",
+		"3\t1\tcmd/main.go",
+		"1\t0\tREADME.md",
+		"",
+		"\x1ebob@example.com",
+		"0\t4\tcmd/main.go",
+	}, "\n")
+
+	stats, err := parseChurn(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []protocol.ChurnStat{
+		{Path: "cmd/main.go", Commits: 3, LinesAdded: 13, LinesDeleted: 7, Authors: 2},
+		{Path: "README.md", Commits: 1, LinesAdded: 1, Authors: 1},
+		{Path: "logo.png", Commits: 1, Authors: 1},
+	}
+	if diff := cmp.Diff(want, stats); diff != "" {
+		t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseChurn(strings.NewReader("\x1ealice@example.com\nnot numstat\n")); err == nil {
+		t.Fatal("expected error for invalid numstat line")
+	}
+}
+
+func TestServer_Churn(t *testing.T) {
+	reposDir := t.TempDir()
+	repoDir := filepath.Join(reposDir, "example.com/repo")
+	if err := os.MkdirAll(repoDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	cmd := func(name string, arg ...string) string {
+		return runCmd(t, repoDir, name, arg...)
+	}
+	makeSingleCommitRepo(cmd)
+	cmd("sh", "-c", "echo goodbye world >> hello.txt && echo readme > README.md")
+	cmd("git", "add", "hello.txt", "README.md")
+	cmd("git", "commit", "-m", "goodbye")
+
+	s := makeTestServer(context.Background(), t, reposDir, "", nil)
+
+	churn := func(req protocol.ChurnRequest) []protocol.ChurnStat {
+		t.Helper()
+		var stats []protocol.ChurnStat
+		err := s.churn(context.Background(), &req, func(stat protocol.ChurnStat) error {
+			stats = append(stats, stat)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stats
+	}
+
+	t.Run("all paths", func(t *testing.T) {
+		want := []protocol.ChurnStat{
+			{Path: "hello.txt", Commits: 2, LinesAdded: 2, Authors: 1},
+			{Path: "README.md", Commits: 1, LinesAdded: 1, Authors: 1},
+		}
+		if diff := cmp.Diff(want, churn(protocol.ChurnRequest{Repo: "example.com/repo"})); diff != "" {
+			t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		want := []protocol.ChurnStat{
+			{Path: "hello.txt", Commits: 2, LinesAdded: 2, Authors: 1},
+		}
+		if diff := cmp.Diff(want, churn(protocol.ChurnRequest{Repo: "example.com/repo", Limit: 1})); diff != "" {
+			t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		want := []protocol.ChurnStat{
+			{Path: "README.md", Commits: 1, LinesAdded: 1, Authors: 1},
+		}
+		if diff := cmp.Diff(want, churn(protocol.ChurnRequest{Repo: "example.com/repo", Paths: []string{"README.md"}})); diff != "" {
+			t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid revision", func(t *testing.T) {
+		req := protocol.ChurnRequest{Repo: "example.com/repo", Revision: "--all"}
+		if err := s.churn(context.Background(), &req, func(protocol.ChurnStat) error { return nil }); err == nil {
+			t.Fatal("expected error for revision starting with -")
+		}
+	})
+
+	t.Run("repo not cloned", func(t *testing.T) {
+		req := protocol.ChurnRequest{Repo: "example.com/missing"}
+		if err := s.churn(context.Background(), &req, func(protocol.ChurnStat) error { return nil }); err == nil {
+			t.Fatal("expected error for repo that is not cloned")
+		}
+	})
+}
@@ -13,6 +13,7 @@ type operations struct {
 	batchLogSemaphoreWait prometheus.Histogram
 	batchLog              *observation.Operation
 	batchLogSingle        *observation.Operation
+	churn                 *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -52,5 +53,6 @@ func newOperations(observationContext *observation.Context) *operations {
 		batchLogSemaphoreWait: batchLogSemaphoreWait,
 		batchLog:              op("BatchLog"),
 		batchLogSingle:        subOp("batchLogSingle"),
+		churn:                 op("Churn"),
 	}
 }
@@ -485,6 +485,7 @@ func (s *Server) Handler() http.Handler {
 	)))
 	mux.HandleFunc("/search", trace.WithRouteName("search", s.handleSearch))
 	mux.HandleFunc("/batch-log", trace.WithRouteName("batch-log", s.handleBatchLog))
+	mux.HandleFunc("/churn", trace.WithRouteName("churn", s.handleChurn))
 	mux.HandleFunc("/p4-exec", trace.WithRouteName("p4-exec", accesslog.HTTPMiddleware(
 		s.Logger.Scoped("p4-exec.accesslog", "p4-exec endpoint access log"),
 		conf.DefaultClient(),
@@ -0,0 +1,515 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+func (squirrel *SquirrelService) getDefCsharp(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefCsharp: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "compilation_unit":
+				for _, child := range children(cur) {
+					if found := findDeclCsharp(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				return squirrel.getDefInOtherFilesCsharp(ctx, swapNode(node, cur), ident)
+
+			case "using_directive":
+				// Namespaces don't map to files
+				return nil, nil
+
+			// Check for member access
+			case "member_access_expression":
+				expression := cur.ChildByFieldName("expression")
+				if expression == nil || nodeId(expression) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldCsharp(ctx, swapNode(node, expression), ident)
+
+			case "qualified_name":
+				qualifier := cur.ChildByFieldName("qualifier")
+				if qualifier == nil || nodeId(qualifier) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldCsharp(ctx, swapNode(node, qualifier), ident)
+
+			// Check nodes that might have bindings:
+			case "namespace_declaration", "file_scoped_namespace_declaration":
+				body := cur.ChildByFieldName("body")
+				if body == nil {
+					body = cur
+				}
+				for _, child := range children(body) {
+					if found := findDeclCsharp(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "block", "switch_section":
+				for _, child := range children(cur) {
+					if found := findDeclCsharp(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "method_declaration", "constructor_declaration", "local_function_statement", "lambda_expression", "anonymous_method_expression":
+				if found := findParamCsharp(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "for_statement", "using_statement", "fixed_statement":
+				for _, child := range children(cur) {
+					if child.Type() != "variable_declaration" {
+						continue
+					}
+					if found := findDeclaratorCsharp(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "for_each_statement":
+				left := cur.ChildByFieldName("left")
+				if left != nil && left.Type() == "identifier" && left.Content(node.Contents) == ident {
+					return swapNodePtr(node, left), nil
+				}
+				continue
+
+			case "catch_clause":
+				for _, child := range children(cur) {
+					if child.Type() != "catch_declaration" {
+						continue
+					}
+					name := child.ChildByFieldName("name")
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration":
+				if prev.Type() == "base_list" {
+					// Base types are resolved in the enclosing scope
+					continue
+				}
+				if found := findTypeParamCsharp(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				found, err := squirrel.lookupFieldCsharp(ctx, swapNode(node, cur), ident)
+				if err != nil {
+					return nil, err
+				}
+				if found != nil {
+					return found, nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInOtherFilesCsharp searches for a type declared in another file. Namespaces don't
+// necessarily match directories, so this first looks in the current directory and then in the
+// rest of the repository.
+func (squirrel *SquirrelService) getDefInOtherFilesCsharp(ctx context.Context, compilationUnit Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(compilationUnit, &Tuple{String(compilationUnit.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	dir := filepath.Dir(compilationUnit.RepoCommitPath.Path)
+	dirPattern := `^[^/]+\.cs$`
+	if dir != "." {
+		dirPattern = fmt.Sprintf(`^%s/[^/]+\.cs$`, regexp.QuoteMeta(dir))
+	}
+
+	for _, pattern := range []string{dirPattern, `\.cs$`} {
+		found, err := squirrel.symbolSearchOne(
+			ctx,
+			compilationUnit.RepoCommitPath.Repo,
+			compilationUnit.RepoCommitPath.Commit,
+			[]string{pattern},
+			ident,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldCsharp(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	var ty *Node
+	switch object.Type() {
+	case "identifier":
+		def, err := squirrel.getDefCsharp(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			return nil, nil
+		}
+		ty, err = squirrel.defToTypeCsharp(ctx, *def)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		ty, err = squirrel.getTypeDefCsharp(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldCsharp(ctx, *ty, field)
+}
+
+// lookupFieldCsharp finds a member of the given type declaration, including inherited members.
+func (squirrel *SquirrelService) lookupFieldCsharp(ctx context.Context, ty Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty, &Tuple{String(ty.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	body := ty.ChildByFieldName("body")
+	for _, member := range children(body) {
+		switch member.Type() {
+		case "field_declaration", "event_field_declaration":
+			for _, child := range children(member) {
+				if child.Type() != "variable_declaration" {
+					continue
+				}
+				if found := findDeclaratorCsharp(swapNode(ty, child), field); found != nil {
+					return found, nil
+				}
+			}
+		case "method_declaration", "property_declaration", "event_declaration", "enum_member_declaration",
+			"class_declaration", "struct_declaration", "interface_declaration", "record_declaration", "enum_declaration", "delegate_declaration":
+			name := member.ChildByFieldName("name")
+			if name != nil && name.Content(ty.Contents) == field {
+				return swapNodePtr(ty, name), nil
+			}
+		}
+	}
+
+	for _, super := range getBaseTypesCsharp(ty) {
+		superTy, err := squirrel.getTypeDefCsharp(ctx, super)
+		if err != nil {
+			return nil, err
+		}
+		if superTy == nil || nodeId(superTy.Node) == nodeId(ty.Node) {
+			continue
+		}
+		found, err := squirrel.lookupFieldCsharp(ctx, *superTy, field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getTypeDefCsharp returns the type declaration of the type of the given expression or type.
+func (squirrel *SquirrelService) getTypeDefCsharp(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		found, err := squirrel.getDefCsharp(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeCsharp(ctx, *found)
+	case "this_expression":
+		class := getEnclosingTypeCsharp(node)
+		if class == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, class), nil
+	case "base_expression":
+		class := getEnclosingTypeCsharp(node)
+		if class == nil {
+			return nil, nil
+		}
+		for _, super := range getBaseTypesCsharp(swapNode(node, class)) {
+			return squirrel.getTypeDefCsharp(ctx, super)
+		}
+		return nil, nil
+	case "generic_name", "nullable_type", "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefCsharp(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "qualified_name":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCsharp(ctx, swapNode(node, name))
+	case "object_creation_expression", "cast_expression":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCsharp(ctx, swapNode(node, ty))
+	case "member_access_expression":
+		expression := node.ChildByFieldName("expression")
+		name := node.ChildByFieldName("name")
+		if expression == nil || name == nil {
+			return nil, nil
+		}
+		found, err := squirrel.getFieldCsharp(ctx, swapNode(node, expression), name.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeCsharp(ctx, *found)
+	case "invocation_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		var found *Node
+		switch fn.Type() {
+		case "identifier":
+			found, err = squirrel.getDefCsharp(ctx, swapNode(node, fn))
+		case "member_access_expression":
+			expression := fn.ChildByFieldName("expression")
+			name := fn.ChildByFieldName("name")
+			if expression == nil || name == nil {
+				return nil, nil
+			}
+			found, err = squirrel.getFieldCsharp(ctx, swapNode(node, expression), name.Content(node.Contents))
+		default:
+			squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefCsharp: unrecognized function node type %q", fn.Type()))
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		method := found.Parent()
+		if method == nil || (method.Type() != "method_declaration" && method.Type() != "local_function_statement") {
+			return nil, nil
+		}
+		returnType := getDeclaredTypeCsharp(method)
+		if returnType == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCsharp(ctx, swapNode(*found, returnType))
+	case "predefined_type", "implicit_type":
+		return nil, nil
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefCsharp: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+// defToTypeCsharp returns the type declaration of the type of the given definition.
+func (squirrel *SquirrelService) defToTypeCsharp(ctx context.Context, def Node) (*Node, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration", "enum_declaration":
+		return swapNodePtr(def, parent), nil
+	case "parameter", "property_declaration", "for_each_statement", "catch_declaration":
+		ty := getDeclaredTypeCsharp(parent)
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCsharp(ctx, swapNode(def, ty))
+	case "variable_declarator":
+		declaration := parent.Parent()
+		if declaration == nil {
+			return nil, nil
+		}
+		ty := getDeclaredTypeCsharp(declaration)
+		if ty != nil && ty.Type() != "implicit_type" {
+			return squirrel.getTypeDefCsharp(ctx, swapNode(def, ty))
+		}
+		// var x = new Foo();
+		for _, child := range children(parent) {
+			if child.Type() == "equals_value_clause" && child.NamedChildCount() > 0 {
+				return squirrel.getTypeDefCsharp(ctx, swapNode(def, child.NamedChild(0)))
+			}
+		}
+		return nil, nil
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("defToTypeCsharp: unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// findDeclCsharp finds the definition of ident in the given statement or member declaration.
+func findDeclCsharp(stmt Node, ident string) *Node {
+	switch stmt.Type() {
+	case "local_declaration_statement":
+		for _, child := range children(stmt.Node) {
+			if child.Type() != "variable_declaration" {
+				continue
+			}
+			if found := findDeclaratorCsharp(swapNode(stmt, child), ident); found != nil {
+				return found
+			}
+		}
+	case "local_function_statement", "class_declaration", "struct_declaration", "interface_declaration",
+		"record_declaration", "enum_declaration", "delegate_declaration":
+		name := stmt.ChildByFieldName("name")
+		if name != nil && name.Content(stmt.Contents) == ident {
+			return swapNodePtr(stmt, name)
+		}
+	}
+	return nil
+}
+
+// findDeclaratorCsharp finds ident in the declarators of a variable_declaration.
+func findDeclaratorCsharp(declaration Node, ident string) *Node {
+	for _, declarator := range children(declaration.Node) {
+		if declarator.Type() != "variable_declarator" || declarator.NamedChildCount() == 0 {
+			continue
+		}
+		name := declarator.NamedChild(0)
+		if name.Type() == "identifier" && name.Content(declaration.Contents) == ident {
+			return swapNodePtr(declaration, name)
+		}
+	}
+	return nil
+}
+
+// findParamCsharp finds ident in the parameters or type parameters of a method, local function, or
+// lambda.
+func findParamCsharp(fn Node, ident string) *Node {
+	if found := findTypeParamCsharp(fn, ident); found != nil {
+		return found
+	}
+	parameters := fn.ChildByFieldName("parameters")
+	if parameters == nil {
+		for _, child := range children(fn.Node) {
+			switch child.Type() {
+			case "parameter_list":
+				parameters = child
+			case "identifier", "implicit_parameter":
+				// x => ...
+				if fn.Type() == "lambda_expression" && parameters == nil {
+					parameters = child
+				}
+			}
+		}
+	}
+	if parameters == nil {
+		return nil
+	}
+	if parameters.Type() == "identifier" || parameters.Type() == "implicit_parameter" {
+		if parameters.Content(fn.Contents) == ident {
+			return swapNodePtr(fn, parameters)
+		}
+		return nil
+	}
+	for _, param := range children(parameters) {
+		if param.Type() != "parameter" {
+			continue
+		}
+		name := param.ChildByFieldName("name")
+		if name != nil && name.Content(fn.Contents) == ident {
+			return swapNodePtr(fn, name)
+		}
+	}
+	return nil
+}
+
+// findTypeParamCsharp finds ident in the type parameters of a declaration.
+func findTypeParamCsharp(decl Node, ident string) *Node {
+	for _, child := range children(decl.Node) {
+		if child.Type() != "type_parameter_list" {
+			continue
+		}
+		for _, typeParameter := range children(child) {
+			for _, name := range children(typeParameter) {
+				if name.Type() == "identifier" && name.Content(decl.Contents) == ident {
+					return swapNodePtr(decl, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getDeclaredTypeCsharp returns the type of a declaration, or the return type of a method.
+func getDeclaredTypeCsharp(decl *sitter.Node) *sitter.Node {
+	if ty := decl.ChildByFieldName("type"); ty != nil {
+		return ty
+	}
+	return decl.ChildByFieldName("returns")
+}
+
+// getEnclosingTypeCsharp returns the closest type declaration that contains the given node.
+func getEnclosingTypeCsharp(node Node) *sitter.Node {
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "class_declaration", "struct_declaration", "record_declaration", "interface_declaration":
+			return cur
+		}
+	}
+	return nil
+}
+
+// getBaseTypesCsharp returns the base class and interfaces of the given type declaration.
+func getBaseTypesCsharp(decl Node) []Node {
+	bases := []Node{}
+	for _, child := range children(decl.Node) {
+		if child.Type() != "base_list" {
+			continue
+		}
+		for _, base := range children(child) {
+			bases = append(bases, swapNode(decl, base))
+		}
+	}
+	return bases
+}
@@ -0,0 +1,761 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "field_identifier", "package_identifier":
+		ident := node.Content(node.Contents)
+
+		// Field names only refer to something when they're selected from an operand
+		if node.Type() == "field_identifier" {
+			parent := node.Parent()
+			if parent == nil || parent.Type() != "selector_expression" {
+				return nil, nil
+			}
+		}
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefGo: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return squirrel.getDefInPackageGo(ctx, swapNode(node, cur), ident)
+
+			case "import_spec":
+				return squirrel.getImportDirGo(ctx, swapNode(node, cur))
+
+			// Check for field access
+			case "selector_expression":
+				operand := cur.ChildByFieldName("operand")
+				if operand == nil || nodeId(operand) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldGo(ctx, swapNode(node, operand), ident)
+
+			case "qualified_type":
+				pkg := cur.ChildByFieldName("package")
+				if pkg == nil || nodeId(pkg) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldGo(ctx, swapNode(node, pkg), ident)
+
+			// Check nodes that might have bindings:
+			case "block", "expression_case", "default_case", "type_case", "communication_case":
+				if cur.Type() == "communication_case" {
+					communication := cur.ChildByFieldName("communication")
+					if communication != nil && communication.Type() == "receive_statement" {
+						if found := findIdentGo(swapNode(node, communication.ChildByFieldName("left")), ident); found != nil {
+							return found, nil
+						}
+					}
+				}
+				for stmt := prev.PrevNamedSibling(); stmt != nil; stmt = stmt.PrevNamedSibling() {
+					if found := findDeclGo(swapNode(node, stmt), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "if_statement", "expression_switch_statement", "type_switch_statement":
+				if cur.Type() == "type_switch_statement" {
+					if found := findIdentGo(swapNode(node, cur.ChildByFieldName("alias")), ident); found != nil {
+						return found, nil
+					}
+				}
+				initializer := cur.ChildByFieldName("initializer")
+				if initializer != nil && nodeId(initializer) != nodeId(prev) {
+					if found := findDeclGo(swapNode(node, initializer), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "for_statement":
+				for _, child := range children(cur) {
+					switch child.Type() {
+					case "for_clause":
+						initializer := child.ChildByFieldName("initializer")
+						if initializer == nil {
+							continue
+						}
+						if found := findDeclGo(swapNode(node, initializer), ident); found != nil {
+							return found, nil
+						}
+					case "range_clause":
+						if found := findIdentGo(swapNode(node, child.ChildByFieldName("left")), ident); found != nil {
+							return found, nil
+						}
+					}
+				}
+				continue
+
+			case "function_declaration", "method_declaration", "func_literal":
+				for _, field := range []string{"receiver", "type_parameters", "parameters", "result"} {
+					list := cur.ChildByFieldName(field)
+					if list == nil || (list.Type() != "parameter_list" && list.Type() != "type_parameter_list") {
+						continue
+					}
+					for _, param := range children(list) {
+						if found := findIdentGo(swapNode(node, param), ident); found != nil {
+							return found, nil
+						}
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "interpreted_string_literal", "raw_string_literal":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "import_spec" {
+			return nil, nil
+		}
+		return squirrel.getImportDirGo(ctx, swapNode(node, parent))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInPackageGo looks for ident in the file scope, then in the imports of the file, and then in
+// the other files of the package.
+func (squirrel *SquirrelService) getDefInPackageGo(ctx context.Context, sourceFile Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(sourceFile, &Tuple{String(sourceFile.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	// Check top-level declarations in the current file
+	for _, child := range children(sourceFile.Node) {
+		if found := findDeclGo(swapNode(sourceFile, child), ident); found != nil {
+			return found, nil
+		}
+	}
+
+	// Check imports
+	dotImports := []*sitter.Node{}
+	for _, spec := range getImportSpecsGo(sourceFile.Node) {
+		name := getImportNameGo(swapNode(sourceFile, spec))
+		if name == "." {
+			dotImports = append(dotImports, spec)
+			continue
+		}
+		if name == ident {
+			return squirrel.getImportDirGo(ctx, swapNode(sourceFile, spec))
+		}
+	}
+
+	// Search in the other files of the current package
+	found, err := squirrel.symbolSearchOne(
+		ctx,
+		sourceFile.RepoCommitPath.Repo,
+		sourceFile.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(filepath.Dir(sourceFile.RepoCommitPath.Path))},
+		ident,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Search in packages imported with a dot
+	for _, spec := range dotImports {
+		dir, err := squirrel.getImportDirGo(ctx, swapNode(sourceFile, spec))
+		if err != nil {
+			return nil, err
+		}
+		if dir == nil {
+			continue
+		}
+		found, err := squirrel.symbolSearchOne(
+			ctx,
+			sourceFile.RepoCommitPath.Repo,
+			sourceFile.RepoCommitPath.Commit,
+			[]string{packageFilesPatternGo(dir.RepoCommitPath.Path)},
+			ident,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+var goModuleRegex = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+
+// getImportDirGo returns the directory of the package imported by the given import_spec. Only
+// packages in the same Go module are found, so imports of the standard library and of other
+// modules return nil.
+func (squirrel *SquirrelService) getImportDirGo(ctx context.Context, spec Node) (ret *Node, err error) {
+	defer squirrel.onCall(spec, String(spec.Type()), lazyNodeStringer(&ret))()
+
+	pathNode := spec.ChildByFieldName("path")
+	if pathNode == nil {
+		return nil, nil
+	}
+	importPath := strings.Trim(pathNode.Content(spec.Contents), "\"`")
+
+	// Find the closest go.mod in the current directory or any of its parents.
+	dir := filepath.Dir(spec.RepoCommitPath.Path)
+	for {
+		goMod, err := squirrel.readFile(ctx, types.RepoCommitPath{
+			Repo:   spec.RepoCommitPath.Repo,
+			Commit: spec.RepoCommitPath.Commit,
+			Path:   filepath.Join(dir, "go.mod"),
+		})
+		if err == nil {
+			match := goModuleRegex.FindSubmatch(goMod)
+			if match == nil {
+				squirrel.breadcrumb(spec, "getImportDirGo: go.mod has no module directive")
+				return nil, nil
+			}
+			module := string(match[1])
+			if importPath != module && !strings.HasPrefix(importPath, module+"/") {
+				squirrel.breadcrumb(spec, fmt.Sprintf("getImportDirGo: %s is not in module %s", importPath, module))
+				return nil, nil
+			}
+			return &Node{
+				RepoCommitPath: types.RepoCommitPath{
+					Repo:   spec.RepoCommitPath.Repo,
+					Commit: spec.RepoCommitPath.Commit,
+					Path:   filepath.Join(dir, strings.TrimPrefix(importPath, module)),
+				},
+				Node:     nil,
+				Contents: spec.Contents,
+				LangSpec: spec.LangSpec,
+			}, nil
+		}
+		if dir == "." || dir == "/" {
+			squirrel.breadcrumb(spec, "getImportDirGo: could not find go.mod")
+			return nil, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func (squirrel *SquirrelService) getFieldGo(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	var ty *Node
+	switch object.Type() {
+	case "identifier", "package_identifier":
+		def, err := squirrel.getDefGo(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			return nil, nil
+		}
+		if def.Node == nil {
+			// The object is an imported package
+			return squirrel.symbolSearchOne(
+				ctx,
+				def.RepoCommitPath.Repo,
+				def.RepoCommitPath.Commit,
+				[]string{packageFilesPatternGo(def.RepoCommitPath.Path)},
+				field,
+			)
+		}
+		ty, err = squirrel.defToTypeGo(ctx, *def)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		ty, err = squirrel.getTypeDefGo(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldGo(ctx, *ty, field)
+}
+
+// lookupFieldGo finds a field or method of the type declared by the given type_spec.
+func (squirrel *SquirrelService) lookupFieldGo(ctx context.Context, typeSpec Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(typeSpec, &Tuple{String(typeSpec.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	name := typeSpec.ChildByFieldName("name")
+	ty := typeSpec.ChildByFieldName("type")
+	if name == nil || ty == nil {
+		return nil, nil
+	}
+
+	// Check the fields of structs and the methods of interfaces
+	embedded := []*sitter.Node{}
+	switch ty.Type() {
+	case "struct_type":
+		for _, list := range children(ty) {
+			if list.Type() != "field_declaration_list" {
+				continue
+			}
+			for _, decl := range children(list) {
+				if decl.Type() != "field_declaration" {
+					continue
+				}
+				hasName := false
+				for _, child := range children(decl) {
+					if child.Type() != "field_identifier" {
+						continue
+					}
+					hasName = true
+					if child.Content(typeSpec.Contents) == field {
+						return swapNodePtr(typeSpec, child), nil
+					}
+				}
+				if !hasName {
+					embeddedTy := decl.ChildByFieldName("type")
+					if embeddedTy == nil {
+						continue
+					}
+					if getTypeNameGo(swapNode(typeSpec, embeddedTy)) == field {
+						return swapNodePtr(typeSpec, embeddedTy), nil
+					}
+					embedded = append(embedded, embeddedTy)
+				}
+			}
+		}
+	case "interface_type":
+		for _, child := range children(ty) {
+			if child.Type() != "method_spec" && child.Type() != "method_elem" {
+				continue
+			}
+			methodName := child.ChildByFieldName("name")
+			if methodName != nil && methodName.Content(typeSpec.Contents) == field {
+				return swapNodePtr(typeSpec, methodName), nil
+			}
+		}
+	}
+
+	// Check methods
+	found, err := squirrel.getMethodGo(ctx, typeSpec, name.Content(typeSpec.Contents), field)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Check fields and methods promoted from embedded structs
+	for _, embeddedTy := range embedded {
+		found, err := squirrel.getFieldGo(ctx, swapNode(typeSpec, embeddedTy), field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getMethodGo finds the method with the given receiver type name, first in the file of the type
+// and then in the rest of its package.
+func (squirrel *SquirrelService) getMethodGo(ctx context.Context, typeSpec Node, typeName string, method string) (ret *Node, err error) {
+	defer squirrel.onCall(typeSpec, &Tuple{String(typeName), String(method)}, lazyNodeStringer(&ret))()
+
+	for _, child := range children(getRoot(typeSpec.Node)) {
+		if child.Type() != "method_declaration" {
+			continue
+		}
+		name := child.ChildByFieldName("name")
+		if name == nil || name.Content(typeSpec.Contents) != method {
+			continue
+		}
+		if getReceiverTypeNameGo(swapNode(typeSpec, child)) == typeName {
+			return swapNodePtr(typeSpec, name), nil
+		}
+	}
+
+	found, err := squirrel.symbolSearchOne(
+		ctx,
+		typeSpec.RepoCommitPath.Repo,
+		typeSpec.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(filepath.Dir(typeSpec.RepoCommitPath.Path))},
+		method,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, nil
+	}
+	decl := found.Parent()
+	if decl == nil || decl.Type() != "method_declaration" {
+		return nil, nil
+	}
+	if getReceiverTypeNameGo(swapNode(*found, decl)) != typeName {
+		return nil, nil
+	}
+	return found, nil
+}
+
+// getTypeDefGo returns the type_spec of the named type of the given expression or type.
+func (squirrel *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+		found, err := squirrel.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.Node == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	case "qualified_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, name))
+	case "generic_type":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, ty))
+	case "pointer_type", "parenthesized_type", "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefGo(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "unary_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, operand))
+	case "composite_literal":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, ty))
+	case "selector_expression":
+		operand := node.ChildByFieldName("operand")
+		field := node.ChildByFieldName("field")
+		if operand == nil || field == nil {
+			return nil, nil
+		}
+		found, err := squirrel.getFieldGo(ctx, swapNode(node, operand), field.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		var found *Node
+		switch fn.Type() {
+		case "identifier":
+			found, err = squirrel.getDefGo(ctx, swapNode(node, fn))
+		case "selector_expression":
+			operand := fn.ChildByFieldName("operand")
+			field := fn.ChildByFieldName("field")
+			if operand == nil || field == nil {
+				return nil, nil
+			}
+			found, err = squirrel.getFieldGo(ctx, swapNode(node, operand), field.Content(node.Contents))
+		default:
+			squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized function node type %q", fn.Type()))
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.Node == nil {
+			return nil, nil
+		}
+		parent := found.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+		switch parent.Type() {
+		case "function_declaration", "method_declaration", "method_spec", "method_elem":
+			result := parent.ChildByFieldName("result")
+			if result == nil {
+				return nil, nil
+			}
+			if result.Type() == "parameter_list" {
+				// Only functions with a single result have a usable type
+				if result.NamedChildCount() != 1 {
+					return nil, nil
+				}
+				result = result.NamedChild(0).ChildByFieldName("type")
+				if result == nil {
+					return nil, nil
+				}
+			}
+			return squirrel.getTypeDefGo(ctx, swapNode(*found, result))
+		case "type_spec":
+			// It's a conversion, e.g. Foo(x)
+			return squirrel.defToTypeGo(ctx, *found)
+		default:
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+// defToTypeGo returns the type_spec of the type of the given definition.
+func (squirrel *SquirrelService) defToTypeGo(ctx context.Context, def Node) (*Node, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "type_spec":
+		return swapNodePtr(def, parent), nil
+	case "type_alias", "parameter_declaration", "variadic_parameter_declaration", "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeGo: could not find type")
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "var_spec":
+		ty := parent.ChildByFieldName("type")
+		if ty != nil {
+			return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		value := getValueAtGo(parent, parent.ChildByFieldName("value"), def.Node)
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, value))
+	case "expression_list":
+		grandparent := parent.Parent()
+		if grandparent == nil || grandparent.Type() != "short_var_declaration" {
+			return nil, nil
+		}
+		value := getValueAtGo(parent, grandparent.ChildByFieldName("right"), def.Node)
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, value))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("defToTypeGo: unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// findDeclGo finds the definition of ident in the given statement or top-level declaration.
+func findDeclGo(stmt Node, ident string) *Node {
+	switch stmt.Type() {
+	case "short_var_declaration":
+		return findIdentGo(swapNode(stmt, stmt.ChildByFieldName("left")), ident)
+	case "var_declaration", "const_declaration":
+		for _, spec := range getSpecsGo(stmt.Node) {
+			if found := findIdentGo(swapNode(stmt, spec), ident); found != nil {
+				return found
+			}
+		}
+	case "type_declaration":
+		for _, spec := range getSpecsGo(stmt.Node) {
+			name := spec.ChildByFieldName("name")
+			if name != nil && name.Content(stmt.Contents) == ident {
+				return swapNodePtr(stmt, name)
+			}
+		}
+	case "function_declaration":
+		name := stmt.ChildByFieldName("name")
+		if name != nil && name.Content(stmt.Contents) == ident {
+			return swapNodePtr(stmt, name)
+		}
+	}
+	return nil
+}
+
+// findIdentGo finds ident in an identifier, an expression_list, or the names of a declaration like
+// var_spec or parameter_declaration.
+func findIdentGo(node Node, ident string) *Node {
+	if node.Node == nil {
+		return nil
+	}
+	if node.Type() == "identifier" {
+		if node.Content(node.Contents) == ident {
+			return &node
+		}
+		return nil
+	}
+	for _, child := range children(node.Node) {
+		if child.Type() == "identifier" && child.Content(node.Contents) == ident {
+			return swapNodePtr(node, child)
+		}
+	}
+	return nil
+}
+
+// getSpecsGo returns the specs of a var, const, or type declaration, which might be grouped in
+// parentheses.
+func getSpecsGo(decl *sitter.Node) []*sitter.Node {
+	specs := []*sitter.Node{}
+	for _, child := range children(decl) {
+		switch child.Type() {
+		case "var_spec", "const_spec", "type_spec", "type_alias":
+			specs = append(specs, child)
+		case "var_spec_list":
+			specs = append(specs, children(child)...)
+		}
+	}
+	return specs
+}
+
+// getImportSpecsGo returns all import_spec nodes in the given source_file.
+func getImportSpecsGo(sourceFile *sitter.Node) []*sitter.Node {
+	specs := []*sitter.Node{}
+	for _, decl := range children(sourceFile) {
+		if decl.Type() != "import_declaration" {
+			continue
+		}
+		for _, child := range children(decl) {
+			switch child.Type() {
+			case "import_spec":
+				specs = append(specs, child)
+			case "import_spec_list":
+				for _, spec := range children(child) {
+					if spec.Type() == "import_spec" {
+						specs = append(specs, spec)
+					}
+				}
+			}
+		}
+	}
+	return specs
+}
+
+var goMajorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// getImportNameGo returns the name that an import_spec binds, which is either the explicit name
+// or the last component of the import path (skipping major version suffixes like /v2).
+func getImportNameGo(spec Node) string {
+	name := spec.ChildByFieldName("name")
+	if name != nil {
+		return name.Content(spec.Contents)
+	}
+	path := spec.ChildByFieldName("path")
+	if path == nil {
+		return ""
+	}
+	components := strings.Split(strings.Trim(path.Content(spec.Contents), "\"`"), "/")
+	last := components[len(components)-1]
+	if len(components) > 1 && goMajorVersionRegex.MatchString(last) {
+		last = components[len(components)-2]
+	}
+	return last
+}
+
+// getReceiverTypeNameGo returns the name of the receiver type of a method_declaration.
+func getReceiverTypeNameGo(method Node) string {
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil || receiver.NamedChildCount() == 0 {
+		return ""
+	}
+	ty := receiver.NamedChild(0).ChildByFieldName("type")
+	if ty == nil {
+		return ""
+	}
+	return getTypeNameGo(swapNode(method, ty))
+}
+
+// getTypeNameGo returns the unqualified name of a named type, stripping pointers and type
+// arguments.
+func getTypeNameGo(ty Node) string {
+	switch ty.Type() {
+	case "type_identifier":
+		return ty.Content(ty.Contents)
+	case "pointer_type", "parenthesized_type":
+		for _, child := range children(ty.Node) {
+			return getTypeNameGo(swapNode(ty, child))
+		}
+	case "generic_type":
+		inner := ty.ChildByFieldName("type")
+		if inner != nil {
+			return getTypeNameGo(swapNode(ty, inner))
+		}
+	case "qualified_type":
+		name := ty.ChildByFieldName("name")
+		if name != nil {
+			return name.Content(ty.Contents)
+		}
+	}
+	return ""
+}
+
+// getValueAtGo returns the value in values at the same position as name in names, e.g. for
+// `x, y := 1, 2` it returns `2` for `y`.
+func getValueAtGo(names *sitter.Node, values *sitter.Node, name *sitter.Node) *sitter.Node {
+	if values == nil {
+		return nil
+	}
+	valueNodes := []*sitter.Node{values}
+	if values.Type() == "expression_list" {
+		valueNodes = children(values)
+	}
+	nameNodes := []*sitter.Node{}
+	for _, child := range children(names) {
+		if child.Type() == "identifier" {
+			nameNodes = append(nameNodes, child)
+		}
+	}
+	// Multiple names with a single value (e.g. `x, err := f()`) can't be matched up
+	if len(nameNodes) != len(valueNodes) {
+		return nil
+	}
+	for i, child := range nameNodes {
+		if nodeId(child) == nodeId(name) {
+			return valueNodes[i]
+		}
+	}
+	return nil
+}
+
+// packageFilesPatternGo returns a pattern that matches the files directly inside the given
+// package directory.
+func packageFilesPatternGo(dir string) string {
+	if dir == "." || dir == "" {
+		return `^[^/]+\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]+\.go$`, regexp.QuoteMeta(dir))
+}
@@ -0,0 +1,782 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefTypescript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "property_identifier", "shorthand_property_identifier":
+		ident := node.Content(node.Contents)
+
+		// Property names only refer to something when they're accessed on an object
+		if node.Type() == "property_identifier" {
+			parent := node.Parent()
+			if parent == nil || parent.Type() != "member_expression" {
+				return nil, nil
+			}
+		}
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefTypescript: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "program":
+				for _, child := range children(cur) {
+					if found := findDeclTypescript(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				return squirrel.getDefInImportsTypescript(ctx, swapNode(node, cur), ident)
+
+			case "import_statement":
+				return squirrel.getDefInImportsTypescript(ctx, swapNode(node, cur.Parent()), ident)
+
+			// Check for field access
+			case "member_expression":
+				object := cur.ChildByFieldName("object")
+				if object == nil || nodeId(object) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldTypescript(ctx, swapNode(node, object), ident)
+
+			case "nested_type_identifier":
+				module := cur.ChildByFieldName("module")
+				if module == nil || nodeId(module) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldTypescript(ctx, swapNode(node, module), ident)
+
+			// Check nodes that might have bindings:
+			case "statement_block", "switch_case":
+				// Function declarations are hoisted and let/const are in scope for the whole block, so
+				// check all statements, not just the ones before the reference.
+				for _, child := range children(cur) {
+					if found := findDeclTypescript(swapNode(node, child), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "function", "function_declaration", "generator_function", "generator_function_declaration", "method_definition", "arrow_function":
+				if cur.Type() == "function" || cur.Type() == "generator_function" {
+					// Named function expressions can refer to themselves
+					name := cur.ChildByFieldName("name")
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				if found := findParamTypescript(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "class_declaration", "abstract_class_declaration", "class", "interface_declaration", "type_alias_declaration":
+				if found := findTypeParamTypescript(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				if cur.Type() == "class" {
+					// Named class expressions can refer to themselves
+					name := cur.ChildByFieldName("name")
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "for_statement":
+				initializer := cur.ChildByFieldName("initializer")
+				if initializer == nil {
+					continue
+				}
+				if found := findDeclTypescript(swapNode(node, initializer), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "for_in_statement":
+				left := cur.ChildByFieldName("left")
+				if left == nil || nodeId(left) == nodeId(prev) {
+					continue
+				}
+				if found := findPatternIdentTypescript(swapNode(node, left), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "catch_clause":
+				parameter := cur.ChildByFieldName("parameter")
+				if parameter == nil {
+					continue
+				}
+				if found := findPatternIdentTypescript(swapNode(node, parameter), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "this":
+		class := getEnclosingClassTypescript(node)
+		if class == nil {
+			return nil, nil
+		}
+		name := class.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, name), nil
+
+	case "string", "string_fragment":
+		if node.Type() == "string_fragment" {
+			node = swapNode(node, node.Parent())
+		}
+		// Jump to the imported file from the source of an import or export
+		parent := node.Parent()
+		if parent == nil || (parent.Type() != "import_statement" && parent.Type() != "export_statement") {
+			return nil, nil
+		}
+		return squirrel.resolveModuleTypescript(ctx, node, getStringContentsTypescript(node))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInImportsTypescript looks for ident in the import statements of the given program. If the
+// imported module can't be found (e.g. it's a package in node_modules), the local binding in the
+// import statement is returned.
+func (squirrel *SquirrelService) getDefInImportsTypescript(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(program.Node) {
+		if stmt.Type() != "import_statement" {
+			continue
+		}
+		source := stmt.ChildByFieldName("source")
+		if source == nil {
+			continue
+		}
+		for _, clause := range children(stmt) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+			for _, child := range children(clause) {
+				var local *sitter.Node
+				exported := ""
+				switch child.Type() {
+				case "identifier":
+					// import x from './x'
+					local = child
+					exported = "default"
+				case "namespace_import":
+					// import * as x from './x'
+					for _, nsChild := range children(child) {
+						if nsChild.Type() == "identifier" {
+							local = nsChild
+						}
+					}
+				case "named_imports":
+					// import { x, y as z } from './x'
+					for _, specifier := range children(child) {
+						if specifier.Type() != "import_specifier" {
+							continue
+						}
+						name := specifier.ChildByFieldName("name")
+						alias := specifier.ChildByFieldName("alias")
+						if name == nil {
+							continue
+						}
+						local = name
+						if alias != nil {
+							local = alias
+						}
+						if local.Content(program.Contents) == ident {
+							exported = name.Content(program.Contents)
+							break
+						}
+						local = nil
+					}
+				}
+				if local == nil || local.Content(program.Contents) != ident {
+					continue
+				}
+
+				module, err := squirrel.resolveModuleTypescript(ctx, swapNode(program, source), getStringContentsTypescript(swapNode(program, source)))
+				if err != nil {
+					return nil, err
+				}
+				if module == nil {
+					return swapNodePtr(program, local), nil
+				}
+				if exported == "" {
+					// It's a namespace import, so the module itself is the definition
+					return module, nil
+				}
+				found, err := squirrel.findExportTypescript(ctx, *module, exported)
+				if err != nil {
+					return nil, err
+				}
+				if found == nil {
+					return swapNodePtr(program, local), nil
+				}
+				return found, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveModuleTypescript parses the file imported by a relative module specifier like "./foo".
+// Non-relative specifiers refer to packages, which are not resolved.
+func (squirrel *SquirrelService) resolveModuleTypescript(ctx context.Context, from Node, specifier string) (ret *Node, err error) {
+	defer squirrel.onCall(from, String(specifier), lazyNodeStringer(&ret))()
+
+	if !strings.HasPrefix(specifier, "./") && !strings.HasPrefix(specifier, "../") {
+		squirrel.breadcrumb(from, fmt.Sprintf("resolveModuleTypescript: not a relative import %q", specifier))
+		return nil, nil
+	}
+
+	base := filepath.Join(filepath.Dir(from.RepoCommitPath.Path), specifier)
+	// ES modules written in TypeScript import "./foo.js" to refer to "./foo.ts"
+	base = strings.TrimSuffix(base, ".js")
+	for _, candidate := range []string{
+		base,
+		base + ".ts",
+		base + ".tsx",
+		base + ".d.ts",
+		filepath.Join(base, "index.ts"),
+		filepath.Join(base, "index.tsx"),
+	} {
+		module, err := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   from.RepoCommitPath.Repo,
+			Commit: from.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			continue
+		}
+		return module, nil
+	}
+
+	squirrel.breadcrumb(from, fmt.Sprintf("resolveModuleTypescript: could not find %q", specifier))
+	return nil, nil
+}
+
+// findExportTypescript finds the definition of the given export of a module. The name "default"
+// refers to the default export.
+func (squirrel *SquirrelService) findExportTypescript(ctx context.Context, module Node, name string) (ret *Node, err error) {
+	defer squirrel.onCall(module, String(name), lazyNodeStringer(&ret))()
+
+	reexports := []*sitter.Node{}
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+
+		source := stmt.ChildByFieldName("source")
+		declaration := stmt.ChildByFieldName("declaration")
+		value := stmt.ChildByFieldName("value")
+
+		if isDefaultExportTypescript(stmt) {
+			if name != "default" {
+				continue
+			}
+			if declaration != nil {
+				if declName := declaration.ChildByFieldName("name"); declName != nil {
+					return swapNodePtr(module, declName), nil
+				}
+				return swapNodePtr(module, declaration), nil
+			}
+			if value != nil && value.Type() == "identifier" {
+				return squirrel.getDefTypescript(ctx, swapNode(module, value))
+			}
+			if value != nil {
+				return swapNodePtr(module, value), nil
+			}
+			continue
+		}
+
+		// export function f() { ... }
+		if declaration != nil {
+			if found := findDeclTypescript(swapNode(module, declaration), name); found != nil {
+				return found, nil
+			}
+			continue
+		}
+
+		// export { x, y as z } and export { x } from './x'
+		foundClause := false
+		for _, clause := range children(stmt) {
+			if clause.Type() != "export_clause" {
+				continue
+			}
+			foundClause = true
+			for _, specifier := range children(clause) {
+				if specifier.Type() != "export_specifier" {
+					continue
+				}
+				specName := specifier.ChildByFieldName("name")
+				if specName == nil {
+					continue
+				}
+				exported := specName
+				if alias := specifier.ChildByFieldName("alias"); alias != nil {
+					exported = alias
+				}
+				if exported.Content(module.Contents) != name {
+					continue
+				}
+				if source != nil {
+					other, err := squirrel.resolveModuleTypescript(ctx, swapNode(module, source), getStringContentsTypescript(swapNode(module, source)))
+					if err != nil {
+						return nil, err
+					}
+					if other == nil {
+						return nil, nil
+					}
+					return squirrel.findExportTypescript(ctx, *other, specName.Content(module.Contents))
+				}
+				return squirrel.getDefTypescript(ctx, swapNode(module, specName))
+			}
+		}
+
+		// export * from './x'
+		if !foundClause && source != nil {
+			reexports = append(reexports, source)
+		}
+	}
+
+	for _, source := range reexports {
+		other, err := squirrel.resolveModuleTypescript(ctx, swapNode(module, source), getStringContentsTypescript(swapNode(module, source)))
+		if err != nil {
+			return nil, err
+		}
+		if other == nil {
+			continue
+		}
+		found, err := squirrel.findExportTypescript(ctx, *other, name)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldTypescript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	var ty *Node
+	switch object.Type() {
+	case "identifier":
+		def, err := squirrel.getDefTypescript(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			return nil, nil
+		}
+		if def.Type() == "program" {
+			// The object is a namespace import
+			return squirrel.findExportTypescript(ctx, *def, field)
+		}
+		ty, err = squirrel.defToTypeTypescript(ctx, *def)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		ty, err = squirrel.getTypeDefTypescript(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldTypescript(ctx, *ty, field)
+}
+
+// lookupFieldTypescript finds a member of the given class or interface declaration, including
+// inherited members.
+func (squirrel *SquirrelService) lookupFieldTypescript(ctx context.Context, ty Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty, &Tuple{String(ty.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	body := ty.ChildByFieldName("body")
+	if body == nil {
+		return nil, nil
+	}
+	for _, member := range children(body) {
+		switch member.Type() {
+		case "method_definition", "method_signature", "abstract_method_signature", "public_field_definition", "property_signature":
+			name := member.ChildByFieldName("name")
+			if name != nil && name.Content(ty.Contents) == field {
+				return swapNodePtr(ty, name), nil
+			}
+			if member.Type() == "method_definition" && name != nil && name.Content(ty.Contents) == "constructor" {
+				// Parameter properties, e.g. constructor(private x: number) { ... }
+				parameters := member.ChildByFieldName("parameters")
+				for _, param := range children(parameters) {
+					hasModifier := false
+					for _, child := range children(param) {
+						if child.Type() == "accessibility_modifier" {
+							hasModifier = true
+						}
+					}
+					if !hasModifier {
+						continue
+					}
+					if found := findPatternIdentTypescript(swapNode(ty, param), field); found != nil {
+						return found, nil
+					}
+				}
+			}
+		}
+	}
+
+	for _, super := range getSuperclassesTypescript(ty) {
+		superTy, err := squirrel.getTypeDefTypescript(ctx, super)
+		if err != nil {
+			return nil, err
+		}
+		if superTy == nil {
+			continue
+		}
+		found, err := squirrel.lookupFieldTypescript(ctx, *superTy, field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getTypeDefTypescript returns the class or interface declaration of the type of the given
+// expression or type.
+func (squirrel *SquirrelService) getTypeDefTypescript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+		found, err := squirrel.getDefTypescript(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.Node == nil || found.Type() == "program" {
+			return nil, nil
+		}
+		return squirrel.defToTypeTypescript(ctx, *found)
+	case "this":
+		class := getEnclosingClassTypescript(node)
+		if class == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, class), nil
+	case "type_annotation", "parenthesized_expression", "non_null_expression", "await_expression", "parenthesized_type":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefTypescript(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "generic_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypescript(ctx, swapNode(node, name))
+	case "nested_type_identifier":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypescript(ctx, swapNode(node, name))
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypescript(ctx, swapNode(node, constructor))
+	case "as_expression":
+		// x as T
+		if node.NamedChildCount() < 2 {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypescript(ctx, swapNode(node, node.NamedChild(1)))
+	case "member_expression":
+		object := node.ChildByFieldName("object")
+		property := node.ChildByFieldName("property")
+		if object == nil || property == nil {
+			return nil, nil
+		}
+		found, err := squirrel.getFieldTypescript(ctx, swapNode(node, object), property.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeTypescript(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		var found *Node
+		switch fn.Type() {
+		case "identifier":
+			found, err = squirrel.getDefTypescript(ctx, swapNode(node, fn))
+		case "member_expression":
+			object := fn.ChildByFieldName("object")
+			property := fn.ChildByFieldName("property")
+			if object == nil || property == nil {
+				return nil, nil
+			}
+			found, err = squirrel.getFieldTypescript(ctx, swapNode(node, object), property.Content(node.Contents))
+		default:
+			squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefTypescript: unrecognized function node type %q", fn.Type()))
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.Node == nil {
+			return nil, nil
+		}
+		parent := found.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+		switch parent.Type() {
+		case "function_declaration", "method_definition", "method_signature", "abstract_method_signature":
+			returnType := parent.ChildByFieldName("return_type")
+			if returnType == nil {
+				return nil, nil
+			}
+			return squirrel.getTypeDefTypescript(ctx, swapNode(*found, returnType))
+		default:
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefTypescript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+// defToTypeTypescript returns the class or interface declaration of the type of the given
+// definition.
+func (squirrel *SquirrelService) defToTypeTypescript(ctx context.Context, def Node) (*Node, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "class_declaration", "abstract_class_declaration", "class", "interface_declaration":
+		return swapNodePtr(def, parent), nil
+	case "variable_declarator", "public_field_definition", "property_signature", "required_parameter", "optional_parameter":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			for _, child := range children(parent) {
+				if child.Type() == "type_annotation" {
+					ty = child
+				}
+			}
+		}
+		if ty != nil {
+			return squirrel.getTypeDefTypescript(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value != nil {
+			return squirrel.getTypeDefTypescript(ctx, swapNode(def, value))
+		}
+		return nil, nil
+	case "import_specifier", "import_clause", "namespace_import":
+		// The import could not be resolved
+		return nil, nil
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("defToTypeTypescript: unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// findDeclTypescript finds the definition of ident in the given statement.
+func findDeclTypescript(stmt Node, ident string) *Node {
+	switch stmt.Type() {
+	case "lexical_declaration", "variable_declaration":
+		for _, declarator := range children(stmt.Node) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			name := declarator.ChildByFieldName("name")
+			if name == nil {
+				continue
+			}
+			if found := findPatternIdentTypescript(swapNode(stmt, name), ident); found != nil {
+				return found
+			}
+		}
+	case "function_declaration", "generator_function_declaration", "class_declaration", "abstract_class_declaration",
+		"interface_declaration", "type_alias_declaration", "enum_declaration", "internal_module", "module":
+		name := stmt.ChildByFieldName("name")
+		if name != nil && name.Content(stmt.Contents) == ident {
+			return swapNodePtr(stmt, name)
+		}
+	case "export_statement":
+		declaration := stmt.ChildByFieldName("declaration")
+		if declaration != nil {
+			return findDeclTypescript(swapNode(stmt, declaration), ident)
+		}
+	case "expression_statement":
+		// namespace Foo { ... } is wrapped in an expression_statement
+		for _, child := range children(stmt.Node) {
+			if child.Type() == "internal_module" {
+				return findDeclTypescript(swapNode(stmt, child), ident)
+			}
+		}
+	}
+	return nil
+}
+
+// findParamTypescript finds ident in the parameters or type parameters of a function.
+func findParamTypescript(fn Node, ident string) *Node {
+	if found := findTypeParamTypescript(fn, ident); found != nil {
+		return found
+	}
+	parameter := fn.ChildByFieldName("parameter")
+	if parameter != nil && parameter.Content(fn.Contents) == ident {
+		// x => ...
+		return swapNodePtr(fn, parameter)
+	}
+	parameters := fn.ChildByFieldName("parameters")
+	for _, param := range children(parameters) {
+		switch param.Type() {
+		case "required_parameter", "optional_parameter":
+			if found := findPatternIdentTypescript(swapNode(fn, param), ident); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// findTypeParamTypescript finds ident in the type parameters of a declaration.
+func findTypeParamTypescript(decl Node, ident string) *Node {
+	typeParameters := decl.ChildByFieldName("type_parameters")
+	for _, typeParameter := range children(typeParameters) {
+		name := typeParameter.ChildByFieldName("name")
+		if name != nil && name.Content(decl.Contents) == ident {
+			return swapNodePtr(decl, name)
+		}
+	}
+	return nil
+}
+
+// findPatternIdentTypescript finds ident in a binding pattern, which is either an identifier or a
+// destructuring pattern like { x, y } or [x, y].
+func findPatternIdentTypescript(pattern Node, ident string) *Node {
+	switch pattern.Type() {
+	case "identifier", "shorthand_property_identifier_pattern":
+		if pattern.Content(pattern.Contents) == ident {
+			return &pattern
+		}
+		return nil
+	case "required_parameter", "optional_parameter", "rest_pattern", "object_pattern", "array_pattern", "pair_pattern", "object_assignment_pattern", "assignment_pattern":
+		for _, child := range children(pattern.Node) {
+			// Skip types, default values, and the keys of { key: value } patterns
+			switch child.Type() {
+			case "type_annotation", "accessibility_modifier", "property_identifier":
+				continue
+			}
+			if left := pattern.ChildByFieldName("left"); left != nil && nodeId(child) != nodeId(left) {
+				continue
+			}
+			if value := pattern.ChildByFieldName("value"); value != nil && nodeId(child) == nodeId(value) {
+				continue
+			}
+			if found := findPatternIdentTypescript(swapNode(pattern, child), ident); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// getEnclosingClassTypescript returns the closest class that contains the given node.
+func getEnclosingClassTypescript(node Node) *sitter.Node {
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "class_declaration", "abstract_class_declaration", "class":
+			return cur
+		}
+	}
+	return nil
+}
+
+// getSuperclassesTypescript returns the classes and interfaces that the given declaration extends.
+func getSuperclassesTypescript(decl Node) []Node {
+	supers := []Node{}
+	for _, child := range children(decl.Node) {
+		switch child.Type() {
+		case "class_heritage":
+			for _, clause := range children(child) {
+				if clause.Type() != "extends_clause" {
+					continue
+				}
+				for _, super := range children(clause) {
+					if super.Type() == "type_arguments" {
+						continue
+					}
+					supers = append(supers, swapNode(decl, super))
+				}
+			}
+		case "extends_clause", "extends_type_clause":
+			for _, super := range children(child) {
+				supers = append(supers, swapNode(decl, super))
+			}
+		}
+	}
+	return supers
+}
+
+// isDefaultExportTypescript returns true for `export default ...` statements.
+func isDefaultExportTypescript(stmt *sitter.Node) bool {
+	for i := 0; i < int(stmt.ChildCount()); i++ {
+		if stmt.Child(i).Type() == "default" {
+			return true
+		}
+	}
+	return false
+}
+
+// getStringContentsTypescript returns the contents of a string literal without the quotes.
+func getStringContentsTypescript(str Node) string {
+	return strings.Trim(str.Content(str.Contents), "\"'`")
+}
@@ -124,6 +124,13 @@ var langToLangSpec = map[string]LangSpec{
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration                  name: (identifier)       @symbol))
+(source_file (method_declaration                    name: (field_identifier) @symbol))
+(source_file (type_declaration  (type_spec          name: (type_identifier)  @symbol)))
+(source_file (var_declaration   (var_spec           name: (identifier)       @symbol)))
+(source_file (const_declaration (const_spec         name: (identifier)       @symbol)))
 `,
 	},
 	"csharp": {
@@ -149,6 +156,14 @@ var langToLangSpec = map[string]LangSpec{
 (variable_declarator (identifier) @definition)       ; int x = ...
 (for_each_statement  left: (identifier) @definition) ; foreach (int x in xs) ...
 (catch_declaration   name: (identifier) @definition) ; catch (Exception e) { ... }
+`,
+		topLevelSymbolsQuery: `
+(compilation_unit                         (class_declaration     name: (identifier) @symbol))
+(compilation_unit                         (interface_declaration name: (identifier) @symbol))
+(compilation_unit                         (enum_declaration      name: (identifier) @symbol))
+(namespace_declaration (declaration_list (class_declaration     name: (identifier) @symbol)))
+(namespace_declaration (declaration_list (interface_declaration name: (identifier) @symbol)))
+(namespace_declaration (declaration_list (enum_declaration      name: (identifier) @symbol)))
 `,
 	},
 	"python": {
@@ -227,6 +242,14 @@ var langToLangSpec = map[string]LangSpec{
 (arrow_function parameter: (identifier) @definition)            ; x => ...
 (for_in_statement left: (identifier) @definition)               ; for (const x of xs) ...
 (catch_clause parameter: (identifier) @definition)              ; catch (e) ...
+`,
+		topLevelSymbolsQuery: `
+(program                                 (function_declaration  name: (identifier)      @symbol))
+(program                                 (class_declaration     name: (type_identifier) @symbol))
+(program                                 (interface_declaration name: (type_identifier) @symbol))
+(program (export_statement declaration: (function_declaration  name: (identifier)      @symbol)))
+(program (export_statement declaration: (class_declaration     name: (type_identifier) @symbol)))
+(program (export_statement declaration: (interface_declaration name: (type_identifier) @symbol)))
 `,
 	},
 	"cpp": {
@@ -148,11 +148,13 @@ func (squirrel *SquirrelService) getDef(ctx context.Context, node Node) (*Node,
 		return squirrel.getDefStarlark(ctx, node)
 	case "python":
 		return squirrel.getDefPython(ctx, node)
-	// case "go":
-	// case "csharp":
-	// case "python":
+	case "go":
+		return squirrel.getDefGo(ctx, node)
+	case "csharp":
+		return squirrel.getDefCsharp(ctx, node)
+	case "typescript":
+		return squirrel.getDefTypescript(ctx, node)
 	// case "javascript":
-	// case "typescript":
 	// case "cpp":
 	// case "ruby":
 	default:
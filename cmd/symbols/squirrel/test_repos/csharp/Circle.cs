@@ -0,0 +1,51 @@
+using System;
+
+namespace Geometry
+{
+    //                    vvvvv cs.Shape ref
+    public class Circle : Shape // < "Circle" cs.Circle def
+    {
+        private double radius; // < "radius" cs.Circle.radius def
+
+        //                   vvvvvv cs.Circle.ctor.radius def
+        public Circle(double radius)
+        {
+            //   vvvvvv cs.Circle.radius ref
+            //            vvvvvv cs.Circle.ctor.radius ref
+            this.radius = radius;
+        }
+
+        public override double Area() // < "Area" cs.Circle.Area def
+        {
+            //               vvvvvv cs.Circle.radius ref
+            return Math.PI * radius * radius;
+        }
+
+        public string Describe(double[] scales) // < "scales" cs.Describe.scales def
+        {
+            //  vvvvv cs.Describe.other def
+            //              vvvvvv cs.Circle ref
+            var other = new Circle(1);
+            //             vvvvv cs.Describe.other ref
+            //                   vvvvvv cs.Circle.radius ref
+            double total = other.radius;
+            //                   vvvv cs.Shape.Name ref
+            string label = other.Name;
+
+            //                   vvvvvv cs.Describe.square def
+            //                                    v cs.Describe.n def
+            //                                              v cs.Describe.n ref
+            Func<double, double> square = (double n) => n * n;
+            //           vvvvv cs.Describe.scale def
+            //                    vvvvvv cs.Describe.scales ref
+            foreach (var scale in scales)
+            {
+                //       vvvvvv cs.Describe.square ref
+                //              vvvvv cs.Describe.scale ref
+                total += square(scale);
+            }
+
+            return label + total;
+        }
+    }
+}
@@ -0,0 +1,12 @@
+namespace Geometry
+{
+    public class Shape // < "Shape" cs.Shape def
+    {
+        public string Name; // < "Name" cs.Shape.Name def
+
+        public virtual double Area() // < "Area" cs.Shape.Area def
+        {
+            return 0;
+        }
+    }
+}
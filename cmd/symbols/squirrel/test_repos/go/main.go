@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/squirrel/util" // < "example.com" util path
+)
+
+type Server struct { // < "Server" go.Server def
+	name   string       // < "name" go.Server.name def
+	logger *util.Logger // < "Logger" go.util.Logger ref
+}
+
+func NewServer(name string) *Server { // < "NewServer" go.NewServer def
+	//      vvvvvv go.Server ref
+	return &Server{name: name, logger: util.NewLogger()}
+}
+
+func (s *Server) Name() string { // < "Name" go.Server.Name def
+	//       vvvv go.Server.name ref
+	return s.name
+}
+
+func (s *Server) Log(msg string) {
+	//       vvvv go.util.Logger.Info ref
+	s.logger.Info(msg)
+}
+
+func count(items []string) int { // < "items" go.count.items def
+	total := 0 // < "total" go.count.total def
+	//     vvvv go.count.item def
+	//                   vvvvv go.count.items ref
+	for i, item := range items { // < "i" go.count.i def
+		// v go.count.n def
+		//          vvvv go.count.item ref
+		//                     v go.count.i ref
+		if n := len(item); n > i {
+			total += n // < "total" go.count.total ref < "n" go.count.n ref
+		}
+	}
+	//             v go.count.f def
+	double := func(f int) int {
+		return f * 2 // < "f" go.count.f ref
+	}
+	//            vvvvv go.count.total ref
+	return double(total)
+}
+
+func describe(value interface{}) string { // < "value" go.describe.value def
+	//     v go.describe.x def
+	//          vvvvv go.describe.value ref
+	switch x := value.(type) {
+	case string:
+		return x // < "x" go.describe.x ref
+	}
+	return ""
+}
+
+func main() {
+	server := NewServer("squirrel") // < "NewServer" go.NewServer ref
+	//                 vvvv go.Server.Name ref
+	fmt.Println(server.Name())
+	//        vvvv util path
+	//             vvvvvvvvv go.util.NewLogger ref
+	helper := util.NewLogger()
+	helper.Info("hello") // < "Info" go.util.Logger.Info ref
+	//               vvvvvvv go.util.Version ref
+	fmt.Println(util.Version)
+	//          vvvvvvvvvvv go.defaultName ref
+	fmt.Println(defaultName, count(nil), describe(nil))
+}
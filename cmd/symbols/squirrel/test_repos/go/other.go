@@ -0,0 +1,3 @@
+package main
+
+const defaultName = "squirrel" // < "defaultName" go.defaultName def
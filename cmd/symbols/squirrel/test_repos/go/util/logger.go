@@ -0,0 +1,16 @@
+package util
+
+const Version = "1.0" // < "Version" go.util.Version def
+
+type Logger struct { // < "Logger" go.util.Logger def
+	prefix string // < "prefix" go.util.Logger.prefix def
+}
+
+func NewLogger() *Logger { // < "NewLogger" go.util.NewLogger def
+	return &Logger{prefix: "> "}
+}
+
+func (l *Logger) Info(msg string) { // < "Info" go.util.Logger.Info def
+	//        vvvvvv go.util.Logger.prefix ref
+	println(l.prefix + msg)
+}
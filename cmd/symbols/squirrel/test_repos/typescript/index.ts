@@ -0,0 +1,45 @@
+import origin, { add, Vector as Vec } from './math'
+import * as math from './math'
+import { Formatter } from './util'
+
+function main(values: number[]): void { // < "values" ts.main.values def
+    let sum = 0 // < "sum" ts.main.sum def
+    //         vvvvv ts.main.value def
+    //                  vvvvvv ts.main.values ref
+    for (const value of values) {
+        //    vvv ts.add ref
+        //        vvv ts.main.sum ref
+        //             vvvvv ts.main.value ref
+        sum = add(sum, value)
+    }
+
+    //              v ts.Vector ref
+    const vec = new Vec(sum, sum)
+    //              vvvvvv ts.Vector.length ref
+    console.log(vec.length())
+    //               vvv ts.add ref
+    console.log(math.add(1, 2))
+    //          v ts.ORIGIN ref
+    //                 v ts.Vector.x ref
+    console.log(origin.x)
+
+    //              v ts.main.n def
+    //                            v ts.main.n ref
+    const square = (n: number) => n * n
+    //          v ts.main.k def
+    //               v ts.main.k ref
+    const inc = k => k + 1
+
+    //                    vvvvvvvvv ts.Formatter ref
+    const formatter = new Formatter()
+    //                    vvvvvv ts.Formatter.format ref
+    console.log(formatter.format(square(inc(sum))))
+
+    try {
+        console.log(vec.x)
+        //   vvv ts.main.err def
+    } catch (err) {
+        //          vvv ts.main.err ref
+        console.log(err)
+    }
+}
@@ -0,0 +1,18 @@
+export function add(a: number, b: number): number { // < "add" ts.add def
+    return a + b
+}
+
+export class Vector { // < "Vector" ts.Vector def
+    //                 v ts.Vector.x def
+    constructor(public x: number, public y: number) {}
+
+    //     vvvvvv ts.Vector.length def
+    public length(): number {
+        //                    v ts.Vector.x ref
+        return Math.sqrt(this.x * this.x + this.y * this.y)
+    }
+}
+
+const ORIGIN = new Vector(0, 0) // < "ORIGIN" ts.ORIGIN def
+
+export default ORIGIN
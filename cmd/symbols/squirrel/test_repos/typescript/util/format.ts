@@ -0,0 +1,5 @@
+export class Formatter { // < "Formatter" ts.Formatter def
+    public format(n: number): string { // < "format" ts.Formatter.format def
+        return n.toFixed(2)
+    }
+}
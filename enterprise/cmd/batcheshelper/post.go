@@ -1,11 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 	"time"
 
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -39,12 +42,25 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		return errors.Wrap(err, "failed to read stderr file")
 	}
 
+	// Read the outcome of the current step.
+	exitCode, attempts, err := readStepOutcome(stepIdx)
+	if err != nil {
+		return err
+	}
+	failed := exitCode != 0
+	if failed && !step.ContinueOnError {
+		return errors.Newf("step %d failed with exit code %d", stepIdx+1, exitCode)
+	}
+
 	// Build the step result.
 	stepResult := execution.AfterStepResult{
 		Stdout:    string(stdout),
 		Stderr:    string(stderr),
 		StepIndex: stepIdx,
 		Diff:      string(diff),
+		ExitCode:  exitCode,
+		Attempts:  attempts,
+		Failed:    failed,
 		// Those will be set below.
 		Outputs: make(map[string]interface{}),
 	}
@@ -91,6 +107,12 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		return errors.Wrap(err, "failed to write step result file")
 	}
 
+	// Don't cache the result of a failed step, so that it's run again the next
+	// time the workspace is executed.
+	if failed {
+		return nil
+	}
+
 	key := cache.KeyForWorkspace(
 		&executionInput.BatchChangeAttributes,
 		batcheslib.Repository{
@@ -127,6 +149,31 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 	return nil
 }
 
+// readStepOutcome returns the exit code of the last run of the step with the
+// given index and the number of times it was run. The exit code is -1 if the
+// last run didn't finish, for example because it timed out.
+func readStepOutcome(stepIdx int) (exitCode, attempts int, err error) {
+	c, err := os.ReadFile(fmt.Sprintf("attempts%d", stepIdx))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read attempts file")
+	}
+	attempts = bytes.Count(c, []byte("\n"))
+
+	c, err = os.ReadFile(fmt.Sprintf("exitcode%d", stepIdx))
+	if os.IsNotExist(err) {
+		return -1, attempts, nil
+	}
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read exit code file")
+	}
+	exitCode, err = strconv.Atoi(strings.TrimSpace(string(c)))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid exit code")
+	}
+
+	return exitCode, attempts, nil
+}
+
 func runGitCmd(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
 	cmd.Dir = "repository"
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -90,7 +91,34 @@ func (c *Client) Dequeue(ctx context.Context, queueName string, job *executor.Jo
 		return false, err
 	}
 
-	return c.client.DoAndDecode(ctx, req, &job)
+	c.operations.dequeueAttempts.WithLabelValues(queueName).Inc()
+	dequeued, err := c.client.DoAndDecode(ctx, req, &job)
+	if dequeued && err == nil {
+		c.operations.jobsDequeued.WithLabelValues(queueName).Inc()
+	}
+	return dequeued, err
+}
+
+// DequeueAny dequeues a job from the first of the given queues that has one, and returns
+// the name of that queue. Queues that fail to dequeue are skipped, so that one failing
+// queue does not starve the others. An error is only returned if no job was dequeued.
+func (c *Client) DequeueAny(ctx context.Context, queueNames []string, job *executor.Job) (queueName string, dequeued bool, err error) {
+	var errs error
+	for _, name := range queueNames {
+		dequeued, err := c.Dequeue(ctx, name, job)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "dequeueing from queue %q", name))
+			continue
+		}
+		if dequeued {
+			if errs != nil {
+				c.logger.Warn("Failed to dequeue from some queues", log.Error(errs))
+			}
+			return name, true, nil
+		}
+	}
+
+	return "", false, errs
 }
 
 func (c *Client) AddExecutionLogEntry(ctx context.Context, queueName string, jobID int, entry workerutil.ExecutionLogEntry) (entryID int, err error) {
@@ -225,11 +253,55 @@ func (c *Client) Heartbeat(ctx context.Context, queueName string, jobIDs []int)
 	}})
 	defer endObservation(1, observation.Args{})
 
+	return c.heartbeat(ctx, queueName, jobIDs, c.gatherMetrics())
+}
+
+// HeartbeatQueues sends a heartbeat for the given running jobs to each of the given
+// queues, and returns the known and canceled job IDs by queue. A heartbeat is sent to
+// every queue, even if no job of that queue is running. Metrics are only gathered once
+// for all queues.
+func (c *Client) HeartbeatQueues(ctx context.Context, jobIDsByQueue map[string][]int) (knownIDsByQueue, cancelIDsByQueue map[string][]int, err error) {
+	queueNames := make([]string, 0, len(jobIDsByQueue))
+	for queueName := range jobIDsByQueue {
+		queueNames = append(queueNames, queueName)
+	}
+	sort.Strings(queueNames)
+
+	ctx, _, endObservation := c.operations.heartbeatQueues.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("queueNames", strings.Join(queueNames, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	metrics := c.gatherMetrics()
+
+	knownIDsByQueue = make(map[string][]int, len(queueNames))
+	cancelIDsByQueue = make(map[string][]int, len(queueNames))
+	for _, queueName := range queueNames {
+		knownIDs, cancelIDs, err := c.heartbeat(ctx, queueName, jobIDsByQueue[queueName], metrics)
+		if err != nil {
+			// Returning partial results would make the worker drop the running jobs of
+			// the failed queue, so fail the entire heartbeat instead.
+			return nil, nil, errors.Wrapf(err, "heartbeat for queue %q", queueName)
+		}
+
+		knownIDsByQueue[queueName] = knownIDs
+		cancelIDsByQueue[queueName] = cancelIDs
+	}
+
+	return knownIDsByQueue, cancelIDsByQueue, nil
+}
+
+func (c *Client) gatherMetrics() string {
 	metrics, err := gatherMetrics(c.logger, c.metricsGatherer)
 	if err != nil {
 		c.logger.Error("Failed to collect prometheus metrics for heartbeat", log.Error(err))
 		// Continue, no metric errors should prevent heartbeats.
 	}
+	return metrics
+}
+
+func (c *Client) heartbeat(ctx context.Context, queueName string, jobIDs []int, metrics string) (knownIDs, cancelIDs []int, err error) {
+	c.operations.runningJobs.WithLabelValues(queueName).Set(float64(len(jobIDs)))
 
 	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", queueName), executor.HeartbeatRequest{
 		// Request the new-fashioned payload.
@@ -87,6 +87,55 @@ func TestDequeueBadResponse(t *testing.T) {
 	})
 }
 
+func TestDequeueAny(t *testing.T) {
+	specs := []routeSpec{
+		{
+			expectedMethod:  "POST",
+			expectedPath:    "/.executors/queue/first_queue/dequeue",
+			expectedToken:   "hunter2",
+			expectedPayload: `{"executorName": "deadbeef"}`,
+			responseStatus:  http.StatusNoContent,
+		},
+		{
+			expectedMethod:  "POST",
+			expectedPath:    "/.executors/queue/second_queue/dequeue",
+			expectedToken:   "hunter2",
+			expectedPayload: `{"executorName": "deadbeef"}`,
+			responseStatus:  http.StatusInternalServerError,
+		},
+		{
+			expectedMethod:  "POST",
+			expectedPath:    "/.executors/queue/third_queue/dequeue",
+			expectedToken:   "hunter2",
+			expectedPayload: `{"executorName": "deadbeef"}`,
+			responseStatus:  http.StatusOK,
+			responsePayload: `{"id": 42}`,
+		},
+	}
+
+	testRoutes(t, specs, func(client *Client) {
+		var job executor.Job
+		queueName, dequeued, err := client.DequeueAny(context.Background(), []string{"first_queue", "second_queue", "third_queue"}, &job)
+		if err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+		if !dequeued {
+			t.Fatalf("expected record to be dequeued")
+		}
+		if queueName != "third_queue" {
+			t.Errorf("unexpected queue. want=%q have=%q", "third_queue", queueName)
+		}
+		if job.ID != 42 {
+			t.Errorf("unexpected id. want=%d have=%d", 42, job.ID)
+		}
+
+		// If no queue has a job, the errors of the failed queues are returned.
+		if _, dequeued, err := client.DequeueAny(context.Background(), []string{"first_queue", "second_queue"}, &job); err == nil || dequeued {
+			t.Errorf("expected an error. have dequeued=%v err=%v", dequeued, err)
+		}
+	})
+}
+
 func TestAddExecutionLogEntry(t *testing.T) {
 	entry := workerutil.ExecutionLogEntry{
 		Key:        "foo",
@@ -420,6 +469,61 @@ func TestHeartbeatBadResponse(t *testing.T) {
 	})
 }
 
+func TestHeartbeatQueues(t *testing.T) {
+	heartbeatPayload := func(jobIDs string) string {
+		return `{
+			"executorName": "deadbeef",
+			"jobIds": ` + jobIDs + `,
+			"version": "V2",
+
+			"os": "test-os",
+			"architecture": "test-architecture",
+			"dockerVersion": "test-docker-version",
+			"executorVersion": "test-executor-version",
+			"gitVersion": "test-git-version",
+			"igniteVersion": "test-ignite-version",
+			"srcCliVersion": "test-src-cli-version",
+
+			"prometheusMetrics": ""
+		}`
+	}
+	specs := []routeSpec{
+		{
+			expectedMethod:  "POST",
+			expectedPath:    "/.executors/queue/first_queue/heartbeat",
+			expectedToken:   "hunter2",
+			expectedPayload: heartbeatPayload("[1,2]"),
+			responseStatus:  http.StatusOK,
+			responsePayload: `{"knownIDs": [1], "cancelIDs": [1]}`,
+		},
+		{
+			expectedMethod:  "POST",
+			expectedPath:    "/.executors/queue/second_queue/heartbeat",
+			expectedToken:   "hunter2",
+			expectedPayload: heartbeatPayload("null"),
+			responseStatus:  http.StatusOK,
+			responsePayload: `{"knownIDs": [], "cancelIDs": []}`,
+		},
+	}
+
+	testRoutes(t, specs, func(client *Client) {
+		knownIDs, cancelIDs, err := client.HeartbeatQueues(context.Background(), map[string][]int{
+			"first_queue":  {1, 2},
+			"second_queue": nil,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error performing heartbeat: %s", err)
+		}
+
+		if diff := cmp.Diff(map[string][]int{"first_queue": {1}, "second_queue": {}}, knownIDs); diff != "" {
+			t.Errorf("unexpected known ids (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(map[string][]int{"first_queue": {1}, "second_queue": {}}, cancelIDs); diff != "" {
+			t.Errorf("unexpected cancel ids (-want +got):\n%s", diff)
+		}
+	})
+}
+
 type routeSpec struct {
 	expectedMethod   string
 	expectedPath     string
@@ -434,11 +538,17 @@ func testRoute(t *testing.T, spec routeSpec, f func(client *Client)) {
 	ts := testServer(t, spec)
 	defer ts.Close()
 
-	options := Options{
+	client, err := New(testOptions(ts.URL), prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return nil, nil }), &observation.TestContext)
+	require.NoError(t, err)
+	f(client)
+}
+
+func testOptions(url string) Options {
+	return Options{
 		ExecutorName: "deadbeef",
 		BaseClientOptions: apiclient.BaseClientOptions{
 			EndpointOptions: apiclient.EndpointOptions{
-				URL:        ts.URL,
+				URL:        url,
 				PathPrefix: "/.executors/queue",
 				Token:      "hunter2",
 			},
@@ -453,14 +563,34 @@ func testRoute(t *testing.T, spec routeSpec, f func(client *Client)) {
 			SrcCliVersion:   "test-src-cli-version",
 		},
 	}
+}
 
-	client, err := New(options, prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return nil, nil }), &observation.TestContext)
+// testRoutes is like testRoute, but serves a route for each of the given specs. Requests
+// to other paths fail the test.
+func testRoutes(t *testing.T, specs []routeSpec, f func(client *Client)) {
+	mux := http.NewServeMux()
+	for _, spec := range specs {
+		mux.Handle(spec.expectedPath, routeHandler(t, spec))
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client, err := New(testOptions(ts.URL), prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return nil, nil }), &observation.TestContext)
 	require.NoError(t, err)
 	f(client)
 }
 
 func testServer(t *testing.T, spec routeSpec) *httptest.Server {
-	handler := func(w http.ResponseWriter, r *http.Request) {
+	return httptest.NewServer(routeHandler(t, spec))
+}
+
+func routeHandler(t *testing.T, spec routeSpec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != spec.expectedMethod {
 			t.Errorf("unexpected method. want=%s have=%s", spec.expectedMethod, r.Method)
 		}
@@ -486,8 +616,6 @@ func testServer(t *testing.T, spec routeSpec) *httptest.Server {
 		w.WriteHeader(spec.responseStatus)
 		w.Write([]byte(spec.responsePayload))
 	}
-
-	return httptest.NewServer(http.HandlerFunc(handler))
 }
 
 func normalizeJSON(v []byte) string {
@@ -3,6 +3,8 @@ package queue
 import (
 	"fmt"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -15,6 +17,11 @@ type operations struct {
 	markErrored             *observation.Operation
 	markFailed              *observation.Operation
 	heartbeat               *observation.Operation
+	heartbeatQueues         *observation.Operation
+
+	dequeueAttempts *prometheus.CounterVec
+	jobsDequeued    *prometheus.CounterVec
+	runningJobs     *prometheus.GaugeVec
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -33,6 +40,24 @@ func newOperations(observationContext *observation.Context) *operations {
 		})
 	}
 
+	dequeueAttempts := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_executor_queue_dequeue_attempts_total",
+		Help: "Total number of dequeue requests sent to a queue.",
+	}, []string{"queue"})
+	observationContext.Registerer.MustRegister(dequeueAttempts)
+
+	jobsDequeued := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_executor_queue_jobs_dequeued_total",
+		Help: "Total number of jobs dequeued from a queue.",
+	}, []string{"queue"})
+	observationContext.Registerer.MustRegister(jobsDequeued)
+
+	runningJobs := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_executor_queue_running_jobs",
+		Help: "The number of running jobs of a queue, as of the last heartbeat.",
+	}, []string{"queue"})
+	observationContext.Registerer.MustRegister(runningJobs)
+
 	return &operations{
 		dequeue:                 op("Dequeue"),
 		addExecutionLogEntry:    op("AddExecutionLogEntry"),
@@ -41,5 +66,10 @@ func newOperations(observationContext *observation.Context) *operations {
 		markErrored:             op("MarkErrored"),
 		markFailed:              op("MarkFailed"),
 		heartbeat:               op("Heartbeat"),
+		heartbeatQueues:         op("HeartbeatQueues"),
+
+		dequeueAttempts: dequeueAttempts,
+		jobsDequeued:    jobsDequeued,
+		runningJobs:     runningJobs,
 	}
 }
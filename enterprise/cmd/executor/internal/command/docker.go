@@ -3,6 +3,10 @@ package command
 import (
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // ScriptsPath is the location relative to the executor workspace where the executor
@@ -34,8 +38,10 @@ func formatRawOrDockerCommand(spec CommandSpec, dir string, options Options) com
 	return command{
 		Key: spec.Key,
 		Command: flatten(
+			timeoutCommand(spec.Timeout),
 			"docker", "run", "--rm",
-			dockerResourceFlags(options.ResourceOptions),
+			dockerInitFlags(spec.Timeout),
+			dockerResourceFlags(options.ResourceOptions, spec.CPUs, spec.Memory),
 			dockerVolumeFlags(hostDir),
 			dockerWorkingdirectoryFlags(spec.Dir),
 			dockerEnvFlags(spec.Env),
@@ -43,22 +49,105 @@ func formatRawOrDockerCommand(spec CommandSpec, dir string, options Options) com
 			spec.Image,
 			filepath.Join("/data", ScriptsPath, spec.ScriptPath),
 		),
+		Timeout:   spec.Timeout,
 		Operation: spec.Operation,
 	}
 }
 
-func dockerResourceFlags(options ResourceOptions) []string {
+// timeoutExitCode is the exit code of the timeout command when the command it
+// runs timed out.
+const timeoutExitCode = 124
+
+// timeoutKillAfter is the grace period after which the timeout command kills
+// the command it runs if it didn't exit after being terminated.
+const timeoutKillAfter = "10s"
+
+// timeoutCommand returns the command prefix that bounds the command following it
+// by the given timeout.
+//
+// The docker client has to be terminated rather than the context of the command
+// being canceled, so that it forwards the signal to the container instead of
+// leaving it behind. This also makes the timeout apply inside Firecracker VMs.
+func timeoutCommand(timeout time.Duration) []string {
+	if timeout <= 0 {
+		return nil
+	}
+
+	return []string{"timeout", "--kill-after", timeoutKillAfter, strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64) + "s"}
+}
+
+// dockerInitFlags runs an init process in containers with a timeout. Without it
+// the script is PID 1 in the container, which ignores the signal forwarded by
+// the docker client on timeout.
+func dockerInitFlags(timeout time.Duration) []string {
+	if timeout <= 0 {
+		return nil
+	}
+
+	return []string{"--init"}
+}
+
+func dockerResourceFlags(options ResourceOptions, cpus float64, memory string) []string {
 	flags := make([]string, 0, 2)
-	if options.NumCPUs != 0 {
+	if cpus > 0 && (options.NumCPUs == 0 || cpus < float64(options.NumCPUs)) {
+		flags = append(flags, "--cpus", strconv.FormatFloat(cpus, 'f', -1, 64))
+	} else if options.NumCPUs != 0 {
 		flags = append(flags, "--cpus", strconv.Itoa(options.NumCPUs))
 	}
-	if options.Memory != "0" && options.Memory != "" {
+	if memory != "" && lowerMemoryLimit(memory, options.Memory) {
+		flags = append(flags, "--memory", memory)
+	} else if options.Memory != "0" && options.Memory != "" {
 		flags = append(flags, "--memory", options.Memory)
 	}
 
 	return flags
 }
 
+// lowerMemoryLimit returns true if the given memory limit is lower than the
+// limit the executor is configured with, or the executor has no limit.
+func lowerMemoryLimit(memory, limit string) bool {
+	v, err := parseMemory(memory)
+	if err != nil || v == 0 {
+		return false
+	}
+	if limit == "0" || limit == "" {
+		return true
+	}
+
+	l, err := parseMemory(limit)
+	if err != nil {
+		return false
+	}
+	return v < l
+}
+
+// parseMemory returns the number of bytes of a memory limit in the format
+// accepted by docker, such as "512m" or "20G".
+func parseMemory(memory string) (int64, error) {
+	s := strings.TrimSuffix(strings.ToLower(memory), "b")
+
+	multiplier := int64(1)
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'k':
+			multiplier = 1 << 10
+		case 'm':
+			multiplier = 1 << 20
+		case 'g':
+			multiplier = 1 << 30
+		}
+		if multiplier != 1 {
+			s = s[:len(s)-1]
+		}
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid memory limit %q", memory)
+	}
+	return v * multiplier, nil
+}
+
 func dockerVolumeFlags(wd string) []string {
 	return []string{"-v", wd + ":/data"}
 }
@@ -2,6 +2,7 @@ package command
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -142,3 +143,86 @@ func TestFormatRawOrDockerCommandDockerScriptWithDockerHostMountPath(t *testing.
 		t.Errorf("unexpected command (-want +got):\n%s", diff)
 	}
 }
+
+func TestFormatRawOrDockerCommandDockerScriptWithStepLimits(t *testing.T) {
+	actual := formatRawOrDockerCommand(
+		CommandSpec{
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Timeout:    90 * time.Second,
+			CPUs:       0.5,
+			Memory:     "512m",
+			Operation:  makeTestOperation(),
+		},
+		"/proj/src",
+		Options{
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"timeout", "--kill-after", "10s", "90s",
+			"docker", "run", "--rm",
+			"--init",
+			"--cpus", "0.5",
+			"--memory", "512m",
+			"-v", "/proj/src:/data",
+			"-w", "/data/subdir",
+			"--entrypoint",
+			"/bin/sh",
+			"alpine:latest",
+			"/data/.sourcegraph-executor/myscript.sh",
+		},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestDockerResourceFlags(t *testing.T) {
+	for name, tc := range map[string]struct {
+		options ResourceOptions
+		cpus    float64
+		memory  string
+		want    []string
+	}{
+		"executor limits": {
+			options: ResourceOptions{NumCPUs: 4, Memory: "20G"},
+			want:    []string{"--cpus", "4", "--memory", "20G"},
+		},
+		"lower step limits": {
+			options: ResourceOptions{NumCPUs: 4, Memory: "20G"},
+			cpus:    2,
+			memory:  "1g",
+			want:    []string{"--cpus", "2", "--memory", "1g"},
+		},
+		"higher step limits": {
+			options: ResourceOptions{NumCPUs: 4, Memory: "20G"},
+			cpus:    8,
+			memory:  "32g",
+			want:    []string{"--cpus", "4", "--memory", "20G"},
+		},
+		"step limits without executor limits": {
+			options: ResourceOptions{Memory: "0"},
+			cpus:    1.5,
+			memory:  "2048m",
+			want:    []string{"--cpus", "1.5", "--memory", "2048m"},
+		},
+		"invalid step memory": {
+			options: ResourceOptions{Memory: "20G"},
+			memory:  "lots",
+			want:    []string{"--memory", "20G"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, dockerResourceFlags(tc.options, tc.cpus, tc.memory)); diff != "" {
+				t.Errorf("unexpected flags (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -54,6 +54,7 @@ func formatFirecrackerCommand(spec CommandSpec, name string, options Options) co
 	return command{
 		Key:       spec.Key,
 		Command:   []string{"ignite", "exec", name, "--", innerCommand},
+		Timeout:   rawOrDockerCommand.Timeout,
 		Operation: spec.Operation,
 	}
 }
@@ -0,0 +1,391 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// KubernetesInstanceLabel is the label of Kubernetes jobs that identifies the executor
+	// instance that created them. The janitor only removes orphaned jobs of its own instance.
+	KubernetesInstanceLabel = "executor.sourcegraph.com/instance"
+
+	// KubernetesExecutorNameAnnotation is the annotation of Kubernetes jobs that holds the
+	// name of the executor job (see Options.ExecutorName) the job was created for.
+	KubernetesExecutorNameAnnotation = "executor.sourcegraph.com/name"
+
+	kubernetesKeyAnnotation = "executor.sourcegraph.com/key"
+	kubernetesContainerName = "job"
+	kubernetesVolumeName    = "workspace"
+	kubernetesContainerDir  = "/data"
+
+	// kubernetesJobTTL is the time after which finished jobs are removed by Kubernetes,
+	// in case the executor didn't get the chance to remove them itself.
+	kubernetesJobTTL = int32(time.Hour / time.Second)
+)
+
+// kubernetesPollInterval is the interval in which the status of the pods of
+// Kubernetes jobs is checked. It can be lowered for testing.
+var kubernetesPollInterval = time.Second
+
+type KubernetesOptions struct {
+	// Enabled determines if commands with an image will be run in Kubernetes jobs.
+	Enabled bool
+
+	// Clientset is the client used to manage jobs and read pod logs.
+	Clientset kubernetes.Interface
+
+	// Namespace is the namespace jobs are created in.
+	Namespace string
+
+	// InstanceName identifies this executor instance. It's used to label the jobs this
+	// instance creates, so that the janitors of several executors can share a namespace.
+	InstanceName string
+
+	// PersistenceVolumeName is the name of the persistent volume claim that holds the
+	// workspaces. It's mounted into the pods of jobs, with the workspace of the job as
+	// the sub path.
+	PersistenceVolumeName string
+
+	// MountPath is the path at which the persistent volume claim is mounted into the
+	// executor. All workspaces must be created below this path.
+	MountPath string
+
+	// NodeName, if set, schedules the pods of jobs onto the given node. This is required
+	// for persistent volumes that can only be mounted on a single node.
+	NodeName string
+
+	// NodeSelector, if set, constrains the nodes the pods of jobs are scheduled on.
+	NodeSelector map[string]string
+}
+
+type kubernetesRunner struct {
+	dir     string
+	logger  Logger
+	options Options
+
+	// subPath is the path of the workspace within the persistent volume.
+	subPath string
+	// numJobs is the number of jobs created by this runner. It's used to give each
+	// job a unique name.
+	numJobs int
+	// jobNames holds the names of the jobs created by this runner that might not
+	// have been deleted yet.
+	jobNames []string
+}
+
+var _ Runner = &kubernetesRunner{}
+
+func (r *kubernetesRunner) Setup(ctx context.Context) error {
+	subPath, err := filepath.Rel(r.options.KubernetesOptions.MountPath, r.dir)
+	if err != nil || subPath == ".." || strings.HasPrefix(subPath, "../") {
+		return errors.Newf("workspace %q is not within the Kubernetes volume mount path %q", r.dir, r.options.KubernetesOptions.MountPath)
+	}
+	r.subPath = subPath
+	return nil
+}
+
+func (r *kubernetesRunner) Teardown(ctx context.Context) (err error) {
+	for _, name := range r.jobNames {
+		if deleteErr := deleteKubernetesJob(ctx, r.options.KubernetesOptions, name); deleteErr != nil {
+			err = errors.Append(err, deleteErr)
+		}
+	}
+	r.jobNames = nil
+	return err
+}
+
+func (r *kubernetesRunner) Run(ctx context.Context, spec CommandSpec) (err error) {
+	// Commands without an image are run on the host, which is the executor pod.
+	if spec.Image == "" {
+		return runCommand(ctx, formatRawOrDockerCommand(spec, r.dir, r.options), r.logger)
+	}
+
+	ctx, _, endObservation := spec.Operation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	opts := r.options.KubernetesOptions
+	job := newKubernetesJob(fmt.Sprintf("%s-%d", r.options.ExecutorName, r.numJobs), spec, r.subPath, r.options)
+	r.numJobs++
+	log15.Info(fmt.Sprintf("Running command in Kubernetes job %s: %s", job.Name, strings.Join(job.Spec.Template.Spec.Containers[0].Command, " ")))
+
+	handle := r.logger.Log(spec.Key, flatten(spec.Image, job.Spec.Template.Spec.Containers[0].Command))
+	defer handle.Close()
+
+	job, err = opts.Clientset.BatchV1().Jobs(opts.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		handle.Finalize(1)
+		return errors.Wrap(err, "creating Kubernetes job")
+	}
+	r.jobNames = append(r.jobNames, job.Name)
+	defer func() {
+		// Delete the job outside of the job context, so that jobs are also cleaned up on
+		// timeout or cancellation.
+		if deleteErr := deleteKubernetesJob(context.Background(), opts, job.Name); deleteErr == nil {
+			r.jobNames = r.jobNames[:len(r.jobNames)-1]
+		}
+	}()
+
+	pod, err := waitForKubernetesPod(ctx, opts, job.Name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		handle.Finalize(1)
+		return err
+	}
+
+	if err := streamKubernetesPodLogs(ctx, opts, pod.Name, handle); err != nil {
+		handle.Finalize(1)
+		return err
+	}
+
+	pod, err = waitForKubernetesPod(ctx, opts, job.Name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		handle.Finalize(1)
+		return err
+	}
+
+	exitCode := kubernetesExitCode(pod)
+	handle.Finalize(exitCode)
+	if exitCode == 0 {
+		return nil
+	}
+
+	if pod.Status.Reason == "DeadlineExceeded" {
+		return errors.Newf("command timed out after %s", spec.Timeout)
+	}
+	return errors.New("command failed")
+}
+
+// newKubernetesJob returns a job that runs the script of the given spec in a container of
+// its image, with the workspace in the persistent volume mounted at /data.
+func newKubernetesJob(name string, spec CommandSpec, subPath string, options Options) *batchv1.Job {
+	opts := options.KubernetesOptions
+
+	env := make([]corev1.EnvVar, 0, len(spec.Env))
+	for _, e := range spec.Env {
+		elems := strings.SplitN(e, "=", 2)
+		if len(elems) != 2 {
+			continue
+		}
+		env = append(env, corev1.EnvVar{Name: elems[0], Value: elems[1]})
+	}
+
+	var activeDeadlineSeconds *int64
+	if spec.Timeout > 0 {
+		seconds := int64((spec.Timeout + time.Second - 1) / time.Second)
+		activeDeadlineSeconds = &seconds
+	}
+
+	backoffLimit := int32(0)
+	ttl := kubernetesJobTTL
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   kubernetesName(name),
+			Labels: map[string]string{KubernetesInstanceLabel: KubernetesLabelValue(opts.InstanceName)},
+			Annotations: map[string]string{
+				KubernetesExecutorNameAnnotation: options.ExecutorName,
+				kubernetesKeyAnnotation:          spec.Key,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{KubernetesInstanceLabel: KubernetesLabelValue(opts.InstanceName)},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					// The deadline is enforced on the pod rather than the job, so that the
+					// timed out pod is kept and reports the reason it failed.
+					ActiveDeadlineSeconds: activeDeadlineSeconds,
+					NodeName:              opts.NodeName,
+					NodeSelector:          opts.NodeSelector,
+					Containers: []corev1.Container{{
+						Name:       kubernetesContainerName,
+						Image:      spec.Image,
+						Command:    []string{"/bin/sh", filepath.Join(kubernetesContainerDir, ScriptsPath, spec.ScriptPath)},
+						WorkingDir: filepath.Join(kubernetesContainerDir, spec.Dir),
+						Env:        env,
+						Resources:  kubernetesResources(options.ResourceOptions, spec.CPUs, spec.Memory),
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      kubernetesVolumeName,
+							MountPath: kubernetesContainerDir,
+							SubPath:   subPath,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: kubernetesVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: opts.PersistenceVolumeName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// kubernetesResources returns the resource requirements of the container of a job. Like
+// dockerResourceFlags, the CPUs and memory of the spec can only lower the resource
+// options. Requests equal limits, so that jobs aren't scheduled onto nodes that can't
+// run them.
+func kubernetesResources(options ResourceOptions, cpus float64, memory string) corev1.ResourceRequirements {
+	resources := corev1.ResourceList{}
+	if cpus > 0 && (options.NumCPUs == 0 || cpus < float64(options.NumCPUs)) {
+		resources[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(cpus*1000), resource.DecimalSI)
+	} else if options.NumCPUs != 0 {
+		resources[corev1.ResourceCPU] = *resource.NewQuantity(int64(options.NumCPUs), resource.DecimalSI)
+	}
+
+	if memory == "" || !lowerMemoryLimit(memory, options.Memory) {
+		memory = options.Memory
+	}
+	if v, err := parseMemory(memory); err == nil && v > 0 {
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(v, resource.BinarySI)
+	}
+
+	if len(resources) == 0 {
+		return corev1.ResourceRequirements{}
+	}
+	return corev1.ResourceRequirements{Requests: resources, Limits: resources}
+}
+
+// waitForKubernetesPod waits until the pod of the given job satisfies the given condition.
+// It returns an error if the pod can't be started, for example because its image can't
+// be pulled.
+func waitForKubernetesPod(ctx context.Context, opts KubernetesOptions, jobName string, cond func(*corev1.Pod) bool) (*corev1.Pod, error) {
+	ticker := time.NewTicker(kubernetesPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pods, err := opts.Clientset.CoreV1().Pods(opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + jobName})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing Kubernetes pods")
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if cond(pod) {
+				return pod, nil
+			}
+			if reason := kubernetesWaitingReason(pod); reason != "" {
+				return nil, errors.Newf("Kubernetes pod %s can't be started: %s", pod.Name, reason)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// kubernetesWaitingReason returns the reason a container of the given pod can't be
+// started, or an empty string if it's still expected to start.
+func kubernetesWaitingReason(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+			return fmt.Sprintf("%s: %s", status.State.Waiting.Reason, status.State.Waiting.Message)
+		}
+	}
+	return ""
+}
+
+// streamKubernetesPodLogs writes the logs of the given pod to the given log entry until
+// the container of the pod exits. Pod logs don't distinguish between the output and
+// error streams, so all lines are prefixed with stdout.
+func streamKubernetesPodLogs(ctx context.Context, opts KubernetesOptions, podName string, handle LogEntry) error {
+	stream, err := opts.Clientset.CoreV1().Pods(opts.Namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: kubernetesContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return errors.Wrap(err, "streaming Kubernetes pod logs")
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	// Allocate an initial buffer of 4k and set the maximum size used to buffer a token
+	// to 100M, like readProcessPipes.
+	scanner.Buffer(make([]byte, 4*1024), 100*1024*1024)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(handle, "stdout: %s\n", scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// kubernetesExitCode returns the exit code of the container of the given finished pod.
+func kubernetesExitCode(pod *corev1.Pod) int {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kubernetesContainerName && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode)
+		}
+	}
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return 0
+	}
+	return 1
+}
+
+func deleteKubernetesJob(ctx context.Context, opts KubernetesOptions, name string) error {
+	propagation := metav1.DeletePropagationBackground
+	err := opts.Clientset.BatchV1().Jobs(opts.Namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	return errors.Wrapf(err, "deleting Kubernetes job %s", name)
+}
+
+var invalidKubernetesNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// kubernetesName returns a valid Kubernetes object name (a DNS-1123 label, which is
+// also valid for the generated pod names) derived from the given name.
+func kubernetesName(name string) string {
+	name = invalidKubernetesNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > 63 {
+		name = name[len(name)-63:]
+	}
+	return strings.Trim(name, "-")
+}
+
+var invalidKubernetesLabelValueChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// KubernetesLabelValue returns a valid Kubernetes label value derived from the given value.
+func KubernetesLabelValue(value string) string {
+	value = invalidKubernetesLabelValueChars.ReplaceAllString(value, "-")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return strings.Trim(value, "-_.")
+}
+
+// KubernetesJobExecutorName returns the name of the executor job the given Kubernetes job
+// was created for.
+func KubernetesJobExecutorName(job *batchv1.Job) string {
+	return job.Annotations[KubernetesExecutorNameAnnotation]
+}
@@ -0,0 +1,314 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewKubernetesJob(t *testing.T) {
+	job := newKubernetesJob(
+		"Executor-1234_5678-0",
+		CommandSpec{
+			Key:        "step.0",
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env: []string{
+				`TEST=true`,
+				`CONTAINS_WHITESPACE=yes it does`,
+			},
+			CPUs:      2,
+			Timeout:   90 * time.Second,
+			Operation: makeTestOperation(),
+		},
+		"workspace-42",
+		Options{
+			ExecutorName: "Executor-1234_5678",
+			KubernetesOptions: KubernetesOptions{
+				Enabled:               true,
+				InstanceName:          "executor-pod-1",
+				PersistenceVolumeName: "executor-workspaces",
+				MountPath:             "/workspaces",
+				NodeName:              "node-1",
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+
+	if job.Name != "executor-1234-5678-0" {
+		t.Errorf("unexpected job name %q", job.Name)
+	}
+	if diff := cmp.Diff(map[string]string{KubernetesInstanceLabel: "executor-pod-1"}, job.Labels); diff != "" {
+		t.Errorf("unexpected labels (-want +got):\n%s", diff)
+	}
+	if name := KubernetesJobExecutorName(job); name != "Executor-1234_5678" {
+		t.Errorf("unexpected executor name %q", name)
+	}
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 0 {
+		t.Errorf("unexpected backoff limit %v", job.Spec.BackoffLimit)
+	}
+
+	podSpec := job.Spec.Template.Spec
+	if podSpec.ActiveDeadlineSeconds == nil || *podSpec.ActiveDeadlineSeconds != 90 {
+		t.Errorf("unexpected active deadline %v", podSpec.ActiveDeadlineSeconds)
+	}
+	if podSpec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("unexpected restart policy %q", podSpec.RestartPolicy)
+	}
+	if podSpec.NodeName != "node-1" {
+		t.Errorf("unexpected node name %q", podSpec.NodeName)
+	}
+	if diff := cmp.Diff("executor-workspaces", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName); diff != "" {
+		t.Errorf("unexpected claim name (-want +got):\n%s", diff)
+	}
+
+	container := podSpec.Containers[0]
+	if diff := cmp.Diff([]string{"/bin/sh", "/data/.sourcegraph-executor/myscript.sh"}, container.Command); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+	if container.WorkingDir != "/data/subdir" {
+		t.Errorf("unexpected working dir %q", container.WorkingDir)
+	}
+	expectedEnv := []corev1.EnvVar{
+		{Name: "TEST", Value: "true"},
+		{Name: "CONTAINS_WHITESPACE", Value: "yes it does"},
+	}
+	if diff := cmp.Diff(expectedEnv, container.Env); diff != "" {
+		t.Errorf("unexpected env (-want +got):\n%s", diff)
+	}
+	expectedMounts := []corev1.VolumeMount{{Name: "workspace", MountPath: "/data", SubPath: "workspace-42"}}
+	if diff := cmp.Diff(expectedMounts, container.VolumeMounts); diff != "" {
+		t.Errorf("unexpected volume mounts (-want +got):\n%s", diff)
+	}
+	if cpu := container.Resources.Limits.Cpu(); cpu.String() != "2" {
+		t.Errorf("unexpected CPU limit %s", cpu)
+	}
+	if memory, expected := container.Resources.Requests.Memory(), mustParseMemory(t, "20G"); memory.Value() != expected {
+		t.Errorf("unexpected memory request. want=%d have=%d", expected, memory.Value())
+	}
+}
+
+func TestKubernetesResources(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        ResourceOptions
+		cpus           float64
+		memory         string
+		expectedCPU    string
+		expectedMemory string
+	}{
+		{name: "options", options: ResourceOptions{NumCPUs: 4, Memory: "12G"}, expectedCPU: "4", expectedMemory: "12G"},
+		{name: "lower spec", options: ResourceOptions{NumCPUs: 4, Memory: "12G"}, cpus: 0.5, memory: "1G", expectedCPU: "500m", expectedMemory: "1G"},
+		{name: "higher spec", options: ResourceOptions{NumCPUs: 4, Memory: "12G"}, cpus: 8, memory: "20G", expectedCPU: "4", expectedMemory: "12G"},
+		{name: "unbounded", options: ResourceOptions{NumCPUs: 0, Memory: "0"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resources := kubernetesResources(testCase.options, testCase.cpus, testCase.memory)
+			if diff := cmp.Diff(resources.Requests, resources.Limits); diff != "" {
+				t.Errorf("requests and limits differ (-requests +limits):\n%s", diff)
+			}
+
+			var cpu string
+			if quantity, ok := resources.Limits[corev1.ResourceCPU]; ok {
+				cpu = quantity.String()
+			}
+			if cpu != testCase.expectedCPU {
+				t.Errorf("unexpected CPU limit. want=%q have=%q", testCase.expectedCPU, cpu)
+			}
+
+			var memory, expectedMemory int64
+			if quantity, ok := resources.Limits[corev1.ResourceMemory]; ok {
+				memory = quantity.Value()
+			}
+			if testCase.expectedMemory != "" {
+				expectedMemory = mustParseMemory(t, testCase.expectedMemory)
+			}
+			if memory != expectedMemory {
+				t.Errorf("unexpected memory limit. want=%d have=%d", expectedMemory, memory)
+			}
+		})
+	}
+}
+
+func TestKubernetesRunner(t *testing.T) {
+	defer func(interval time.Duration) { kubernetesPollInterval = interval }(kubernetesPollInterval)
+	kubernetesPollInterval = time.Millisecond
+
+	newRunner := func(t *testing.T, exitCode int32, reason string) (*kubernetesRunner, *fake.Clientset, *MockLogger, *MockLogEntry) {
+		clientset := fake.NewSimpleClientset()
+		// Simulate the job controller by creating a finished pod for each job.
+		clientset.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+			phase := corev1.PodSucceeded
+			if exitCode != 0 {
+				phase = corev1.PodFailed
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      job.Name + "-abcde",
+					Namespace: action.GetNamespace(),
+					Labels:    map[string]string{"job-name": job.Name},
+				},
+				Status: corev1.PodStatus{
+					Phase:  phase,
+					Reason: reason,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  kubernetesContainerName,
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+					}},
+				},
+			}
+			return false, nil, clientset.Tracker().Add(pod)
+		})
+
+		logEntry := NewMockLogEntry()
+		logger := NewMockLogger()
+		logger.LogFunc.SetDefaultReturn(logEntry)
+
+		runner := NewRunner("/workspaces/workspace-42", logger, Options{
+			ExecutorName: "executor-1234",
+			KubernetesOptions: KubernetesOptions{
+				Enabled:               true,
+				Clientset:             clientset,
+				Namespace:             "executors",
+				InstanceName:          "executor-pod-1",
+				PersistenceVolumeName: "executor-workspaces",
+				MountPath:             "/workspaces",
+			},
+		}, nil).(*kubernetesRunner)
+		if err := runner.Setup(context.Background()); err != nil {
+			t.Fatalf("unexpected error setting up runner: %s", err)
+		}
+
+		return runner, clientset, logger, logEntry
+	}
+
+	spec := CommandSpec{
+		Key:        "step.0",
+		Image:      "alpine:latest",
+		ScriptPath: "myscript.sh",
+		Timeout:    time.Minute,
+		Operation:  makeTestOperation(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		runner, clientset, logger, logEntry := newRunner(t, 0, "")
+		if err := runner.Run(context.Background(), spec); err != nil {
+			t.Fatalf("unexpected error running command: %s", err)
+		}
+
+		if calls := logger.LogFunc.History(); len(calls) != 1 || calls[0].Arg0 != "step.0" {
+			t.Errorf("unexpected log calls %+v", calls)
+		}
+		var output []string
+		for _, call := range logEntry.WriteFunc.History() {
+			output = append(output, string(call.Arg0))
+		}
+		if diff := cmp.Diff("stdout: fake logs\n", strings.Join(output, "")); diff != "" {
+			t.Errorf("unexpected output (-want +got):\n%s", diff)
+		}
+		if calls := logEntry.FinalizeFunc.History(); len(calls) != 1 || calls[0].Arg0 != 0 {
+			t.Errorf("unexpected finalize calls %+v", calls)
+		}
+
+		if runner.subPath != "workspace-42" {
+			t.Errorf("unexpected sub path %q", runner.subPath)
+		}
+		jobs, err := clientset.BatchV1().Jobs("executors").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs.Items) != 0 || len(runner.jobNames) != 0 {
+			t.Errorf("expected job to be deleted, have %d jobs", len(jobs.Items))
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		runner, _, _, logEntry := newRunner(t, 2, "")
+		if err := runner.Run(context.Background(), spec); err == nil || err.Error() != "command failed" {
+			t.Fatalf("unexpected error. want=%q have=%v", "command failed", err)
+		}
+		if calls := logEntry.FinalizeFunc.History(); len(calls) != 1 || calls[0].Arg0 != 2 {
+			t.Errorf("unexpected finalize calls %+v", calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		runner, _, _, _ := newRunner(t, 137, "DeadlineExceeded")
+		if err := runner.Run(context.Background(), spec); err == nil || err.Error() != "command timed out after 1m0s" {
+			t.Fatalf("unexpected error. want=%q have=%v", "command timed out after 1m0s", err)
+		}
+	})
+
+	t.Run("image pull error", func(t *testing.T) {
+		runner, clientset, _, logEntry := newRunner(t, 0, "")
+		clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &corev1.PodList{Items: []corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "executor-1234-0-abcde",
+					Labels: map[string]string{"job-name": "executor-1234-0"},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  kubernetesContainerName,
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "not found"}},
+					}},
+				},
+			}}}, nil
+		})
+		if err := runner.Run(context.Background(), spec); err == nil || !strings.Contains(err.Error(), "ErrImagePull") {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if calls := logEntry.FinalizeFunc.History(); len(calls) != 1 || calls[0].Arg0 != 1 {
+			t.Errorf("unexpected finalize calls %+v", calls)
+		}
+	})
+}
+
+func TestKubernetesRunnerSetupOutsideMountPath(t *testing.T) {
+	runner := NewRunner("/tmp/workspace-42", nil, Options{
+		KubernetesOptions: KubernetesOptions{Enabled: true, MountPath: "/workspaces"},
+	}, nil)
+	if err := runner.Setup(context.Background()); err == nil {
+		t.Fatal("expected error for workspace outside of mount path")
+	}
+}
+
+func TestKubernetesName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"executor-1234-0":       "executor-1234-0",
+		"Executor_ABC.def-0":    "executor-abc-def-0",
+		"-executor-1234-":       "executor-1234",
+		strings.Repeat("a", 70): strings.Repeat("a", 63),
+	} {
+		if actual := kubernetesName(name); actual != expected {
+			t.Errorf("unexpected name for %q. want=%q have=%q", name, expected, actual)
+		}
+	}
+}
+
+func mustParseMemory(t *testing.T, memory string) int64 {
+	t.Helper()
+
+	v, err := parseMemory(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
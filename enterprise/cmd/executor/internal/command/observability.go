@@ -18,6 +18,9 @@ type Operations struct {
 	SetupGitSparseCheckoutSet    *observation.Operation
 	SetupGitCheckout             *observation.Operation
 	SetupGitSetRemoteUrl         *observation.Operation
+	SetupGitCacheInit            *observation.Operation
+	SetupGitCacheFetch           *observation.Operation
+	SetupGitDissociate           *observation.Operation
 	SetupFirecrackerStart        *observation.Operation
 	SetupStartupScript           *observation.Operation
 	TeardownFirecrackerRemove    *observation.Operation
@@ -64,6 +67,9 @@ func NewOperations(observationContext *observation.Context) *Operations {
 		SetupGitSparseCheckoutSet:    op("setup.git.sparse-checkout-set"),
 		SetupGitCheckout:             op("setup.git.checkout"),
 		SetupGitSetRemoteUrl:         op("setup.git.set-remote"),
+		SetupGitCacheInit:            op("setup.git.cache-init"),
+		SetupGitCacheFetch:           op("setup.git.cache-fetch"),
+		SetupGitDissociate:           op("setup.git.dissociate"),
 		SetupFirecrackerStart:        op("setup.firecracker.start"),
 		SetupStartupScript:           op("setup.startup-script"),
 		TeardownFirecrackerRemove:    op("teardown.firecracker.remove"),
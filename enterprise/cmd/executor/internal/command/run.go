@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"golang.org/x/sync/errgroup"
@@ -21,6 +22,7 @@ type command struct {
 	Command   []string
 	Dir       string
 	Env       []string
+	Timeout   time.Duration
 	Operation *observation.Operation
 }
 
@@ -88,6 +90,10 @@ func runCommand(ctx context.Context, command command, logger Logger) (err error)
 			return err
 		}
 
+		if command.Timeout > 0 && exitCode == timeoutExitCode {
+			return errors.Newf("command timed out after %s", command.Timeout)
+		}
+
 		return errors.New("command failed")
 	}
 	return nil
@@ -103,6 +109,12 @@ var allowedBinaries = []string{
 var ErrIllegalCommand = errors.New("illegal command")
 
 func validateCommand(command []string) error {
+	// Commands with a timeout are run by the timeout command (see timeoutCommand),
+	// so we validate the command it runs instead.
+	if len(command) > 4 && command[0] == "timeout" && command[1] == "--kill-after" && command[2] == timeoutKillAfter {
+		command = command[4:]
+	}
+
 	if len(command) == 0 {
 		return ErrIllegalCommand
 	}
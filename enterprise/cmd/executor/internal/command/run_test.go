@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 )
 
 func TestRunCommandEmptyCommand(t *testing.T) {
@@ -24,3 +25,14 @@ func TestRunCommandIllegalCommand(t *testing.T) {
 		t.Errorf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
 	}
 }
+
+func TestRunCommandIllegalCommandWithTimeout(t *testing.T) {
+	command := command{
+		Command:   []string{"timeout", "--kill-after", "10s", "60s", "kill"},
+		Timeout:   time.Minute,
+		Operation: makeTestOperation(),
+	}
+	if err := runCommand(context.Background(), command, nil); err != ErrIllegalCommand {
+		t.Errorf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
+	}
+}
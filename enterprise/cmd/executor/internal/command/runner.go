@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -11,7 +12,7 @@ import (
 // Runner is the interface between an executor and the host on which commands
 // are invoked. Having this interface at this level allows us to use the same
 // code paths for local development (via shell + docker) as well as production
-// usage (via Firecracker).
+// usage (via Firecracker or Kubernetes).
 type Runner interface {
 	// Setup prepares the runner to invoke a series of commands.
 	Setup(ctx context.Context) error
@@ -34,6 +35,15 @@ type CommandSpec struct {
 	Dir        string
 	Env        []string
 	Operation  *observation.Operation
+
+	// Timeout, if non-zero, is the maximum duration the command may take. It's only
+	// honored for commands run in a docker container.
+	Timeout time.Duration
+
+	// CPUs and Memory, if set, lower the resource limits of the docker container the
+	// command is run in. They can't raise them above the ResourceOptions.
+	CPUs   float64
+	Memory string
 }
 
 type Options struct {
@@ -43,6 +53,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
@@ -74,10 +87,12 @@ type FirecrackerOptions struct {
 }
 
 type ResourceOptions struct {
-	// NumCPUs is the number of virtual CPUs a container or VM can use.
+	// NumCPUs is the number of virtual CPUs a container or VM can use. In Kubernetes,
+	// it's both the CPU request and limit of job pods.
 	NumCPUs int
 
-	// Memory is the maximum amount of memory a container or VM can use.
+	// Memory is the maximum amount of memory a container or VM can use. In Kubernetes,
+	// it's both the memory request and limit of job pods.
 	Memory string
 
 	// DiskSpace is the maximum amount of disk a container or VM can use.
@@ -101,6 +116,10 @@ type ResourceOptions struct {
 
 // NewRunner creates a new runner with the given options.
 func NewRunner(dir string, logger Logger, options Options, operations *Operations) Runner {
+	if options.KubernetesOptions.Enabled {
+		return &kubernetesRunner{dir: dir, logger: logger, options: options}
+	}
+
 	if !options.FirecrackerOptions.Enabled {
 		return &dockerRunner{dir: dir, logger: logger, options: options}
 	}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -19,6 +21,7 @@ type Config struct {
 	FrontendURL                   string
 	FrontendAuthorizationToken    string
 	QueueName                     string
+	QueueNames                    string
 	QueuePollInterval             time.Duration
 	MaximumNumJobs                int
 	FirecrackerImage              string
@@ -42,12 +45,22 @@ type Config struct {
 	DockerRegistryNodeExporterURL string
 	WorkerHostname                string
 	DockerRegistryMirrorURL       string
+	UseKubernetes                 bool
+	KubernetesConfigPath          string
+	KubernetesNamespace           string
+	KubernetesPersistenceVolume   string
+	KubernetesMountPath           string
+	KubernetesNodeName            string
+	KubernetesNodeSelector        string
+	RepoCacheDir                  string
+	RepoCacheDiskSpace            string
 }
 
 func (c *Config) Load() {
 	c.FrontendURL = c.Get("EXECUTOR_FRONTEND_URL", "", "The external URL of the sourcegraph instance.")
 	c.FrontendAuthorizationToken = c.Get("EXECUTOR_FRONTEND_PASSWORD", "", "The authorization token supplied to the frontend.")
-	c.QueueName = c.Get("EXECUTOR_QUEUE_NAME", "", "The name of the queue to listen to.")
+	c.QueueName = c.GetOptional("EXECUTOR_QUEUE_NAME", "The name of the queue to listen to.")
+	c.QueueNames = c.GetOptional("EXECUTOR_QUEUE_NAMES", "A comma-separated list of queues to listen to, each with an optional weight (e.g. batches:3,codeintel:1). Cannot be used with EXECUTOR_QUEUE_NAME.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux"), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
@@ -70,6 +83,15 @@ func (c *Config) Load() {
 	c.DockerRegistryNodeExporterURL = c.GetOptional("DOCKER_REGISTRY_NODE_EXPORTER_URL", "The URL of the Docker Registry instance's node_exporter, without the /metrics path.")
 	c.MaxActiveTime = c.GetInterval("EXECUTOR_MAX_ACTIVE_TIME", "0", "The maximum time that can be spent by the worker dequeueing records to be handled.")
 	c.DockerRegistryMirrorURL = c.GetOptional("EXECUTOR_DOCKER_REGISTRY_MIRROR_URL", "The address of a docker registry mirror to use in firecracker VMs. Supports multiple values, separated with a comma.")
+	c.UseKubernetes = c.GetBool("EXECUTOR_USE_KUBERNETES", "false", "Whether to run commands in Kubernetes jobs. Requires the executor to run in a Kubernetes cluster or EXECUTOR_KUBERNETES_CONFIG_PATH to be set.")
+	c.KubernetesConfigPath = c.GetOptional("EXECUTOR_KUBERNETES_CONFIG_PATH", "The path to a kubeconfig file. If not set, the in-cluster configuration is used.")
+	c.KubernetesNamespace = c.Get("EXECUTOR_KUBERNETES_NAMESPACE", "default", "The namespace to run Kubernetes jobs in.")
+	c.KubernetesPersistenceVolume = c.GetOptional("EXECUTOR_KUBERNETES_PERSISTENCE_VOLUME_NAME", "The name of the persistent volume claim that holds the workspaces shared with Kubernetes jobs.")
+	c.KubernetesMountPath = c.GetOptional("EXECUTOR_KUBERNETES_MOUNT_PATH", "The path at which the persistent volume claim is mounted into the executor.")
+	c.KubernetesNodeName = c.GetOptional("EXECUTOR_KUBERNETES_NODE_NAME", "The name of the node to run Kubernetes jobs on. Required for volumes that can only be mounted on a single node.")
+	c.KubernetesNodeSelector = c.GetOptional("EXECUTOR_KUBERNETES_NODE_SELECTOR", "A comma-separated list of key=value node labels that constrain the nodes Kubernetes jobs run on.")
+	c.RepoCacheDir = c.GetOptional("EXECUTOR_REPO_CACHE_DIR", "The absolute path of a directory on the host in which to cache repositories between jobs. If not set, repositories are cloned from scratch for every job.")
+	c.RepoCacheDiskSpace = c.Get("EXECUTOR_REPO_CACHE_DISK_SPACE", "50G", "How much disk space the repository cache may use before the least recently used repositories are evicted.")
 
 	hn := hostname.Get()
 	// Be unique but also descriptive.
@@ -77,8 +99,16 @@ func (c *Config) Load() {
 }
 
 func (c *Config) Validate() error {
-	if c.QueueName != "" && c.QueueName != "batches" && c.QueueName != "codeintel" {
+	if c.QueueName == "" && c.QueueNames == "" {
+		c.AddError(errors.New("one of EXECUTOR_QUEUE_NAME or EXECUTOR_QUEUE_NAMES must be set"))
+	} else if c.QueueName != "" && c.QueueNames != "" {
+		c.AddError(errors.New("EXECUTOR_QUEUE_NAME cannot be used with EXECUTOR_QUEUE_NAMES"))
+	} else if c.QueueName != "" && !isValidQueueName(c.QueueName) {
 		c.AddError(errors.New("EXECUTOR_QUEUE_NAME must be set to 'batches' or 'codeintel'"))
+	} else if c.QueueNames != "" {
+		if _, err := c.Queues(); err != nil {
+			c.AddError(err)
+		}
 	}
 
 	if c.UseFirecracker {
@@ -102,5 +132,91 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.UseKubernetes {
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_KUBERNETES cannot be used with EXECUTOR_USE_FIRECRACKER"))
+		}
+		if c.KubernetesPersistenceVolume == "" {
+			c.AddError(errors.New("EXECUTOR_KUBERNETES_PERSISTENCE_VOLUME_NAME must be set when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+		if c.KubernetesMountPath == "" {
+			c.AddError(errors.New("EXECUTOR_KUBERNETES_MOUNT_PATH must be set when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+		if _, err := c.KubernetesNodeSelectorLabels(); err != nil {
+			c.AddError(err)
+		}
+	}
+
+	if c.RepoCacheDir != "" {
+		if !filepath.IsAbs(c.RepoCacheDir) {
+			c.AddError(errors.New("EXECUTOR_REPO_CACHE_DIR must be an absolute path"))
+		}
+
+		// Make sure disk space is a valid datasize string.
+		if _, err := datasize.ParseString(c.RepoCacheDiskSpace); err != nil {
+			c.AddError(errors.Wrapf(err, "invalid disk size provided for EXECUTOR_REPO_CACHE_DISK_SPACE: %q", c.RepoCacheDiskSpace))
+		}
+	}
+
 	return c.BaseConfig.Validate()
 }
+
+// Queue is a queue the executor listens to.
+type Queue struct {
+	Name string
+	// Weight is the relative share of dequeue attempts that try this queue first.
+	Weight int
+}
+
+// Queues returns the queues to listen to. If EXECUTOR_QUEUE_NAMES is not set, the queue of
+// EXECUTOR_QUEUE_NAME is returned with a weight of 1.
+func (c *Config) Queues() ([]Queue, error) {
+	if c.QueueNames == "" {
+		return []Queue{{Name: c.QueueName, Weight: 1}}, nil
+	}
+
+	var queues []Queue
+	seen := map[string]struct{}{}
+	for _, entry := range strings.Split(c.QueueNames, ",") {
+		name, rawWeight, hasWeight := strings.Cut(strings.TrimSpace(entry), ":")
+		if !isValidQueueName(name) {
+			return nil, errors.Newf("invalid EXECUTOR_QUEUE_NAMES queue %q, must be 'batches' or 'codeintel'", name)
+		}
+		if _, ok := seen[name]; ok {
+			return nil, errors.Newf("duplicate EXECUTOR_QUEUE_NAMES queue %q", name)
+		}
+		seen[name] = struct{}{}
+
+		weight := 1
+		if hasWeight {
+			var err error
+			if weight, err = strconv.Atoi(rawWeight); err != nil || weight < 1 {
+				return nil, errors.Newf("invalid EXECUTOR_QUEUE_NAMES weight %q for queue %q, must be a positive integer", rawWeight, name)
+			}
+		}
+
+		queues = append(queues, Queue{Name: name, Weight: weight})
+	}
+	return queues, nil
+}
+
+func isValidQueueName(name string) bool {
+	return name == "batches" || name == "codeintel"
+}
+
+// KubernetesNodeSelectorLabels parses the labels of EXECUTOR_KUBERNETES_NODE_SELECTOR.
+func (c *Config) KubernetesNodeSelectorLabels() (map[string]string, error) {
+	if c.KubernetesNodeSelector == "" {
+		return nil, nil
+	}
+
+	labels := map[string]string{}
+	for _, pair := range strings.Split(c.KubernetesNodeSelector, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			return nil, errors.Newf("invalid EXECUTOR_KUBERNETES_NODE_SELECTOR label %q, expected key=value", pair)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 type metrics struct {
-	numVMsRemoved prometheus.Counter
-	numErrors     prometheus.Counter
+	numVMsRemoved   prometheus.Counter
+	numJobsRemoved  prometheus.Counter
+	numReposEvicted prometheus.Counter
+	numErrors       prometheus.Counter
 }
 
 var NewMetrics = newMetrics
@@ -28,13 +30,23 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		"src_executor_orphaned_vms_removed_total",
 		"The number of orphaned virtual machines removed from the host.",
 	)
+	numJobsRemoved := counter(
+		"src_executor_orphaned_kubernetes_jobs_removed_total",
+		"The number of orphaned Kubernetes jobs removed from the cluster.",
+	)
+	numReposEvicted := counter(
+		"src_executor_repo_cache_evictions_total",
+		"The number of repositories evicted from the repository cache.",
+	)
 	numErrors := counter(
 		"src_executor_janitor_errors_total",
 		"The number of errors that occur during the janitor job.",
 	)
 
 	return &metrics{
-		numVMsRemoved: numVMsRemoved,
-		numErrors:     numErrors,
+		numVMsRemoved:   numVMsRemoved,
+		numJobsRemoved:  numJobsRemoved,
+		numReposEvicted: numReposEvicted,
+		numErrors:       numErrors,
 	}
 }
@@ -0,0 +1,100 @@
+package janitor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type orphanedJobJanitor struct {
+	options command.KubernetesOptions
+	names   *NameSet
+	metrics *metrics
+}
+
+var _ goroutine.Handler = &orphanedJobJanitor{}
+var _ goroutine.ErrorHandler = &orphanedJobJanitor{}
+
+// NewOrphanedJobJanitor returns a background routine that periodically removes all
+// Kubernetes jobs created by this executor instance that are not known by the worker
+// running within this executor instance.
+func NewOrphanedJobJanitor(
+	options command.KubernetesOptions,
+	names *NameSet,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, newOrphanedJobJanitor(
+		options,
+		names,
+		metrics,
+	))
+}
+
+func newOrphanedJobJanitor(
+	options command.KubernetesOptions,
+	names *NameSet,
+	metrics *metrics,
+) *orphanedJobJanitor {
+	return &orphanedJobJanitor{
+		options: options,
+		names:   names,
+		metrics: metrics,
+	}
+}
+
+func (j *orphanedJobJanitor) Handle(ctx context.Context) (err error) {
+	jobs, err := j.options.Clientset.BatchV1().Jobs(j.options.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: command.KubernetesInstanceLabel + "=" + command.KubernetesLabelValue(j.options.InstanceName),
+	})
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	for _, name := range findOrphanedJobs(jobs.Items, j.names.Slice()) {
+		log15.Info("Removing orphaned Kubernetes job", "name", name)
+
+		if removeErr := j.options.Clientset.BatchV1().Jobs(j.options.Namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation}); removeErr != nil {
+			err = errors.Append(err, removeErr)
+		} else {
+			j.metrics.numJobsRemoved.Inc()
+		}
+	}
+
+	return err
+}
+
+func (j *orphanedJobJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	log15.Error("Failed to remove orphaned Kubernetes jobs", "error", err)
+}
+
+// findOrphanedJobs returns the names of the given Kubernetes jobs that were created for
+// executor jobs absent from the expected names.
+func findOrphanedJobs(jobs []batchv1.Job, expectedNames []string) []string {
+	expectedMap := make(map[string]struct{}, len(expectedNames))
+	for _, name := range expectedNames {
+		expectedMap[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(jobs))
+	for i := range jobs {
+		if _, ok := expectedMap[command.KubernetesJobExecutorName(&jobs[i])]; ok {
+			continue
+		}
+
+		names = append(names, jobs[i].Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
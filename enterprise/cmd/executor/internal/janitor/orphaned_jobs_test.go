@@ -0,0 +1,76 @@
+package janitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFindOrphanedJobs(t *testing.T) {
+	jobs := []batchv1.Job{
+		makeTestJob("a-0", "a"),
+		makeTestJob("b-0", "b"),
+		makeTestJob("b-1", "b"),
+		makeTestJob("c-0", "c"),
+		makeTestJob("d-0", "d"),
+	}
+
+	orphans := findOrphanedJobs(jobs, []string{"c", "d", "x"})
+	if diff := cmp.Diff([]string{"a-0", "b-0", "b-1"}, orphans); diff != "" {
+		t.Fatalf("unexpected orphans (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrphanedJobJanitor(t *testing.T) {
+	ownJob := makeTestJob("a-0", "a")
+	orphanedJob := makeTestJob("b-0", "b")
+	otherInstanceJob := makeTestJob("c-0", "c")
+	otherInstanceJob.Labels[command.KubernetesInstanceLabel] = "executor-pod-2"
+
+	clientset := fake.NewSimpleClientset(&ownJob, &orphanedJob, &otherInstanceJob)
+	options := command.KubernetesOptions{
+		Clientset:    clientset,
+		Namespace:    "executors",
+		InstanceName: "executor-pod-1",
+	}
+
+	names := NewNameSet()
+	names.Add("a")
+
+	observationContext := &observation.Context{Registerer: prometheus.NewRegistry()}
+	janitor := newOrphanedJobJanitor(options, names, newMetrics(observationContext))
+	if err := janitor.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	jobs, err := clientset.BatchV1().Jobs("executors").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var remaining []string
+	for _, job := range jobs.Items {
+		remaining = append(remaining, job.Name)
+	}
+	if diff := cmp.Diff([]string{"a-0", "c-0"}, remaining); diff != "" {
+		t.Errorf("unexpected remaining jobs (-want +got):\n%s", diff)
+	}
+}
+
+func makeTestJob(name, executorName string) batchv1.Job {
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "executors",
+			Labels:      map[string]string{command.KubernetesInstanceLabel: "executor-pod-1"},
+			Annotations: map[string]string{command.KubernetesExecutorNameAnnotation: executorName},
+		},
+	}
+}
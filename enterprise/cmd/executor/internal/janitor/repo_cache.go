@@ -0,0 +1,66 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/repocache"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type repoCacheJanitor struct {
+	cache   *repocache.Cache
+	metrics *metrics
+}
+
+var _ goroutine.Handler = &repoCacheJanitor{}
+var _ goroutine.ErrorHandler = &repoCacheJanitor{}
+
+// NewRepoCacheJanitor returns a background routine that periodically evicts the least
+// recently used repositories from the given cache until it fits within its disk budget,
+// and garbage-collects the remaining repositories.
+func NewRepoCacheJanitor(
+	cache *repocache.Cache,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, newRepoCacheJanitor(
+		cache,
+		metrics,
+	))
+}
+
+func newRepoCacheJanitor(
+	cache *repocache.Cache,
+	metrics *metrics,
+) *repoCacheJanitor {
+	return &repoCacheJanitor{
+		cache:   cache,
+		metrics: metrics,
+	}
+}
+
+func (j *repoCacheJanitor) Handle(ctx context.Context) (err error) {
+	removed, evictErr := j.cache.Evict()
+	for _, name := range removed {
+		log15.Info("Evicted repository from cache", "name", name)
+		j.metrics.numReposEvicted.Inc()
+	}
+	if evictErr != nil {
+		err = errors.Append(err, evictErr)
+	}
+
+	if gcErr := j.cache.GC(ctx); gcErr != nil {
+		err = errors.Append(err, gcErr)
+	}
+
+	return err
+}
+
+func (j *repoCacheJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	log15.Error("Failed to clean up repository cache", "error", err)
+}
@@ -0,0 +1,227 @@
+package repocache
+
+import (
+	"context"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Cache is a host-level cache of bare repositories. Workspaces of jobs borrow objects
+// from the cached repository of their repository, so that only objects added since the
+// last job for that repository have to be fetched through the frontend.
+//
+// Each cached repository may only be used by one job at a time, and cached repositories
+// that are in use are never evicted or garbage-collected.
+type Cache struct {
+	dir        string
+	diskBudget int64
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// New creates a cache of bare repositories rooted at the given directory. Eviction removes
+// the least recently used repositories until the cache fits within the given number of bytes.
+func New(dir string, diskBudget int64) *Cache {
+	return &Cache{
+		dir:        dir,
+		diskBudget: diskBudget,
+		locks:      map[string]*sync.Mutex{},
+	}
+}
+
+// Lock blocks until the cached repository of the given repository is not in use and
+// returns its path. The bare repository at that path may not yet exist. The returned
+// function must be called once the caller is done with the repository.
+func (c *Cache) Lock(repositoryName string) (string, func()) {
+	name := entryName(repositoryName)
+	mu := c.lockFor(name)
+	mu.Lock()
+
+	path := filepath.Join(c.dir, name)
+	return path, func() {
+		// Mark the repository as recently used for eviction. This fails harmlessly if
+		// the repository was never created.
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+
+		mu.Unlock()
+	}
+}
+
+// Entry describes a repository in the cache.
+type Entry struct {
+	Name     string
+	Size     int64
+	LastUsed time.Time
+}
+
+// Entries returns the repositories in the cache.
+func (c *Cache) Entries() ([]Entry, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return nil, err
+		}
+		size, err := dirSize(filepath.Join(c.dir, dirEntry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, Entry{
+			Name:     dirEntry.Name(),
+			Size:     size,
+			LastUsed: info.ModTime(),
+		})
+	}
+
+	return entries, nil
+}
+
+// Evict removes the least recently used repositories from the cache until it fits within
+// the disk budget. Repositories that are in use are skipped. The names of the removed
+// repositories are returned.
+func (c *Cache) Evict() (removed []string, err error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entriesToEvict(entries, c.diskBudget) {
+		ok, removeErr := c.withEntry(entry.Name, func(path string) error {
+			return os.RemoveAll(path)
+		})
+		if removeErr != nil {
+			err = errors.Append(err, removeErr)
+		} else if ok {
+			removed = append(removed, entry.Name)
+		}
+	}
+
+	return removed, err
+}
+
+// GC runs `git gc --auto` in every cached repository that is not in use, which packs the
+// objects accumulated by incremental fetches.
+func (c *Cache) GC(ctx context.Context) (err error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if _, gcErr := c.withEntry(entry.Name, func(path string) error {
+			if out, err := exec.CommandContext(ctx, "git", "-C", path, "gc", "--auto", "--quiet").CombinedOutput(); err != nil {
+				return errors.Wrapf(err, "git gc in %q: %s", path, out)
+			}
+			return nil
+		}); gcErr != nil {
+			err = errors.Append(err, gcErr)
+		}
+	}
+
+	return err
+}
+
+// withEntry invokes f with the path of the named repository if it is not in use. The
+// returned boolean is false if the repository is in use.
+func (c *Cache) withEntry(name string, f func(path string) error) (bool, error) {
+	mu := c.lockFor(name)
+	if !mu.TryLock() {
+		return false, nil
+	}
+	defer mu.Unlock()
+
+	return true, f(filepath.Join(c.dir, name))
+}
+
+func (c *Cache) lockFor(name string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	mu, ok := c.locks[name]
+	if !ok {
+		mu = &sync.Mutex{}
+		c.locks[name] = mu
+	}
+
+	return mu
+}
+
+// entriesToEvict returns the entries that do not fit into the disk budget when keeping the
+// most recently used entries, ordered from least to most recently used.
+func entriesToEvict(entries []Entry, diskBudget int64) []Entry {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LastUsed.After(sorted[j].LastUsed) })
+
+	var total int64
+	var evict []Entry
+	for _, entry := range sorted {
+		total += entry.Size
+		if total > diskBudget {
+			evict = append(evict, entry)
+		}
+	}
+
+	// Reverse so that the least recently used entry is evicted first.
+	for i, j := 0, len(evict)-1; i < j; i, j = i+1, j-1 {
+		evict[i], evict[j] = evict[j], evict[i]
+	}
+
+	return evict
+}
+
+// entryName returns the name of the directory that holds the cached repository of the
+// given repository. Escaping path separators keeps the cache directory flat.
+func entryName(repositoryName string) string {
+	return url.PathEscape(repositoryName) + ".git"
+}
+
+func dirSize(path string) (size int64, err error) {
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files may disappear while a repository is being fetched into.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+
+	return size, err
+}
@@ -0,0 +1,90 @@
+package repocache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEntriesToEvict(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{Name: "a.git", Size: 40, LastUsed: now.Add(-3 * time.Hour)},
+		{Name: "b.git", Size: 30, LastUsed: now.Add(-1 * time.Hour)},
+		{Name: "c.git", Size: 50, LastUsed: now},
+		{Name: "d.git", Size: 10, LastUsed: now.Add(-2 * time.Hour)},
+	}
+
+	var names []string
+	for _, entry := range entriesToEvict(entries, 85) {
+		names = append(names, entry.Name)
+	}
+	if diff := cmp.Diff([]string{"a.git", "d.git"}, names); diff != "" {
+		t.Fatalf("unexpected evicted entries (-want +got):\n%s", diff)
+	}
+
+	if evicted := entriesToEvict(entries, 130); len(evicted) != 0 {
+		t.Fatalf("unexpected evicted entries: %v", evicted)
+	}
+}
+
+func TestEvict(t *testing.T) {
+	dir := t.TempDir()
+	cache := New(dir, 150)
+
+	now := time.Now()
+	for i, name := range []string{"github.com/sourcegraph/a", "github.com/sourcegraph/b", "github.com/sourcegraph/c"} {
+		path := filepath.Join(dir, entryName(name))
+		if err := os.MkdirAll(filepath.Join(path, "objects"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(path, "objects", "pack"), make([]byte, 100), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		lastUsed := now.Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(path, lastUsed, lastUsed); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Repositories in use are skipped, even if they are least recently used.
+	_, unlock := cache.Lock("github.com/sourcegraph/a")
+	removed, err := cache.Evict()
+	if err != nil {
+		t.Fatalf("unexpected error evicting: %s", err)
+	}
+	if diff := cmp.Diff([]string{"github.com%2Fsourcegraph%2Fb.git"}, removed); diff != "" {
+		t.Fatalf("unexpected evicted repositories (-want +got):\n%s", diff)
+	}
+
+	// Releasing a repository marks it as most recently used.
+	unlock()
+	removed, err = cache.Evict()
+	if err != nil {
+		t.Fatalf("unexpected error evicting: %s", err)
+	}
+	if diff := cmp.Diff([]string{"github.com%2Fsourcegraph%2Fc.git"}, removed); diff != "" {
+		t.Fatalf("unexpected evicted repositories (-want +got):\n%s", diff)
+	}
+
+	entries, err := cache.Entries()
+	if err != nil {
+		t.Fatalf("unexpected error listing entries: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "github.com%2Fsourcegraph%2Fa.git" || entries[0].Size != 100 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestEntriesMissingDir(t *testing.T) {
+	entries, err := New(filepath.Join(t.TempDir(), "missing"), 0).Entries()
+	if err != nil {
+		t.Fatalf("unexpected error listing entries: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
@@ -46,6 +46,13 @@ func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
 	logger.Info("Telemetry information gathered", log.String("info", fmt.Sprintf("%+v", queueTelemetryOptions)))
 
 	opts := apiWorkerOptions(cfg, queueTelemetryOptions)
+	if cfg.UseKubernetes {
+		clientset, err := newKubernetesClientset(cfg)
+		if err != nil {
+			return err
+		}
+		opts.KubernetesOptions.Clientset = clientset
+	}
 
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if cliCtx.Bool("verify") {
@@ -98,17 +105,36 @@ func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
 		worker,
 	}
 
+	janitorMetrics := janitor.NewMetrics(observationContext)
+
 	if cfg.UseFirecracker {
 		routines = append(routines, janitor.NewOrphanedVMJanitor(
 			cfg.VMPrefix,
 			nameSet,
 			cfg.CleanupTaskInterval,
-			janitor.NewMetrics(observationContext),
+			janitorMetrics,
 		))
 
 		mustRegisterVMCountMetric(logger, observationContext, cfg.VMPrefix)
 	}
 
+	if cfg.UseKubernetes {
+		routines = append(routines, janitor.NewOrphanedJobJanitor(
+			opts.KubernetesOptions,
+			nameSet,
+			cfg.CleanupTaskInterval,
+			janitorMetrics,
+		))
+	}
+
+	if opts.RepoCache != nil {
+		routines = append(routines, janitor.NewRepoCacheJanitor(
+			opts.RepoCache,
+			cfg.CleanupTaskInterval,
+			janitorMetrics,
+		))
+	}
+
 	go func() {
 		// Block until the worker has exited. The executor worker is unique
 		// in that we want a maximum runtime and/or number of jobs to be
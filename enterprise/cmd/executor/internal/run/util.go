@@ -8,19 +8,27 @@ import (
 	"strings"
 	"time"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/log"
 	"go.opentelemetry.io/otel"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient/queue"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/config"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/repocache"
 	apiworker "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/store"
+	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func newQueueTelemetryOptions(ctx context.Context, useFirecracker bool, logger log.Logger) queue.TelemetryOptions {
@@ -98,9 +106,12 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 		VMPrefix:           c.VMPrefix,
 		KeepWorkspaces:     c.KeepWorkspaces,
 		QueueName:          c.QueueName,
+		Queues:             weightedQueues(c),
 		WorkerOptions:      workerOptions(c),
 		FirecrackerOptions: firecrackerOptions(c),
+		KubernetesOptions:  kubernetesOptions(c),
 		ResourceOptions:    resourceOptions(c),
+		RepoCache:          repoCache(c),
 		GitServicePath:     "/.executors/git",
 		QueueOptions:       queueOptions(c, queueTelemetryOptions),
 		FilesOptions:       filesOptions(c),
@@ -117,11 +128,11 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 
 func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
-		Name:                 fmt.Sprintf("executor_%s_worker", c.QueueName),
+		Name:                 fmt.Sprintf("executor_%s_worker", strings.Join(queueNames(c), "_")),
 		NumHandlers:          c.MaximumNumJobs,
 		Interval:             c.QueuePollInterval,
 		HeartbeatInterval:    5 * time.Second,
-		Metrics:              makeWorkerMetrics(c.QueueName),
+		Metrics:              makeWorkerMetrics(strings.Join(queueNames(c), ",")),
 		NumTotalJobs:         c.NumTotalJobs,
 		MaxActiveTime:        c.MaxActiveTime,
 		WorkerHostname:       c.WorkerHostname,
@@ -129,6 +140,31 @@ func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	}
 }
 
+// weightedQueues returns the queues of EXECUTOR_QUEUE_NAMES, or nil if the executor
+// listens to the single queue of EXECUTOR_QUEUE_NAME.
+func weightedQueues(c *config.Config) []store.WeightedQueue {
+	if c.QueueNames == "" {
+		return nil
+	}
+
+	// The queues are validated by c.Validate.
+	queues, _ := c.Queues()
+	weighted := make([]store.WeightedQueue, 0, len(queues))
+	for _, queue := range queues {
+		weighted = append(weighted, store.WeightedQueue{Name: queue.Name, Weight: queue.Weight})
+	}
+	return weighted
+}
+
+func queueNames(c *config.Config) []string {
+	queues, _ := c.Queues()
+	names := make([]string, 0, len(queues))
+	for _, queue := range queues {
+		names = append(names, queue.Name)
+	}
+	return names
+}
+
 func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	dockerMirrors := []string{}
 	if len(c.DockerRegistryMirrorURL) > 0 {
@@ -144,6 +180,51 @@ func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	}
 }
 
+// kubernetesOptions returns the Kubernetes options without a clientset, which is
+// created by newKubernetesClientset once the config is validated.
+func kubernetesOptions(c *config.Config) command.KubernetesOptions {
+	// The node selector is validated by c.Validate.
+	nodeSelector, _ := c.KubernetesNodeSelectorLabels()
+	return command.KubernetesOptions{
+		Enabled:               c.UseKubernetes,
+		Namespace:             c.KubernetesNamespace,
+		InstanceName:          hostname.Get(),
+		PersistenceVolumeName: c.KubernetesPersistenceVolume,
+		MountPath:             c.KubernetesMountPath,
+		NodeName:              c.KubernetesNodeName,
+		NodeSelector:          nodeSelector,
+	}
+}
+
+// newKubernetesClientset creates a clientset from the kubeconfig file at
+// EXECUTOR_KUBERNETES_CONFIG_PATH or, if not set, from the in-cluster configuration.
+func newKubernetesClientset(c *config.Config) (kubernetes.Interface, error) {
+	var restConfig *rest.Config
+	var err error
+	if c.KubernetesConfigPath != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", c.KubernetesConfigPath)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading Kubernetes configuration")
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// repoCache returns the cache of repositories shared by all jobs, or nil if
+// EXECUTOR_REPO_CACHE_DIR is not set.
+func repoCache(c *config.Config) *repocache.Cache {
+	if c.RepoCacheDir == "" {
+		return nil
+	}
+
+	// The disk space is validated by c.Validate.
+	diskSpace, _ := datasize.ParseString(c.RepoCacheDiskSpace)
+	return repocache.New(c.RepoCacheDir, int64(diskSpace.Bytes()))
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
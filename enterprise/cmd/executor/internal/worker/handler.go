@@ -64,6 +64,7 @@ func (h *handler) PreDequeue(ctx context.Context, logger log.Logger) (dequeueabl
 func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Job) (err error) {
 	logger = logger.With(
 		log.Int("jobID", job.ID),
+		log.String("queue", job.Queue),
 		log.String("repositoryName", job.RepositoryName),
 		log.String("commit", job.Commit))
 
@@ -119,6 +120,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 	options := command.Options{
 		ExecutorName:       name,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		KubernetesOptions:  h.options.KubernetesOptions,
 		ResourceOptions:    h.options.ResourceOptions,
 	}
 	runner := h.runnerFactory(workspace.Path(), commandLogger, options, h.operations)
@@ -153,11 +155,18 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 			Dir:        dockerStep.Dir,
 			Env:        dockerStep.Env,
 			Operation:  h.operations.Exec,
+			Timeout:    dockerStep.Timeout,
+			CPUs:       dockerStep.CPUs,
+			Memory:     dockerStep.Memory,
 		}
 
 		logger.Info(fmt.Sprintf("Running docker step #%d", i))
 
-		if err := runner.Run(ctx, dockerStepCommand); err != nil {
+		if err := runWithRetries(ctx, logger, runner, dockerStepCommand, dockerStep.Retries, dockerStep.RetryBackoff); err != nil {
+			if dockerStep.ContinueOnError && ctx.Err() == nil {
+				logger.Warn(fmt.Sprintf("Docker step #%d failed, continuing", i), log.Error(err))
+				continue
+			}
 			return errors.Wrap(err, "failed to perform docker step")
 		}
 	}
@@ -189,6 +198,27 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 	return nil
 }
 
+// runWithRetries runs the given command and reruns it up to the given number of
+// times if it fails. The backoff before the first retry is doubled for every
+// following retry.
+func runWithRetries(ctx context.Context, logger log.Logger, runner command.Runner, spec command.CommandSpec, retries int, backoff time.Duration) error {
+	for attempt := 0; ; attempt++ {
+		err := runner.Run(ctx, spec)
+		if err == nil || attempt >= retries || ctx.Err() != nil {
+			return err
+		}
+
+		logger.Warn("Command failed, retrying", log.String("key", spec.Key), log.Int("attempt", attempt+1), log.Duration("backoff", backoff), log.Error(err))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
 func union(a, b map[string]string) map[string]string {
 	c := make(map[string]string, len(a)+len(b))
 
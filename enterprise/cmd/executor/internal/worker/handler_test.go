@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestHandle(t *testing.T) {
@@ -276,3 +277,92 @@ func TestHandle_WorkspaceFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, workspace.ScriptPreamble+"\n\nyarn\ninstall\n", string(dockerScriptFile2Content))
 }
+
+func TestHandle_StepFailures(t *testing.T) {
+	testDir := t.TempDir()
+	workspace.MakeTempDirectory = func(string) (string, error) { return testDir, nil }
+	t.Cleanup(func() {
+		workspace.MakeTempDirectory = workspace.MakeTemporaryDirectory
+	})
+
+	if err := os.MkdirAll(filepath.Join(testDir, command.ScriptsPath), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error creating workspace: %s", err)
+	}
+
+	runner := NewMockRunner()
+	runner.RunFunc.SetDefaultHook(func(_ context.Context, spec command.CommandSpec) error {
+		if spec.Image == "broken" {
+			return errors.New("command failed")
+		}
+		return nil
+	})
+	runner.RunFunc.PushReturn(errors.New("command failed"))
+
+	job := executor.Job{
+		ID:             42,
+		Commit:         "deadbeef",
+		RepositoryName: "linux",
+		DockerSteps: []executor.DockerStep{
+			{
+				Image:        "alpine",
+				Commands:     []string{"yarn", "install"},
+				Timeout:      time.Minute,
+				Retries:      2,
+				RetryBackoff: time.Millisecond,
+				CPUs:         0.5,
+				Memory:       "512m",
+			},
+			{
+				Image:           "broken",
+				Commands:        []string{"exit", "1"},
+				Retries:         1,
+				RetryBackoff:    time.Millisecond,
+				ContinueOnError: true,
+			},
+			{
+				Image:    "alpine",
+				Commands: []string{"yarn", "test"},
+			},
+		},
+	}
+
+	h := &handler{
+		store:      NewMockStore[executor.Job](),
+		filesStore: NewMockFilesStore(),
+		nameSet:    janitor.NewNameSet(),
+		options:    Options{},
+		operations: command.NewOperations(&observation.TestContext),
+		runnerFactory: func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner {
+			if dir == "" {
+				return NewMockRunner()
+			}
+
+			return runner
+		},
+	}
+
+	if err := h.Handle(context.Background(), logtest.Scoped(t), job); err != nil {
+		t.Fatalf("unexpected error handling record: %s", err)
+	}
+
+	var images []string
+	for _, call := range runner.RunFunc.History() {
+		images = append(images, call.Arg1.Image)
+	}
+	// The first step fails once and succeeds on the first retry, the second
+	// step fails on all attempts but the job continues.
+	if diff := cmp.Diff([]string{"alpine", "alpine", "broken", "broken", "alpine"}, images); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+
+	spec := runner.RunFunc.History()[0].Arg1
+	assert.Equal(t, time.Minute, spec.Timeout)
+	assert.Equal(t, 0.5, spec.CPUs)
+	assert.Equal(t, "512m", spec.Memory)
+
+	// Without continueOnError the job fails after all retries.
+	job.DockerSteps[1].ContinueOnError = false
+	if err := h.Handle(context.Background(), logtest.Scoped(t), job); err == nil {
+		t.Fatal("expected error handling record")
+	}
+}
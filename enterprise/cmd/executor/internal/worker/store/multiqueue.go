@@ -0,0 +1,164 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// WeightedQueue is a queue processed by an executor that listens to multiple queues.
+type WeightedQueue struct {
+	// Name is the name of the queue.
+	Name string
+
+	// Weight is the relative share of dequeue attempts that try this queue first.
+	Weight int
+}
+
+// MultiQueueStore is a QueueStore that can dequeue from and heartbeat to multiple
+// queues at once.
+type MultiQueueStore interface {
+	QueueStore
+	DequeueAny(ctx context.Context, queueNames []string, payload *executor.Job) (queueName string, dequeued bool, err error)
+	HeartbeatQueues(ctx context.Context, jobIDsByQueue map[string][]int) (knownIDsByQueue, cancelIDsByQueue map[string][]int, err error)
+}
+
+// MultiQueueShim wraps MultiQueueStore to implement workerutil.Store for multiple queues.
+//
+// Each dequeue attempt tries the queues in an order chosen by smooth weighted round-robin,
+// so that the queues are tried first in proportion to their weight. Queues without a job
+// are skipped, so an executor never idles while any of its queues has work.
+//
+// Job IDs are only unique within a queue, so the jobs are given record IDs that encode
+// the index of their queue: recordID = jobID*len(Queues) + index.
+type MultiQueueShim struct {
+	Queues []WeightedQueue
+	Store  MultiQueueStore
+
+	mu sync.Mutex
+	// current holds the current weight of each queue for smooth weighted round-robin.
+	current []int
+}
+
+// Compile time validation.
+var _ workerutil.Store[executor.Job] = &MultiQueueShim{}
+
+func (s *MultiQueueShim) QueuedCount(ctx context.Context) (int, error) {
+	return 0, errors.New("unimplemented")
+}
+
+func (s *MultiQueueShim) Dequeue(ctx context.Context, workerHostname string, extraArguments any) (executor.Job, bool, error) {
+	var job executor.Job
+	queueName, dequeued, err := s.Store.DequeueAny(ctx, s.queueOrder(), &job)
+	if err != nil {
+		return executor.Job{}, false, err
+	}
+	if !dequeued {
+		return executor.Job{}, false, nil
+	}
+
+	for i, queue := range s.Queues {
+		if queue.Name == queueName {
+			job.Queue = queueName
+			job.WorkerRecordID = job.ID*len(s.Queues) + i
+			return job, true, nil
+		}
+	}
+
+	return executor.Job{}, false, errors.Newf("job dequeued from unknown queue %q", queueName)
+}
+
+func (s *MultiQueueShim) Heartbeat(ctx context.Context, ids []int) (knownIDs, cancelIDs []int, err error) {
+	jobIDsByQueue := make(map[string][]int, len(s.Queues))
+	for _, queue := range s.Queues {
+		// Heartbeat to all queues, so that the executor is known to all of them even
+		// while it's not running any of their jobs.
+		jobIDsByQueue[queue.Name] = nil
+	}
+	for _, id := range ids {
+		queueName, jobID := s.decode(id)
+		jobIDsByQueue[queueName] = append(jobIDsByQueue[queueName], jobID)
+	}
+
+	knownIDsByQueue, cancelIDsByQueue, err := s.Store.HeartbeatQueues(ctx, jobIDsByQueue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.encodeAll(knownIDsByQueue), s.encodeAll(cancelIDsByQueue), nil
+}
+
+func (s *MultiQueueShim) AddExecutionLogEntry(ctx context.Context, id int, entry workerutil.ExecutionLogEntry) (int, error) {
+	queueName, jobID := s.decode(id)
+	return s.Store.AddExecutionLogEntry(ctx, queueName, jobID, entry)
+}
+
+func (s *MultiQueueShim) UpdateExecutionLogEntry(ctx context.Context, id, entryID int, entry workerutil.ExecutionLogEntry) error {
+	queueName, jobID := s.decode(id)
+	return s.Store.UpdateExecutionLogEntry(ctx, queueName, jobID, entryID, entry)
+}
+
+func (s *MultiQueueShim) MarkComplete(ctx context.Context, id int) (bool, error) {
+	queueName, jobID := s.decode(id)
+	return true, s.Store.MarkComplete(ctx, queueName, jobID)
+}
+
+func (s *MultiQueueShim) MarkErrored(ctx context.Context, id int, errorMessage string) (bool, error) {
+	queueName, jobID := s.decode(id)
+	return true, s.Store.MarkErrored(ctx, queueName, jobID, errorMessage)
+}
+
+func (s *MultiQueueShim) MarkFailed(ctx context.Context, id int, errorMessage string) (bool, error) {
+	queueName, jobID := s.decode(id)
+	return true, s.Store.MarkFailed(ctx, queueName, jobID, errorMessage)
+}
+
+// queueOrder returns the names of the queues in the order they should be tried by the
+// next dequeue attempt. The first queue is picked by smooth weighted round-robin, and
+// the others follow in their configured order.
+func (s *MultiQueueShim) queueOrder() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.current) != len(s.Queues) {
+		s.current = make([]int, len(s.Queues))
+	}
+
+	first, total := 0, 0
+	for i, queue := range s.Queues {
+		s.current[i] += queue.Weight
+		total += queue.Weight
+		if s.current[i] > s.current[first] {
+			first = i
+		}
+	}
+	s.current[first] -= total
+
+	names := make([]string, 0, len(s.Queues))
+	names = append(names, s.Queues[first].Name)
+	for i, queue := range s.Queues {
+		if i != first {
+			names = append(names, queue.Name)
+		}
+	}
+	return names
+}
+
+// decode returns the queue name and job ID encoded in the given record ID.
+func (s *MultiQueueShim) decode(id int) (queueName string, jobID int) {
+	return s.Queues[id%len(s.Queues)].Name, id / len(s.Queues)
+}
+
+// encodeAll returns the record IDs of the given job IDs by queue.
+func (s *MultiQueueShim) encodeAll(jobIDsByQueue map[string][]int) []int {
+	var ids []int
+	for i, queue := range s.Queues {
+		for _, jobID := range jobIDsByQueue[queue.Name] {
+			ids = append(ids, jobID*len(s.Queues)+i)
+		}
+	}
+	return ids
+}
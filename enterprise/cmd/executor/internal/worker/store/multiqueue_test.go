@@ -0,0 +1,151 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestMultiQueueShim_Dequeue_Weights(t *testing.T) {
+	queueStore := new(queueStoreMock)
+	shim := &store.MultiQueueShim{
+		Queues: []store.WeightedQueue{{Name: "batches", Weight: 3}, {Name: "codeintel", Weight: 1}},
+		Store:  queueStore,
+	}
+
+	var orders [][]string
+	queueStore.On("DequeueAny", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { orders = append(orders, args.Get(1).([]string)) }).
+		Return("", false, nil)
+
+	for i := 0; i < 8; i++ {
+		_, dequeued, err := shim.Dequeue(context.Background(), "host-name", nil)
+		assert.NoError(t, err)
+		assert.False(t, dequeued)
+	}
+
+	batchesFirst := []string{"batches", "codeintel"}
+	codeintelFirst := []string{"codeintel", "batches"}
+	assert.Equal(t, [][]string{
+		batchesFirst, batchesFirst, codeintelFirst, batchesFirst,
+		batchesFirst, batchesFirst, codeintelFirst, batchesFirst,
+	}, orders)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_Dequeue(t *testing.T) {
+	queueStore := new(queueStoreMock)
+	shim := &store.MultiQueueShim{
+		Queues: []store.WeightedQueue{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}},
+		Store:  queueStore,
+	}
+
+	queueStore.On("DequeueAny", mock.Anything, []string{"batches", "codeintel"}, mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(2).(*executor.Job).ID = 7 }).
+		Return("codeintel", true, nil)
+
+	job, dequeued, err := shim.Dequeue(context.Background(), "host-name", nil)
+	assert.NoError(t, err)
+	assert.True(t, dequeued)
+	assert.Equal(t, 7, job.ID)
+	assert.Equal(t, "codeintel", job.Queue)
+	// Job IDs of different queues must not collide within the worker.
+	assert.Equal(t, 15, job.RecordID())
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_Dequeue_Error(t *testing.T) {
+	queueStore := new(queueStoreMock)
+	shim := &store.MultiQueueShim{
+		Queues: []store.WeightedQueue{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}},
+		Store:  queueStore,
+	}
+
+	queueStore.On("DequeueAny", mock.Anything, mock.Anything, mock.Anything).
+		Return("", false, errors.New("failed"))
+
+	job, dequeued, err := shim.Dequeue(context.Background(), "host-name", nil)
+	assert.Error(t, err)
+	assert.Equal(t, "failed", err.Error())
+	assert.False(t, dequeued)
+	assert.Equal(t, executor.Job{}, job)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_Heartbeat(t *testing.T) {
+	queueStore := new(queueStoreMock)
+	shim := &store.MultiQueueShim{
+		Queues: []store.WeightedQueue{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}, {Name: "other", Weight: 1}},
+		Store:  queueStore,
+	}
+
+	queueStore.On("HeartbeatQueues", mock.Anything, map[string][]int{
+		"batches":   {7, 2},
+		"codeintel": {7},
+		"other":     nil,
+	}).Return(
+		map[string][]int{"batches": {7}, "codeintel": {7}, "other": {}},
+		map[string][]int{"batches": {}, "codeintel": {7}, "other": {}},
+		nil,
+	)
+
+	knownIDs, cancelIDs, err := shim.Heartbeat(context.Background(), []int{21, 22, 6})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{21, 22}, knownIDs)
+	assert.Equal(t, []int{22}, cancelIDs)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_MarkComplete(t *testing.T) {
+	queueStore := new(queueStoreMock)
+	shim := &store.MultiQueueShim{
+		Queues: []store.WeightedQueue{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}},
+		Store:  queueStore,
+	}
+
+	queueStore.On("MarkComplete", mock.Anything, "codeintel", 7).
+		Return(nil)
+
+	marked, err := shim.MarkComplete(context.Background(), 15)
+	assert.NoError(t, err)
+	assert.True(t, marked)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_MarkErrored(t *testing.T) {
+	queueStore := new(queueStoreMock)
+	shim := &store.MultiQueueShim{
+		Queues: []store.WeightedQueue{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}},
+		Store:  queueStore,
+	}
+
+	queueStore.On("MarkErrored", mock.Anything, "batches", 7, "failed to handle").
+		Return(nil)
+
+	marked, err := shim.MarkErrored(context.Background(), 14, "failed to handle")
+	assert.NoError(t, err)
+	assert.True(t, marked)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func (m *queueStoreMock) DequeueAny(ctx context.Context, queueNames []string, payload *executor.Job) (string, bool, error) {
+	args := m.Called(ctx, queueNames, payload)
+	return args.String(0), args.Bool(1), args.Error(2)
+}
+
+func (m *queueStoreMock) HeartbeatQueues(ctx context.Context, jobIDsByQueue map[string][]int) (knownIDsByQueue, cancelIDsByQueue map[string][]int, err error) {
+	args := m.Called(ctx, jobIDsByQueue)
+	return args.Get(0).(map[string][]int), args.Get(1).(map[string][]int), args.Error(2)
+}
@@ -38,6 +38,9 @@ func (s *QueueShim) Dequeue(ctx context.Context, workerHostname string, extraArg
 	if err != nil {
 		return executor.Job{}, false, err
 	}
+	if dequeued {
+		job.Queue = s.Name
+	}
 
 	return job, dequeued, nil
 }
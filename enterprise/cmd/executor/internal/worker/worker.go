@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/repocache"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -41,6 +42,11 @@ type Options struct {
 	// horizontal scaling factors while still uniformly processing events.
 	QueueName string
 
+	// Queues, if set, are the queues to process work from instead of QueueName. The
+	// weight of each queue controls how often it's tried first when dequeueing, while
+	// queues without work are skipped.
+	Queues []store.WeightedQueue
+
 	// GitServicePath is the path to the internal git service API proxy in the frontend.
 	// This path should contain the endpoints info/refs and git-upload-pack.
 	GitServicePath string
@@ -63,6 +69,13 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions command.KubernetesOptions
+
+	// RepoCache, if set, is the cache of repositories used to seed the workspaces of
+	// jobs. It is shared with the janitor that evicts repositories from it.
+	RepoCache *repocache.Cache
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
@@ -88,7 +101,10 @@ func NewWorker(logger log.Logger, nameSet *janitor.NameSet, options Options, obs
 	if err != nil {
 		return nil, errors.Wrap(err, "building files store")
 	}
-	shim := &store.QueueShim{Name: options.QueueName, Store: queueStore}
+	var shim workerutil.Store[executor.Job] = &store.QueueShim{Name: options.QueueName, Store: queueStore}
+	if len(options.Queues) > 0 {
+		shim = &store.MultiQueueShim{Queues: options.Queues, Store: queueStore}
+	}
 
 	if !connectToFrontend(logger, queueStore, options) {
 		os.Exit(1)
@@ -123,8 +139,13 @@ func connectToFrontend(logger log.Logger, queueStore *queue.Client, options Opti
 	signal.Notify(signals, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
 	defer signal.Stop(signals)
 
+	pingQueueName := options.QueueName
+	if len(options.Queues) > 0 {
+		pingQueueName = options.Queues[0].Name
+	}
+
 	for {
-		err := queueStore.Ping(context.Background(), options.QueueName, nil)
+		err := queueStore.Ping(context.Background(), pingQueueName, nil)
 		if err == nil {
 			logger.Info("Connected to Sourcegraph instance")
 			return true
@@ -31,6 +31,25 @@ func (h *handler) prepareWorkspace(
 				EndpointURL:    h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
 				GitServicePath: h.options.GitServicePath,
 				ExecutorToken:  h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+				RepoCache:      h.options.RepoCache,
+			},
+			h.operations,
+		)
+	}
+
+	if h.options.KubernetesOptions.Enabled {
+		return workspace.NewKubernetesWorkspace(
+			ctx,
+			h.filesStore,
+			job,
+			h.options.KubernetesOptions.MountPath,
+			commandRunner,
+			commandLogger,
+			workspace.CloneOptions{
+				EndpointURL:    h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
+				GitServicePath: h.options.GitServicePath,
+				ExecutorToken:  h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+				RepoCache:      h.options.RepoCache,
 			},
 			h.operations,
 		)
@@ -46,6 +65,7 @@ func (h *handler) prepareWorkspace(
 			EndpointURL:    h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
 			GitServicePath: h.options.GitServicePath,
 			ExecutorToken:  h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+			RepoCache:      h.options.RepoCache,
 		},
 		h.operations,
 	)
@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/inconshreveable/log15"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -26,6 +28,10 @@ var gitStdEnv = []string{
 	"GIT_LFS_SKIP_SMUDGE=1",
 }
 
+// repoCacheRef is the ref in cached repositories that keeps the objects of the most
+// recently fetched commit from being garbage-collected.
+const repoCacheRef = "refs/executor/latest"
+
 func cloneRepo(
 	ctx context.Context,
 	workspaceDir string,
@@ -94,12 +100,34 @@ func cloneRepo(
 		appendFetchArg("--filter=blob:none")
 	}
 
+	// Borrow objects from the cached copy of the repository while fetching and checking
+	// out, so that only objects that are missing from the cache are transferred. Sparse
+	// checkouts fetch blobs lazily and are not worth caching.
+	gitEnv := gitStdEnv
+	useRepoCache := false
+	if options.RepoCache != nil && len(job.SparseCheckout) == 0 {
+		cachePath, unlock := options.RepoCache.Lock(job.RepositoryName)
+		defer unlock()
+
+		if err := updateRepoCache(ctx, cachePath, cloneURL.String(), job, commandRunner, operations); err != nil {
+			// A broken cache should never fail the job. Clone without it and start
+			// over with a fresh cache on the next job.
+			log15.Warn("Failed to update repository cache", "repo", job.RepositoryName, "error", err)
+			if err := os.RemoveAll(cachePath); err != nil {
+				log15.Warn("Failed to remove repository cache", "repo", job.RepositoryName, "error", err)
+			}
+		} else {
+			gitEnv = append(append([]string{}, gitStdEnv...), "GIT_ALTERNATE_OBJECT_DIRECTORIES="+filepath.Join(cachePath, "objects"))
+			useRepoCache = true
+		}
+	}
+
 	gitCommands := []command.CommandSpec{
 		{Key: "setup.git.init", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "init"}, Operation: operations.SetupGitInit},
 		{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", cloneURL.String()}, Operation: operations.SetupAddRemote},
 		// Disable gc, this can improve performance and should never run for executor clones.
 		{Key: "setup.git.disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "gc.auto", "0"}, Operation: operations.SetupGitDisableGC},
-		{Key: "setup.git.fetch", Env: gitStdEnv, Command: fetchCommand, Operation: operations.SetupGitFetch},
+		{Key: "setup.git.fetch", Env: gitEnv, Command: fetchCommand, Operation: operations.SetupGitFetch},
 	}
 
 	if len(job.SparseCheckout) > 0 {
@@ -142,11 +170,23 @@ func cloneRepo(
 
 	gitCommands = append(gitCommands, command.CommandSpec{
 		Key:       "setup.git.checkout",
-		Env:       gitStdEnv,
+		Env:       gitEnv,
 		Command:   checkoutCommand,
 		Operation: operations.SetupGitCheckout,
 	})
 
+	if useRepoCache {
+		// Copy the borrowed objects reachable from the checked out commit into the
+		// workspace. The cache is not available wherever the workspace is mounted
+		// into, and may be evicted while the job is running.
+		gitCommands = append(gitCommands, command.CommandSpec{
+			Key:       "setup.git.dissociate",
+			Env:       gitEnv,
+			Command:   []string{"git", "-C", repoPath, "repack", "-a", "-d", "-q"},
+			Operation: operations.SetupGitDissociate,
+		})
+	}
+
 	// This is for LSIF, it relies on the origin being set to the upstream repo
 	// for indexing.
 	gitCommands = append(gitCommands, command.CommandSpec{
@@ -172,6 +212,62 @@ func cloneRepo(
 	return nil
 }
 
+// updateRepoCache creates the cached bare repository at the given path if it does not
+// exist yet and incrementally fetches the job's commit into it.
+func updateRepoCache(
+	ctx context.Context,
+	cachePath string,
+	cloneURL string,
+	job executor.Job,
+	commandRunner command.Runner,
+	operations *command.Operations,
+) error {
+	var gitCommands []command.CommandSpec
+
+	if _, err := os.Stat(filepath.Join(cachePath, "HEAD")); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		gitCommands = append(gitCommands, command.CommandSpec{
+			Key:       "setup.git.cache-init",
+			Env:       gitStdEnv,
+			Command:   []string{"git", "init", "--bare", "--quiet", cachePath},
+			Operation: operations.SetupGitCacheInit,
+		})
+	}
+
+	tagsArg := "--no-tags"
+	if job.FetchTags {
+		tagsArg = "--tags"
+	}
+
+	gitCommands = append(gitCommands, command.CommandSpec{
+		Key: "setup.git.cache-fetch",
+		Env: gitStdEnv,
+		Command: []string{
+			"git",
+			"-C", cachePath,
+			"-c", "protocol.version=2",
+			"fetch",
+			"--progress",
+			"--no-recurse-submodules",
+			tagsArg,
+			cloneURL,
+			fmt.Sprintf("+%s:%s", job.Commit, repoCacheRef),
+		},
+		Operation: operations.SetupGitCacheFetch,
+	})
+
+	for _, spec := range gitCommands {
+		if err := commandRunner.Run(ctx, spec); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed %s", spec.Key))
+		}
+	}
+
+	return nil
+}
+
 // newGitProxyServer creates a new HTTP proxy to the Sourcegraph instance on a random port.
 // It handles authentication and additional headers required. The cleanup function
 // should be called after the clone operations are done and _before_ the job is started.
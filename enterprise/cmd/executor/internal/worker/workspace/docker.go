@@ -27,6 +27,21 @@ func NewDockerWorkspace(
 		return nil, err
 	}
 
+	return prepareHostWorkspace(ctx, filesStore, job, workspaceDir, commandRunner, logger, cloneOpts, operations)
+}
+
+// prepareHostWorkspace clones the repo and puts script files into the given directory on
+// the host. The directory is removed if the workspace can't be prepared.
+func prepareHostWorkspace(
+	ctx context.Context,
+	filesStore store.FilesStore,
+	job executor.Job,
+	workspaceDir string,
+	commandRunner command.Runner,
+	logger command.Logger,
+	cloneOpts CloneOptions,
+	operations *command.Operations,
+) (Workspace, error) {
 	if job.RepositoryName != "" {
 		if err := cloneRepo(ctx, workspaceDir, job, commandRunner, cloneOpts, operations); err != nil {
 			_ = os.RemoveAll(workspaceDir)
@@ -1,11 +1,18 @@
 package workspace
 
-import "context"
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/repocache"
+)
 
 type CloneOptions struct {
 	EndpointURL    string
 	GitServicePath string
 	ExecutorToken  string
+	// RepoCache, if set, is used to seed the workspace with the objects of a cached copy
+	// of the repository, so that only new objects are fetched.
+	RepoCache *repocache.Cache
 }
 
 type Workspace interface {
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+)
+
+// NewKubernetesWorkspace creates a new workspace for Kubernetes-based execution. The
+// workspace is set up like a docker workspace, but in a directory below the mount path
+// of the persistent volume that is shared with the pods of Kubernetes jobs.
+func NewKubernetesWorkspace(
+	ctx context.Context,
+	filesStore store.FilesStore,
+	job executor.Job,
+	mountPath string,
+	commandRunner command.Runner,
+	logger command.Logger,
+	cloneOpts CloneOptions,
+	operations *command.Operations,
+) (Workspace, error) {
+	if err := os.MkdirAll(mountPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	workspaceDir, err := os.MkdirTemp(mountPath, "workspace-"+strconv.Itoa(job.ID)+"-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareHostWorkspace(ctx, filesStore, job, workspaceDir, commandRunner, logger, cloneOpts, operations)
+}
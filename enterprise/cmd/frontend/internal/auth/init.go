@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/githuboauth"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/gitlaboauth"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/httpheader"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/ldap"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/openidconnect"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/saml"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/sourcegraphoperator"
@@ -31,6 +32,7 @@ func Init(logger log.Logger, db database.DB) {
 	sourcegraphoperator.Init()
 	saml.Init()
 	httpheader.Init()
+	ldap.Init()
 	githuboauth.Init(logger, db)
 	gitlaboauth.Init(logger, db)
 
@@ -40,6 +42,7 @@ func Init(logger log.Logger, db database.DB) {
 		sourcegraphoperator.Middleware(db),
 		saml.Middleware(db),
 		httpheader.Middleware(db),
+		ldap.Middleware(db),
 		githuboauth.Middleware(db),
 		gitlaboauth.Middleware(db),
 	)
@@ -73,6 +76,8 @@ func Init(logger log.Logger, db database.DB) {
 				name = "GitLab OAuth"
 			case p.HttpHeader != nil:
 				name = "HTTP header"
+			case p.Ldap != nil:
+				name = "LDAP"
 			case p.Openidconnect != nil:
 				name = "OpenID Connect"
 			case p.Saml != nil:
@@ -0,0 +1,144 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const pkgName = "ldap"
+
+func Init() {
+	conf.ContributeValidator(validateConfig)
+
+	logger := log.Scoped(pkgName, "LDAP config watch")
+	go func() {
+		conf.Watch(func() {
+			ps := getProviders()
+			if len(ps) == 0 {
+				providers.Update(pkgName, nil)
+				return
+			}
+
+			if err := licensing.Check(licensing.FeatureSSO); err != nil {
+				logger.Error("Check license for SSO (LDAP)", log.Error(err))
+				providers.Update(pkgName, nil)
+				return
+			}
+			providers.Update(pkgName, ps)
+		})
+	}()
+}
+
+func getProviders() []providers.Provider {
+	var ps []providers.Provider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		ps = append(ps, &provider{config: *p.Ldap})
+	}
+	return ps
+}
+
+func validateConfig(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.SiteConfig().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		pc := p.Ldap
+
+		id := providerConfigID(pc)
+		if j, ok := seen[id]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+			continue
+		}
+		seen[id] = i
+
+		if pc.StartTLS && strings.HasPrefix(pc.Url, "ldaps://") {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `startTLS` cannot be used with an ldaps:// URL", i)))
+		}
+		if pc.TlsCACertificate != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(pc.TlsCACertificate)) {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `tlsCACertificate` does not contain a valid PEM-encoded certificate", i)))
+		}
+		if pc.BindDN == "" && pc.BindPassword != "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `bindPassword` is set but `bindDN` is empty", i)))
+		}
+		if filter := searchFilter(pc); !strings.Contains(filter, "{username}") {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: `searchFilter` must contain the {username} placeholder", i)))
+		}
+	}
+	return problems
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider config object. It is
+// used to distinguish between multiple auth providers of the same type when in multi-step auth
+// flows. Its value is never persisted, and it must be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	// Leave secrets out of the ID, because it is exposed in URLs.
+	c := *pc
+	c.BindPassword = ""
+	data, err := json.Marshal(c)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
+
+func searchFilter(pc *schema.LDAPAuthProvider) string {
+	if pc.SearchFilter != "" {
+		return pc.SearchFilter
+	}
+	return "(&(objectClass=person)(uid={username}))"
+}
+
+func usernameAttribute(pc *schema.LDAPAuthProvider) string {
+	if pc.UsernameAttribute != "" {
+		return pc.UsernameAttribute
+	}
+	return "uid"
+}
+
+func emailAttribute(pc *schema.LDAPAuthProvider) string {
+	if pc.EmailAttribute != "" {
+		return pc.EmailAttribute
+	}
+	return "mail"
+}
+
+func displayNameAttribute(pc *schema.LDAPAuthProvider) string {
+	if pc.DisplayNameAttribute != "" {
+		return pc.DisplayNameAttribute
+	}
+	return "cn"
+}
+
+func groupSearchFilter(gs *schema.LDAPGroupSync) string {
+	if gs.SearchFilter != "" {
+		return gs.SearchFilter
+	}
+	return "(|(member={dn})(uniqueMember={dn})(memberUid={username}))"
+}
+
+func groupNameAttribute(gs *schema.LDAPGroupSync) string {
+	if gs.NameAttribute != "" {
+		return gs.NameAttribute
+	}
+	return "cn"
+}
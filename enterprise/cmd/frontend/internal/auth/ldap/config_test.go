@@ -0,0 +1,78 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateCustom(t *testing.T) {
+	tests := map[string]struct {
+		input        conf.Unified
+		wantProblems conf.Problems
+	}{
+		"valid": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", SearchBase: "dc=example,dc=com"}},
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ad.example.com", StartTLS: true, SearchBase: "dc=example,dc=com"}},
+				},
+			}},
+			wantProblems: nil,
+		},
+		"duplicates": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", SearchBase: "dc=example,dc=com"}},
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", SearchBase: "dc=example,dc=com"}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 1 is duplicate of index 0, ignoring"),
+		},
+		"StartTLS with ldaps": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", StartTLS: true, SearchBase: "dc=example,dc=com"}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0: `startTLS` cannot be used with an ldaps:// URL"),
+		},
+		"invalid CA certificate": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", TlsCACertificate: "-----BEGIN CERTIFICATE-----\nx", SearchBase: "dc=example,dc=com"}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0: `tlsCACertificate` does not contain a valid PEM-encoded certificate"),
+		},
+		"search filter without placeholder": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", SearchBase: "dc=example,dc=com", SearchFilter: "(uid=alice)"}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0: `searchFilter` must contain the {username} placeholder"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf.TestValidator(t, test.input, validateConfig, test.wantProblems)
+		})
+	}
+}
+
+func TestProviderConfigID(t *testing.T) {
+	p := schema.LDAPAuthProvider{Url: "ldaps://ldap.example.com", BindPassword: "secret"}
+	id1 := providerConfigID(&p)
+	id2 := providerConfigID(&p)
+	if id1 != id2 {
+		t.Errorf("id1 (%q) != id2 (%q)", id1, id2)
+	}
+
+	// The bind password must not influence the ID, which is exposed in URLs.
+	p.BindPassword = "other"
+	if id3 := providerConfigID(&p); id3 != id1 {
+		t.Errorf("id3 (%q) != id1 (%q)", id3, id1)
+	}
+}
@@ -0,0 +1,188 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// errInvalidCredentials is returned by authenticate if no user entry matches the username or if
+// the password is wrong. The two cases are deliberately indistinguishable to callers, so that
+// the sign-in form can't be used to enumerate the users in the directory.
+var errInvalidCredentials = errors.New("invalid username or password")
+
+const (
+	dialTimeout    = 10 * time.Second
+	requestTimeout = 30 * time.Second
+)
+
+// directoryUser is the user entry of an authenticated user, along with the names of the groups
+// the user is a member of. It is stored as the account data of the user's external account.
+type directoryUser struct {
+	DN          string   `json:"dn"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+}
+
+// authenticate verifies the username and password against the LDAP directory of the provider
+// config. It looks up the user entry with the service account, binds as the user entry to verify
+// the password and, if group sync is configured, looks up the groups of the user.
+func authenticate(pc *schema.LDAPAuthProvider, username, password string) (*directoryUser, error) {
+	// 🚨 SECURITY: Many LDAP servers treat a simple bind with an empty password as an
+	// unauthenticated bind, which succeeds for any DN.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	conn, err := dial(pc)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := bindServiceAccount(conn, pc); err != nil {
+		return nil, err
+	}
+
+	filter := strings.NewReplacer("{username}", ldap.EscapeFilter(username)).Replace(searchFilter(pc))
+	res, err := conn.Search(ldap.NewSearchRequest(
+		pc.SearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(requestTimeout.Seconds()), false,
+		filter, []string{usernameAttribute(pc), emailAttribute(pc), displayNameAttribute(pc)}, nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for user entry")
+	}
+	switch len(res.Entries) {
+	case 0:
+		return nil, errInvalidCredentials
+	case 1:
+	default:
+		return nil, errors.Errorf("search filter %q matched %d user entries, expected 1", filter, len(res.Entries))
+	}
+	entry := res.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "binding as user entry")
+	}
+
+	user := &directoryUser{
+		DN:          entry.DN,
+		Username:    entry.GetAttributeValue(usernameAttribute(pc)),
+		Email:       entry.GetAttributeValue(emailAttribute(pc)),
+		DisplayName: entry.GetAttributeValue(displayNameAttribute(pc)),
+	}
+	if user.Username == "" {
+		return nil, errors.Errorf("user entry %q has no %q attribute", entry.DN, usernameAttribute(pc))
+	}
+
+	if pc.GroupSync != nil {
+		// The user might not be allowed to read group entries, so search as the service account.
+		if err := bindServiceAccount(conn, pc); err != nil {
+			return nil, err
+		}
+		if user.Groups, err = searchGroups(conn, pc, entry.DN, user.Username); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func searchGroups(conn *ldap.Conn, pc *schema.LDAPAuthProvider, dn, username string) ([]string, error) {
+	gs := pc.GroupSync
+	base := gs.SearchBase
+	if base == "" {
+		base = pc.SearchBase
+	}
+	filter := strings.NewReplacer(
+		"{dn}", ldap.EscapeFilter(dn),
+		"{username}", ldap.EscapeFilter(username),
+	).Replace(groupSearchFilter(gs))
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		base, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(requestTimeout.Seconds()), false,
+		filter, []string{groupNameAttribute(gs)}, nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for groups")
+	}
+
+	groups := make([]string, 0, len(res.Entries))
+	for _, entry := range res.Entries {
+		if name := entry.GetAttributeValue(groupNameAttribute(gs)); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// bindServiceAccount binds as the service account of the provider config, or binds anonymously
+// if no service account is configured.
+func bindServiceAccount(conn *ldap.Conn, pc *schema.LDAPAuthProvider) error {
+	var err error
+	if pc.BindDN != "" {
+		err = conn.Bind(pc.BindDN, pc.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	return errors.Wrap(err, "binding as service account")
+}
+
+// dial connects to the LDAP server of the provider config, upgrading the connection to TLS if
+// StartTLS is enabled.
+func dial(pc *schema.LDAPAuthProvider) (*ldap.Conn, error) {
+	tlsConfig, err := newTLSConfig(pc)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ldap.DialURL(pc.Url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	conn.SetTimeout(requestTimeout)
+
+	if pc.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "starting TLS")
+		}
+	}
+	return conn, nil
+}
+
+func newTLSConfig(pc *schema.LDAPAuthProvider) (*tls.Config, error) {
+	u, err := url.Parse(pc.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing LDAP server URL")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: pc.TlsInsecureSkipVerify,
+	}
+	if pc.TlsCACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(pc.TlsCACertificate)) {
+			return nil, errors.New("invalid tlsCACertificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
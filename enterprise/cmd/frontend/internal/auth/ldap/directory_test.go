@@ -0,0 +1,281 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	testBindDN       = "cn=sourcegraph,ou=services,dc=example,dc=com"
+	testBindPassword = "service-secret"
+)
+
+var testEntries = []*ldap.Entry{
+	ldap.NewEntry("cn=sourcegraph,ou=services,dc=example,dc=com", map[string][]string{
+		"objectClass": {"applicationProcess"},
+		"cn":          {"sourcegraph"},
+	}),
+	ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass": {"person"},
+		"uid":         {"alice"},
+		"cn":          {"Alice Example"},
+		"mail":        {"alice@example.com"},
+	}),
+	ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass": {"person"},
+		"uid":         {"bob"},
+		"cn":          {"Bob Example"},
+	}),
+	ldap.NewEntry("cn=engineering,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"engineering"},
+		"member":      {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+	}),
+	ldap.NewEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"posixGroup"},
+		"cn":          {"admins"},
+		"memberUid":   {"alice"},
+	}),
+}
+
+var testPasswords = map[string]string{
+	testBindDN:                              testBindPassword,
+	"uid=alice,ou=people,dc=example,dc=com": "alice-secret",
+	"uid=bob,ou=people,dc=example,dc=com":   "bob-secret",
+}
+
+func TestAuthenticate(t *testing.T) {
+	s := newTestServer(t, nil, false, testEntries, testPasswords)
+
+	newConfig := func() *schema.LDAPAuthProvider {
+		return &schema.LDAPAuthProvider{
+			Type:         providerType,
+			Url:          "ldap://" + s.addr(),
+			BindDN:       testBindDN,
+			BindPassword: testBindPassword,
+			SearchBase:   "ou=people,dc=example,dc=com",
+		}
+	}
+
+	t.Run("valid credentials", func(t *testing.T) {
+		user, err := authenticate(newConfig(), "alice", "alice-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := &directoryUser{
+			DN:          "uid=alice,ou=people,dc=example,dc=com",
+			Username:    "alice",
+			Email:       "alice@example.com",
+			DisplayName: "Alice Example",
+		}
+		if diff := cmp.Diff(want, user); diff != "" {
+			t.Errorf("unexpected user (-want +got):\n%s", diff)
+		}
+	})
+
+	for name, test := range map[string]struct {
+		username, password string
+	}{
+		"wrong password":   {username: "alice", password: "bob-secret"},
+		"empty password":   {username: "alice", password: ""},
+		"unknown user":     {username: "carol", password: "alice-secret"},
+		"filter injection": {username: "*", password: "alice-secret"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := authenticate(newConfig(), test.username, test.password); !errors.Is(err, errInvalidCredentials) {
+				t.Errorf("expected errInvalidCredentials, got %v", err)
+			}
+		})
+	}
+
+	t.Run("wrong service account password", func(t *testing.T) {
+		pc := newConfig()
+		pc.BindPassword = "wrong"
+		if _, err := authenticate(pc, "alice", "alice-secret"); err == nil || errors.Is(err, errInvalidCredentials) {
+			t.Errorf("expected service account bind error, got %v", err)
+		}
+	})
+
+	t.Run("ambiguous search filter", func(t *testing.T) {
+		pc := newConfig()
+		pc.SearchFilter = "(|(uid={username})(objectClass=person))"
+		if _, err := authenticate(pc, "alice", "alice-secret"); err == nil || errors.Is(err, errInvalidCredentials) {
+			t.Errorf("expected error for ambiguous search filter, got %v", err)
+		}
+	})
+
+	t.Run("attribute mapping", func(t *testing.T) {
+		pc := newConfig()
+		pc.SearchFilter = "(mail={username})"
+		pc.UsernameAttribute = "mail"
+		pc.EmailAttribute = "mail"
+		pc.DisplayNameAttribute = "uid"
+		user, err := authenticate(pc, "alice@example.com", "alice-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if user.Username != "alice@example.com" || user.DisplayName != "alice" {
+			t.Errorf("unexpected user %+v", user)
+		}
+	})
+
+	t.Run("group sync", func(t *testing.T) {
+		pc := newConfig()
+		pc.GroupSync = &schema.LDAPGroupSync{
+			SearchBase: "ou=groups,dc=example,dc=com",
+			Orgs:       map[string]string{"engineering": "eng"},
+		}
+
+		user, err := authenticate(pc, "alice", "alice-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]string{"engineering", "admins"}, user.Groups); diff != "" {
+			t.Errorf("unexpected groups (-want +got):\n%s", diff)
+		}
+
+		user, err = authenticate(pc, "bob", "bob-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]string{"engineering"}, user.Groups); diff != "" {
+			t.Errorf("unexpected groups (-want +got):\n%s", diff)
+		}
+
+		// Groups are searched as the service account, not as the user.
+		binds := s.bindDNs()
+		if len(binds) < 3 || binds[len(binds)-1] != testBindDN || binds[len(binds)-2] != "uid=bob,ou=people,dc=example,dc=com" {
+			t.Errorf("unexpected binds %q", binds)
+		}
+	})
+
+	t.Run("group sync with username attribute", func(t *testing.T) {
+		pc := newConfig()
+		pc.SearchFilter = "(|(uid={username})(mail={username}))"
+		pc.GroupSync = &schema.LDAPGroupSync{
+			SearchBase: "ou=groups,dc=example,dc=com",
+			Orgs:       map[string]string{"admins": "admins"},
+		}
+
+		// Groups are matched by the username attribute of the entry, not by what the user
+		// entered in the sign-in form.
+		user, err := authenticate(pc, "alice@example.com", "alice-secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]string{"engineering", "admins"}, user.Groups); diff != "" {
+			t.Errorf("unexpected groups (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("anonymous search", func(t *testing.T) {
+		pc := newConfig()
+		pc.BindDN = ""
+		pc.BindPassword = ""
+		if _, err := authenticate(pc, "bob", "bob-secret"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if binds := s.bindDNs(); binds[len(binds)-2] != "" {
+			t.Errorf("expected anonymous bind before user bind, got %q", binds)
+		}
+	})
+}
+
+func TestAuthenticateTLS(t *testing.T) {
+	certPEM, tlsConfig := generateTestCertificate(t)
+
+	t.Run("ldaps", func(t *testing.T) {
+		s := newTestServer(t, tlsConfig, true, testEntries, testPasswords)
+		pc := &schema.LDAPAuthProvider{
+			Url:              "ldaps://" + s.addr(),
+			TlsCACertificate: certPEM,
+			SearchBase:       "dc=example,dc=com",
+		}
+		if _, err := authenticate(pc, "alice", "alice-secret"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		// The certificate isn't trusted without the CA certificate.
+		pc.TlsCACertificate = ""
+		if _, err := authenticate(pc, "alice", "alice-secret"); err == nil {
+			t.Fatal("expected certificate verification error")
+		}
+
+		pc.TlsInsecureSkipVerify = true
+		if _, err := authenticate(pc, "alice", "alice-secret"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("StartTLS", func(t *testing.T) {
+		s := newTestServer(t, tlsConfig, false, testEntries, testPasswords)
+		pc := &schema.LDAPAuthProvider{
+			Url:              "ldap://" + s.addr(),
+			StartTLS:         true,
+			TlsCACertificate: certPEM,
+			SearchBase:       "dc=example,dc=com",
+		}
+		if _, err := authenticate(pc, "alice", "alice-secret"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("StartTLS unsupported", func(t *testing.T) {
+		s := newTestServer(t, nil, false, testEntries, testPasswords)
+		pc := &schema.LDAPAuthProvider{
+			Url:        "ldap://" + s.addr(),
+			StartTLS:   true,
+			SearchBase: "dc=example,dc=com",
+		}
+		if _, err := authenticate(pc, "alice", "alice-secret"); err == nil {
+			t.Fatal("expected StartTLS error")
+		}
+		if binds := s.bindDNs(); len(binds) != 0 {
+			t.Errorf("expected no binds without TLS, got %q", binds)
+		}
+	})
+}
+
+// generateTestCertificate generates a self-signed certificate for 127.0.0.1. It returns the
+// PEM-encoded certificate and a server TLS config using it.
+func generateTestCertificate(t *testing.T) (string, *tls.Config) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return string(certPEM), &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
@@ -0,0 +1,77 @@
+package ldap
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+)
+
+// lockoutStore tracks the failed sign-in attempts of LDAP usernames. The users signing in might not
+// have a Sourcegraph account yet, so unlike the lockout of the builtin auth provider, lockouts are
+// keyed by the provider and the username entered in the sign-in form.
+type lockoutStore interface {
+	// IsLockedOut returns true if the username has been locked out.
+	IsLockedOut(providerID, username string) bool
+	// IncreaseFailedAttempt increases the failed sign-in attempt count of the username by 1, and
+	// locks the username out if the count reaches the threshold.
+	IncreaseFailedAttempt(providerID, username string)
+	// Reset clears the failed sign-in attempt count and releases the lockout of the username.
+	Reset(providerID, username string)
+}
+
+type redisLockoutStore struct {
+	failedThreshold int
+	lockouts        *rcache.Cache
+	failedAttempts  *rcache.Cache
+}
+
+// newLockoutStore returns a lockoutStore using the Redis cache, configured with the same
+// auth.lockout site configuration as the builtin auth provider.
+func newLockoutStore() lockoutStore {
+	opts := conf.AuthLockout()
+	return newLockoutStoreWithOptions(
+		opts.FailedAttemptThreshold,
+		time.Duration(opts.LockoutPeriod)*time.Second,
+		time.Duration(opts.ConsecutivePeriod)*time.Second,
+	)
+}
+
+func newLockoutStoreWithOptions(failedThreshold int, lockoutPeriod, consecutivePeriod time.Duration) lockoutStore {
+	return &redisLockoutStore{
+		failedThreshold: failedThreshold,
+		lockouts:        rcache.NewWithTTL("ldap_account_lockout", int(lockoutPeriod.Seconds())),
+		failedAttempts:  rcache.NewWithTTL("ldap_account_failed_attempts", int(consecutivePeriod.Seconds())),
+	}
+}
+
+// lockoutKey returns the cache key of the username. Usernames are matched case-insensitively by
+// most LDAP servers, so they are lowercased to prevent trivially bypassing the lockout.
+func lockoutKey(providerID, username string) string {
+	return providerID + ":" + strings.ToLower(username)
+}
+
+func (s *redisLockoutStore) IsLockedOut(providerID, username string) bool {
+	_, locked := s.lockouts.Get(lockoutKey(providerID, username))
+	return locked
+}
+
+func (s *redisLockoutStore) IncreaseFailedAttempt(providerID, username string) {
+	key := lockoutKey(providerID, username)
+	s.failedAttempts.Increase(key)
+
+	// Get right after Increase should make the key always exist
+	v, _ := s.failedAttempts.Get(key)
+	count, _ := strconv.Atoi(string(v))
+	if count >= s.failedThreshold {
+		s.lockouts.Set(key, []byte("too many failed attempts"))
+	}
+}
+
+func (s *redisLockoutStore) Reset(providerID, username string) {
+	key := lockoutKey(providerID, username)
+	s.lockouts.Delete(key)
+	s.failedAttempts.Delete(key)
+}
@@ -0,0 +1,58 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+)
+
+func TestLockoutStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Run("locked out after threshold", func(t *testing.T) {
+		rcache.SetupForTest(t)
+
+		s := newLockoutStoreWithOptions(2, time.Minute, time.Minute)
+
+		s.IncreaseFailedAttempt("pc", "alice")
+		if s.IsLockedOut("pc", "alice") {
+			t.Fatal("expected alice not to be locked out after one failed attempt")
+		}
+
+		// Usernames are case-insensitive.
+		s.IncreaseFailedAttempt("pc", "Alice")
+		if !s.IsLockedOut("pc", "alice") {
+			t.Fatal("expected alice to be locked out after two failed attempts")
+		}
+
+		// Lockouts are per provider and per username.
+		if s.IsLockedOut("other", "alice") || s.IsLockedOut("pc", "bob") {
+			t.Error("expected lockout to only apply to alice of the provider")
+		}
+
+		s.Reset("pc", "alice")
+		if s.IsLockedOut("pc", "alice") {
+			t.Error("expected alice not to be locked out after reset")
+		}
+	})
+
+	t.Run("automatically released", func(t *testing.T) {
+		rcache.SetupForTest(t)
+
+		s := newLockoutStoreWithOptions(1, 2*time.Second, time.Minute)
+
+		s.IncreaseFailedAttempt("pc", "alice")
+		if !s.IsLockedOut("pc", "alice") {
+			t.Fatal("expected alice to be locked out")
+		}
+
+		// Wait for an extra second to eliminate flakiness
+		time.Sleep(3 * time.Second)
+		if s.IsLockedOut("pc", "alice") {
+			t.Error("expected lockout to be released")
+		}
+	})
+}
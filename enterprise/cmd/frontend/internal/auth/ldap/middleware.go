@@ -0,0 +1,127 @@
+package ldap
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the sign-in endpoint under the auth path
+// prefix. The sign-in form itself is part of the sign-in page of the web app.
+//
+// 🚨 SECURITY
+func Middleware(db database.DB) *auth.Middleware {
+	return newMiddleware(db, newLockoutStore())
+}
+
+func newMiddleware(db database.DB, lockouts lockoutStore) *auth.Middleware {
+	logger := log.Scoped(pkgName, "LDAP authentication middleware")
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler {
+			return next
+		},
+		App: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, authPrefix+"/") {
+					authHandler(logger, db, lockouts, w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		},
+	}
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func authHandler(logger log.Logger, db database.DB, lockouts lockoutStore, w http.ResponseWriter, r *http.Request) {
+	if strings.TrimPrefix(r.URL.Path, authPrefix) != "/login" {
+		http.Error(w, "", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 🚨 SECURITY: Require the X-Requested-With header, which browsers only send cross-origin if
+	// the request passed the CORS preflight request, so that other sites can't sign visitors in as
+	// another user.
+	if _, ok := r.Header["X-Requested-With"]; !ok {
+		http.Error(w, "Missing X-Requested-With header.", http.StatusForbidden)
+		return
+	}
+
+	p := getProvider(r.URL.Query().Get("pc"))
+	if p == nil {
+		http.Error(w, "Misconfigured LDAP auth provider.", http.StatusNotFound)
+		return
+	}
+	providerID := p.ConfigID().ID
+
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		http.Error(w, "Could not decode request body.", http.StatusBadRequest)
+		return
+	}
+
+	// 🚨 SECURITY: Check the lockout before contacting the LDAP server, so that passwords can't be
+	// guessed by brute force. This also protects the directory accounts from being locked out by
+	// the password policy of the LDAP server.
+	if lockouts.IsLockedOut(providerID, creds.Username) {
+		http.Error(w, "Account has been locked out due to too many failed sign-in attempts.", http.StatusUnprocessableEntity)
+		return
+	}
+
+	user, err := authenticate(&p.config, creds.Username, creds.Password)
+	if errors.Is(err, errInvalidCredentials) {
+		lockouts.IncreaseFailedAttempt(providerID, creds.Username)
+		http.Error(w, "Invalid username or password.", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		logger.Error("authenticating LDAP user", log.String("username", creds.Username), log.Error(err))
+		http.Error(w, "Unexpected error authenticating with the LDAP server. A site admin must check the logs and the configuration.", http.StatusInternalServerError)
+		return
+	}
+	lockouts.Reset(providerID, creds.Username)
+
+	actor, safeErrMsg, err := getOrCreateUser(r.Context(), db, p, user)
+	if err != nil {
+		logger.Error("looking up LDAP-authenticated user", log.String("userErr", safeErrMsg), log.Error(err))
+		http.Error(w, safeErrMsg, http.StatusInternalServerError)
+		return
+	}
+
+	if gs := p.config.GroupSync; gs != nil {
+		// Failing to sync the organizations of the user doesn't prevent the user from signing in.
+		if err := syncOrgMemberships(r.Context(), logger, db, gs, actor.UID, user.Groups); err != nil {
+			logger.Error("syncing organization memberships of LDAP-authenticated user", log.Int32("userID", actor.UID), log.Error(err))
+		}
+	}
+
+	u, err := db.Users().GetByID(r.Context(), actor.UID)
+	if err != nil {
+		logger.Error("retrieving LDAP-authenticated user from database", log.Error(err))
+		http.Error(w, "Failed to retrieve user: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := session.SetActor(w, r, actor, 0, u.CreatedAt); err != nil {
+		logger.Error("setting LDAP-authenticated actor in session", log.Error(err))
+		http.Error(w, "Error starting LDAP-authenticated session. Try signing in again.", http.StatusInternalServerError)
+		return
+	}
+}
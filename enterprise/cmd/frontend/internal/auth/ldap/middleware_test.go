@@ -0,0 +1,187 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMiddleware(t *testing.T) {
+	defer licensing.TestingSkipFeatureChecks()()
+
+	s := newTestServer(t, nil, false, testEntries, testPasswords)
+	p := &provider{config: schema.LDAPAuthProvider{
+		Type:         providerType,
+		Url:          "ldap://" + s.addr(),
+		BindDN:       testBindDN,
+		BindPassword: testBindPassword,
+		SearchBase:   "dc=example,dc=com",
+		GroupSync: &schema.LDAPGroupSync{
+			Orgs: map[string]string{"engineering": "eng"},
+		},
+	}}
+	providers.MockProviders = []providers.Provider{p}
+	defer func() { providers.MockProviders = nil }()
+
+	cleanup := session.ResetMockSessionStore(t)
+	defer cleanup()
+
+	const mockedUserID = 123
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		if op.ExternalAccount.ServiceType == "ldap" && op.ExternalAccount.ServiceID == p.config.Url && op.ExternalAccount.AccountID == "alice" &&
+			op.UserProps.Username == "alice" && op.UserProps.Email == "alice@example.com" && op.UserProps.EmailIsVerified && op.CreateIfNotExist {
+			return mockedUserID, "", nil
+		}
+		return 0, "safeErr", errors.Errorf("account %v not found in mock", op.ExternalAccount)
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	users := database.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, CreatedAt: time.Now()}, nil
+	})
+	orgs := database.NewStrictMockOrgStore()
+	orgs.GetByNameFunc.SetDefaultReturn(&types.Org{ID: 7, Name: "eng"}, nil)
+	orgMembers := database.NewStrictMockOrgMemberStore()
+	orgMembers.GetByUserIDFunc.SetDefaultReturn(nil, nil)
+	orgMembers.CreateFunc.SetDefaultReturn(&types.OrgMembership{OrgID: 7, UserID: mockedUserID}, nil)
+
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+
+	lockouts := newFakeLockoutStore(3)
+	handler := newMiddleware(db, lockouts).App(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("This is the home"))
+	}))
+
+	loginURL := "http://example.com/.auth/ldap/login?pc=" + url.QueryEscape(p.ConfigID().ID)
+	doRequest := func(method, urlStr string, headers map[string]string, body string) *http.Response {
+		req := httptest.NewRequest(method, urlStr, strings.NewReader(body))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	xhrHeaders := map[string]string{"X-Requested-With": "Sourcegraph", "Content-Type": "application/json"}
+	signIn := func(username, password string) *http.Response {
+		return doRequest("POST", loginURL, xhrHeaders, fmt.Sprintf(`{"username": %q, "password": %q}`, username, password))
+	}
+
+	t.Run("other paths are passed through", func(t *testing.T) {
+		resp := doRequest("GET", "http://example.com/", nil, "")
+		if body, _ := io.ReadAll(resp.Body); string(body) != "This is the home" {
+			t.Errorf("got body %q", body)
+		}
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		resp := doRequest("POST", "http://example.com/.auth/ldap/login?pc=unknown", xhrHeaders, `{"username": "alice", "password": "alice-secret"}`)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("got status code %v, want %v", resp.StatusCode, http.StatusNotFound)
+		}
+	})
+
+	t.Run("GET not allowed", func(t *testing.T) {
+		resp := doRequest("GET", loginURL, nil, "")
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("got status code %v, want %v", resp.StatusCode, http.StatusMethodNotAllowed)
+		}
+	})
+
+	t.Run("missing X-Requested-With header", func(t *testing.T) {
+		resp := doRequest("POST", loginURL, map[string]string{"Content-Type": "application/json"}, `{"username": "alice", "password": "alice-secret"}`)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("got status code %v, want %v", resp.StatusCode, http.StatusForbidden)
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		resp := signIn("alice", "wrong")
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("got status code %v, want %v", resp.StatusCode, http.StatusUnauthorized)
+		}
+		if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), "Invalid username or password.") {
+			t.Errorf("unexpected error %q", body)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp := signIn("alice", "alice-secret")
+		if want := http.StatusOK; resp.StatusCode != want {
+			t.Fatalf("got status code %v, want %v", resp.StatusCode, want)
+		}
+		if len(resp.Cookies()) == 0 {
+			t.Error("expected session cookie to be set")
+		}
+		if calls := orgMembers.CreateFunc.History(); len(calls) != 1 || calls[0].Arg1 != 7 || calls[0].Arg2 != mockedUserID {
+			t.Errorf("expected user to be added to org, have %+v", calls)
+		}
+		if failed := lockouts.failedAttempts[p.ConfigID().ID+":alice"]; failed != 0 {
+			t.Errorf("expected failed attempts to be reset, have %d", failed)
+		}
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if resp := signIn("bob", "wrong"); resp.StatusCode != http.StatusUnauthorized {
+				t.Fatalf("got status code %v, want %v", resp.StatusCode, http.StatusUnauthorized)
+			}
+		}
+
+		// The correct password is rejected without contacting the LDAP server while locked out.
+		binds := len(s.bindDNs())
+		resp := signIn("bob", "bob-secret")
+		if resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("got status code %v, want %v", resp.StatusCode, http.StatusUnprocessableEntity)
+		}
+		if got := len(s.bindDNs()); got != binds {
+			t.Errorf("expected no binds while locked out, got %d", got-binds)
+		}
+
+		// Other users are not locked out.
+		if resp := signIn("alice", "alice-secret"); resp.StatusCode != http.StatusOK {
+			t.Errorf("got status code %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+}
+
+// fakeLockoutStore is an in-memory lockoutStore.
+type fakeLockoutStore struct {
+	failedThreshold int
+	failedAttempts  map[string]int
+}
+
+func newFakeLockoutStore(failedThreshold int) *fakeLockoutStore {
+	return &fakeLockoutStore{failedThreshold: failedThreshold, failedAttempts: map[string]int{}}
+}
+
+func (s *fakeLockoutStore) IsLockedOut(providerID, username string) bool {
+	return s.failedAttempts[lockoutKey(providerID, username)] >= s.failedThreshold
+}
+
+func (s *fakeLockoutStore) IncreaseFailedAttempt(providerID, username string) {
+	s.failedAttempts[lockoutKey(providerID, username)]++
+}
+
+func (s *fakeLockoutStore) Reset(providerID, username string) {
+	delete(s.failedAttempts, lockoutKey(providerID, username))
+}
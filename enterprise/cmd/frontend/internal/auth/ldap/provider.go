@@ -0,0 +1,57 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = "ldap"
+
+type provider struct {
+	config schema.LDAPAuthProvider
+}
+
+// ConfigID implements providers.Provider.
+func (p *provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   providerConfigID(&p.config),
+	}
+}
+
+// Config implements providers.Provider.
+func (p *provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// Refresh implements providers.Provider. The LDAP server is contacted on every sign-in, so there
+// is nothing to refresh.
+func (p *provider) Refresh(context.Context) error { return nil }
+
+// CachedInfo implements providers.Provider.
+func (p *provider) CachedInfo() *providers.Info {
+	info := providers.Info{
+		ServiceID:   p.config.Url,
+		ClientID:    p.config.SearchBase,
+		DisplayName: p.config.DisplayName,
+		AuthenticationURL: (&url.URL{
+			Path:     path.Join(authPrefix, "login"),
+			RawQuery: url.Values{"pc": []string{providerConfigID(&p.config)}}.Encode(),
+		}).String(),
+	}
+	if info.DisplayName == "" {
+		info.DisplayName = "LDAP"
+	}
+	return &info
+}
+
+// getProvider looks up the registered LDAP authentication provider with the given config ID. It
+// returns nil if no such provider exists.
+func getProvider(id string) *provider {
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*provider)
+	return p
+}
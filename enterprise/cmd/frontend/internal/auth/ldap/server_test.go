@@ -0,0 +1,201 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// testServer is a minimal in-process LDAP server. It supports simple binds, StartTLS, and
+// searches with and, or, not, equality and presence filters, which is enough to exercise the
+// LDAP client against a real connection.
+type testServer struct {
+	t        *testing.T
+	listener net.Listener
+	entries  []*ldap.Entry
+
+	// passwords maps the DNs that can bind to their passwords.
+	passwords map[string]string
+
+	// tlsConfig is used for StartTLS. StartTLS is rejected if it is nil.
+	tlsConfig *tls.Config
+
+	mu    sync.Mutex
+	binds []string
+}
+
+// newTestServer starts an LDAP server on a random local port. If listenTLS is set, the server
+// only accepts TLS connections using tlsConfig.
+func newTestServer(t *testing.T, tlsConfig *tls.Config, listenTLS bool, entries []*ldap.Entry, passwords map[string]string) *testServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listenTLS {
+		l = tls.NewListener(l, tlsConfig)
+	}
+
+	s := &testServer{t: t, listener: l, entries: entries, passwords: passwords, tlsConfig: tlsConfig}
+	go s.serve()
+	t.Cleanup(func() { _ = l.Close() })
+	return s
+}
+
+// addr returns the host:port the server listens on.
+func (s *testServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+// bindDNs returns the DNs of all successful binds so far. Anonymous binds are recorded as "".
+func (s *testServer) bindDNs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Data.String()
+			password := op.Children[2].Data.String()
+			code := uint16(ldap.LDAPResultSuccess)
+			if dn != "" || password != "" {
+				if want, ok := s.passwords[dn]; !ok || want != password {
+					code = ldap.LDAPResultInvalidCredentials
+				}
+			}
+			if code == ldap.LDAPResultSuccess {
+				s.mu.Lock()
+				s.binds = append(s.binds, dn)
+				s.mu.Unlock()
+			}
+			s.write(conn, messageID, ldap.ApplicationBindResponse, code)
+
+		case ldap.ApplicationSearchRequest:
+			base := op.Children[0].Data.String()
+			filter := op.Children[6]
+			for _, entry := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(entry.DN), strings.ToLower(base)) || !matchFilter(entry, filter) {
+					continue
+				}
+				s.writeEntry(conn, messageID, entry)
+			}
+			s.write(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+
+		case ldap.ApplicationExtendedRequest:
+			if op.Children[0].Data.String() != startTLSOID || s.tlsConfig == nil {
+				s.write(conn, messageID, ldap.ApplicationExtendedResponse, ldap.LDAPResultUnwillingToPerform)
+				continue
+			}
+			s.write(conn, messageID, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess)
+			conn = tls.Server(conn, s.tlsConfig)
+
+		case ldap.ApplicationUnbindRequest:
+			return
+
+		default:
+			s.t.Logf("unsupported LDAP operation %d", op.Tag)
+			return
+		}
+	}
+}
+
+func (s *testServer) write(conn net.Conn, messageID int64, tag ber.Tag, code uint16) {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	s.writeMessage(conn, messageID, op)
+}
+
+func (s *testServer) writeEntry(conn net.Conn, messageID int64, entry *ldap.Entry) {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, ""))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for _, attribute := range entry.Attributes {
+		a := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		a.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, attribute.Name, ""))
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range attribute.Values {
+			values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+		a.AppendChild(values)
+		attributes.AppendChild(a)
+	}
+	op.AppendChild(attributes)
+	s.writeMessage(conn, messageID, op)
+}
+
+func (s *testServer) writeMessage(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, ""))
+	packet.AppendChild(op)
+	if _, err := conn.Write(packet.Bytes()); err != nil {
+		s.t.Logf("writing LDAP response: %s", err)
+	}
+}
+
+func matchFilter(entry *ldap.Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(entry, child) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchFilter(entry, child) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchFilter(entry, filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		want := filter.Children[1].Data.String()
+		for _, value := range entry.GetEqualFoldAttributeValues(filter.Children[0].Data.String()) {
+			if strings.EqualFold(value, want) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(entry.GetEqualFoldAttributeValues(filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,102 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// getOrCreateUser gets or creates the Sourcegraph user of an authenticated directory user. It
+// returns the authenticated actor if successful; otherwise it returns a friendly error message
+// (safeErrMsg) that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, db database.DB, p *provider, user *directoryUser) (_ *actor.Actor, safeErrMsg string, err error) {
+	serializedData, err := json.Marshal(user)
+	if err != nil {
+		return nil, "", err
+	}
+	data := extsvc.AccountData{
+		Data: extsvc.NewUnencryptedData(serializedData),
+	}
+
+	username, err := auth.NormalizeUsername(user.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", user.Username), err
+	}
+
+	allowSignup := p.config.AllowSignup == nil || *p.config.AllowSignup
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username:        username,
+			Email:           user.Email,
+			EmailIsVerified: user.Email != "", // Directory emails are managed by admins, so they are assumed to be verified
+			DisplayName:     user.DisplayName,
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   p.config.Url,
+			ClientID:    p.config.SearchBase,
+			AccountID:   user.Username,
+		},
+		ExternalAccountData: data,
+		CreateIfNotExist:    allowSignup,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
+
+// syncOrgMemberships adds the user to the organizations that the groups of the user are mapped to
+// in the group sync config, and removes the user from the mapped organizations that none of the
+// groups of the user are mapped to. Organizations that are not mapped are left untouched.
+func syncOrgMemberships(ctx context.Context, logger log.Logger, db database.DB, gs *schema.LDAPGroupSync, userID int32, groups []string) error {
+	want := make(map[string]bool, len(gs.Orgs))
+	for _, orgName := range gs.Orgs {
+		want[orgName] = false
+	}
+	for _, group := range groups {
+		if orgName, ok := gs.Orgs[group]; ok {
+			want[orgName] = true
+		}
+	}
+
+	memberships, err := db.OrgMembers().GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	isMember := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		isMember[m.OrgID] = true
+	}
+
+	for orgName, member := range want {
+		org, err := db.Orgs().GetByName(ctx, orgName)
+		if errcode.IsNotFound(err) {
+			logger.Warn("organization in LDAP group sync config does not exist", log.String("org", orgName))
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		switch {
+		case member && !isMember[org.ID]:
+			if _, err := db.OrgMembers().Create(ctx, org.ID, userID); err != nil {
+				return err
+			}
+		case !member && isMember[org.ID]:
+			if err := db.OrgMembers().Remove(ctx, org.ID, userID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package ldap
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSyncOrgMemberships(t *testing.T) {
+	orgIDs := map[string]int32{"eng": 1, "admins": 2, "sales": 3}
+	orgs := database.NewMockOrgStore()
+	orgs.GetByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Org, error) {
+		if id, ok := orgIDs[name]; ok {
+			return &types.Org{ID: id, Name: name}, nil
+		}
+		return nil, &database.OrgNotFoundError{Message: name}
+	})
+	orgMembers := database.NewMockOrgMemberStore()
+	orgMembers.GetByUserIDFunc.SetDefaultReturn([]*types.OrgMembership{
+		{OrgID: 2, UserID: 42}, // admins, no longer a member of the group
+		{OrgID: 3, UserID: 42}, // sales, not mapped
+	}, nil)
+	db := database.NewMockDB()
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+
+	gs := &schema.LDAPGroupSync{
+		Orgs: map[string]string{
+			"engineering": "eng",
+			"developers":  "eng",
+			"admins":      "admins",
+			"marketing":   "marketing", // does not exist
+		},
+	}
+	if err := syncOrgMemberships(context.Background(), logtest.Scoped(t), db, gs, 42, []string{"developers", "sales"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var created []int32
+	for _, call := range orgMembers.CreateFunc.History() {
+		created = append(created, call.Arg1)
+	}
+	if diff := cmp.Diff([]int32{1}, created); diff != "" {
+		t.Errorf("unexpected created memberships (-want +got):\n%s", diff)
+	}
+
+	var removed []int32
+	for _, call := range orgMembers.RemoveFunc.History() {
+		removed = append(removed, call.Arg1)
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+	if diff := cmp.Diff([]int32{2}, removed); diff != "" {
+		t.Errorf("unexpected removed memberships (-want +got):\n%s", diff)
+	}
+}
@@ -124,6 +124,8 @@ func changesetJobTypeToBulkOperationType(t btypes.ChangesetJobType) (string, err
 		return "CLOSE", nil
 	case btypes.ChangesetJobTypePublish:
 		return "PUBLISH", nil
+	case btypes.ChangesetJobTypeAssign:
+		return "ASSIGN", nil
 	default:
 		return "", errors.Errorf("invalid job type %q", t)
 	}
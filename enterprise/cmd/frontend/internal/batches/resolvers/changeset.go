@@ -355,6 +355,20 @@ func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
 
+func (r *changesetResolver) AutoMergeBlockedReason(ctx context.Context) (*string, error) {
+	autoMerge, err := r.store.GetChangesetAutoMerge(ctx, r.changeset.ID)
+	if err == store.ErrNoResults {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if autoMerge.BlockedReason == "" {
+		return nil, nil
+	}
+	return &autoMerge.BlockedReason, nil
+}
+
 func (r *changesetResolver) ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error) {
 	// We need to find out how deep in the queue this changeset is.
 	place, err := r.store.GetChangesetPlaceInSchedulerQueue(ctx, r.changeset.ID)
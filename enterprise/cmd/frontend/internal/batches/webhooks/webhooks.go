@@ -167,11 +167,18 @@ func (h webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	wasComplete := cs.Complete()
 	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
+	// Changesets that are stacked on this one need to be retargeted once it
+	// has been merged or closed.
+	if !wasComplete && cs.Complete() {
+		return tx.EnqueueChangesetsDependingOn(ctx, cs)
+	}
+
 	return nil
 }
 
@@ -64,6 +64,14 @@ func (r *Resolver) DeleteLSIFUploads(ctx context.Context, args *resolverstubs.De
 	return r.uploadsRootResolver.DeleteLSIFUploads(ctx, args)
 }
 
+func (r *Resolver) FailedLSIFUploads(ctx context.Context, args *resolverstubs.FailedLSIFUploadsQueryArgs) (_ resolverstubs.LSIFUploadConnectionResolver, err error) {
+	return r.uploadsRootResolver.FailedLSIFUploads(ctx, args)
+}
+
+func (r *Resolver) RequeueFailedLSIFUploads(ctx context.Context, args *resolverstubs.RequeueFailedLSIFUploadsArgs) (_ *resolverstubs.EmptyResponse, err error) {
+	return r.uploadsRootResolver.RequeueFailedLSIFUploads(ctx, args)
+}
+
 func (r *Resolver) LSIFIndexByID(ctx context.Context, id graphql.ID) (_ resolverstubs.LSIFIndexResolver, err error) {
 	return r.autoIndexingRootResolver.LSIFIndexByID(ctx, id)
 }
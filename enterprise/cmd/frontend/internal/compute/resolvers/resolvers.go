@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func NewResolver(logger log.Logger, db database.DB) gql.ComputeResolver {
@@ -228,6 +229,10 @@ func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db datab
 		return nil, err
 	}
 
+	if _, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		return nil, errors.New("the aggregate command is only supported by the compute streaming endpoint")
+	}
+
 	searchQuery, err := computeQuery.ToSearchQuery()
 	if err != nil {
 		return nil, err
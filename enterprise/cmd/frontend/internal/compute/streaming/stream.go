@@ -24,6 +24,14 @@ import (
 // and this is best avoided on large instances like Sourcegraph.com
 const maxRequestDuration = time.Minute
 
+// maxAggregationValues bounds the number of distinct values an aggregate
+// command counts, so that high-cardinality values don't exhaust memory.
+const maxAggregationValues = 10000
+
+// defaultAggregationDisplay is the number of top counts sent for an aggregate
+// command when the request does not specify display.
+const defaultAggregationDisplay = 100
+
 // NewComputeStreamHandler is an http handler which streams back compute results.
 func NewComputeStreamHandler(logger log.Logger, db database.DB) http.Handler {
 	return &streamHandler{
@@ -93,6 +101,17 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Log events to trace
 	eventWriter.StatHook = eventStreamOTHook(tr.LogFields)
 
+	// Aggregate commands stream snapshots of their running totals instead of
+	// a result per search match.
+	var aggregator *compute.Aggregator
+	aggregationDisplay := args.Display
+	if _, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(maxAggregationValues)
+		if aggregationDisplay < 0 {
+			aggregationDisplay = defaultAggregationDisplay
+		}
+	}
+
 	events, getResults := NewComputeStream(ctx, h.logger, h.db, searchQuery, computeQuery.Command)
 	events = batchEvents(events, 50*time.Millisecond)
 
@@ -103,6 +122,9 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return eventWriter.EventBytes("results", data)
 	})
 	matchesFlush := func() {
+		if aggregator != nil && aggregator.Dirty() {
+			_ = matchesBuf.Append(aggregator.Snapshot(aggregationDisplay))
+		}
 		if err := matchesBuf.Flush(); err != nil {
 			// EOF
 			return
@@ -124,11 +146,17 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		progress.Stats.Update(&event.Stats)
 
 		for _, result := range event.Results {
+			if aggregator != nil {
+				if aggregation, ok := result.(*compute.Aggregation); ok {
+					aggregator.Add(aggregation)
+				}
+				continue
+			}
 			_ = matchesBuf.Append(result)
 		}
 
 		// Instantly send results if we have not sent any yet.
-		if first && matchesBuf.Len() > 0 {
+		if first && (matchesBuf.Len() > 0 || (aggregator != nil && aggregator.Dirty())) {
 			first = false
 			matchesFlush()
 		}
@@ -203,7 +231,7 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.New("no query found")
 	}
 
-	display := get("display", "-1") // TODO(rvantonder): Currently unused; implement a limit for compute results.
+	display := get("display", "-1") // TODO(rvantonder): Currently only limits aggregate results; implement a limit for other compute results.
 	var err error
 	if a.Display, err = strconv.Atoi(display); err != nil {
 		return nil, errors.Errorf("display must be an integer, got %q: %w", display, err)
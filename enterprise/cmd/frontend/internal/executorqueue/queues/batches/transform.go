@@ -262,13 +262,24 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 				Key:   fmt.Sprintf("step.%d.run", i),
 				Image: step.Container,
 				Dir:   runDir,
-				// Invoke the script file but also write stdout and stderr to separate files, which will then be
-				// consumed by the post step to build the AfterStepResult.
+				// Invoke the script file but also write stdout, stderr and the exit code to separate files, which
+				// will then be consumed by the post step to build the AfterStepResult.
 				Commands: []string{
 					// Hide commands from stderr.
 					"{ set +x; } 2>/dev/null",
-					fmt.Sprintf(`(exec "%s/step%d.sh" | tee %s/stdout%d.log) 3>&1 1>&2 2>&3 | tee %s/stderr%d.log`, runDirToScriptDir, i, runDirToScriptDir, i, runDirToScriptDir, i),
+					// Count the attempts and clear the exit code of the previous attempt, if the step is retried.
+					fmt.Sprintf(`echo >> %s/attempts%d`, runDirToScriptDir, i),
+					fmt.Sprintf(`rm -f %s/exitcode%d`, runDirToScriptDir, i),
+					fmt.Sprintf(`({ "%s/step%d.sh"; echo $? > %s/exitcode%d; } | tee %s/stdout%d.log) 3>&1 1>&2 2>&3 | tee %s/stderr%d.log`, runDirToScriptDir, i, runDirToScriptDir, i, runDirToScriptDir, i, runDirToScriptDir, i),
+					// Fail the step if the script failed, so that it's retried.
+					fmt.Sprintf(`exit "$(cat %s/exitcode%d)"`, runDirToScriptDir, i),
 				},
+				Timeout:         step.TimeoutDuration(),
+				Retries:         step.Retries,
+				RetryBackoff:    step.RetryBackoffDuration(),
+				ContinueOnError: step.ContinueOnError,
+				CPUs:            step.CPUs,
+				Memory:          step.Memory,
 			})
 
 			// This step gets the diff, reads stdout and stderr, renders the outputs and builds the AfterStepResult.
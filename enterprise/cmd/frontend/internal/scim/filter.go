@@ -0,0 +1,58 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// filter is an equality filter on a single attribute. This is the only kind of filter that identity
+// providers use to look up resources before provisioning them (e.g. `userName eq "alice"`), and the
+// only kind of filter supported by this package.
+type filter struct {
+	// attribute is the lowercased name of the attribute.
+	attribute string
+	value     string
+}
+
+var filterPattern = lazyregexp.New(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+(?:"((?:[^"\\]|\\.)*)"|([^\s"]+))\s*$`)
+
+// parseFilter parses the given filter expression. A nil filter is returned for an empty expression.
+func parseFilter(expression string) (*filter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+
+	match := filterPattern.FindStringSubmatch(expression)
+	if match == nil {
+		return nil, newError(http.StatusBadRequest, "invalidFilter", fmt.Sprintf("unsupported filter %q: only `attribute eq value` filters are supported", expression))
+	}
+
+	value := match[3]
+	if value == "" {
+		unquoted, err := strconv.Unquote(`"` + match[2] + `"`)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidFilter", fmt.Sprintf("invalid value in filter %q", expression))
+		}
+		value = unquoted
+	}
+
+	return &filter{attribute: strings.ToLower(match[1]), value: value}, nil
+}
+
+// matches returns true if the given attribute value, as decoded from JSON, is equal to the value of
+// the filter. Strings are compared case-insensitively, as all string attributes we filter by are
+// case-insensitive (RFC 7643 section 2.2).
+func (f *filter) matches(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.EqualFold(v, f.value)
+	case nil:
+		return false
+	default:
+		return strings.EqualFold(fmt.Sprint(v), f.value)
+	}
+}
@@ -0,0 +1,57 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFilter(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   *filter
+		wantErr    bool
+	}{
+		{expression: "", expected: nil},
+		{expression: `userName eq "alice@example.com"`, expected: &filter{attribute: "username", value: "alice@example.com"}},
+		{expression: `externalId EQ "a\"b"`, expected: &filter{attribute: "externalid", value: `a"b`}},
+		{expression: `emails.value eq "alice@example.com"`, expected: &filter{attribute: "emails.value", value: "alice@example.com"}},
+		{expression: `id eq 42`, expected: &filter{attribute: "id", value: "42"}},
+		{expression: `userName sw "alice"`, wantErr: true},
+		{expression: `userName eq "alice" and active eq true`, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			f, err := parseFilter(testCase.expression)
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, f, cmp.AllowUnexported(filter{})); diff != "" {
+				t.Errorf("unexpected filter (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	f := &filter{attribute: "username", value: "Alice"}
+
+	for value, expected := range map[any]bool{
+		"alice": true,
+		"ALICE": true,
+		"bob":   false,
+		nil:     false,
+	} {
+		if matches := f.matches(value); matches != expected {
+			t.Errorf("unexpected match for %v. want=%v have=%v", value, expected, matches)
+		}
+	}
+}
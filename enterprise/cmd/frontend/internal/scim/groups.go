@@ -0,0 +1,374 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (h *handler) listGroups(r *http.Request) (int, any, error) {
+	ctx := r.Context()
+
+	p, err := parseListParameters(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if p.filter == nil {
+		totalResults, err := h.db.Orgs().Count(ctx, database.OrgsListOptions{})
+		if err != nil {
+			return 0, nil, err
+		}
+		orgs, err := h.db.Orgs().List(ctx, &database.OrgsListOptions{LimitOffset: p.limitOffset()})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		resources := make([]any, 0, len(orgs))
+		for _, org := range orgs {
+			g, err := h.toSCIMGroup(ctx, org)
+			if err != nil {
+				return 0, nil, err
+			}
+			resources = append(resources, g)
+		}
+
+		return http.StatusOK, newListResponse(p, totalResults, resources), nil
+	}
+
+	org, err := h.findGroup(ctx, p.filter)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var matches []any
+	if org != nil {
+		g, err := h.toSCIMGroup(ctx, org)
+		if err != nil {
+			return 0, nil, err
+		}
+		if (p.filter.attribute == "id" && p.filter.matches(g.ID)) || p.filter.matches(g.DisplayName) {
+			matches = append(matches, g)
+		}
+	}
+
+	return http.StatusOK, newListResponse(p, len(matches), paginate(matches, p)), nil
+}
+
+// findGroup returns the organization that may match the given filter, if any.
+func (h *handler) findGroup(ctx context.Context, f *filter) (*types.Org, error) {
+	var (
+		org *types.Org
+		err error
+	)
+
+	switch f.attribute {
+	case "id":
+		id, parseErr := strconv.ParseInt(f.value, 10, 32)
+		if parseErr != nil {
+			return nil, nil
+		}
+		org, err = h.db.Orgs().GetByID(ctx, int32(id))
+
+	case "displayname":
+		name, normalizeErr := auth.NormalizeUsername(f.value)
+		if normalizeErr != nil {
+			return nil, nil
+		}
+		org, err = h.db.Orgs().GetByName(ctx, name)
+
+	default:
+		return nil, newError(http.StatusBadRequest, "invalidFilter", fmt.Sprintf("filtering groups by %q is not supported", f.attribute))
+	}
+
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+func (h *handler) getGroup(r *http.Request) (int, any, error) {
+	org, err := h.lookupGroup(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	g, err := h.toSCIMGroup(r.Context(), org)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, g, nil
+}
+
+func (h *handler) createGroup(r *http.Request) (int, any, error) {
+	ctx := r.Context()
+
+	var g Group
+	if err := decodeBody(r, &g); err != nil {
+		return 0, nil, err
+	}
+	name, err := orgName(g.DisplayName)
+	if err != nil {
+		return 0, nil, err
+	}
+	memberIDs, err := h.memberIDs(ctx, g.Members)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Organizations share their namespace with users.
+	if _, err := h.db.Orgs().GetByName(ctx, name); err == nil {
+		return 0, nil, newError(http.StatusConflict, "uniqueness", fmt.Sprintf("an organization named %q already exists", name))
+	} else if !errcode.IsNotFound(err) {
+		return 0, nil, err
+	}
+	if _, err := h.db.Users().GetByUsername(ctx, name); err == nil {
+		return 0, nil, newError(http.StatusConflict, "uniqueness", fmt.Sprintf("a user named %q already exists", name))
+	} else if !errcode.IsNotFound(err) {
+		return 0, nil, err
+	}
+
+	org, err := h.db.Orgs().Create(ctx, name, &g.DisplayName)
+	if err != nil {
+		return 0, nil, err
+	}
+	h.logger.Info("provisioned organization", log.Int32("orgID", org.ID), log.String("name", name))
+
+	if err := h.syncMembers(ctx, org.ID, memberIDs); err != nil {
+		return 0, nil, err
+	}
+
+	created, err := h.toSCIMGroup(ctx, org)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, created, nil
+}
+
+func (h *handler) replaceGroup(r *http.Request) (int, any, error) {
+	var g Group
+	if err := decodeBody(r, &g); err != nil {
+		return 0, nil, err
+	}
+
+	org, err := h.lookupGroup(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateGroup(r.Context(), org, &g)
+}
+
+func (h *handler) patchGroup(r *http.Request) (int, any, error) {
+	ctx := r.Context()
+
+	var patch PatchRequest
+	if err := decodeBody(r, &patch); err != nil {
+		return 0, nil, err
+	}
+
+	org, err := h.lookupGroup(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	g, err := h.toSCIMGroup(ctx, org)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := applyPatch(g, schemaGroup, patch.Operations); err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateGroup(ctx, org, g)
+}
+
+// updateGroup updates the display name and members of the given organization to match the given
+// SCIM attributes. The name of the organization is not changed, as it is part of the URLs of the
+// organization.
+func (h *handler) updateGroup(ctx context.Context, org *types.Org, g *Group) (int, any, error) {
+	if g.DisplayName == "" {
+		return 0, nil, newError(http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+	memberIDs, err := h.memberIDs(ctx, g.Members)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if g.DisplayName != orgDisplayName(org) {
+		if org, err = h.db.Orgs().Update(ctx, org.ID, &g.DisplayName); err != nil {
+			return 0, nil, err
+		}
+	}
+	if err := h.syncMembers(ctx, org.ID, memberIDs); err != nil {
+		return 0, nil, err
+	}
+
+	updated, err := h.toSCIMGroup(ctx, org)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, updated, nil
+}
+
+func (h *handler) deleteGroup(r *http.Request) (int, any, error) {
+	org, err := h.lookupGroup(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := h.db.Orgs().Delete(r.Context(), org.ID); err != nil {
+		return 0, nil, err
+	}
+	h.logger.Info("deprovisioned organization", log.Int32("orgID", org.ID))
+
+	return http.StatusNoContent, nil, nil
+}
+
+// syncMembers adds the given users to the organization and removes all other members from it.
+func (h *handler) syncMembers(ctx context.Context, orgID int32, userIDs map[int32]struct{}) error {
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[int32]struct{}, len(memberships))
+	for _, membership := range memberships {
+		existing[membership.UserID] = struct{}{}
+		if _, ok := userIDs[membership.UserID]; ok {
+			continue
+		}
+		if err := h.db.OrgMembers().Remove(ctx, orgID, membership.UserID); err != nil {
+			return err
+		}
+	}
+
+	for userID := range userIDs {
+		if _, ok := existing[userID]; ok {
+			continue
+		}
+		if _, err := h.db.OrgMembers().Create(ctx, orgID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// memberIDs returns the IDs of the given group members, all of which must be existing users.
+func (h *handler) memberIDs(ctx context.Context, members []Membership) (map[int32]struct{}, error) {
+	ids := make(map[int32]struct{}, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member.Value, 10, 32)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidValue", fmt.Sprintf("invalid member %q", member.Value))
+		}
+		ids[int32(id)] = struct{}{}
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	userIDs := make([]int32, 0, len(ids))
+	for id := range ids {
+		userIDs = append(userIDs, id)
+	}
+	users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(ids) {
+		return nil, newError(http.StatusBadRequest, "invalidValue", "members must reference existing users")
+	}
+
+	return ids, nil
+}
+
+// lookupGroup returns the organization referenced in the request path.
+func (h *handler) lookupGroup(r *http.Request) (*types.Org, error) {
+	id, err := parseID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := h.db.Orgs().GetByID(r.Context(), id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, newError(http.StatusNotFound, "", "group not found")
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// toSCIMGroup returns the SCIM representation of the given organization.
+func (h *handler) toSCIMGroup(ctx context.Context, org *types.Org) (*Group, error) {
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]Membership, 0, len(memberships))
+	if len(memberships) > 0 {
+		userIDs := make([]int32, 0, len(memberships))
+		for _, membership := range memberships {
+			userIDs = append(userIDs, membership.UserID)
+		}
+		users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			members = append(members, Membership{
+				Value:   strconv.Itoa(int(user.ID)),
+				Display: user.Username,
+				Ref:     location("/Users/" + strconv.Itoa(int(user.ID))),
+			})
+		}
+	}
+
+	return &Group{
+		Schemas:     []string{schemaGroup},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: orgDisplayName(org),
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      org.CreatedAt,
+			LastModified: org.UpdatedAt,
+			Location:     location("/Groups/" + strconv.Itoa(int(org.ID))),
+		},
+	}, nil
+}
+
+// orgName returns the organization name for a group with the given display name. Organization names
+// share the namespace and format of usernames.
+func orgName(displayName string) (string, error) {
+	if displayName == "" {
+		return "", newError(http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+
+	name, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		return "", newError(http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	return name, nil
+}
+
+func orgDisplayName(org *types.Org) string {
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		return *org.DisplayName
+	}
+	return org.Name
+}
@@ -0,0 +1,289 @@
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PathPrefix is the path under which the SCIM API is served.
+const PathPrefix = "/.api/scim/v2"
+
+// contentType is the media type of SCIM request and response bodies (RFC 7644 section 8.1).
+const contentType = "application/scim+json"
+
+// defaultCount is the number of resources returned by list requests that do not specify a count.
+const defaultCount = 100
+
+type handler struct {
+	logger log.Logger
+	db     database.DB
+}
+
+// NewHandler returns the handler serving the SCIM 2.0 API, which identity providers use to provision
+// users (backed by Sourcegraph users) and groups (backed by Sourcegraph organizations).
+func NewHandler(logger log.Logger, db database.DB) http.Handler {
+	h := &handler{logger: logger, db: db}
+
+	r := mux.NewRouter().PathPrefix(PathPrefix).Subrouter()
+	r.Path("/Users").Methods("GET").HandlerFunc(h.serve(h.listUsers))
+	r.Path("/Users").Methods("POST").HandlerFunc(h.serve(h.createUser))
+	r.Path("/Users/{id}").Methods("GET").HandlerFunc(h.serve(h.getUser))
+	r.Path("/Users/{id}").Methods("PUT").HandlerFunc(h.serve(h.replaceUser))
+	r.Path("/Users/{id}").Methods("PATCH").HandlerFunc(h.serve(h.patchUser))
+	r.Path("/Users/{id}").Methods("DELETE").HandlerFunc(h.serve(h.deleteUser))
+	r.Path("/Groups").Methods("GET").HandlerFunc(h.serve(h.listGroups))
+	r.Path("/Groups").Methods("POST").HandlerFunc(h.serve(h.createGroup))
+	r.Path("/Groups/{id}").Methods("GET").HandlerFunc(h.serve(h.getGroup))
+	r.Path("/Groups/{id}").Methods("PUT").HandlerFunc(h.serve(h.replaceGroup))
+	r.Path("/Groups/{id}").Methods("PATCH").HandlerFunc(h.serve(h.patchGroup))
+	r.Path("/Groups/{id}").Methods("DELETE").HandlerFunc(h.serve(h.deleteGroup))
+	r.Path("/ServiceProviderConfig").Methods("GET").HandlerFunc(h.serve(serviceProviderConfig))
+	r.Path("/ResourceTypes").Methods("GET").HandlerFunc(h.serve(resourceTypes))
+	r.Path("/Schemas").Methods("GET").HandlerFunc(h.serve(schemas))
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, newError(http.StatusNotFound, "", "resource endpoint not found"))
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, newError(http.StatusMethodNotAllowed, "", "method not allowed"))
+	})
+
+	return authMiddleware(r)
+}
+
+// authMiddleware rejects requests that do not have an Authorization header with the bearer token
+// configured in the site configuration. Authenticated requests are treated as coming from an internal
+// actor, as the identity provider manages all users and organizations.
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedToken := conf.SiteConfig().ScimAuthToken
+		if expectedToken == "" {
+			writeError(w, newError(http.StatusNotFound, "", "SCIM provisioning is not enabled on this instance"))
+			return
+		}
+
+		// 🚨 SECURITY: Use a constant-time comparison to avoid leaking the token through timing.
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+			writeError(w, newError(http.StatusUnauthorized, "", "invalid bearer token"))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(actor.WithInternalActor(r.Context())))
+	})
+}
+
+// serve adapts a function returning a status code and response body to an http.HandlerFunc.
+func (h *handler) serve(f func(r *http.Request) (int, any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, body, err := f(r)
+		if err != nil {
+			var e *scimError
+			if !errors.As(err, &e) {
+				h.logger.Error("handling SCIM request", log.String("method", r.Method), log.String("path", r.URL.Path), log.Error(err))
+				e = newError(http.StatusInternalServerError, "", "internal error").(*scimError)
+			}
+			writeError(w, e)
+			return
+		}
+
+		if body == nil {
+			w.WriteHeader(status)
+			return
+		}
+		writeJSON(w, status, body)
+	}
+}
+
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+// newError returns an error that is reported to the client as a SCIM error response with the given
+// HTTP status, SCIM error type (RFC 7644 section 3.12) and detail message.
+func newError(status int, scimType, detail string) error {
+	return &scimError{status: status, scimType: scimType, detail: detail}
+}
+
+func (e *scimError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, e.scimType, e.detail)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var e *scimError
+	if !errors.As(err, &e) {
+		e = &scimError{status: http.StatusInternalServerError}
+	}
+
+	writeJSON(w, e.status, Error{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(e.status),
+		ScimType: e.scimType,
+		Detail:   e.detail,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// decodeBody decodes the JSON request body into v.
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("invalid request body: %s", err))
+	}
+	return nil
+}
+
+// listParameters are the pagination and filtering parameters of a list request (RFC 7644 section 3.4.2).
+type listParameters struct {
+	filter *filter
+	// startIndex is the 1-based index of the first resource to return.
+	startIndex int
+	count      int
+}
+
+func parseListParameters(r *http.Request) (*listParameters, error) {
+	query := r.URL.Query()
+
+	f, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &listParameters{filter: f, startIndex: 1, count: defaultCount}
+	if v := query.Get("startIndex"); v != "" {
+		startIndex, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidValue", fmt.Sprintf("invalid startIndex %q", v))
+		}
+		if startIndex > 1 {
+			p.startIndex = startIndex
+		}
+	}
+	if v := query.Get("count"); v != "" {
+		count, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidValue", fmt.Sprintf("invalid count %q", v))
+		}
+		if count < 0 {
+			count = 0
+		}
+		if count < defaultCount {
+			p.count = count
+		}
+	}
+
+	return p, nil
+}
+
+func (p *listParameters) limitOffset() *database.LimitOffset {
+	return &database.LimitOffset{Limit: p.count, Offset: p.startIndex - 1}
+}
+
+func newListResponse(p *listParameters, totalResults int, resources []any) *ListResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+
+	return &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// parseID parses the ID of the resource in the request path. IDs that are not valid Sourcegraph IDs
+// refer to resources that don't exist.
+func parseID(r *http.Request) (int32, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		return 0, newError(http.StatusNotFound, "", "resource not found")
+	}
+	return int32(id), nil
+}
+
+// location returns the absolute URL of the resource at the given path.
+func location(path string) string {
+	return strings.TrimSuffix(conf.ExternalURL(), "/") + PathPrefix + path
+}
+
+func serviceProviderConfig(r *http.Request) (int, any, error) {
+	supported := func(supported bool) map[string]any { return map[string]any{"supported": supported} }
+
+	return http.StatusOK, map[string]any{
+		"schemas":          []string{schemaServiceProviderConfig},
+		"documentationUri": "https://docs.sourcegraph.com/admin/auth/scim",
+		"patch":            supported(true),
+		"bulk":             map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]any{"supported": true, "maxResults": defaultCount},
+		"changePassword":   supported(false),
+		"sort":             supported(false),
+		"etag":             supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Authentication with the bearer token configured in the scim.authToken site configuration property.",
+			"primary":     true,
+		}},
+	}, nil
+}
+
+func resourceTypes(r *http.Request) (int, any, error) {
+	resourceType := func(name, endpoint, schema string) map[string]any {
+		return map[string]any{
+			"schemas":  []string{schemaResourceType},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+			"meta": map[string]any{
+				"resourceType": "ResourceType",
+				"location":     location("/ResourceTypes/" + name),
+			},
+		}
+	}
+
+	p := &listParameters{startIndex: 1}
+	return http.StatusOK, newListResponse(p, 2, []any{
+		resourceType("User", "/Users", schemaUser),
+		resourceType("Group", "/Groups", schemaGroup),
+	}), nil
+}
+
+func schemas(r *http.Request) (int, any, error) {
+	schema := func(id, name string) map[string]any {
+		return map[string]any{
+			"id":   id,
+			"name": name,
+			"meta": map[string]any{
+				"resourceType": "Schema",
+				"location":     location("/Schemas/" + id),
+			},
+		}
+	}
+
+	p := &listParameters{startIndex: 1}
+	return http.StatusOK, newListResponse(p, 2, []any{
+		schema(schemaUser, "User"),
+		schema(schemaGroup, "Group"),
+	}), nil
+}
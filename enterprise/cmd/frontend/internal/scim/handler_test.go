@@ -0,0 +1,175 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testToken = "0123456789abcdefghij"
+
+func mockSiteConfig(t *testing.T, token string) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExternalURL:   "https://sourcegraph.example.com",
+		ScimAuthToken: token,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+}
+
+func serve(t *testing.T, db database.DB, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, PathPrefix+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	NewHandler(logtest.Scoped(t), db).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	db := database.NewMockDB()
+
+	testCases := []struct {
+		name           string
+		configured     string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "not configured", configured: "", authorization: "Bearer ", expectedStatus: http.StatusNotFound},
+		{name: "no token", configured: testToken, authorization: "", expectedStatus: http.StatusUnauthorized},
+		{name: "wrong token", configured: testToken, authorization: "Bearer " + strings.ToUpper(testToken), expectedStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", configured: testToken, authorization: "token " + testToken, expectedStatus: http.StatusUnauthorized},
+		{name: "correct token", configured: testToken, authorization: "Bearer " + testToken, expectedStatus: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockSiteConfig(t, testCase.configured)
+
+			req := httptest.NewRequest("GET", PathPrefix+"/ServiceProviderConfig", nil)
+			req.Header.Set("Authorization", testCase.authorization)
+			rec := httptest.NewRecorder()
+			NewHandler(logtest.Scoped(t), db).ServeHTTP(rec, req)
+
+			if rec.Code != testCase.expectedStatus {
+				t.Errorf("unexpected status code. want=%d have=%d", testCase.expectedStatus, rec.Code)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/scim+json" {
+				t.Errorf("unexpected content type %q", contentType)
+			}
+		})
+	}
+}
+
+func TestPatchUserDeactivate(t *testing.T) {
+	mockSiteConfig(t, testToken)
+
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 42, Username: "alice", DisplayName: "Alice"}, nil)
+	externalAccounts := database.NewMockUserExternalAccountsStore()
+	externalAccounts.ListFunc.SetDefaultReturn([]*extsvc.Account{{
+		UserID: 42,
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(json.RawMessage(`{"userName": "alice@example.com", "externalId": "abc"}`)),
+		},
+	}}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.OrgMembersFunc.SetDefaultReturn(database.NewMockOrgMemberStore())
+
+	rec := serve(t, db, "PATCH", "/Users/42", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "Replace", "path": "active", "value": "False"}]
+	}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var u User
+	if err := json.Unmarshal(rec.Body.Bytes(), &u); err != nil {
+		t.Fatalf("unexpected error decoding response: %s", err)
+	}
+	if u.ID != "42" || u.UserName != "alice@example.com" || u.ExternalID != "abc" || u.isActive() {
+		t.Errorf("unexpected user %+v", u)
+	}
+
+	if calls := users.UpdateFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected user update %+v", calls[0].Arg2)
+	}
+	if calls := externalAccounts.AssociateUserAndSaveFunc.History(); len(calls) != 1 || calls[0].Arg2.AccountID != "abc" {
+		t.Errorf("expected SCIM attributes to be saved, have %+v", calls)
+	}
+	if calls := users.InvalidateSessionsByIDFunc.History(); len(calls) != 1 || calls[0].Arg1 != 42 {
+		t.Errorf("expected sessions of user 42 to be invalidated, have %+v", calls)
+	}
+	if calls := users.DeleteFunc.History(); len(calls) != 1 || calls[0].Arg1 != 42 {
+		t.Errorf("expected user 42 to be deleted, have %+v", calls)
+	}
+}
+
+func TestPatchUserReactivate(t *testing.T) {
+	mockSiteConfig(t, testToken)
+
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.PushReturn(nil, database.NewUserNotFoundError(42))
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 42, Username: "alice"}, nil)
+	users.RecoverUsersListFunc.SetDefaultReturn([]int32{42}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.OrgMembersFunc.SetDefaultReturn(database.NewMockOrgMemberStore())
+
+	rec := serve(t, db, "PATCH", "/Users/42", `{"Operations": [{"op": "replace", "value": {"active": true}}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if calls := users.RecoverUsersListFunc.History(); len(calls) != 1 {
+		t.Errorf("expected user to be recovered, have %+v", calls)
+	}
+	if calls := users.DeleteFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected user deletion")
+	}
+
+	// Patches that don't reactivate deactivated users fail.
+	users.GetByIDFunc.PushReturn(nil, database.NewUserNotFoundError(42))
+	rec = serve(t, db, "PATCH", "/Users/42", `{"Operations": [{"op": "replace", "path": "displayName", "value": "Alice"}]}`)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestSyncMembers(t *testing.T) {
+	orgMembers := database.NewMockOrgMemberStore()
+	orgMembers.GetByOrgIDFunc.SetDefaultReturn([]*types.OrgMembership{
+		{OrgID: 1, UserID: 1},
+		{OrgID: 1, UserID: 2},
+	}, nil)
+	db := database.NewMockDB()
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+
+	h := &handler{logger: logtest.Scoped(t), db: db}
+	if err := h.syncMembers(context.Background(), 1, map[int32]struct{}{2: {}, 3: {}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls := orgMembers.RemoveFunc.History(); len(calls) != 1 || calls[0].Arg2 != 1 {
+		t.Errorf("expected user 1 to be removed, have %+v", calls)
+	}
+	if calls := orgMembers.CreateFunc.History(); len(calls) != 1 || calls[0].Arg2 != 3 {
+		t.Errorf("expected user 3 to be added, have %+v", calls)
+	}
+}
@@ -0,0 +1,264 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// applyPatch applies the given PATCH operations to the given resource, which must be a pointer to a
+// resource struct. The operations are applied to the JSON representation of the resource, so that the
+// semantics of RFC 7644 section 3.5.2 don't have to be implemented for every attribute separately.
+// Unknown attributes are accepted but have no effect.
+func applyPatch(resource any, schema string, operations []PatchOperation) error {
+	serialized, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	var attributes map[string]any
+	if err := json.Unmarshal(serialized, &attributes); err != nil {
+		return err
+	}
+
+	for _, operation := range operations {
+		if err := applyOperation(attributes, schema, operation); err != nil {
+			return err
+		}
+	}
+
+	// Some identity providers (notably Azure AD) send boolean values as strings, e.g. "False".
+	if key := lookupKey(attributes, "active"); attributes[key] != nil {
+		if value, ok := attributes[key].(string); ok {
+			active, err := strconv.ParseBool(value)
+			if err != nil {
+				return newError(http.StatusBadRequest, "invalidValue", fmt.Sprintf("invalid value for active: %q", value))
+			}
+			attributes[key] = active
+		}
+	}
+
+	serialized, err = json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	// Decode into a new value, so that removed attributes are not retained from the original resource.
+	patched := reflect.New(reflect.TypeOf(resource).Elem())
+	if err := json.Unmarshal(serialized, patched.Interface()); err != nil {
+		return newError(http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	reflect.ValueOf(resource).Elem().Set(patched.Elem())
+
+	return nil
+}
+
+// patchPath is a parsed attribute path of a PATCH operation, e.g. `emails[type eq "work"].value`.
+type patchPath struct {
+	attribute string
+	// filter, if set, selects the elements of a multi-valued attribute the operation applies to.
+	filter *filter
+	// subAttribute, if set, is the attribute of the (selected elements of the) attribute the
+	// operation applies to.
+	subAttribute string
+}
+
+var pathPattern = lazyregexp.New(`^([A-Za-z][\w$]*)(?:\[(.+)\])?(?:\.([A-Za-z][\w$]*))?$`)
+
+func parsePath(schema, path string) (*patchPath, error) {
+	// Attributes of the core schema may be prefixed by the schema URN.
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+		path = path[len(schema)+1:]
+	}
+
+	match := pathPattern.FindStringSubmatch(path)
+	if match == nil {
+		return nil, newError(http.StatusBadRequest, "invalidPath", fmt.Sprintf("unsupported path %q", path))
+	}
+
+	p := &patchPath{attribute: match[1], subAttribute: match[3]}
+	if match[2] != "" {
+		f, err := parseFilter(match[2])
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidPath", fmt.Sprintf("unsupported filter in path %q", path))
+		}
+		p.filter = f
+	}
+
+	return p, nil
+}
+
+func applyOperation(attributes map[string]any, schema string, operation PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return newError(http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("unsupported operation %q", operation.Op))
+	}
+
+	if operation.Path == "" {
+		if op == "remove" {
+			return newError(http.StatusBadRequest, "noTarget", "remove operations require a path")
+		}
+
+		values, ok := operation.Value.(map[string]any)
+		if !ok {
+			return newError(http.StatusBadRequest, "invalidValue", "operations without a path require an object value")
+		}
+		for name, value := range values {
+			path, err := parsePath(schema, name)
+			if err != nil {
+				return err
+			}
+			applyToPath(attributes, op, path, value)
+		}
+
+		return nil
+	}
+
+	path, err := parsePath(schema, operation.Path)
+	if err != nil {
+		return err
+	}
+	applyToPath(attributes, op, path, operation.Value)
+
+	return nil
+}
+
+func applyToPath(attributes map[string]any, op string, path *patchPath, value any) {
+	key := lookupKey(attributes, path.attribute)
+
+	if path.filter == nil {
+		if path.subAttribute != "" {
+			// Complex attribute, such as `name.givenName`
+			parent, _ := attributes[key].(map[string]any)
+			if parent == nil {
+				parent = map[string]any{}
+			}
+			applyToAttribute(parent, op, lookupKey(parent, path.subAttribute), value)
+			attributes[key] = parent
+			return
+		}
+
+		applyToAttribute(attributes, op, key, value)
+		return
+	}
+
+	// Multi-valued attribute with a filter selecting elements, such as `members[value eq "1"]`
+	// or `emails[type eq "work"].value`
+	elements, _ := attributes[key].([]any)
+	remaining := elements[:0:0]
+	matched := false
+	for _, element := range elements {
+		object, ok := element.(map[string]any)
+		if !ok || !path.filter.matches(object[lookupKey(object, path.filter.attribute)]) {
+			remaining = append(remaining, element)
+			continue
+		}
+		matched = true
+
+		switch {
+		case op == "remove" && path.subAttribute == "":
+			continue
+		case path.subAttribute == "":
+			if replacement, ok := value.(map[string]any); ok {
+				for name, v := range replacement {
+					applyToAttribute(object, op, lookupKey(object, name), v)
+				}
+			}
+		default:
+			applyToAttribute(object, op, lookupKey(object, path.subAttribute), value)
+		}
+		remaining = append(remaining, object)
+	}
+
+	// Adding or replacing a sub-attribute of an element that does not exist yet creates the element,
+	// e.g. replacing `emails[type eq "work"].value` of a user without a work email.
+	if !matched && op != "remove" && path.subAttribute != "" {
+		remaining = append(remaining, map[string]any{
+			path.filter.attribute: path.filter.value,
+			path.subAttribute:     value,
+		})
+	}
+
+	attributes[key] = remaining
+}
+
+func applyToAttribute(attributes map[string]any, op, key string, value any) {
+	switch op {
+	case "remove":
+		if values, ok := value.([]any); ok {
+			// Some identity providers remove elements of multi-valued attributes by listing the
+			// elements in the value of the operation instead of using a filter.
+			if elements, ok := attributes[key].([]any); ok {
+				attributes[key] = removeElements(elements, values)
+				return
+			}
+		}
+		delete(attributes, key)
+
+	case "add":
+		if values, ok := value.([]any); ok {
+			if elements, ok := attributes[key].([]any); ok {
+				attributes[key] = append(elements, values...)
+				return
+			}
+		}
+		if object, ok := value.(map[string]any); ok {
+			if existing, ok := attributes[key].(map[string]any); ok {
+				for name, v := range object {
+					existing[lookupKey(existing, name)] = v
+				}
+				return
+			}
+		}
+		attributes[key] = value
+
+	default:
+		attributes[key] = value
+	}
+}
+
+// removeElements removes the elements with the same value attribute as one of the given values.
+func removeElements(elements, values []any) []any {
+	remaining := elements[:0:0]
+	for _, element := range elements {
+		if !containsElement(values, element) {
+			remaining = append(remaining, element)
+		}
+	}
+
+	return remaining
+}
+
+func containsElement(values []any, element any) bool {
+	object, ok := element.(map[string]any)
+	if !ok {
+		return false
+	}
+	f := &filter{attribute: "value", value: fmt.Sprint(object[lookupKey(object, "value")])}
+
+	for _, value := range values {
+		if candidate, ok := value.(map[string]any); ok && f.matches(candidate[lookupKey(candidate, "value")]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupKey returns the key of the given attribute in the given object. Attribute names are
+// case-insensitive (RFC 7643 section 2.1), so the name is returned as-is if it's not present.
+func lookupKey(object map[string]any, name string) string {
+	if _, ok := object[name]; ok {
+		return name
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+
+	return name
+}
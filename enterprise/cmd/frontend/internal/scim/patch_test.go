@@ -0,0 +1,206 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyPatchUser(t *testing.T) {
+	active := true
+	inactive := false
+
+	newUser := func() *User {
+		return &User{
+			UserName:    "alice",
+			DisplayName: "Alice",
+			Active:      &active,
+			Emails: []Email{
+				{Value: "alice@example.com", Type: "work", Primary: true},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		operations string
+		expected   *User
+	}{
+		{
+			name:       "replace attribute",
+			operations: `[{"op": "Replace", "path": "displayName", "value": "Alice Example"}]`,
+			expected: &User{
+				UserName:    "alice",
+				DisplayName: "Alice Example",
+				Active:      &active,
+				Emails:      []Email{{Value: "alice@example.com", Type: "work", Primary: true}},
+			},
+		},
+		{
+			name:       "replace without path",
+			operations: `[{"op": "replace", "value": {"active": false, "name.givenName": "Alice"}}]`,
+			expected: &User{
+				UserName:    "alice",
+				DisplayName: "Alice",
+				Name:        &Name{GivenName: "Alice"},
+				Active:      &inactive,
+				Emails:      []Email{{Value: "alice@example.com", Type: "work", Primary: true}},
+			},
+		},
+		{
+			name:       "boolean as string",
+			operations: `[{"op": "Replace", "path": "active", "value": "False"}]`,
+			expected: &User{
+				UserName:    "alice",
+				DisplayName: "Alice",
+				Active:      &inactive,
+				Emails:      []Email{{Value: "alice@example.com", Type: "work", Primary: true}},
+			},
+		},
+		{
+			name:       "schema prefix",
+			operations: `[{"op": "replace", "path": "urn:ietf:params:scim:schemas:core:2.0:User:userName", "value": "alice2"}]`,
+			expected: &User{
+				UserName:    "alice2",
+				DisplayName: "Alice",
+				Active:      &active,
+				Emails:      []Email{{Value: "alice@example.com", Type: "work", Primary: true}},
+			},
+		},
+		{
+			name:       "replace filtered sub-attribute",
+			operations: `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@work.example.com"}]`,
+			expected: &User{
+				UserName:    "alice",
+				DisplayName: "Alice",
+				Active:      &active,
+				Emails:      []Email{{Value: "alice@work.example.com", Type: "work", Primary: true}},
+			},
+		},
+		{
+			name:       "add filtered sub-attribute of missing element",
+			operations: `[{"op": "add", "path": "emails[type eq \"home\"].value", "value": "alice@home.example.com"}]`,
+			expected: &User{
+				UserName:    "alice",
+				DisplayName: "Alice",
+				Active:      &active,
+				Emails: []Email{
+					{Value: "alice@example.com", Type: "work", Primary: true},
+					{Value: "alice@home.example.com", Type: "home"},
+				},
+			},
+		},
+		{
+			name:       "remove attribute",
+			operations: `[{"op": "remove", "path": "displayName"}]`,
+			expected: &User{
+				UserName: "alice",
+				Active:   &active,
+				Emails:   []Email{{Value: "alice@example.com", Type: "work", Primary: true}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var operations []PatchOperation
+			if err := json.Unmarshal([]byte(testCase.operations), &operations); err != nil {
+				t.Fatalf("unexpected error decoding operations: %s", err)
+			}
+
+			u := newUser()
+			if err := applyPatch(u, schemaUser, operations); err != nil {
+				t.Fatalf("unexpected error applying patch: %s", err)
+			}
+			if diff := cmp.Diff(testCase.expected, u); diff != "" {
+				t.Errorf("unexpected user (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyPatchGroupMembers(t *testing.T) {
+	newGroup := func() *Group {
+		return &Group{
+			DisplayName: "Engineering",
+			Members: []Membership{
+				{Value: "1", Display: "alice"},
+				{Value: "2", Display: "bob"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		operations string
+		expected   []Membership
+	}{
+		{
+			name:       "add members",
+			operations: `[{"op": "add", "path": "members", "value": [{"value": "3"}]}]`,
+			expected:   []Membership{{Value: "1", Display: "alice"}, {Value: "2", Display: "bob"}, {Value: "3"}},
+		},
+		{
+			name:       "remove members by value",
+			operations: `[{"op": "remove", "path": "members", "value": [{"value": "1"}]}]`,
+			expected:   []Membership{{Value: "2", Display: "bob"}},
+		},
+		{
+			name:       "remove members by filter",
+			operations: `[{"op": "remove", "path": "members[value eq \"2\"]"}]`,
+			expected:   []Membership{{Value: "1", Display: "alice"}},
+		},
+		{
+			name:       "remove all members",
+			operations: `[{"op": "remove", "path": "members"}]`,
+			expected:   nil,
+		},
+		{
+			name:       "replace members",
+			operations: `[{"op": "replace", "path": "members", "value": [{"value": "4"}]}]`,
+			expected:   []Membership{{Value: "4"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var operations []PatchOperation
+			if err := json.Unmarshal([]byte(testCase.operations), &operations); err != nil {
+				t.Fatalf("unexpected error decoding operations: %s", err)
+			}
+
+			g := newGroup()
+			if err := applyPatch(g, schemaGroup, operations); err != nil {
+				t.Fatalf("unexpected error applying patch: %s", err)
+			}
+			if diff := cmp.Diff(testCase.expected, g.Members); diff != "" {
+				t.Errorf("unexpected members (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	for _, operations := range []string{
+		`[{"op": "move", "path": "displayName"}]`,
+		`[{"op": "remove"}]`,
+		`[{"op": "replace", "value": "alice"}]`,
+		`[{"op": "replace", "path": "emails[type sw \"w\"].value", "value": "alice@example.com"}]`,
+		`[{"op": "replace", "path": "active", "value": "maybe"}]`,
+	} {
+		var ops []PatchOperation
+		if err := json.Unmarshal([]byte(operations), &ops); err != nil {
+			t.Fatalf("unexpected error decoding operations: %s", err)
+		}
+
+		if err := applyPatch(&User{UserName: "alice"}, schemaUser, ops); !isBadRequest(err) {
+			t.Errorf("expected bad request error for %s, got %v", operations, err)
+		}
+	}
+}
+
+func isBadRequest(err error) bool {
+	e, ok := err.(*scimError)
+	return ok && e.status == 400
+}
@@ -0,0 +1,639 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// serviceType is the service type, service ID and client ID of the user external accounts that store
+// the SCIM attributes of provisioned users, so that the attributes sent by the identity provider
+// (which may not map to Sourcegraph attributes, or be normalized when they do) can be returned as-is.
+const serviceType = "scim"
+
+func (h *handler) listUsers(r *http.Request) (int, any, error) {
+	ctx := r.Context()
+
+	p, err := parseListParameters(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if p.filter == nil {
+		totalResults, err := h.db.Users().Count(ctx, &database.UsersListOptions{})
+		if err != nil {
+			return 0, nil, err
+		}
+		users, err := h.db.Users().List(ctx, &database.UsersListOptions{LimitOffset: p.limitOffset()})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		resources := make([]any, 0, len(users))
+		for _, user := range users {
+			u, err := h.toSCIMUser(ctx, user)
+			if err != nil {
+				return 0, nil, err
+			}
+			resources = append(resources, u)
+		}
+
+		return http.StatusOK, newListResponse(p, totalResults, resources), nil
+	}
+
+	candidates, err := h.findUsers(ctx, p.filter)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var matches []any
+	for _, user := range candidates {
+		u, err := h.toSCIMUser(ctx, user)
+		if err != nil {
+			return 0, nil, err
+		}
+		if matchesUser(p.filter, u) {
+			matches = append(matches, u)
+		}
+	}
+
+	return http.StatusOK, newListResponse(p, len(matches), paginate(matches, p)), nil
+}
+
+// findUsers returns the users that may match the given filter.
+func (h *handler) findUsers(ctx context.Context, f *filter) ([]*types.User, error) {
+	var (
+		user *types.User
+		err  error
+	)
+
+	switch f.attribute {
+	case "id":
+		id, parseErr := strconv.ParseInt(f.value, 10, 32)
+		if parseErr != nil {
+			return nil, nil
+		}
+		user, err = h.db.Users().GetByID(ctx, int32(id))
+
+	case "username":
+		username, normalizeErr := auth.NormalizeUsername(f.value)
+		if normalizeErr != nil {
+			return nil, nil
+		}
+		user, err = h.db.Users().GetByUsername(ctx, username)
+
+	case "emails", "emails.value":
+		user, err = h.db.Users().GetByVerifiedEmail(ctx, f.value)
+
+	case "externalid":
+		accounts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+			ServiceType: serviceType,
+			ServiceID:   serviceType,
+			ClientID:    serviceType,
+			AccountID:   f.value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int32, 0, len(accounts))
+		for _, account := range accounts {
+			ids = append(ids, account.UserID)
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+		return h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: ids})
+
+	default:
+		return nil, newError(http.StatusBadRequest, "invalidFilter", fmt.Sprintf("filtering users by %q is not supported", f.attribute))
+	}
+
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []*types.User{user}, nil
+}
+
+func matchesUser(f *filter, u *User) bool {
+	switch f.attribute {
+	case "id":
+		return f.matches(u.ID)
+	case "username":
+		return f.matches(u.UserName)
+	case "externalid":
+		return f.matches(u.ExternalID)
+	default:
+		for _, email := range u.Emails {
+			if f.matches(email.Value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (h *handler) getUser(r *http.Request) (int, any, error) {
+	user, err := h.lookupUser(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	u, err := h.toSCIMUser(r.Context(), user)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, u, nil
+}
+
+func (h *handler) createUser(r *http.Request) (int, any, error) {
+	ctx := r.Context()
+
+	var u User
+	if err := decodeBody(r, &u); err != nil {
+		return 0, nil, err
+	}
+	username, err := normalizeUsername(u.UserName)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var primaryEmail string
+	if email := u.primaryEmail(); email != nil {
+		primaryEmail = email.Value
+	}
+
+	data, err := accountData(&u)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// 🚨 SECURITY: The emails of provisioned users are considered verified, as the identity provider
+	// is the source of truth for the users of this instance.
+	userID, err := h.db.UserExternalAccounts().CreateUserAndSave(ctx, database.NewUser{
+		Username:        username,
+		Email:           primaryEmail,
+		DisplayName:     u.displayName(),
+		EmailIsVerified: true,
+	}, accountSpec(&u), data)
+	if err != nil {
+		if database.IsUsernameExists(err) || database.IsEmailExists(err) {
+			return 0, nil, newError(http.StatusConflict, "uniqueness", err.Error())
+		}
+		return 0, nil, err
+	}
+	h.logger.Info("provisioned user", log.Int32("userID", userID), log.String("username", username))
+
+	if err := h.addEmails(ctx, userID, u.Emails); err != nil {
+		return 0, nil, err
+	}
+
+	user, err := h.db.Users().GetByID(ctx, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	created, err := h.toSCIMUser(ctx, user)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, created, nil
+}
+
+func (h *handler) replaceUser(r *http.Request) (int, any, error) {
+	var u User
+	if err := decodeBody(r, &u); err != nil {
+		return 0, nil, err
+	}
+
+	user, err := h.lookupUser(r)
+	if err != nil {
+		if !isNotFound(err) || !u.isActive() {
+			return 0, nil, err
+		}
+		if user, err = h.recoverUser(r); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	return h.updateUser(r.Context(), user, &u)
+}
+
+func (h *handler) patchUser(r *http.Request) (int, any, error) {
+	ctx := r.Context()
+
+	var patch PatchRequest
+	if err := decodeBody(r, &patch); err != nil {
+		return 0, nil, err
+	}
+
+	user, err := h.lookupUser(r)
+	if err != nil {
+		if !isNotFound(err) {
+			return 0, nil, err
+		}
+
+		// Deactivated users are soft-deleted, so the only patch that can be applied to them is one that
+		// reactivates them.
+		inactive := false
+		reactivation := User{Active: &inactive}
+		if patchErr := applyPatch(&reactivation, schemaUser, patch.Operations); patchErr != nil || !reactivation.isActive() {
+			return 0, nil, err
+		}
+		if user, err = h.recoverUser(r); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	u, err := h.toSCIMUser(ctx, user)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := applyPatch(u, schemaUser, patch.Operations); err != nil {
+		return 0, nil, err
+	}
+
+	return h.updateUser(ctx, user, u)
+}
+
+// updateUser updates the given user to match the given SCIM attributes.
+func (h *handler) updateUser(ctx context.Context, user *types.User, u *User) (int, any, error) {
+	username, err := normalizeUsername(u.UserName)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	update := database.UserUpdate{}
+	if username != user.Username {
+		update.Username = username
+	}
+	if displayName := u.displayName(); displayName != "" && displayName != user.DisplayName {
+		update.DisplayName = &displayName
+	}
+	if update != (database.UserUpdate{}) {
+		if err := h.db.Users().Update(ctx, user.ID, update); err != nil {
+			if database.IsUsernameExists(err) {
+				return 0, nil, newError(http.StatusConflict, "uniqueness", err.Error())
+			}
+			return 0, nil, err
+		}
+	}
+
+	if err := h.addEmails(ctx, user.ID, u.Emails); err != nil {
+		return 0, nil, err
+	}
+
+	data, err := accountData(u)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := h.db.UserExternalAccounts().AssociateUserAndSave(ctx, user.ID, accountSpec(u), data); err != nil {
+		return 0, nil, err
+	}
+
+	if !u.isActive() {
+		if err := h.deactivateUser(ctx, user.ID); err != nil {
+			return 0, nil, err
+		}
+
+		active := false
+		deactivated := *u
+		deactivated.Active = &active
+		deactivated.ID = strconv.Itoa(int(user.ID))
+		deactivated.Meta = userMeta(user)
+		return http.StatusOK, &deactivated, nil
+	}
+
+	if user, err = h.db.Users().GetByID(ctx, user.ID); err != nil {
+		return 0, nil, err
+	}
+	updated, err := h.toSCIMUser(ctx, user)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, updated, nil
+}
+
+func (h *handler) deleteUser(r *http.Request) (int, any, error) {
+	user, err := h.lookupUser(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := h.deactivateUser(r.Context(), user.ID); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// deactivateUser signs the user out of all sessions and soft-deletes the user, so that the user can
+// be reactivated by the identity provider later on.
+func (h *handler) deactivateUser(ctx context.Context, userID int32) error {
+	if err := h.db.Users().InvalidateSessionsByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := h.db.Users().Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	h.logger.Info("deprovisioned user", log.Int32("userID", userID))
+	return nil
+}
+
+// recoverUser restores the soft-deleted user referenced in the request path, along with the emails
+// stored in the SCIM attributes of the user.
+func (h *handler) recoverUser(r *http.Request) (*types.User, error) {
+	ctx := r.Context()
+
+	id, err := parseID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	recovered, err := h.db.Users().RecoverUsersList(ctx, []int32{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(recovered) == 0 {
+		return nil, newError(http.StatusNotFound, "", "user not found")
+	}
+	h.logger.Info("reprovisioned user", log.Int32("userID", id))
+
+	user, err := h.db.Users().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emails are not recovered with the user, so we re-add the emails last sent by the identity provider.
+	account, err := h.scimAccount(ctx, id)
+	if err != nil || account == nil {
+		return user, err
+	}
+	u, err := encryption.DecryptJSON[User](ctx, account.Data)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.addEmails(ctx, id, u.Emails); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// lookupUser returns the user referenced in the request path.
+func (h *handler) lookupUser(r *http.Request) (*types.User, error) {
+	id, err := parseID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.db.Users().GetByID(r.Context(), id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, newError(http.StatusNotFound, "", "user not found")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// addEmails adds the given emails that the user does not have yet as verified emails, and makes the
+// primary email the primary email of the user. Emails are never removed, as they may have been added
+// by the user.
+func (h *handler) addEmails(ctx context.Context, userID int32, emails []Email) error {
+	existing, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		if email.Value == "" {
+			continue
+		}
+
+		var found *database.UserEmail
+		for _, e := range existing {
+			if strings.EqualFold(e.Email, email.Value) {
+				found = e
+				break
+			}
+		}
+
+		if found == nil {
+			other, err := h.db.Users().GetByVerifiedEmail(ctx, email.Value)
+			if err != nil && !errcode.IsNotFound(err) {
+				return err
+			}
+			if other != nil && other.ID != userID {
+				return newError(http.StatusConflict, "uniqueness", fmt.Sprintf("email %q belongs to another user", email.Value))
+			}
+
+			if err := h.db.UserEmails().Add(ctx, userID, email.Value, nil); err != nil {
+				return err
+			}
+			found = &database.UserEmail{Email: email.Value}
+		}
+		if found.VerifiedAt == nil {
+			if err := h.db.UserEmails().SetVerified(ctx, userID, found.Email, true); err != nil {
+				return err
+			}
+		}
+		if email.Primary && !found.Primary {
+			if err := h.db.UserEmails().SetPrimaryEmail(ctx, userID, found.Email); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// toSCIMUser returns the SCIM representation of the given user. Users that have not been provisioned
+// via SCIM (e.g. users created by an authentication provider on first sign-in) are represented by
+// their Sourcegraph attributes, so that the identity provider can take them over.
+func (h *handler) toSCIMUser(ctx context.Context, user *types.User) (*User, error) {
+	u := &User{UserName: user.Username, DisplayName: user.DisplayName}
+
+	account, err := h.scimAccount(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if account != nil {
+		stored, err := encryption.DecryptJSON[User](ctx, account.Data)
+		if err != nil {
+			return nil, err
+		}
+		u = stored
+	}
+
+	emails, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: user.ID, OnlyVerified: true})
+	if err != nil {
+		return nil, err
+	}
+	emailTypes := map[string]string{}
+	for _, email := range u.Emails {
+		emailTypes[strings.ToLower(email.Value)] = email.Type
+	}
+	u.Emails = make([]Email, 0, len(emails))
+	for _, email := range emails {
+		u.Emails = append(u.Emails, Email{Value: email.Email, Type: emailTypes[strings.ToLower(email.Email)], Primary: email.Primary})
+	}
+
+	memberships, err := h.db.OrgMembers().GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	u.Groups = make([]Membership, 0, len(memberships))
+	for _, membership := range memberships {
+		org, err := h.db.Orgs().GetByID(ctx, membership.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		u.Groups = append(u.Groups, Membership{
+			Value:   strconv.Itoa(int(org.ID)),
+			Display: orgDisplayName(org),
+			Ref:     location("/Groups/" + strconv.Itoa(int(org.ID))),
+		})
+	}
+
+	u.Schemas = []string{schemaUser}
+	u.ID = strconv.Itoa(int(user.ID))
+	active := true
+	u.Active = &active
+	u.Meta = userMeta(user)
+	return u, nil
+}
+
+// scimAccount returns the most recently updated external account storing the SCIM attributes of the
+// given user, or nil if the user has not been provisioned via SCIM.
+func (h *handler) scimAccount(ctx context.Context, userID int32) (*extsvc.Account, error) {
+	accounts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:      userID,
+		ServiceType: serviceType,
+		ServiceID:   serviceType,
+		ClientID:    serviceType,
+	})
+	if err != nil || len(accounts) == 0 {
+		return nil, err
+	}
+
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].UpdatedAt.After(accounts[j].UpdatedAt) })
+	return accounts[0], nil
+}
+
+func accountSpec(u *User) extsvc.AccountSpec {
+	accountID := u.ExternalID
+	if accountID == "" {
+		accountID = u.UserName
+	}
+
+	return extsvc.AccountSpec{
+		ServiceType: serviceType,
+		ServiceID:   serviceType,
+		ClientID:    serviceType,
+		AccountID:   accountID,
+	}
+}
+
+// accountData returns the SCIM attributes of the given user to be stored in its external account.
+// Attributes that are derived from Sourcegraph data are not stored.
+func accountData(u *User) (extsvc.AccountData, error) {
+	stored := *u
+	stored.Schemas = nil
+	stored.ID = ""
+	stored.Active = nil
+	stored.Groups = nil
+	stored.Meta = nil
+
+	serialized, err := json.Marshal(stored)
+	if err != nil {
+		return extsvc.AccountData{}, err
+	}
+	return extsvc.AccountData{Data: extsvc.NewUnencryptedData(serialized)}, nil
+}
+
+func userMeta(user *types.User) *Meta {
+	return &Meta{
+		ResourceType: "User",
+		Created:      user.CreatedAt,
+		LastModified: user.UpdatedAt,
+		Location:     location("/Users/" + strconv.Itoa(int(user.ID))),
+	}
+}
+
+func normalizeUsername(userName string) (string, error) {
+	if userName == "" {
+		return "", newError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+
+	username, err := auth.NormalizeUsername(userName)
+	if err != nil {
+		return "", newError(http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	return username, nil
+}
+
+// isActive returns true unless the user is explicitly marked as inactive.
+func (u *User) isActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// displayName returns the display name of the user, falling back to the name of the user.
+func (u *User) displayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// primaryEmail returns the primary email of the user, falling back to the first email.
+func (u *User) primaryEmail() *Email {
+	for i := range u.Emails {
+		if u.Emails[i].Primary {
+			return &u.Emails[i]
+		}
+	}
+	if len(u.Emails) > 0 {
+		return &u.Emails[0]
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var e *scimError
+	return errors.As(err, &e) && e.status == http.StatusNotFound
+}
+
+// paginate returns the page of the given resources selected by the list parameters.
+func paginate(resources []any, p *listParameters) []any {
+	start := p.startIndex - 1
+	if start > len(resources) {
+		start = len(resources)
+	}
+	end := start + p.count
+	if end > len(resources) {
+		end = len(resources)
+	}
+	return resources[start:end]
+}
@@ -46,6 +46,7 @@ func main() {
 		"insights-query-runner-job":     workerinsights.NewInsightsQueryRunnerJob(),
 		"batches-janitor":               batches.NewJanitorJob(),
 		"batches-scheduler":             batches.NewSchedulerJob(),
+		"batches-auto-merger":           batches.NewAutoMergerJob(),
 		"batches-reconciler":            batches.NewReconcilerJob(),
 		"batches-bulk-processor":        batches.NewBulkOperationProcessorJob(),
 		"batches-workspace-resolver":    batches.NewWorkspaceResolverJob(),
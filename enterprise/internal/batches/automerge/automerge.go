@@ -0,0 +1,194 @@
+// Package automerge evaluates the auto-merge policies of batch changes and
+// enqueues merges of the changesets that satisfy them.
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/config"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// evaluateInterval is how often changesets that have been synced since
+	// they were last evaluated are picked up.
+	evaluateInterval = 1 * time.Minute
+
+	// evaluateBatchSize is the maximum number of changesets evaluated in one
+	// run.
+	evaluateBatchSize = 100
+)
+
+// NewMerger creates a new goroutine.PeriodicGoroutine that evaluates the
+// auto-merge policy of batch changes for each of their changesets after it
+// has been synced, enqueues a merge of the changesets that satisfy it and
+// records why the others weren't merged.
+func NewMerger(ctx context.Context, logger log.Logger, s *store.Store) goroutine.BackgroundRoutine {
+	m := &merger{logger: logger, store: s}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		evaluateInterval,
+		goroutine.NewHandlerWithErrorMessage("evaluating batch change auto-merge policies", m.evaluate),
+	)
+}
+
+type merger struct {
+	logger log.Logger
+	store  *store.Store
+}
+
+// batchChangePolicy is the auto-merge policy of a batch change, along with
+// the number of merges the policy enqueued in the last hour.
+type batchChangePolicy struct {
+	batchChange *btypes.BatchChange
+	policy      *batcheslib.AutoMerge
+	enqueued    int
+}
+
+func (m *merger) evaluate(ctx context.Context) error {
+	cs, err := m.store.ListChangesetsToAutoMerge(ctx, evaluateBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets to auto-merge")
+	}
+
+	now := m.store.Clock()()
+	windowOpen := config.ActiveWindow().IsOpen(now)
+
+	policies := make(map[int64]*batchChangePolicy)
+	var errs error
+	for _, ch := range cs {
+		p, ok := policies[ch.OwnedByBatchChangeID]
+		if !ok {
+			p, err = m.loadPolicy(ctx, ch.OwnedByBatchChangeID, now)
+			if err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "loading auto-merge policy of batch change %d", ch.OwnedByBatchChangeID))
+				continue
+			}
+			policies[ch.OwnedByBatchChangeID] = p
+		}
+
+		if err := m.evaluateChangeset(ctx, p, ch, windowOpen, now); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "evaluating auto-merge policy for changeset %d", ch.ID))
+		}
+	}
+
+	return errs
+}
+
+func (m *merger) loadPolicy(ctx context.Context, batchChangeID int64, now time.Time) (*batchChangePolicy, error) {
+	batchChange, err := m.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	batchSpec, err := m.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	enqueued, err := m.store.CountChangesetAutoMergesEnqueuedSince(ctx, batchChangeID, now.Add(-1*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangePolicy{
+		batchChange: batchChange,
+		policy:      batchSpec.Spec.AutoMerge,
+		enqueued:    enqueued,
+	}, nil
+}
+
+func (m *merger) evaluateChangeset(ctx context.Context, p *batchChangePolicy, ch *btypes.Changeset, windowOpen bool, now time.Time) (err error) {
+	autoMerge := &btypes.ChangesetAutoMerge{
+		ChangesetID:   ch.ID,
+		BatchChangeID: p.batchChange.ID,
+		EvaluatedAt:   now,
+		CheckState:    ch.ExternalCheckState,
+		ReviewState:   ch.ExternalReviewState,
+	}
+
+	if p.policy == nil {
+		// The batch change was applied with a new batch spec without a
+		// policy since the changeset was listed.
+		autoMerge.BlockedReason = "The batch change has no auto-merge policy."
+		return m.store.UpsertChangesetAutoMerge(ctx, autoMerge)
+	}
+
+	if reason := blockedReason(p.policy, ch, p.batchChange.ID, p.enqueued, windowOpen); reason != "" {
+		autoMerge.BlockedReason = reason
+		return m.store.UpsertChangesetAutoMerge(ctx, autoMerge)
+	}
+
+	bulkGroupID, err := store.RandomID()
+	if err != nil {
+		return errors.Wrap(err, "creating bulkGroupID failed")
+	}
+
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// The merge is run by the bulk processor with the credentials of the user
+	// that last applied the batch change, like any other bulk operation.
+	if err := tx.CreateChangesetJob(ctx, &btypes.ChangesetJob{
+		BulkGroup:     bulkGroupID,
+		ChangesetID:   ch.ID,
+		BatchChangeID: p.batchChange.ID,
+		UserID:        p.batchChange.LastApplierID,
+		State:         btypes.ChangesetJobStateQueued,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		Payload:       &btypes.ChangesetJobMergePayload{Squash: p.policy.Squash},
+	}); err != nil {
+		return errors.Wrap(err, "creating changeset job")
+	}
+
+	autoMerge.MergeEnqueuedAt = now
+	if err := tx.UpsertChangesetAutoMerge(ctx, autoMerge); err != nil {
+		return err
+	}
+
+	p.enqueued++
+	m.logger.Debug("enqueued auto-merge of changeset",
+		log.Int64("changesetID", ch.ID),
+		log.Int64("batchChangeID", p.batchChange.ID))
+
+	return nil
+}
+
+// blockedReason returns why the given changeset of the batch change with the
+// given ID can't be merged under the given policy, or an empty string if it
+// can be merged. enqueued is the number of merges the policy enqueued in the
+// last hour.
+func blockedReason(policy *batcheslib.AutoMerge, ch *btypes.Changeset, batchChangeID int64, enqueued int, windowOpen bool) string {
+	switch {
+	case ch.ArchivedIn(batchChangeID):
+		return "The changeset is archived."
+	case ch.ExternalState == btypes.ChangesetExternalStateDraft:
+		return "The changeset is a draft."
+	case ch.ExternalState != btypes.ChangesetExternalStateOpen:
+		return "The changeset is not open."
+	case ch.ReconcilerState != btypes.ReconcilerStateCompleted:
+		return "The changeset is being updated on the code host."
+	case ch.ExternalReviewState != btypes.ChangesetReviewStateApproved:
+		return fmt.Sprintf("The changeset has not been approved (review state: %s).", ch.ExternalReviewState)
+	case ch.ExternalCheckState != btypes.ChangesetCheckStatePassed:
+		return fmt.Sprintf("The checks of the changeset have not passed (check state: %s).", ch.ExternalCheckState)
+	case policy.RespectRolloutWindows && !windowOpen:
+		return "No rollout window currently allows changesets to be merged."
+	case policy.MaxPerHour > 0 && enqueued >= policy.MaxPerHour:
+		return fmt.Sprintf("The maximum of %d changesets merged per hour has been reached.", policy.MaxPerHour)
+	}
+
+	return ""
+}
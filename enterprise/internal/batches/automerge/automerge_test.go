@@ -0,0 +1,96 @@
+package automerge
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestBlockedReason(t *testing.T) {
+	const batchChangeID = 1
+
+	mergeable := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		policy     *batcheslib.AutoMerge
+		changeset  func(*btypes.Changeset)
+		enqueued   int
+		windowOpen bool
+		want       string
+	}{
+		"mergeable": {
+			policy:     &batcheslib.AutoMerge{},
+			windowOpen: true,
+		},
+		"archived": {
+			policy: &batcheslib.AutoMerge{},
+			changeset: func(c *btypes.Changeset) {
+				c.BatchChanges[0].IsArchived = true
+			},
+			want: "The changeset is archived.",
+		},
+		"draft": {
+			policy:    &batcheslib.AutoMerge{},
+			changeset: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateDraft },
+			want:      "The changeset is a draft.",
+		},
+		"closed": {
+			policy:    &batcheslib.AutoMerge{},
+			changeset: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateClosed },
+			want:      "The changeset is not open.",
+		},
+		"reconciling": {
+			policy:    &batcheslib.AutoMerge{},
+			changeset: func(c *btypes.Changeset) { c.ReconcilerState = btypes.ReconcilerStateQueued },
+			want:      "The changeset is being updated on the code host.",
+		},
+		"changes requested": {
+			policy:    &batcheslib.AutoMerge{},
+			changeset: func(c *btypes.Changeset) { c.ExternalReviewState = btypes.ChangesetReviewStateChangesRequested },
+			want:      "The changeset has not been approved (review state: CHANGES_REQUESTED).",
+		},
+		"checks pending": {
+			policy:    &batcheslib.AutoMerge{},
+			changeset: func(c *btypes.Changeset) { c.ExternalCheckState = btypes.ChangesetCheckStatePending },
+			want:      "The checks of the changeset have not passed (check state: PENDING).",
+		},
+		"window closed": {
+			policy: &batcheslib.AutoMerge{RespectRolloutWindows: true},
+			want:   "No rollout window currently allows changesets to be merged.",
+		},
+		"window closed but not respected": {
+			policy: &batcheslib.AutoMerge{},
+		},
+		"rate limited": {
+			policy:     &batcheslib.AutoMerge{MaxPerHour: 2},
+			enqueued:   2,
+			windowOpen: true,
+			want:       "The maximum of 2 changesets merged per hour has been reached.",
+		},
+		"below rate limit": {
+			policy:     &batcheslib.AutoMerge{MaxPerHour: 2},
+			enqueued:   1,
+			windowOpen: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ch := mergeable()
+			if tc.changeset != nil {
+				tc.changeset(ch)
+			}
+
+			if have := blockedReason(tc.policy, ch, batchChangeID, tc.enqueued, tc.windowOpen); have != tc.want {
+				t.Errorf("unexpected reason: have=%q want=%q", have, tc.want)
+			}
+		})
+	}
+}
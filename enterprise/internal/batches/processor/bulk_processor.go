@@ -98,6 +98,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
 		return b.publishChangeset(ctx, job)
+	case btypes.ChangesetJobTypeAssign:
+		return b.assignChangeset(ctx, job)
 
 	default:
 		return &unknownJobTypeErr{jobType: string(job.JobType)}
@@ -196,6 +198,12 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errcode.MakeNonRetryable(err)
 	}
 
+	// Changesets that are stacked on this one need to be retargeted.
+	if err := b.tx.EnqueueChangesetsDependingOn(ctx, cs.Changeset); err != nil {
+		log15.Error("EnqueueChangesetsDependingOn", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
 	return nil
 }
 
@@ -231,6 +239,12 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context) (err error) {
 		return errcode.MakeNonRetryable(err)
 	}
 
+	// Changesets that are stacked on this one need to be retargeted.
+	if err := b.tx.EnqueueChangesetsDependingOn(ctx, cs.Changeset); err != nil {
+		log15.Error("EnqueueChangesetsDependingOn", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
 	return nil
 }
 
@@ -282,3 +296,59 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 
 	return nil
 }
+
+func (b *bulkProcessor) assignChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobAssignPayload)
+	if !ok {
+		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobAssignPayload{}, job.Payload)
+	}
+
+	assignableCss, err := sources.ToAssignableChangesetSource(b.css)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
+	if err != nil {
+		return errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  b.ch,
+		TargetRepo: b.repo,
+		RemoteRepo: remoteRepo,
+	}
+	// Reviewers, assignees and labels that the code host doesn't support are
+	// ignored.
+	if b.ch.SupportsReviewers() {
+		cs.Reviewers = typedPayload.Reviewers
+	}
+	if b.ch.SupportsAssignees() {
+		cs.Assignees = typedPayload.Assignees
+	}
+	if b.ch.SupportsLabels() {
+		cs.Labels = typedPayload.Labels
+	}
+	if err := assignableCss.AssignChangeset(ctx, cs); err != nil {
+		return err
+	}
+
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		log15.Error("Events", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient(b.tx.DatabaseDB()), cs.Changeset, events)
+
+	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		log15.Error("UpsertChangesetEvents", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		log15.Error("UpdateChangeset", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	return nil
+}
@@ -39,6 +39,10 @@ func executePlan(ctx context.Context, logger log.Logger, client gitserver.Client
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		base:              plan.Base,
+	}
+	if e.base == nil && e.spec != nil {
+		e.base = &stackBase{Ref: e.spec.BaseRef, Rev: e.spec.BaseRev}
 	}
 
 	return e.Run(ctx, plan)
@@ -52,6 +56,7 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	base              *stackBase
 
 	// targetRepo represents the repo where the changeset should be opened.
 	targetRepo *types.Repo
@@ -145,7 +150,18 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		return err
 	}
 
-	return e.tx.UpdateChangeset(ctx, e.ch)
+	if err := e.tx.UpdateChangeset(ctx, e.ch); err != nil {
+		return err
+	}
+
+	// Changesets that are stacked on this one need to be rebased when it got
+	// a new commit, and retargeted when it was closed.
+	for _, op := range plan.Ops {
+		if op == btypes.ReconcilerOperationPush || op == btypes.ReconcilerOperationClose {
+			return e.tx.EnqueueChangesetsDependingOn(ctx, e.ch)
+		}
+	}
+	return nil
 }
 
 var errCannotPushToArchivedRepo = errcode.MakeNonRetryable(errors.New("cannot push to an archived repo"))
@@ -187,10 +203,13 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	opts, err := buildCommitOpts(e.targetRepo, e.spec, pushConf)
+	opts, err := buildCommitOpts(e.targetRepo, e.spec, e.base.Rev, pushConf)
 	if err != nil {
 		return err
 	}
+	if mcss, ok := css.(sources.CommitModifyingChangesetSource); ok {
+		mcss.ModifyCommitOpts(e.targetRepo, e.spec, &opts)
+	}
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
@@ -230,7 +249,7 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 	cs := &sources.Changeset{
 		Title:      e.spec.Title,
 		Body:       body,
-		BaseRef:    e.spec.BaseRef,
+		BaseRef:    e.base.Ref,
 		HeadRef:    e.spec.HeadRef,
 		RemoteRepo: remoteRepo,
 		TargetRepo: e.targetRepo,
@@ -272,6 +291,11 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 			}
 		}
 	}
+
+	if err := e.assignChangeset(ctx, css, cs); err != nil {
+		return err
+	}
+
 	// Set the changeset to published.
 	e.ch.PublicationState = btypes.ChangesetPublicationStatePublished
 	return nil
@@ -348,7 +372,7 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 	cs := sources.Changeset{
 		Title:      e.spec.Title,
 		Body:       body,
-		BaseRef:    e.spec.BaseRef,
+		BaseRef:    e.base.Ref,
 		HeadRef:    e.spec.HeadRef,
 		RemoteRepo: remoteRepo,
 		TargetRepo: e.targetRepo,
@@ -357,14 +381,37 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 
 	if err := css.UpdateChangeset(ctx, &cs); err != nil {
 		if errcode.IsArchived(err) {
-			if err := e.handleArchivedRepo(ctx); err != nil {
-				return err
-			}
-		} else {
-			return errors.Wrap(err, "updating changeset")
+			return e.handleArchivedRepo(ctx)
 		}
+		return errors.Wrap(err, "updating changeset")
 	}
 
+	return e.assignChangeset(ctx, css, &cs)
+}
+
+// assignChangeset adds the reviewers, assignees and labels of the changeset
+// spec that are supported by the code host to the changeset.
+func (e *executor) assignChangeset(ctx context.Context, css sources.ChangesetSource, cs *sources.Changeset) error {
+	if e.ch.SupportsReviewers() {
+		cs.Reviewers = e.spec.Reviewers
+	}
+	if e.ch.SupportsAssignees() {
+		cs.Assignees = e.spec.Assignees
+	}
+	if e.ch.SupportsLabels() {
+		cs.Labels = e.spec.Labels
+	}
+	if len(cs.Reviewers) == 0 && len(cs.Assignees) == 0 && len(cs.Labels) == 0 {
+		return nil
+	}
+
+	assignableCss, err := sources.ToAssignableChangesetSource(css)
+	if err != nil {
+		return err
+	}
+	if err := assignableCss.AssignChangeset(ctx, cs); err != nil {
+		return errors.Wrap(err, "assigning changeset")
+	}
 	return nil
 }
 
@@ -383,7 +430,7 @@ func (e *executor) reopenChangeset(ctx context.Context) (err error) {
 	cs := sources.Changeset{
 		Title:      e.spec.Title,
 		Body:       e.spec.Body,
-		BaseRef:    e.spec.BaseRef,
+		BaseRef:    e.base.Ref,
 		HeadRef:    e.spec.HeadRef,
 		RemoteRepo: remoteRepo,
 		TargetRepo: e.targetRepo,
@@ -475,7 +522,7 @@ func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	cs := &sources.Changeset{
 		Title:      e.spec.Title,
 		Body:       e.spec.Body,
-		BaseRef:    e.spec.BaseRef,
+		BaseRef:    e.base.Ref,
 		HeadRef:    e.spec.HeadRef,
 		RemoteRepo: remoteRepo,
 		TargetRepo: e.targetRepo,
@@ -614,10 +661,10 @@ func handleArchivedRepo(
 	return nil
 }
 
-func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
+func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, baseRev string, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
 	opts = protocol.CreateCommitFromPatchRequest{
 		Repo:       repo.Name,
-		BaseCommit: api.CommitID(spec.BaseRev),
+		BaseCommit: api.CommitID(baseRev),
 		// IMPORTANT: We add a trailing newline here, otherwise `git apply`
 		// will fail with "corrupt patch at line <N>" where N is the last line.
 		Patch:     string(spec.Diff) + "\n",
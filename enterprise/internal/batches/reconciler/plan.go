@@ -98,6 +98,10 @@ type Plan struct {
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// The base the changeset is pushed onto and proposed against. If it's
+	// nil, the base of the ChangesetSpec is used.
+	Base *stackBase
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
@@ -294,6 +298,18 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 		delta.BaseRefChanged = true
 	}
 
+	// Reviewers, assignees and labels are only ever added to the changeset on
+	// the code host, so we only need to update it when new ones were added.
+	if hasAddedEntries(previous.Reviewers, current.Reviewers) {
+		delta.ReviewersChanged = true
+	}
+	if hasAddedEntries(previous.Assignees, current.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if hasAddedEntries(previous.Labels, current.Labels) {
+		delta.LabelsChanged = true
+	}
+
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
 	previousCalc := calculatePublicationState(previous.Published, uiPublicationState)
@@ -333,6 +349,21 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	return delta, nil
 }
 
+// hasAddedEntries returns whether current contains entries that are not in
+// previous.
+func hasAddedEntries(previous, current []string) bool {
+	seen := make(map[string]struct{}, len(previous))
+	for _, p := range previous {
+		seen[p] = struct{}{}
+	}
+	for _, c := range current {
+		if _, ok := seen[c]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
 type ChangesetSpecDelta struct {
 	TitleChanged         bool
 	BodyChanged          bool
@@ -342,6 +373,9 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	AssigneesChanged     bool
+	LabelsChanged        bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
@@ -351,7 +385,7 @@ func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged || d.ReviewersChanged || d.AssigneesChanged || d.LabelsChanged
 }
 
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
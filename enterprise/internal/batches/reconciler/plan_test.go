@@ -229,6 +229,25 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			// We expect a no-op here.
 			wantOperations: Operations{},
 		},
+		{
+			name:         "reviewers added on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels removed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"automation", "cleanup"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"automation"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			// Labels are never removed from changesets, so this is a no-op.
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
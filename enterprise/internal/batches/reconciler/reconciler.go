@@ -80,9 +80,21 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return err
 	}
 
+	// Stacked changesets are based on the changeset they depend on, which can
+	// require additional operations.
+	if err := r.planStack(ctx, tx, plan); err != nil {
+		if err == errStackDependencyNotPublished {
+			// Requeue the changeset without consuming one of its retries, since
+			// the changeset it depends on can take any time to be published.
+			logger.Info("Reconciler waiting for changeset dependency", log.Int64("changeset", ch.ID))
+			return tx.RequeueChangeset(ctx, ch, tx.Clock()().Add(stackDependencyWaitDelay))
+		}
+		return err
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
-	return executePlan(
+	if err := executePlan(
 		ctx,
 		logger,
 		r.client,
@@ -90,7 +102,11 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		r.noSleepBeforeSync,
 		tx,
 		plan,
-	)
+	); err != nil {
+		return err
+	}
+
+	return requeueIfStackBaseChanged(ctx, tx, plan)
 }
 
 func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changeset) (prev, curr *btypes.ChangesetSpec, err error) {
@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log/logtest"
+
 	stesting "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/testing"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
@@ -14,11 +16,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 func TestReconcilerProcess_IntegrationTest(t *testing.T) {
@@ -175,3 +180,91 @@ func TestReconcilerProcess_IntegrationTest(t *testing.T) {
 		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
 	}
 }
+
+func TestReconcilerProcess_StackDependencyNotPublished(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	clock := &bt.TestClock{Time: timeutil.Now()}
+	s := store.NewWithClock(db, &observation.TestContext, nil, clock.Now)
+	workerStore := store.NewReconcilerWorkerStore(db.Handle(), &observation.TestContext)
+
+	admin := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "stacked", admin.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "stacked", admin.ID, batchSpec.ID)
+
+	createChangeset := func(headRef, dependsOn string, published bool, state btypes.ReconcilerState) *btypes.Changeset {
+		spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+			User:      admin.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   headRef,
+			DependsOn: dependsOn,
+			Published: published,
+			Typ:       btypes.ChangesetSpecTypeBranch,
+		})
+		return bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalBranch:      headRef,
+			CurrentSpec:         spec.ID,
+			BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			OwnedByBatchChange:  batchChange.ID,
+			PublicationState:    btypes.ChangesetPublicationStateUnpublished,
+			ReconcilerState:     state,
+		})
+	}
+
+	createChangeset("refs/heads/part-1", "", false, btypes.ReconcilerStateCompleted)
+	changeset := createChangeset("refs/heads/part-2", "refs/heads/part-1", true, btypes.ReconcilerStateQueued)
+
+	handler := New(nil, nil, s).HandlerFunc()
+
+	// The changeset it depends on takes longer to be published than the 60
+	// retries of the reconciler worker store, which must not fail the
+	// changeset.
+	for i := 0; i < 100; i++ {
+		record, ok, err := workerStore.Dequeue(ctx, "test", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || record.ID != changeset.ID {
+			t.Fatalf("attempt %d: changeset not dequeued", i)
+		}
+
+		if err := handler(ctx, logger, record); err != nil {
+			t.Fatalf("attempt %d: unexpected error: %s", i, err)
+		}
+		if marked, err := workerStore.MarkComplete(ctx, record.RecordID(), dbworkerstore.MarkFinalOptions{}); err != nil {
+			t.Fatal(err)
+		} else if marked {
+			t.Fatalf("attempt %d: changeset marked as complete", i)
+		}
+
+		have, err := s.GetChangesetByID(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ReconcilerState != btypes.ReconcilerStateQueued {
+			t.Fatalf("attempt %d: wrong reconciler state. want=%s, have=%s", i, btypes.ReconcilerStateQueued, have.ReconcilerState)
+		}
+		if have.NumFailures != 0 {
+			t.Fatalf("attempt %d: wrong number of failures. want=%d, have=%d", i, 0, have.NumFailures)
+		}
+		if want := clock.Now().Add(stackDependencyWaitDelay); !have.ProcessAfter.Equal(want) {
+			t.Fatalf("attempt %d: wrong process after. want=%s, have=%s", i, want, have.ProcessAfter)
+		}
+
+		// Skip the delay.
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE changesets SET process_after = NULL WHERE id = %s", changeset.ID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// errStackDependencyNotPublished is returned when a stacked changeset needs
+// to be pushed before the changeset it depends on has been published. Waiting
+// isn't a failure: the changeset is requeued after stackDependencyWaitDelay,
+// and it's enqueued again once the changeset it depends on has been published.
+var errStackDependencyNotPublished = errors.New("waiting for the changeset this changeset depends on to be published")
+
+// stackDependencyWaitDelay is how long a stacked changeset waits before it's
+// processed again when the changeset it depends on hasn't been published yet.
+const stackDependencyWaitDelay = 1 * time.Minute
+
+// stackBase is the ref and revision a changeset is based on.
+type stackBase struct {
+	Ref string
+	Rev string
+
+	// Waiting is true if the changeset depends on a changeset that hasn't
+	// been published yet, in which case Ref and Rev are empty.
+	Waiting bool
+}
+
+type getChangesetter interface {
+	GetChangeset(ctx context.Context, opts store.GetChangesetOpts) (*btypes.Changeset, error)
+}
+
+// loadStackBase returns the base of the given changeset. Changesets whose spec
+// depends on another changeset of the same batch change are based on the
+// branch of that changeset while it's open, and on the base of their spec once
+// it's merged or closed.
+func loadStackBase(ctx context.Context, tx getChangesetter, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (*stackBase, error) {
+	base := &stackBase{Ref: spec.BaseRef, Rev: spec.BaseRev}
+	if spec.DependsOn == "" || !btypes.ExternalServiceSupports(ch.ExternalServiceType, btypes.CodehostCapabilityStackedChangesets) {
+		return base, nil
+	}
+
+	dep, err := tx.GetChangeset(ctx, store.GetChangesetOpts{
+		RepoID:               ch.RepoID,
+		ExternalServiceType:  ch.ExternalServiceType,
+		ExternalBranch:       spec.DependsOn,
+		OwnedByBatchChangeID: ch.OwnedByBatchChangeID,
+	})
+	if err != nil && err != store.ErrNoResults {
+		return nil, err
+	}
+	if dep == nil || !dep.Published() {
+		return &stackBase{Waiting: true}, nil
+	}
+
+	// Once the changeset we depend on is merged or closed, we're rebased onto
+	// the base branch. The same goes for changesets that were pushed to a
+	// fork, since we can't propose changes against a branch in a fork.
+	if !dep.HasDiff() || dep.ExternalForkNamespace != "" {
+		return base, nil
+	}
+
+	rev := dep.SyncState.HeadRefOid
+	if rev == "" {
+		return &stackBase{Waiting: true}, nil
+	}
+	return &stackBase{Ref: dep.ExternalBranch, Rev: rev}, nil
+}
+
+// planStack sets the base of the plan and, for stacked changesets, adds the
+// operations to rebase and retarget the changeset when the changeset it
+// depends on was updated, merged or closed.
+func (r *Reconciler) planStack(ctx context.Context, tx *store.Store, pl *Plan) error {
+	if pl.ChangesetSpec == nil {
+		return nil
+	}
+
+	base, err := loadStackBase(ctx, tx, pl.Changeset, pl.ChangesetSpec)
+	if err != nil {
+		return err
+	}
+	pl.Base = base
+
+	if pl.ChangesetSpec.DependsOn == "" || base.Waiting || !wantsStackUpdate(pl) {
+		return addStackOps(pl, "", "")
+	}
+
+	ch := pl.Changeset
+	currentRef, err := ch.BaseRef()
+	if err != nil {
+		return err
+	}
+
+	// The changeset is based on the parent of its head commit, which we need
+	// to compare with the revision it should be based on.
+	var currentRev string
+	if ch.SyncState.HeadRefOid != "" {
+		repo, err := tx.Repos().Get(ctx, ch.RepoID)
+		if err != nil {
+			return errors.Wrap(err, "failed to load repository")
+		}
+		parent, err := r.client.ResolveRevision(ctx, repo.Name, ch.SyncState.HeadRefOid+"^", gitserver.ResolveRevisionOptions{})
+		if err != nil {
+			return errors.Wrap(err, "resolving base revision of changeset")
+		}
+		currentRev = string(parent)
+	}
+
+	return addStackOps(pl, currentRef, currentRev)
+}
+
+// requeueIfStackBaseChanged requeues the changeset in the plan if the base it
+// was processed with is outdated. Changesets that are being processed aren't
+// enqueued when the changeset they depend on changes, so this is checked
+// again once the plan has been executed.
+func requeueIfStackBaseChanged(ctx context.Context, tx *store.Store, pl *Plan) error {
+	if pl.Base == nil || pl.ChangesetSpec == nil || pl.ChangesetSpec.DependsOn == "" {
+		return nil
+	}
+
+	base, err := loadStackBase(ctx, tx, pl.Changeset, pl.ChangesetSpec)
+	if err != nil {
+		return err
+	}
+	if *base == *pl.Base {
+		return nil
+	}
+	return tx.RequeueChangeset(ctx, pl.Changeset, tx.Clock()())
+}
+
+// wantsStackUpdate returns whether the changeset in the plan is open on the
+// code host and stays that way after the plan has been executed.
+func wantsStackUpdate(pl *Plan) bool {
+	ch := pl.Changeset
+	if !ch.Published() || !ch.HasDiff() || ch.Closing {
+		return false
+	}
+	for _, op := range pl.Ops {
+		switch op {
+		case btypes.ReconcilerOperationClose, btypes.ReconcilerOperationDetach, btypes.ReconcilerOperationArchive:
+			return false
+		}
+	}
+	return true
+}
+
+// addStackOps adds the operations needed to move the changeset in the plan
+// onto its base, given the ref and revision it's currently based on. Empty
+// current values are treated as up to date.
+func addStackOps(pl *Plan, currentRef, currentRev string) error {
+	if pl.Base.Waiting {
+		for _, op := range pl.Ops {
+			if op == btypes.ReconcilerOperationPush {
+				return errStackDependencyNotPublished
+			}
+		}
+		return nil
+	}
+
+	retarget := currentRef != "" && currentRef != pl.Base.Ref
+	rebase := currentRev != "" && currentRev != pl.Base.Rev
+	if retarget || rebase {
+		pl.AddOp(btypes.ReconcilerOperationPush)
+		pl.AddOp(btypes.ReconcilerOperationUpdate)
+	}
+	return nil
+}
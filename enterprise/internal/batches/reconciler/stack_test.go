@@ -0,0 +1,188 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestLoadStackBase(t *testing.T) {
+	ctx := context.Background()
+
+	ch := &btypes.Changeset{
+		RepoID:               1,
+		ExternalServiceType:  extsvc.TypeGitHub,
+		OwnedByBatchChangeID: 2,
+	}
+	spec := &btypes.ChangesetSpec{
+		BaseRef:   "refs/heads/main",
+		BaseRev:   "d34db33f",
+		HeadRef:   "refs/heads/part-2",
+		DependsOn: "refs/heads/part-1",
+	}
+	specBase := &stackBase{Ref: "refs/heads/main", Rev: "d34db33f"}
+
+	dependency := func(publication btypes.ChangesetPublicationState, state btypes.ChangesetExternalState, headRefOid string) *btypes.Changeset {
+		return &btypes.Changeset{
+			ExternalBranch:   "refs/heads/part-1",
+			PublicationState: publication,
+			ExternalState:    state,
+			SyncState:        btypes.ChangesetSyncState{HeadRefOid: headRefOid},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		ch   *btypes.Changeset
+		spec *btypes.ChangesetSpec
+		dep  *btypes.Changeset
+		want *stackBase
+	}{
+		"not stacked": {
+			ch:   ch,
+			spec: &btypes.ChangesetSpec{BaseRef: "refs/heads/main", BaseRev: "d34db33f"},
+			want: specBase,
+		},
+		"code host without stacking": {
+			ch:   &btypes.Changeset{ExternalServiceType: extsvc.TypeGerrit},
+			spec: spec,
+			want: specBase,
+		},
+		"dependency missing": {
+			ch:   ch,
+			spec: spec,
+			want: &stackBase{Waiting: true},
+		},
+		"dependency unpublished": {
+			ch:   ch,
+			spec: spec,
+			dep:  dependency(btypes.ChangesetPublicationStateUnpublished, "", ""),
+			want: &stackBase{Waiting: true},
+		},
+		"dependency not synced": {
+			ch:   ch,
+			spec: spec,
+			dep:  dependency(btypes.ChangesetPublicationStatePublished, btypes.ChangesetExternalStateOpen, ""),
+			want: &stackBase{Waiting: true},
+		},
+		"dependency open": {
+			ch:   ch,
+			spec: spec,
+			dep:  dependency(btypes.ChangesetPublicationStatePublished, btypes.ChangesetExternalStateOpen, "c0ff33"),
+			want: &stackBase{Ref: "refs/heads/part-1", Rev: "c0ff33"},
+		},
+		"dependency draft": {
+			ch:   ch,
+			spec: spec,
+			dep:  dependency(btypes.ChangesetPublicationStatePublished, btypes.ChangesetExternalStateDraft, "c0ff33"),
+			want: &stackBase{Ref: "refs/heads/part-1", Rev: "c0ff33"},
+		},
+		"dependency merged": {
+			ch:   ch,
+			spec: spec,
+			dep:  dependency(btypes.ChangesetPublicationStatePublished, btypes.ChangesetExternalStateMerged, "c0ff33"),
+			want: specBase,
+		},
+		"dependency closed": {
+			ch:   ch,
+			spec: spec,
+			dep:  dependency(btypes.ChangesetPublicationStatePublished, btypes.ChangesetExternalStateClosed, "c0ff33"),
+			want: specBase,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var haveOpts store.GetChangesetOpts
+			tx := &FakeStore{
+				GetChangesetMock: func(_ context.Context, opts store.GetChangesetOpts) (*btypes.Changeset, error) {
+					haveOpts = opts
+					if tc.dep == nil {
+						return nil, store.ErrNoResults
+					}
+					return tc.dep, nil
+				},
+			}
+
+			have, err := loadStackBase(ctx, tx, tc.ch, tc.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("wrong base (-want +have):\n%s", diff)
+			}
+
+			if haveOpts != (store.GetChangesetOpts{}) {
+				wantOpts := store.GetChangesetOpts{
+					RepoID:               ch.RepoID,
+					ExternalServiceType:  ch.ExternalServiceType,
+					ExternalBranch:       spec.DependsOn,
+					OwnedByBatchChangeID: ch.OwnedByBatchChangeID,
+				}
+				if diff := cmp.Diff(wantOpts, haveOpts); diff != "" {
+					t.Errorf("wrong opts (-want +have):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestAddStackOps(t *testing.T) {
+	base := &stackBase{Ref: "refs/heads/part-1", Rev: "c0ff33"}
+
+	for name, tc := range map[string]struct {
+		base       *stackBase
+		ops        Operations
+		currentRef string
+		currentRev string
+		wantOps    Operations
+		wantErr    error
+	}{
+		"up to date": {
+			base:       base,
+			currentRef: "refs/heads/part-1",
+			currentRev: "c0ff33",
+		},
+		"unknown current base": {
+			base:    base,
+			ops:     Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOps: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+		},
+		"dependency updated": {
+			base:       base,
+			currentRef: "refs/heads/part-1",
+			currentRev: "d34db33f",
+			wantOps:    Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+		},
+		"dependency merged": {
+			base:       &stackBase{Ref: "refs/heads/main", Rev: "d34db33f"},
+			currentRef: "refs/heads/part-1",
+			currentRev: "c0ff33",
+			wantOps:    Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+		},
+		"waiting without push": {
+			base:    &stackBase{Waiting: true},
+			ops:     Operations{btypes.ReconcilerOperationUndraft},
+			wantOps: Operations{btypes.ReconcilerOperationUndraft},
+		},
+		"waiting with push": {
+			base:    &stackBase{Waiting: true},
+			ops:     Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOps: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantErr: errStackDependencyNotPublished,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pl := &Plan{Ops: tc.ops, Base: tc.base}
+
+			if err := addStackOps(pl, tc.currentRef, tc.currentRev); err != tc.wantErr {
+				t.Fatalf("wrong error. want=%v, have=%v", tc.wantErr, err)
+			}
+			if !pl.Ops.Equal(tc.wantOps) {
+				t.Errorf("wrong operations. want=%s, have=%s", tc.wantOps, pl.Ops)
+			}
+		})
+	}
+}
@@ -1561,6 +1561,12 @@ func (s *Service) RetryBatchSpecExecution(ctx context.Context, opts RetryBatchSp
 	return nil
 }
 
+// supportsAssignment returns whether reviewers, assignees or labels can be
+// added to the given changeset on its code host.
+func supportsAssignment(changeset *btypes.Changeset) bool {
+	return changeset.SupportsReviewers() || changeset.SupportsAssignees() || changeset.SupportsLabels()
+}
+
 type GetAvailableBulkOperationsOpts struct {
 	BatchChange int64
 	Changesets  []int64
@@ -1576,6 +1582,7 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		btypes.ChangesetJobTypeMerge:     0,
 		btypes.ChangesetJobTypePublish:   0,
 		btypes.ChangesetJobTypeReenqueue: 0,
+		btypes.ChangesetJobTypeAssign:    0,
 	}
 
 	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
@@ -1637,6 +1644,11 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		if isChangesetCommentable {
 			bulkOperationsCounter[btypes.ChangesetJobTypeComment] += 1
 		}
+
+		// ASSIGN
+		if !isChangesetArchived && (isChangesetOpen || isChangesetDraft) && supportsAssignment(changeset) {
+			bulkOperationsCounter[btypes.ChangesetJobTypeAssign] += 1
+		}
 	}
 
 	noOfChangesets := len(opts.Changesets)
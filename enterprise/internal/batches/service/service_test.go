@@ -2546,7 +2546,7 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"CLOSE", "COMMENT", "PUBLISH"}
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "PUBLISH", "ASSIGN"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -2572,6 +2572,33 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH", "ASSIGN"}
+			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
+				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
+			}
+		})
+
+		t.Run("open changesets on code host without assignment support", func(t *testing.T) {
+			changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+				Repo:                rs[0].ID,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				BatchChange:         batchChange.ID,
+				OwnedByBatchChange:  batchChange.ID,
+				ExternalState:       btypes.ChangesetExternalStateOpen,
+				ExternalServiceType: extsvc.TypeBitbucketCloud,
+			})
+
+			bulkOperations, err := svc.GetAvailableBulkOperations(ctx, GetAvailableBulkOperationsOpts{
+				Changesets: []int64{
+					changeset.ID,
+				},
+				BatchChange: batchChange.ID,
+			})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
@@ -2666,7 +2693,7 @@ changesetTemplate:
 			})
 
 			assert.NoError(t, err)
-			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE"}
+			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE", "ASSIGN"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
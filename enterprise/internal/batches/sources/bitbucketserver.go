@@ -23,7 +23,10 @@ type BitbucketServerSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = BitbucketServerSource{}
+var (
+	_ ForkableChangesetSource   = BitbucketServerSource{}
+	_ AssignableChangesetSource = BitbucketServerSource{}
+)
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -261,6 +264,28 @@ func (s BitbucketServerSource) CreateComment(ctx context.Context, c *Changeset,
 	return err
 }
 
+// AssignChangeset adds the reviewers set on the Changeset to the pull
+// request. Bitbucket Server doesn't support assignees and labels, so they are
+// ignored.
+func (s BitbucketServerSource) AssignChangeset(ctx context.Context, c *Changeset) error {
+	if len(c.Reviewers) == 0 {
+		return nil
+	}
+
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	for _, reviewer := range c.Reviewers {
+		if err := s.client.AddPullRequestReviewer(ctx, pr, reviewer); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", reviewer)
+		}
+	}
+
+	return s.LoadChangeset(ctx, c)
+}
+
 // MergeChangeset merges a Changeset on the code host, if in a mergeable state.
 // The squash parameter is ignored, as Bitbucket Server does not support
 // squash merges.
@@ -48,6 +48,29 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A CommitModifyingChangesetSource needs to modify the commit that is created
+// for a changeset, and where it is pushed to, because the code host does not
+// create changesets from branches.
+type CommitModifyingChangesetSource interface {
+	ChangesetSource
+
+	// ModifyCommitOpts updates the options used to create the commit of the
+	// changeset described by the given spec in the given target repo, and to
+	// push it to the code host.
+	ModifyCommitOpts(targetRepo *types.Repo, spec *btypes.ChangesetSpec, opts *protocol.CreateCommitFromPatchRequest)
+}
+
+// An AssignableChangesetSource can request reviews on changesets, assign
+// users to them and label them.
+type AssignableChangesetSource interface {
+	ChangesetSource
+
+	// AssignChangeset adds the reviewers, assignees and labels set on the
+	// Changeset to the changeset on the code host. Existing reviewers,
+	// assignees and labels are kept.
+	AssignChangeset(context.Context, *Changeset) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -128,6 +151,12 @@ type Changeset struct {
 	// opened.
 	TargetRepo *types.Repo
 
+	// Reviewers, Assignees and Labels are added to the changeset by
+	// AssignableChangesetSources.
+	Reviewers []string
+	Assignees []string
+	Labels    []string
+
 	*btypes.Changeset
 }
 
@@ -0,0 +1,279 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ DraftChangesetSource           = GerritSource{}
+	_ CommitModifyingChangesetSource = GerritSource{}
+	_ AssignableChangesetSource      = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	var ba *auth.BasicAuth
+	switch av := a.(type) {
+	case *auth.BasicAuth:
+		ba = av
+	case *auth.BasicAuthWithSSH:
+		ba = &av.BasicAuth
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(ba)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+//
+// Gerrit creates changes when commits are pushed to refs/for/<branch>, so the
+// change was already created when the commit was pushed, and this only loads
+// it.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.client.GetChange(ctx, gerritChangeID(cs.TargetRepo, cs.HeadRef))
+	if err != nil {
+		return false, errors.Wrap(err, "getting change")
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	// Pushing a new patch set to an existing change doesn't create a new
+	// change, and we can't tell whether the change existed before the push,
+	// so we say it did and go through the IsOutdated check regardless.
+	return true, nil
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode, which on Gerrit means the change is marked as work in progress.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	exists, err := s.CreateChangeset(ctx, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	if err := s.client.SetWorkInProgress(ctx, cs.ExternalID); err != nil {
+		return exists, errors.Wrap(err, "marking change as work in progress")
+	}
+
+	return exists, s.LoadChangeset(ctx, cs)
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.SetReadyForReview(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	updated, err := s.client.AbandonChange(ctx, cs.ExternalID)
+	if err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.setChangesetMetadata(updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+//
+// The title and body of a change are taken from the commit message of its
+// current patch set, which was already updated when the new patch set was
+// pushed, so this only reloads the change.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	if change, ok := cs.Metadata.(*gerritbatches.AnnotatedChange); ok && change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	updated, err := s.client.RestoreChange(ctx, cs.ExternalID)
+	if err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.setChangesetMetadata(updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.SetReview(ctx, cs.ExternalID, gerrit.ReviewInput{
+		Message: comment,
+	})
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit submits changes according to the submit type of the project, so
+// squash is ignored. If the changeset cannot be merged, because it is in an
+// unmergeable state, ChangesetNotMergeableError is returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	updated, err := s.client.SubmitChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errors.Is(err, gerrit.ErrNotMergeable) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "submitting change")
+	}
+
+	return s.setChangesetMetadata(updated, cs)
+}
+
+// AssignChangeset adds the reviewers set on the Changeset to the change.
+// Gerrit doesn't support assignees and labels in the sense of other code
+// hosts, so they are ignored.
+func (s GerritSource) AssignChangeset(ctx context.Context, cs *Changeset) error {
+	if len(cs.Reviewers) == 0 {
+		return nil
+	}
+
+	for _, reviewer := range cs.Reviewers {
+		if err := s.client.AddReviewer(ctx, cs.ExternalID, reviewer); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", reviewer)
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ModifyCommitOpts pushes the commit to refs/for/<base branch>, which creates
+// a change or a new patch set of an existing change on Gerrit, and adds the
+// Change-Id trailer that identifies the change to the commit message.
+func (s GerritSource) ModifyCommitOpts(targetRepo *types.Repo, spec *btypes.ChangesetSpec, opts *protocol.CreateCommitFromPatchRequest) {
+	pushRef := "refs/for/" + gitdomain.AbbreviateRef(spec.BaseRef)
+	opts.PushRef = &pushRef
+	opts.CommitInfo.Message = gerritCommitMessage(opts.CommitInfo.Message, gerritChangeID(targetRepo, spec.HeadRef))
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	u := *s.client.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + fmt.Sprintf("/c/%s/+/%d", url.PathEscape(change.Project), change.Number)
+
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change: change,
+		URL:    u.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// gerritChangeID returns the Change-Id of the change created for the given
+// head ref in the given repo. It's derived from both, so that pushing a new
+// commit for the same changeset creates a new patch set of the same change.
+func gerritChangeID(repo *types.Repo, headRef string) string {
+	h := sha1.New()
+	h.Write([]byte(repo.ExternalRepo.ID))
+	h.Write([]byte{0})
+	h.Write([]byte(headRef))
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// gerritCommitMessage returns the given commit message with the given
+// Change-Id trailer, replacing any Change-Id lines already in the message.
+func gerritCommitMessage(message, changeID string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		if strings.HasPrefix(line, "Change-Id:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	message = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+
+	// Gerrit only reads the trailer from the last paragraph of the message.
+	return message + "\n\nChange-Id: " + changeID + "\n"
+}
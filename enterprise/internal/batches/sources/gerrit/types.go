@@ -0,0 +1,13 @@
+package gerrit
+
+import "github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// URL is the URL of the change in the Gerrit web UI, which is not part of
+	// the Change returned by the API.
+	URL string
+}
@@ -0,0 +1,246 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid", func(t *testing.T) {
+		s, err := NewGerritSource(ctx, &types.ExternalService{
+			Config: extsvc.NewUnencryptedConfig("invalid JSON"),
+		}, nil)
+		assert.Nil(t, s)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewGerritSource(ctx, &types.ExternalService{
+			Config: extsvc.NewUnencryptedConfig(`{"url": "https://gerrit.example.com/"}`),
+		}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s := mockGerritSource(t, "https://gerrit.example.com/")
+
+	for name, a := range map[string]auth.Authenticator{
+		"BasicAuth":        &auth.BasicAuth{Username: "user", Password: "pass"},
+		"BasicAuthWithSSH": &auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			css, err := s.WithAuthenticator(a)
+			require.NoError(t, err)
+			assert.Equal(t, &auth.BasicAuth{Username: "user", Password: "pass"}, css.(*GerritSource).client.Authenticator())
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := s.WithAuthenticator(&auth.OAuthBearerToken{Token: "token"})
+		assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+	})
+}
+
+func TestGerritSource_Changesets(t *testing.T) {
+	repo := &types.Repo{
+		Name:         "gerrit.example.com/repo",
+		ExternalRepo: api.ExternalRepoSpec{ID: "repo", ServiceType: extsvc.TypeGerrit},
+	}
+	changeID := gerritChangeID(repo, "refs/heads/my-branch")
+
+	status := gerrit.ChangeStatusNew
+	wip := false
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+
+		action := strings.TrimPrefix(r.URL.Path, "/a/changes/"+changeID)
+		if action == r.URL.Path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch action {
+		case "":
+		case "/abandon":
+			status = gerrit.ChangeStatusAbandoned
+		case "/restore":
+			status = gerrit.ChangeStatusNew
+		case "/submit":
+			if wip {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			status = gerrit.ChangeStatusMerged
+		case "/wip":
+			wip = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "/ready":
+			wip = false
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "/revisions/current/review":
+			_, _ = io.WriteString(w, ")]}'\n{}")
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, ")]}'\n"+`{"project": "repo", "branch": "main", "change_id": %q, "subject": "Fix it", "status": %q, "_number": 42, "work_in_progress": %t}`, changeID, status, wip)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	s := mockGerritSource(t, srv.URL+"/")
+	cs := &Changeset{
+		HeadRef:    "refs/heads/my-branch",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+
+	exists, err := s.CreateDraftChangeset(ctx, cs)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, changeID, cs.ExternalID)
+	assert.Equal(t, srv.URL+"/c/repo/+/42", cs.Metadata.(*gerritbatches.AnnotatedChange).URL)
+	assert.True(t, cs.Metadata.(*gerritbatches.AnnotatedChange).WorkInProgress)
+
+	err = s.MergeChangeset(ctx, cs, false)
+	assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+
+	require.NoError(t, s.UndraftChangeset(ctx, cs))
+	assert.False(t, cs.Metadata.(*gerritbatches.AnnotatedChange).WorkInProgress)
+
+	require.NoError(t, s.CreateComment(ctx, cs, "hello"))
+
+	require.NoError(t, s.CloseChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusAbandoned, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+
+	require.NoError(t, s.ReopenChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusNew, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+
+	// Reopening an open change is a noop.
+	n := len(requests)
+	require.NoError(t, s.ReopenChangeset(ctx, cs))
+	assert.Len(t, requests, n)
+
+	require.NoError(t, s.MergeChangeset(ctx, cs, true))
+	assert.Equal(t, gerrit.ChangeStatusMerged, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+
+	assert.Contains(t, requests, `POST /a/changes/`+changeID+`/revisions/current/review {"message":"hello"}`)
+
+	missing := &Changeset{Changeset: &btypes.Changeset{ExternalID: "Imissing"}}
+	assert.ErrorAs(t, s.LoadChangeset(ctx, missing), &ChangesetNotFoundError{})
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a/changes/Iconflict/submit":
+			w.WriteHeader(http.StatusConflict)
+		case "/a/changes/Iforbidden/submit":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	s := mockGerritSource(t, srv.URL+"/")
+
+	t.Run("conflict", func(t *testing.T) {
+		cs := &Changeset{Changeset: &btypes.Changeset{ExternalID: "Iconflict"}}
+		assert.ErrorAs(t, s.MergeChangeset(ctx, cs, false), &ChangesetNotMergeableError{})
+	})
+
+	for _, changeID := range []string{"Iforbidden", "Imissing"} {
+		t.Run(changeID, func(t *testing.T) {
+			cs := &Changeset{Changeset: &btypes.Changeset{ExternalID: changeID}}
+			err := s.MergeChangeset(ctx, cs, false)
+			require.Error(t, err)
+			assert.False(t, errors.As(err, &ChangesetNotMergeableError{}))
+		})
+	}
+}
+
+func TestGerritSource_ModifyCommitOpts(t *testing.T) {
+	s := mockGerritSource(t, "https://gerrit.example.com/")
+	repo := &types.Repo{ExternalRepo: api.ExternalRepoSpec{ID: "repo"}}
+	spec := &btypes.ChangesetSpec{BaseRef: "refs/heads/main", HeadRef: "refs/heads/my-branch"}
+
+	opts := protocol.CreateCommitFromPatchRequest{
+		TargetRef:  spec.HeadRef,
+		CommitInfo: protocol.PatchCommitInfo{Message: "Fix it\n\nChange-Id: I0000\n"},
+	}
+	s.ModifyCommitOpts(repo, spec, &opts)
+
+	changeID := gerritChangeID(repo, spec.HeadRef)
+	require.NotNil(t, opts.PushRef)
+	assert.Equal(t, "refs/for/main", *opts.PushRef)
+	assert.Equal(t, "Fix it\n\nChange-Id: "+changeID+"\n", opts.CommitInfo.Message)
+
+	// The Change-Id is stable for the same changeset and differs between
+	// changesets.
+	assert.Equal(t, changeID, gerritChangeID(repo, spec.HeadRef))
+	assert.NotEqual(t, changeID, gerritChangeID(repo, "refs/heads/other-branch"))
+	assert.NotEqual(t, changeID, gerritChangeID(&types.Repo{ExternalRepo: api.ExternalRepoSpec{ID: "other"}}, spec.HeadRef))
+}
+
+func TestGerritCommitMessage(t *testing.T) {
+	for name, tc := range map[string]struct {
+		message string
+		want    string
+	}{
+		"subject only": {
+			message: "Fix it",
+			want:    "Fix it\n\nChange-Id: Iabc\n",
+		},
+		"with body": {
+			message: "Fix it\n\nIt was broken.\n",
+			want:    "Fix it\n\nIt was broken.\n\nChange-Id: Iabc\n",
+		},
+		"with existing Change-Id": {
+			message: "Fix it\n\nChange-Id: Idef\nSigned-off-by: Alice <alice@example.com>\n",
+			want:    "Fix it\n\nSigned-off-by: Alice <alice@example.com>\n\nChange-Id: Iabc\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, gerritCommitMessage(tc.message, "Iabc"))
+		})
+	}
+}
+
+func mockGerritSource(t *testing.T, url string) *GerritSource {
+	t.Helper()
+
+	client, err := gerrit.NewClient("gerrit", &schema.GerritConnection{Url: url, Username: "admin", Password: "secret"}, nil)
+	require.NoError(t, err)
+	return &GerritSource{client: client}
+}
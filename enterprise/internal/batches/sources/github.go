@@ -24,7 +24,10 @@ type GithubSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = GithubSource{}
+var (
+	_ ForkableChangesetSource   = GithubSource{}
+	_ AssignableChangesetSource = GithubSource{}
+)
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -293,6 +296,41 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// AssignChangeset requests reviews from the reviewers and adds the assignees
+// and labels set on the Changeset to the pull request.
+func (s GithubSource) AssignChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "parsing repo name")
+	}
+
+	if len(c.Reviewers) > 0 {
+		if err := s.client.RequestReviewers(ctx, owner, name, pr.Number, c.Reviewers); err != nil {
+			return errors.Wrap(err, "requesting reviewers")
+		}
+	}
+	if len(c.Assignees) > 0 {
+		if err := s.client.AddAssignees(ctx, owner, name, pr.Number, c.Assignees); err != nil {
+			return errors.Wrap(err, "adding assignees")
+		}
+	}
+	if len(c.Labels) > 0 {
+		if err := s.client.AddLabels(ctx, owner, name, pr.Number, c.Labels); err != nil {
+			return errors.Wrap(err, "adding labels")
+		}
+	}
+
+	// Reload the pull request, so that the new labels are reflected in the
+	// metadata.
+	return s.LoadChangeset(ctx, c)
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
@@ -29,6 +29,7 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ AssignableChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -487,6 +488,79 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// AssignChangeset adds the reviewers, assignees and labels set on the
+// Changeset to the merge request.
+func (s *GitLabSource) AssignChangeset(ctx context.Context, c *Changeset) error {
+	if len(c.Reviewers) == 0 && len(c.Assignees) == 0 && len(c.Labels) == 0 {
+		return nil
+	}
+
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	// GitLab replaces the reviewers and assignees of a merge request with the
+	// given ones, so we need the current ones to keep them.
+	mr, err := s.client.GetMergeRequest(ctx, project, mr.IID)
+	if err != nil {
+		return errors.Wrapf(err, "retrieving merge request %s", c.ExternalID)
+	}
+
+	opts := gitlab.UpdateMergeRequestOpts{
+		AddLabels: strings.Join(c.Labels, ","),
+	}
+	if len(c.Reviewers) > 0 {
+		if opts.ReviewerIDs, err = s.userIDs(ctx, mr.Reviewers, c.Reviewers); err != nil {
+			return err
+		}
+	}
+	if len(c.Assignees) > 0 {
+		if opts.AssigneeIDs, err = s.userIDs(ctx, mr.Assignees, c.Assignees); err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// userIDs returns the IDs of the given users, followed by the IDs of the users
+// with the given usernames that aren't among them yet.
+func (s *GitLabSource) userIDs(ctx context.Context, users []gitlab.User, usernames []string) ([]int32, error) {
+	ids := make([]int32, 0, len(users)+len(usernames))
+	seen := make(map[string]struct{}, len(users)+len(usernames))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+		seen[u.Username] = struct{}{}
+	}
+
+	for _, username := range usernames {
+		if _, ok := seen[username]; ok {
+			continue
+		}
+		seen[username] = struct{}{}
+
+		u, err := s.client.GetUserByUsername(ctx, username)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up GitLab user %q", username)
+		}
+		ids = append(ids, u.ID)
+	}
+
+	return ids, nil
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -218,6 +218,16 @@ func ToDraftChangesetSource(css ChangesetSource) (DraftChangesetSource, error) {
 	return draftCss, nil
 }
 
+// ToAssignableChangesetSource returns the given ChangesetSource as an
+// AssignableChangesetSource, or an error if it doesn't implement it.
+func ToAssignableChangesetSource(css ChangesetSource) (AssignableChangesetSource, error) {
+	assignableCss, ok := css.(AssignableChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't implement AssignableChangesetSource")
+	}
+	return assignableCss, nil
+}
+
 type getBatchChanger interface {
 	GetBatchChange(ctx context.Context, opts store.GetBatchChangeOpts) (*btypes.BatchChange, error)
 }
@@ -296,7 +306,8 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 		case *schema.GitHubConnection,
 			*schema.BitbucketServerConnection,
 			*schema.GitLabConnection,
-			*schema.BitbucketCloudConnection:
+			*schema.BitbucketCloudConnection,
+			*schema.GerritConnection:
 			return e, nil
 		}
 	}
@@ -316,6 +327,8 @@ func buildChangesetSource(ctx context.Context, cf *httpcli.Factory, externalServ
 		return NewBitbucketServerSource(ctx, externalService, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(ctx, externalService, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(ctx, externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -365,6 +378,9 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeGerrit:
+		return errors.New("require username/HTTP password to push commits to Gerrit")
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -378,7 +394,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeGerrit:
 		u.User = url.UserPassword(username, password)
 
 	default:
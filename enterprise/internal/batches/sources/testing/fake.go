@@ -59,6 +59,7 @@ type FakeChangesetSource struct {
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
 	IsArchivedPushErrorCalled   bool
+	AssignChangesetCalled       bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -99,6 +100,10 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*sources.Changeset
 
+	// AssignedChangesets contains the changesets that were passed to
+	// AssignChangeset
+	AssignedChangesets []*sources.Changeset
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 
@@ -110,6 +115,7 @@ var (
 	_ sources.ChangesetSource           = &FakeChangesetSource{}
 	_ sources.ArchivableChangesetSource = &FakeChangesetSource{}
 	_ sources.DraftChangesetSource      = &FakeChangesetSource{}
+	_ sources.AssignableChangesetSource = &FakeChangesetSource{}
 )
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *sources.Changeset) (bool, error) {
@@ -296,6 +302,25 @@ func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *sources.Chan
 	return s.Err
 }
 
+func (s *FakeChangesetSource) AssignChangeset(ctx context.Context, c *sources.Changeset) error {
+	s.AssignChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.TargetRepo == nil {
+		return noReposErr{name: "target"}
+	}
+	if c.RemoteRepo == nil {
+		return noReposErr{name: "remote"}
+	}
+
+	s.AssignedChangesets = append(s.AssignedChangesets, c)
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
 func (s *FakeChangesetSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
 	return sources.GitserverPushConfig(repo, s.CurrentAuthenticator)
 }
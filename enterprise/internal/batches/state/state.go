@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -20,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -112,6 +114,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritCheckState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -238,6 +243,30 @@ func computeBitbucketCloudBuildState(lastSynced time.Time, apr *bbcs.AnnotatedPu
 	return combineCheckStates(states)
 }
 
+// computeGerritCheckState computes the check state of a Gerrit change from
+// the votes on its Verified label, which is the label CI systems vote on.
+// Gerrit doesn't send webhook events, so only the metadata is considered.
+func computeGerritCheckState(c *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := c.Labels[gerritVerifiedLabel]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	switch {
+	case label.Rejected != nil, label.Disliked != nil:
+		return btypes.ChangesetCheckStateFailed
+	case label.Approved != nil, label.Recommended != nil:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
+const (
+	gerritCodeReviewLabel = "Code-Review"
+	gerritVerifiedLabel   = "Verified"
+)
+
 func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btypes.ChangesetCheckState {
 	switch s {
 	case bitbucketcloud.PullRequestStatusStateFailed, bitbucketcloud.PullRequestStatusStateStopped:
@@ -515,6 +544,21 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -584,6 +628,21 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// A negative vote on the Code-Review label blocks the change from
+		// being submitted until it is removed, while the maximum vote
+		// approves it. A positive vote below the maximum still needs an
+		// approval.
+		label := m.Labels[gerritCodeReviewLabel]
+		switch {
+		case label.Rejected != nil, label.Disliked != nil:
+			states[btypes.ChangesetReviewStateChangesRequested] = true
+		case label.Approved != nil:
+			states[btypes.ChangesetReviewStateApproved] = true
+		default:
+			states[btypes.ChangesetReviewStatePending] = true
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
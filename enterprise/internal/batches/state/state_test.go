@@ -7,9 +7,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +409,51 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	voter := &gerrit.Account{Username: "ci"}
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.LabelInfo
+		want   btypes.ChangesetCheckState
+	}{
+		"no verified label": {
+			labels: map[string]gerrit.LabelInfo{"Code-Review": {Approved: voter}},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		"no votes": {
+			labels: map[string]gerrit.LabelInfo{"Verified": {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		"approved": {
+			labels: map[string]gerrit.LabelInfo{"Verified": {Approved: voter}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"recommended": {
+			labels: map[string]gerrit.LabelInfo{"Verified": {Recommended: voter}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"rejected": {
+			labels: map[string]gerrit.LabelInfo{"Verified": {Rejected: voter, Approved: voter}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+		"disliked": {
+			labels: map[string]gerrit.LabelInfo{"Verified": {Disliked: voter}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := computeGerritCheckState(&gerritbatches.AnnotatedChange{
+				Change: &gerrit.Change{Labels: tc.labels},
+			})
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +583,36 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Code-Review": {Approved: &gerrit.Account{Username: "alice"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - recommended is still pending",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Code-Review": {Recommended: &gerrit.Account{Username: "alice"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - disliked",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, map[string]gerrit.LabelInfo{
+				"Code-Review": {Approved: &gerrit.Account{Username: "alice"}, Disliked: &gerrit.Account{Username: "bob"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +821,30 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - new",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - work in progress",
+			changeset: setDraft(gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gerrit - merged",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "gerrit - abandoned",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
 	}
 
 	for i, tc := range tests {
@@ -900,6 +1001,19 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, labels map[string]gerrit.LabelInfo) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{
+				Status: status,
+				Labels: labels,
+			},
+		},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// changesetAutoMergeColumns are used by the changeset auto-merge related Store
+// methods to read from the database.
+var changesetAutoMergeColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_auto_merges.changeset_id"),
+	sqlf.Sprintf("changeset_auto_merges.batch_change_id"),
+	sqlf.Sprintf("changeset_auto_merges.blocked_reason"),
+	sqlf.Sprintf("changeset_auto_merges.merge_enqueued_at"),
+	sqlf.Sprintf("changeset_auto_merges.evaluated_at"),
+	sqlf.Sprintf("changeset_auto_merges.check_state"),
+	sqlf.Sprintf("changeset_auto_merges.review_state"),
+}
+
+// UpsertChangesetAutoMerge records the given evaluation of the auto-merge
+// policy of a batch change, replacing the previous evaluation for the same
+// changeset. The time the last merge was enqueued is kept if no merge was
+// enqueued this time.
+func (s *Store) UpsertChangesetAutoMerge(ctx context.Context, m *btypes.ChangesetAutoMerge) (err error) {
+	ctx, _, endObservation := s.operations.upsertChangesetAutoMerge.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(m.ChangesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if m.EvaluatedAt.IsZero() {
+		m.EvaluatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		upsertChangesetAutoMergeQueryFmtstr,
+		m.ChangesetID,
+		m.BatchChangeID,
+		dbutil.NullStringColumn(m.BlockedReason),
+		dbutil.NullTimeColumn(m.MergeEnqueuedAt),
+		m.EvaluatedAt,
+		dbutil.NullStringColumn(string(m.CheckState)),
+		dbutil.NullStringColumn(string(m.ReviewState)),
+		sqlf.Join(changesetAutoMergeColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetAutoMerge(m, sc)
+	})
+}
+
+var upsertChangesetAutoMergeQueryFmtstr = `
+INSERT INTO changeset_auto_merges (
+	changeset_id,
+	batch_change_id,
+	blocked_reason,
+	merge_enqueued_at,
+	evaluated_at,
+	check_state,
+	review_state
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (changeset_id) DO UPDATE SET
+	batch_change_id = excluded.batch_change_id,
+	blocked_reason = excluded.blocked_reason,
+	merge_enqueued_at = COALESCE(excluded.merge_enqueued_at, changeset_auto_merges.merge_enqueued_at),
+	evaluated_at = excluded.evaluated_at,
+	check_state = excluded.check_state,
+	review_state = excluded.review_state
+RETURNING %s
+`
+
+// GetChangesetAutoMerge returns the latest evaluation of the auto-merge policy
+// for the given changeset. ErrNoResults is returned if the policy has never
+// been evaluated for it.
+func (s *Store) GetChangesetAutoMerge(ctx context.Context, changesetID int64) (m *btypes.ChangesetAutoMerge, err error) {
+	ctx, _, endObservation := s.operations.getChangesetAutoMerge.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getChangesetAutoMergeQueryFmtstr,
+		sqlf.Join(changesetAutoMergeColumns, ", "),
+		changesetID,
+	)
+
+	var c btypes.ChangesetAutoMerge
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetAutoMerge(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ChangesetID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getChangesetAutoMergeQueryFmtstr = `
+SELECT %s FROM changeset_auto_merges
+WHERE changeset_id = %s
+`
+
+// CountChangesetAutoMergesEnqueuedSince returns the number of changesets of
+// the given batch change whose merge was enqueued by the auto-merge policy
+// after the given time.
+func (s *Store) CountChangesetAutoMergesEnqueuedSince(ctx context.Context, batchChangeID int64, since time.Time) (count int, err error) {
+	ctx, _, endObservation := s.operations.countChangesetAutoMergesEnqueuedSince.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countChangesetAutoMergesEnqueuedSinceQueryFmtstr,
+		batchChangeID,
+		since,
+	))
+}
+
+var countChangesetAutoMergesEnqueuedSinceQueryFmtstr = `
+SELECT COUNT(*) FROM changeset_auto_merges
+WHERE batch_change_id = %s AND merge_enqueued_at > %s
+`
+
+// ListChangesetsToAutoMerge returns up to limit published, open changesets
+// that are owned by a batch change with an auto-merge policy, least recently
+// updated first. A changeset is only returned if the policy has not been
+// evaluated for it since it was updated or since its check or review state
+// changed, and if no merge job of the changeset is pending.
+func (s *Store) ListChangesetsToAutoMerge(ctx context.Context, limit int) (cs btypes.Changesets, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsToAutoMerge.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listChangesetsToAutoMergeQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+		btypes.ChangesetExternalStateDraft,
+		btypes.ChangesetJobTypeMerge,
+		btypes.ChangesetJobStateQueued.ToDB(),
+		btypes.ChangesetJobStateProcessing.ToDB(),
+		btypes.ChangesetJobStateErrored.ToDB(),
+		limit,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.Changeset
+		if err := scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listChangesetsToAutoMergeQueryFmtstr = `
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+INNER JOIN batch_changes ON batch_changes.id = changesets.owned_by_batch_change_id
+INNER JOIN batch_specs ON batch_specs.id = batch_changes.batch_spec_id
+LEFT JOIN changeset_auto_merges ON changeset_auto_merges.changeset_id = changesets.id
+WHERE
+	batch_specs.spec->'autoMerge' IS NOT NULL
+	AND batch_changes.closed_at IS NULL
+	AND repo.deleted_at IS NULL
+	AND changesets.publication_state = %s
+	AND changesets.external_state IN (%s, %s)
+	AND (
+		changeset_auto_merges.evaluated_at IS NULL
+		OR changesets.updated_at > changeset_auto_merges.evaluated_at
+		OR changesets.external_check_state IS DISTINCT FROM changeset_auto_merges.check_state
+		OR changesets.external_review_state IS DISTINCT FROM changeset_auto_merges.review_state
+	)
+	-- A merge that is still pending must not be enqueued again.
+	AND NOT EXISTS (
+		SELECT 1 FROM changeset_jobs
+		WHERE
+			changeset_jobs.changeset_id = changesets.id
+			AND changeset_jobs.job_type = %s
+			AND changeset_jobs.state IN (%s, %s, %s)
+	)
+ORDER BY changesets.updated_at ASC
+LIMIT %s
+`
+
+func scanChangesetAutoMerge(m *btypes.ChangesetAutoMerge, s dbutil.Scanner) error {
+	var checkState, reviewState string
+	if err := s.Scan(
+		&m.ChangesetID,
+		&m.BatchChangeID,
+		&dbutil.NullString{S: &m.BlockedReason},
+		&dbutil.NullTime{Time: &m.MergeEnqueuedAt},
+		&m.EvaluatedAt,
+		&dbutil.NullString{S: &checkState},
+		&dbutil.NullString{S: &reviewState},
+	); err != nil {
+		return err
+	}
+
+	m.CheckState = btypes.ChangesetCheckState(checkState)
+	m.ReviewState = btypes.ChangesetReviewState(reviewState)
+	return nil
+}
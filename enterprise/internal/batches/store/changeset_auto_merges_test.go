@@ -0,0 +1,243 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreChangesetAutoMerges(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	policySpec := &btypes.BatchSpec{
+		UserID:          1,
+		NamespaceUserID: 1,
+		Spec: &batcheslib.BatchSpec{
+			Name:      "auto-merge",
+			AutoMerge: &batcheslib.AutoMerge{Squash: true},
+		},
+	}
+	if err := s.CreateBatchSpec(ctx, policySpec); err != nil {
+		t.Fatal(err)
+	}
+	policyBatchChange := bt.CreateBatchChange(t, ctx, s, "auto-merge", 1, policySpec.ID)
+
+	spec := bt.CreateBatchSpec(t, ctx, s, "manual", 1, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "manual", 1, spec.ID)
+
+	open := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		OwnedByBatchChange: policyBatchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+	})
+	bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		OwnedByBatchChange: policyBatchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateMerged,
+	})
+	bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		OwnedByBatchChange: policyBatchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+	})
+	bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		OwnedByBatchChange: batchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+	})
+
+	listIDs := func(t *testing.T) []int64 {
+		t.Helper()
+		cs, err := s.ListChangesetsToAutoMerge(ctx, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int64
+		for _, c := range cs {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+
+	t.Run("ListChangesetsToAutoMerge", func(t *testing.T) {
+		if diff := cmp.Diff([]int64{open.ID}, listIDs(t)); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("GetChangesetAutoMerge not found", func(t *testing.T) {
+		if _, err := s.GetChangesetAutoMerge(ctx, open.ID); err != ErrNoResults {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("UpsertChangesetAutoMerge", func(t *testing.T) {
+		clock.Add(1 * time.Minute)
+		blocked := &btypes.ChangesetAutoMerge{
+			ChangesetID:   open.ID,
+			BatchChangeID: policyBatchChange.ID,
+			BlockedReason: "not approved",
+		}
+		if err := s.UpsertChangesetAutoMerge(ctx, blocked); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangesetAutoMerge(ctx, open.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &btypes.ChangesetAutoMerge{
+			ChangesetID:   open.ID,
+			BatchChangeID: policyBatchChange.ID,
+			BlockedReason: "not approved",
+			EvaluatedAt:   clock.Now(),
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// The changeset wasn't updated since it was evaluated.
+		if ids := listIDs(t); len(ids) != 0 {
+			t.Fatalf("unexpected changesets to auto-merge: %v", ids)
+		}
+
+		clock.Add(1 * time.Minute)
+		enqueued := &btypes.ChangesetAutoMerge{
+			ChangesetID:     open.ID,
+			BatchChangeID:   policyBatchChange.ID,
+			MergeEnqueuedAt: clock.Now(),
+		}
+		if err := s.UpsertChangesetAutoMerge(ctx, enqueued); err != nil {
+			t.Fatal(err)
+		}
+
+		// A later evaluation that doesn't enqueue a merge keeps the time the
+		// merge was enqueued.
+		clock.Add(1 * time.Minute)
+		if err := s.UpsertChangesetAutoMerge(ctx, &btypes.ChangesetAutoMerge{
+			ChangesetID:   open.ID,
+			BatchChangeID: policyBatchChange.ID,
+			BlockedReason: "merge already enqueued",
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.GetChangesetAutoMerge(ctx, open.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !have.MergeEnqueuedAt.Equal(enqueued.MergeEnqueuedAt) {
+			t.Fatalf("unexpected merge enqueued at: have=%s want=%s", have.MergeEnqueuedAt, enqueued.MergeEnqueuedAt)
+		}
+	})
+
+	t.Run("CountChangesetAutoMergesEnqueuedSince", func(t *testing.T) {
+		for since, want := range map[time.Time]int{
+			clock.Now().Add(-1 * time.Hour): 1,
+			clock.Now():                     0,
+		} {
+			have, err := s.CountChangesetAutoMergesEnqueuedSince(ctx, policyBatchChange.ID, since)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != want {
+				t.Errorf("unexpected count since %s: have=%d want=%d", since, have, want)
+			}
+		}
+	})
+
+	t.Run("ListChangesetsToAutoMerge check state changes", func(t *testing.T) {
+		setCheckState := func(t *testing.T, state btypes.ChangesetCheckState) {
+			t.Helper()
+			// The check state is updated without touching updated_at.
+			if err := s.Exec(ctx, sqlf.Sprintf("UPDATE changesets SET external_check_state = %s WHERE id = %s", state, open.ID)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		setCheckState(t, btypes.ChangesetCheckStatePassed)
+		if diff := cmp.Diff([]int64{open.ID}, listIDs(t)); diff != "" {
+			t.Fatal(diff)
+		}
+
+		clock.Add(1 * time.Minute)
+		if err := s.UpsertChangesetAutoMerge(ctx, &btypes.ChangesetAutoMerge{
+			ChangesetID:   open.ID,
+			BatchChangeID: policyBatchChange.ID,
+			BlockedReason: "not approved",
+			CheckState:    btypes.ChangesetCheckStatePassed,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		have, err := s.GetChangesetAutoMerge(ctx, open.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.CheckState != btypes.ChangesetCheckStatePassed {
+			t.Fatalf("unexpected check state: %s", have.CheckState)
+		}
+		if ids := listIDs(t); len(ids) != 0 {
+			t.Fatalf("unexpected changesets to auto-merge: %v", ids)
+		}
+	})
+
+	t.Run("ListChangesetsToAutoMerge pending merge", func(t *testing.T) {
+		job := &btypes.ChangesetJob{
+			BulkGroup:     "auto-merge",
+			ChangesetID:   open.ID,
+			BatchChangeID: policyBatchChange.ID,
+			UserID:        1,
+			State:         btypes.ChangesetJobStateQueued,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			Payload:       &btypes.ChangesetJobMergePayload{},
+		}
+		if err := s.CreateChangesetJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+
+		// The changeset needs to be evaluated again, but its merge is pending.
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE changesets SET external_check_state = %s WHERE id = %s", btypes.ChangesetCheckStateFailed, open.ID)); err != nil {
+			t.Fatal(err)
+		}
+		for _, state := range []btypes.ChangesetJobState{
+			btypes.ChangesetJobStateQueued,
+			btypes.ChangesetJobStateProcessing,
+			btypes.ChangesetJobStateErrored,
+		} {
+			if err := s.Exec(ctx, sqlf.Sprintf("UPDATE changeset_jobs SET state = %s WHERE id = %s", state.ToDB(), job.ID)); err != nil {
+				t.Fatal(err)
+			}
+			if ids := listIDs(t); len(ids) != 0 {
+				t.Fatalf("unexpected changesets to auto-merge with %s merge job: %v", state, ids)
+			}
+		}
+
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE changeset_jobs SET state = %s WHERE id = %s", btypes.ChangesetJobStateFailed.ToDB(), job.ID)); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{open.ID}, listIDs(t)); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
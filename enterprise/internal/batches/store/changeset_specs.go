@@ -45,6 +45,10 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"reviewers",
+	"assignees",
+	"labels",
+	"depends_on",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +76,10 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.reviewers",
+	"changeset_specs.assignees",
+	"changeset_specs.labels",
+	"changeset_specs.depends_on",
 }
 
 var oneGigabyte = 1000000000
@@ -137,6 +145,10 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				pq.Array(nonNilStrings(c.Reviewers)),
+				pq.Array(nonNilStrings(c.Assignees)),
+				pq.Array(nonNilStrings(c.Labels)),
+				dbutil.NewNullString(c.DependsOn),
 			); err != nil {
 				return err
 			}
@@ -562,6 +574,10 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		pq.Array(&c.Reviewers),
+		pq.Array(&c.Assignees),
+		pq.Array(&c.Labels),
+		&dbutil.NullString{S: &c.DependsOn},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -569,6 +585,18 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 
 	c.Type = btypes.ChangesetSpecType(typ)
 
+	// Empty arrays are scanned as empty slices, but specs without reviewers,
+	// assignees or labels have nil slices everywhere else.
+	if len(c.Reviewers) == 0 {
+		c.Reviewers = nil
+	}
+	if len(c.Assignees) == 0 {
+		c.Assignees = nil
+	}
+	if len(c.Labels) == 0 {
+		c.Labels = nil
+	}
+
 	if len(published) != 0 {
 		if err := json.Unmarshal(published, &c.Published); err != nil {
 			return err
@@ -578,6 +606,15 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 	return nil
 }
 
+// nonNilStrings returns the given slice, or an empty slice if it's nil, so
+// that it's stored as an empty array rather than NULL.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
 type GetRewirerMappingsOpts struct {
 	BatchSpecID   int64
 	BatchChangeID int64
@@ -16,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -24,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -360,13 +362,14 @@ func (s *Store) GetChangesetByID(ctx context.Context, id int64) (*btypes.Changes
 
 // GetChangesetOpts captures the query options needed for getting a Changeset
 type GetChangesetOpts struct {
-	ID                  int64
-	RepoID              api.RepoID
-	ExternalID          string
-	ExternalServiceType string
-	ExternalBranch      string
-	ReconcilerState     btypes.ReconcilerState
-	PublicationState    btypes.ChangesetPublicationState
+	ID                   int64
+	RepoID               api.RepoID
+	ExternalID           string
+	ExternalServiceType  string
+	ExternalBranch       string
+	ReconcilerState      btypes.ReconcilerState
+	PublicationState     btypes.ChangesetPublicationState
+	OwnedByBatchChangeID int64
 }
 
 // GetChangeset gets a changeset matching the given options.
@@ -425,6 +428,9 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 	if opts.PublicationState != "" {
 		preds = append(preds, sqlf.Sprintf("changesets.publication_state = %s", opts.PublicationState))
 	}
+	if opts.OwnedByBatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.owned_by_batch_change_id = %s", opts.OwnedByBatchChangeID))
+	}
 
 	return sqlf.Sprintf(
 		getChangesetsQueryFmtstr,
@@ -713,6 +719,86 @@ func (s *Store) enqueueChangesetQuery(cs *btypes.Changeset, resetState, currentS
 	)
 }
 
+// EnqueueChangesetsDependingOn enqueues the changesets owned by the same batch
+// change as the given changeset whose current spec is stacked on its branch,
+// so that the reconciler rebases and retargets them. Changesets that are
+// currently being processed are skipped: the reconciler checks whether the
+// changeset they depend on changed once it's done and requeues them itself.
+func (s *Store) EnqueueChangesetsDependingOn(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetsDependingOn.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if cs.OwnedByBatchChangeID == 0 || cs.ExternalBranch == "" {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueChangesetsDependingOnQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		cs.ID,
+		cs.RepoID,
+		cs.OwnedByBatchChangeID,
+		cs.ExternalBranch,
+		btypes.ReconcilerStateProcessing.ToDB(),
+	))
+}
+
+var enqueueChangesetsDependingOnQueryFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM changeset_specs
+WHERE
+	changeset_specs.id = changesets.current_spec_id
+	AND changesets.id != %s
+	AND changesets.repo_id = %s
+	AND changesets.owned_by_batch_change_id = %s
+	AND changeset_specs.depends_on = %s
+	AND changesets.reconciler_state != %s
+`
+
+// RequeueChangeset sets the reconciler state of the given changeset, which is
+// currently being processed, back to queued without counting the attempt as a
+// failure. The changeset is not processed again before processAfter.
+func (s *Store) RequeueChangeset(ctx context.Context, cs *btypes.Changeset, processAfter time.Time) (err error) {
+	ctx, _, endObservation := s.operations.requeueChangeset.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		requeueChangesetQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		processAfter,
+		s.now(),
+		cs.ID,
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, sc)
+	})
+}
+
+var requeueChangesetQueryFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	started_at = NULL,
+	process_after = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING
+	%s
+`
+
 // UpdateChangeset updates the given Changeset.
 func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err error) {
 	ctx, _, endObservation := s.operations.updateChangeset.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -1171,6 +1257,11 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		// Ensure the inner PR is initialized, it should never be nil.
 		m.PullRequest = &bitbucketcloud.PullRequest{}
 		t.Metadata = m
+	case extsvc.TypeGerrit:
+		m := new(gerritbatches.AnnotatedChange)
+		// Ensure the inner change is initialized, it should never be nil.
+		m.Change = &gerrit.Change{}
+		t.Metadata = m
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1541,6 +1541,104 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 		})
 	})
 
+	t.Run("EnqueueChangesetsDependingOn", func(t *testing.T) {
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, "stacked", user.ID, 0)
+		batchChange := bt.CreateBatchChange(t, ctx, s, "stacked", user.ID, batchSpec.ID)
+
+		createChangeset := func(branch, dependsOn string, repoID api.RepoID) *btypes.Changeset {
+			spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+				User:      user.ID,
+				Repo:      repoID,
+				BatchSpec: batchSpec.ID,
+				HeadRef:   branch,
+				DependsOn: dependsOn,
+			})
+			return bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+				Repo:               repoID,
+				CurrentSpec:        spec.ID,
+				OwnedByBatchChange: batchChange.ID,
+				ExternalBranch:     branch,
+				ReconcilerState:    btypes.ReconcilerStateCompleted,
+				PublicationState:   btypes.ChangesetPublicationStatePublished,
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+				NumFailures:        5,
+			})
+		}
+
+		base := createChangeset("refs/heads/stacked-a", "", repo.ID)
+		dependent := createChangeset("refs/heads/stacked-b", "refs/heads/stacked-a", repo.ID)
+		independent := createChangeset("refs/heads/stacked-c", "", repo.ID)
+		otherRepoDependent := createChangeset("refs/heads/stacked-b", "refs/heads/stacked-a", otherRepo.ID)
+		processingDependent := createChangeset("refs/heads/stacked-d", "refs/heads/stacked-a", repo.ID)
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE changesets SET reconciler_state = %s WHERE id = %s", btypes.ReconcilerStateProcessing.ToDB(), processingDependent.ID)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.EnqueueChangesetsDependingOn(ctx, base); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		for _, tc := range []struct {
+			changeset *btypes.Changeset
+			want      btypes.ReconcilerState
+		}{
+			{changeset: base, want: btypes.ReconcilerStateCompleted},
+			{changeset: dependent, want: btypes.ReconcilerStateQueued},
+			{changeset: independent, want: btypes.ReconcilerStateCompleted},
+			{changeset: otherRepoDependent, want: btypes.ReconcilerStateCompleted},
+			// The reconciler requeues the changeset itself once it's done.
+			{changeset: processingDependent, want: btypes.ReconcilerStateProcessing},
+		} {
+			have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: tc.changeset.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.ReconcilerState != tc.want {
+				t.Errorf("changeset %d: wrong reconciler state. want=%s, have=%s", tc.changeset.ID, tc.want, have.ReconcilerState)
+			}
+		}
+
+		have, err := s.GetChangeset(ctx, GetChangesetOpts{
+			RepoID:               repo.ID,
+			ExternalBranch:       "refs/heads/stacked-a",
+			OwnedByBatchChangeID: batchChange.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ID != base.ID {
+			t.Errorf("wrong changeset. want=%d, have=%d", base.ID, have.ID)
+		}
+	})
+
+	t.Run("RequeueChangeset", func(t *testing.T) {
+		c := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:            repo.ID,
+			ReconcilerState: btypes.ReconcilerStateProcessing,
+			NumFailures:     3,
+		})
+
+		processAfter := clock.Now().Add(5 * time.Minute)
+		if err := s.RequeueChangeset(ctx, c, processAfter); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.ReconcilerState != btypes.ReconcilerStateQueued {
+			t.Errorf("wrong reconciler state. want=%s, have=%s", btypes.ReconcilerStateQueued, have.ReconcilerState)
+		}
+		if !have.ProcessAfter.Equal(processAfter) {
+			t.Errorf("wrong process after. want=%s, have=%s", processAfter, have.ProcessAfter)
+		}
+		// Requeueing isn't a failure.
+		if have.NumFailures != 3 {
+			t.Errorf("wrong number of failures. want=%d, have=%d", 3, have.NumFailures)
+		}
+	})
+
 	t.Run("UpdateChangesetBatchChanges", func(t *testing.T) {
 		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
 			ReconcilerState:  btypes.ReconcilerStateCompleted,
@@ -215,6 +215,11 @@ type operations struct {
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
 
+	upsertChangesetAutoMerge              *observation.Operation
+	getChangesetAutoMerge                 *observation.Operation
+	countChangesetAutoMergesEnqueuedSince *observation.Operation
+	listChangesetsToAutoMerge             *observation.Operation
+
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
 	deleteChangesetSpec                      *observation.Operation
@@ -234,6 +239,8 @@ type operations struct {
 	listChangesetSyncData             *observation.Operation
 	listChangesets                    *observation.Operation
 	enqueueChangeset                  *observation.Operation
+	enqueueChangesetsDependingOn      *observation.Operation
+	requeueChangeset                  *observation.Operation
 	updateChangeset                   *observation.Operation
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
@@ -358,6 +365,11 @@ func newOperations(observationContext *observation.Context) *operations {
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
 
+			upsertChangesetAutoMerge:              op("UpsertChangesetAutoMerge"),
+			getChangesetAutoMerge:                 op("GetChangesetAutoMerge"),
+			countChangesetAutoMergesEnqueuedSince: op("CountChangesetAutoMergesEnqueuedSince"),
+			listChangesetsToAutoMerge:             op("ListChangesetsToAutoMerge"),
+
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
 			deleteChangesetSpec:                      op("DeleteChangesetSpec"),
@@ -377,6 +389,8 @@ func newOperations(observationContext *observation.Context) *operations {
 			listChangesetSyncData:             op("ListChangesetSyncData"),
 			listChangesets:                    op("ListChangesets"),
 			enqueueChangeset:                  op("EnqueueChangeset"),
+			enqueueChangesetsDependingOn:      op("EnqueueChangesetsDependingOn"),
+			requeueChangeset:                  op("RequeueChangeset"),
 			updateChangeset:                   op("UpdateChangeset"),
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
@@ -521,6 +521,7 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 	if err != nil {
 		return err
 	}
+	wasComplete := c.Complete()
 	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
 
 	tx, err := syncStore.Transact(ctx)
@@ -537,5 +538,13 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
+	// Changesets that are stacked on this one need to be retargeted once it
+	// has been merged or closed.
+	if !wasComplete && c.Complete() {
+		if err := tx.EnqueueChangesetsDependingOn(ctx, c); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
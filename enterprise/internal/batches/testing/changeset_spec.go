@@ -36,6 +36,12 @@ type TestSpecOpts struct {
 	CommitAuthorEmail string
 	CommitAuthorName  string
 
+	Reviewers []string
+	Assignees []string
+	Labels    []string
+
+	DependsOn string
+
 	BaseRev string
 	BaseRef string
 
@@ -72,6 +78,10 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		Diff:              []byte(opts.CommitDiff),
 		CommitAuthorEmail: opts.CommitAuthorEmail,
 		CommitAuthorName:  opts.CommitAuthorName,
+		Reviewers:         opts.Reviewers,
+		Assignees:         opts.Assignees,
+		Labels:            opts.Labels,
+		DependsOn:         opts.DependsOn,
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
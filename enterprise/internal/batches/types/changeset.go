@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -420,6 +421,18 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = pr.ChangeID
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Changes are pushed to refs/for/<branch> and Gerrit stores each
+		// patch set under its own ref, so the ref of the current patch set is
+		// the closest thing to a head branch.
+		if rev := pr.CurrentRevisionInfo(); rev != nil {
+			c.ExternalBranch = rev.Ref
+		}
+		c.ExternalUpdatedAt = pr.Updated.Time
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +460,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +481,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +510,8 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +530,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
 	default:
 		return time.Time{}
 	}
@@ -527,6 +548,13 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit changes have no description other than the commit message.
+		rev := m.CurrentRevisionInfo()
+		if rev == nil || rev.Commit == nil {
+			return "", nil
+		}
+		return gerritCommitMessageBody(rev.Commit.Message), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +600,8 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *gerritbatches.AnnotatedChange:
+		return m.URL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -761,6 +791,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +810,11 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		if rev := m.CurrentRevisionInfo(); rev != nil {
+			return rev.Ref, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +833,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,11 +852,28 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
 }
 
+// gerritCommitMessageBody returns the commit message of a Gerrit change without
+// its subject and Change-Id trailer.
+func gerritCommitMessageBody(message string) string {
+	_, body, _ := strings.Cut(message, "\n")
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "Change-Id:") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
 // AttachedTo returns true if the changeset is currently attached to the batch
 // change with the given batchChangeID.
 func (c *Changeset) AttachedTo(batchChangeID int64) bool {
@@ -897,6 +953,18 @@ func (c *Changeset) SupportsDraft() bool {
 	return ExternalServiceSupports(c.ExternalServiceType, CodehostCapabilityDraftChangesets)
 }
 
+// SupportsReviewers returns whether the code host on which the changeset is
+// hosted supports requesting reviews from users.
+func (c *Changeset) SupportsReviewers() bool {
+	return ExternalServiceSupports(c.ExternalServiceType, CodehostCapabilityReviewers)
+}
+
+// SupportsAssignees returns whether the code host on which the changeset is
+// hosted supports assigning users to changesets.
+func (c *Changeset) SupportsAssignees() bool {
+	return ExternalServiceSupports(c.ExternalServiceType, CodehostCapabilityAssignees)
+}
+
 func (c *Changeset) Labels() []ChangesetLabel {
 	switch m := c.Metadata.(type) {
 	case *github.PullRequest:
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// ChangesetAutoMerge is the latest evaluation of the auto-merge policy of a
+// batch change for one of the changesets it owns.
+type ChangesetAutoMerge struct {
+	ChangesetID   int64
+	BatchChangeID int64
+
+	// BlockedReason is why the changeset wasn't merged. It is empty if a
+	// merge of the changeset was enqueued.
+	BlockedReason   string
+	MergeEnqueuedAt time.Time
+	EvaluatedAt     time.Time
+
+	// CheckState and ReviewState are the states of the changeset the policy
+	// was evaluated against. The policy is evaluated again when either of
+	// them changes.
+	CheckState  ChangesetCheckState
+	ReviewState ChangesetReviewState
+}
@@ -47,6 +47,7 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	ChangesetJobTypeAssign    ChangesetJobType = "assign"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -67,6 +68,12 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobAssignPayload struct {
+	Reviewers []string `json:"reviewers,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
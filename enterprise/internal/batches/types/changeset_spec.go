@@ -35,6 +35,10 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
+		Reviewers:  spec.Reviewers,
+		Assignees:  spec.Assignees,
+		Labels:     spec.Labels,
+		DependsOn:  spec.DependsOn,
 	}
 
 	if spec.IsImportingExisting() {
@@ -113,6 +117,16 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// Reviewers, Assignees and Labels are added to the changeset on the code
+	// host, if it supports them.
+	Reviewers []string
+	Assignees []string
+	Labels    []string
+
+	// DependsOn is the head ref of the changeset in the same repository and
+	// batch change that this changeset is stacked on.
+	DependsOn string
+
 	ForkNamespace *string
 }
 
@@ -197,6 +197,51 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Thursday at 10:00 UTC.
+	at := time.Date(2021, 4, 8, 10, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		want bool
+	}{
+		"no rollout windows": {
+			cfg:  &Configuration{windows: []Window{}},
+			want: true,
+		},
+		"open window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(time.Thursday), rate: rate{n: 10, unit: ratePerHour}},
+				},
+			},
+			want: true,
+		},
+		"open window with zero rate": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(time.Thursday), rate: rate{n: 0}},
+				},
+			},
+			want: false,
+		},
+		"no open window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(time.Friday), rate: rate{n: -1}},
+				},
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
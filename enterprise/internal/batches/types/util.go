@@ -10,8 +10,11 @@ import (
 type CodehostCapability string
 
 const (
-	CodehostCapabilityLabels          CodehostCapability = "Labels"
-	CodehostCapabilityDraftChangesets CodehostCapability = "DraftChangesets"
+	CodehostCapabilityLabels            CodehostCapability = "Labels"
+	CodehostCapabilityDraftChangesets   CodehostCapability = "DraftChangesets"
+	CodehostCapabilityReviewers         CodehostCapability = "Reviewers"
+	CodehostCapabilityAssignees         CodehostCapability = "Assignees"
+	CodehostCapabilityStackedChangesets CodehostCapability = "StackedChangesets"
 )
 
 type CodehostCapabilities map[CodehostCapability]bool
@@ -21,10 +24,11 @@ type CodehostCapabilities map[CodehostCapability]bool
 // whose type is not in this list will simply be filtered out from the search
 // results.
 var SupportedExternalServices = map[string]CodehostCapabilities{
-	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-	extsvc.TypeBitbucketServer: {},
-	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-	extsvc.TypeBitbucketCloud:  {},
+	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityReviewers: true, CodehostCapabilityAssignees: true, CodehostCapabilityStackedChangesets: true},
+	extsvc.TypeBitbucketServer: {CodehostCapabilityReviewers: true, CodehostCapabilityStackedChangesets: true},
+	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityReviewers: true, CodehostCapabilityAssignees: true, CodehostCapabilityStackedChangesets: true},
+	extsvc.TypeBitbucketCloud:  {CodehostCapabilityStackedChangesets: true},
+	extsvc.TypeGerrit:          {CodehostCapabilityDraftChangesets: true, CodehostCapabilityReviewers: true},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -7496,9 +7496,15 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *WorkerStoreAddExecutionLogEntryFunc[T]
+	// DeadLettersFunc is an instance of a mock function object controlling
+	// the behavior of the method DeadLetters.
+	DeadLettersFunc *WorkerStoreDeadLettersFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *WorkerStoreDequeueFunc[T]
+	// FailDependentsFunc is an instance of a mock function object
+	// controlling the behavior of the method FailDependents.
+	FailDependentsFunc *WorkerStoreFailDependentsFunc[T]
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *WorkerStoreHandleFunc[T]
@@ -7520,9 +7526,15 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *WorkerStoreQueuedCountFunc[T]
+	// QueuedCountByPriorityFunc is an instance of a mock function object
+	// controlling the behavior of the method QueuedCountByPriority.
+	QueuedCountByPriorityFunc *WorkerStoreQueuedCountByPriorityFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *WorkerStoreRequeueFunc[T]
+	// RequeueDeadLettersFunc is an instance of a mock function object
+	// controlling the behavior of the method RequeueDeadLetters.
+	RequeueDeadLettersFunc *WorkerStoreRequeueDeadLettersFunc[T]
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *WorkerStoreResetStalledFunc[T]
@@ -7543,11 +7555,21 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		DeadLettersFunc: &WorkerStoreDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store1.DeadLetterOptions) (r0 []T, r1 error) {
+				return
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
 			},
 		},
+		FailDependentsFunc: &WorkerStoreFailDependentsFunc[T]{
+			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -7583,11 +7605,21 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		QueuedCountByPriorityFunc: &WorkerStoreQueuedCountByPriorityFunc[T]{
+			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
+				return
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
 			},
 		},
+		RequeueDeadLettersFunc: &WorkerStoreRequeueDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store1.DeadLetterOptions) (r0 []int, r1 error) {
+				return
+			},
+		},
 		ResetStalledFunc: &WorkerStoreResetStalledFunc[T]{
 			defaultHook: func(context.Context) (r0 map[int]time.Duration, r1 map[int]time.Duration, r2 error) {
 				return
@@ -7615,11 +7647,21 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.AddExecutionLogEntry")
 			},
 		},
+		DeadLettersFunc: &WorkerStoreDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store1.DeadLetterOptions) ([]T, error) {
+				panic("unexpected invocation of MockWorkerStore.DeadLetters")
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockWorkerStore.Dequeue")
 			},
 		},
+		FailDependentsFunc: &WorkerStoreFailDependentsFunc[T]{
+			defaultHook: func(context.Context) (map[int]int, error) {
+				panic("unexpected invocation of MockWorkerStore.FailDependents")
+			},
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockWorkerStore.Handle")
@@ -7655,11 +7697,21 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.QueuedCount")
 			},
 		},
+		QueuedCountByPriorityFunc: &WorkerStoreQueuedCountByPriorityFunc[T]{
+			defaultHook: func(context.Context) (map[int]int, error) {
+				panic("unexpected invocation of MockWorkerStore.QueuedCountByPriority")
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockWorkerStore.Requeue")
 			},
 		},
+		RequeueDeadLettersFunc: &WorkerStoreRequeueDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store1.DeadLetterOptions) ([]int, error) {
+				panic("unexpected invocation of MockWorkerStore.RequeueDeadLetters")
+			},
+		},
 		ResetStalledFunc: &WorkerStoreResetStalledFunc[T]{
 			defaultHook: func(context.Context) (map[int]time.Duration, map[int]time.Duration, error) {
 				panic("unexpected invocation of MockWorkerStore.ResetStalled")
@@ -7686,9 +7738,15 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		DeadLettersFunc: &WorkerStoreDeadLettersFunc[T]{
+			defaultHook: i.DeadLetters,
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
+		FailDependentsFunc: &WorkerStoreFailDependentsFunc[T]{
+			defaultHook: i.FailDependents,
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: i.Handle,
 		},
@@ -7710,9 +7768,15 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		QueuedCountByPriorityFunc: &WorkerStoreQueuedCountByPriorityFunc[T]{
+			defaultHook: i.QueuedCountByPriority,
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
+		RequeueDeadLettersFunc: &WorkerStoreRequeueDeadLettersFunc[T]{
+			defaultHook: i.RequeueDeadLetters,
+		},
 		ResetStalledFunc: &WorkerStoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
@@ -7842,6 +7906,114 @@ func (c WorkerStoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreDeadLettersFunc describes the behavior when the DeadLetters
+// method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreDeadLettersFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, store1.DeadLetterOptions) ([]T, error)
+	hooks       []func(context.Context, store1.DeadLetterOptions) ([]T, error)
+	history     []WorkerStoreDeadLettersFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// DeadLetters delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) DeadLetters(v0 context.Context, v1 store1.DeadLetterOptions) ([]T, error) {
+	r0, r1 := m.DeadLettersFunc.nextHook()(v0, v1)
+	m.DeadLettersFunc.appendCall(WorkerStoreDeadLettersFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeadLetters method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreDeadLettersFunc[T]) SetDefaultHook(hook func(context.Context, store1.DeadLetterOptions) ([]T, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeadLetters method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreDeadLettersFunc[T]) PushHook(hook func(context.Context, store1.DeadLetterOptions) ([]T, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreDeadLettersFunc[T]) SetDefaultReturn(r0 []T, r1 error) {
+	f.SetDefaultHook(func(context.Context, store1.DeadLetterOptions) ([]T, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreDeadLettersFunc[T]) PushReturn(r0 []T, r1 error) {
+	f.PushHook(func(context.Context, store1.DeadLetterOptions) ([]T, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreDeadLettersFunc[T]) nextHook() func(context.Context, store1.DeadLetterOptions) ([]T, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreDeadLettersFunc[T]) appendCall(r0 WorkerStoreDeadLettersFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreDeadLettersFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreDeadLettersFunc[T]) History() []WorkerStoreDeadLettersFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreDeadLettersFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreDeadLettersFuncCall is an object that describes an invocation
+// of method DeadLetters on an instance of MockWorkerStore.
+type WorkerStoreDeadLettersFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store1.DeadLetterOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []T
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreDeadLettersFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreDeadLettersFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreDequeueFunc[T workerutil.Record] struct {
@@ -7956,6 +8128,111 @@ func (c WorkerStoreDequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// WorkerStoreFailDependentsFunc describes the behavior when the
+// FailDependents method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreFailDependentsFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []WorkerStoreFailDependentsFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// FailDependents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) FailDependents(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.FailDependentsFunc.nextHook()(v0)
+	m.FailDependentsFunc.appendCall(WorkerStoreFailDependentsFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FailDependents
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreFailDependentsFunc[T]) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FailDependents method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreFailDependentsFunc[T]) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreFailDependentsFunc[T]) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreFailDependentsFunc[T]) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreFailDependentsFunc[T]) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreFailDependentsFunc[T]) appendCall(r0 WorkerStoreFailDependentsFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreFailDependentsFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreFailDependentsFunc[T]) History() []WorkerStoreFailDependentsFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreFailDependentsFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreFailDependentsFuncCall is an object that describes an
+// invocation of method FailDependents on an instance of MockWorkerStore.
+type WorkerStoreFailDependentsFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreFailDependentsFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreFailDependentsFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreHandleFunc describes the behavior when the Handle method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreHandleFunc[T workerutil.Record] struct {
@@ -8722,6 +8999,114 @@ func (c WorkerStoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreQueuedCountByPriorityFunc describes the behavior when the
+// QueuedCountByPriority method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreQueuedCountByPriorityFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []WorkerStoreQueuedCountByPriorityFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueuedCountByPriority delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) QueuedCountByPriority(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.QueuedCountByPriorityFunc.nextHook()(v0)
+	m.QueuedCountByPriorityFunc.appendCall(WorkerStoreQueuedCountByPriorityFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueuedCountByPriority method of the parent MockWorkerStore instance is
+// invoked and the hook queue is empty.
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueuedCountByPriority method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) appendCall(r0 WorkerStoreQueuedCountByPriorityFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreQueuedCountByPriorityFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreQueuedCountByPriorityFunc[T]) History() []WorkerStoreQueuedCountByPriorityFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreQueuedCountByPriorityFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreQueuedCountByPriorityFuncCall is an object that describes an
+// invocation of method QueuedCountByPriority on an instance of
+// MockWorkerStore.
+type WorkerStoreQueuedCountByPriorityFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreQueuedCountByPriorityFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreQueuedCountByPriorityFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreRequeueFunc describes the behavior when the Requeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreRequeueFunc[T workerutil.Record] struct {
@@ -8830,6 +9215,116 @@ func (c WorkerStoreRequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// WorkerStoreRequeueDeadLettersFunc describes the behavior when the
+// RequeueDeadLetters method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreRequeueDeadLettersFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, store1.DeadLetterOptions) ([]int, error)
+	hooks       []func(context.Context, store1.DeadLetterOptions) ([]int, error)
+	history     []WorkerStoreRequeueDeadLettersFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// RequeueDeadLetters delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) RequeueDeadLetters(v0 context.Context, v1 store1.DeadLetterOptions) ([]int, error) {
+	r0, r1 := m.RequeueDeadLettersFunc.nextHook()(v0, v1)
+	m.RequeueDeadLettersFunc.appendCall(WorkerStoreRequeueDeadLettersFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RequeueDeadLetters
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) SetDefaultHook(hook func(context.Context, store1.DeadLetterOptions) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequeueDeadLetters method of the parent MockWorkerStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) PushHook(hook func(context.Context, store1.DeadLetterOptions) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, store1.DeadLetterOptions) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, store1.DeadLetterOptions) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) nextHook() func(context.Context, store1.DeadLetterOptions) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) appendCall(r0 WorkerStoreRequeueDeadLettersFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreRequeueDeadLettersFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreRequeueDeadLettersFunc[T]) History() []WorkerStoreRequeueDeadLettersFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreRequeueDeadLettersFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreRequeueDeadLettersFuncCall is an object that describes an
+// invocation of method RequeueDeadLetters on an instance of
+// MockWorkerStore.
+type WorkerStoreRequeueDeadLettersFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store1.DeadLetterOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreRequeueDeadLettersFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreRequeueDeadLettersFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreResetStalledFunc describes the behavior when the ResetStalled
 // method of the parent MockWorkerStore instance is invoked.
 type WorkerStoreResetStalledFunc[T workerutil.Record] struct {
@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/memo"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
 )
 
 // GetService creates or returns an already-initialized symbols service.
@@ -43,6 +44,7 @@ var initServiceMemo = memo.NewMemoizedConstructorWithArg(func(deps serviceDepend
 		lsifStore,
 		deps.uploadSvc,
 		deps.gitserver,
+		symbols.DefaultClient,
 		scopedContext("service"),
 	)
 
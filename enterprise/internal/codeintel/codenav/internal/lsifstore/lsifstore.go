@@ -40,8 +40,16 @@ type LsifStore interface {
 	// Ranges
 	GetRanges(ctx context.Context, bundleID int, path string, startLine, endLine int) (_ []shared.CodeIntelligenceRange, err error)
 
+	// Document symbols
+	GetDocumentSymbols(ctx context.Context, bundleID int, path string) (_ []shared.DocumentSymbol, err error)
+
 	// Paths
 	GetPathExists(ctx context.Context, bundleID int, path string) (_ bool, err error)
+
+	// Calls
+	GetCallableSymbols(ctx context.Context, uploadID int, path string, line, character int) (_ []string, err error)
+	GetIncomingCalls(ctx context.Context, uploadIDs []int, symbols []string, limit, offset int) (_ []shared.Call, totalCount int, err error)
+	GetOutgoingCalls(ctx context.Context, uploadID int, path string, line, character int) (_ []shared.Call, err error)
 }
 
 type store struct {
@@ -0,0 +1,360 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetCallableSymbols returns the global function and method symbols of the occurrences containing the
+// given position. Only SCIP documents carry the symbol information required to determine callables, so
+// this method returns no symbols for LSIF uploads.
+func (s *store) GetCallableSymbols(ctx context.Context, uploadID int, path string, line, character int) (_ []string, err error) {
+	ctx, trace, endObservation := s.operations.getCallableSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		scipDocumentQuery,
+		uploadID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, err
+	}
+
+	symbols := callableSymbolsAtPosition(documentData.SCIPData, newCallableSymbolCache(), scip.Position{Line: int32(line), Character: int32(character)})
+	trace.Log(log.Int("numSymbols", len(symbols)))
+
+	return symbols, nil
+}
+
+// GetIncomingCalls returns the calls to any of the given symbols from within one of the given uploads.
+// The caller of each call site is the function or method definition that most closely precedes it in
+// its document. The limit and offset apply to the documents containing references to the given symbols,
+// so a single page may contain more calls than the limit. This method also returns the number of such
+// documents to aid in pagination.
+func (s *store) GetIncomingCalls(ctx context.Context, uploadIDs []int, symbols []string, limit, offset int) (_ []shared.Call, totalCount int, err error) {
+	ctx, trace, endObservation := s.operations.getIncomingCalls.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numUploadIDs", len(uploadIDs)),
+		log.String("uploadIDs", intsToString(uploadIDs)),
+		log.Int("numSymbols", len(symbols)),
+		log.String("symbols", strings.Join(symbols, ", ")),
+		log.Int("limit", limit),
+		log.Int("offset", offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 || len(symbols) == 0 {
+		return nil, 0, nil
+	}
+
+	totalCount, _, err = basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		incomingCallsDocumentsCountQuery,
+		pq.Array(uploadIDs),
+		pq.Array(symbols),
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+	trace.Log(log.Int("totalCount", totalCount))
+
+	documents, err := s.scanDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		incomingCallsDocumentsQuery,
+		pq.Array(uploadIDs),
+		pq.Array(symbols),
+		limit,
+		offset,
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+	trace.Log(log.Int("numDocuments", len(documents)))
+
+	symbolSet := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		symbolSet[symbol] = struct{}{}
+	}
+
+	cache := newCallableSymbolCache()
+	var calls []shared.Call
+	for _, document := range documents {
+		if document.SCIPData == nil {
+			continue
+		}
+
+		calls = append(calls, incomingCalls(document.UploadID, document.Path, document.SCIPData, cache, symbolSet)...)
+	}
+	trace.Log(log.Int("numCalls", len(calls)))
+
+	return calls, totalCount, nil
+}
+
+const incomingCallsDocumentsCTE = `
+WITH referencing_documents AS (
+	SELECT DISTINCT ss.document_lookup_id
+	FROM codeintel_scip_symbols ss
+	WHERE
+		ss.upload_id = ANY(%s) AND
+		ss.symbol_name = ANY(%s) AND
+		ss.reference_ranges IS NOT NULL
+)
+`
+
+const incomingCallsDocumentsCountQuery = incomingCallsDocumentsCTE + `
+SELECT COUNT(*) FROM referencing_documents
+`
+
+const incomingCallsDocumentsQuery = incomingCallsDocumentsCTE + `
+SELECT
+	sdl.upload_id,
+	sdl.document_path,
+	NULL AS data,
+	NULL AS ranges,
+	NULL AS hovers,
+	NULL AS monikers,
+	NULL AS packages,
+	NULL AS diagnostics,
+	sd.raw_scip_payload AS scip_document
+FROM codeintel_scip_document_lookup sdl
+JOIN codeintel_scip_documents sd ON sd.id = sdl.document_id
+WHERE sdl.id IN (SELECT document_lookup_id FROM referencing_documents)
+ORDER BY sdl.upload_id, sdl.document_path
+LIMIT %s OFFSET %s
+`
+
+// GetOutgoingCalls returns the calls made from within the function or method defined at the given
+// position. If the position is not on the name of a function or method definition, the calls made
+// from within the definition that most closely precedes the position are returned. A definition is
+// assumed to extend to the next function or method definition in the same document.
+func (s *store) GetOutgoingCalls(ctx context.Context, uploadID int, path string, line, character int) (_ []shared.Call, err error) {
+	ctx, trace, endObservation := s.operations.getOutgoingCalls.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		scipDocumentQuery,
+		uploadID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, err
+	}
+
+	calls := outgoingCalls(uploadID, path, documentData.SCIPData, newCallableSymbolCache(), scip.Position{Line: int32(line), Character: int32(character)})
+	trace.Log(log.Int("numCalls", len(calls)))
+
+	return calls, nil
+}
+
+const scipDocumentQuery = `
+SELECT
+	sdl.upload_id,
+	sdl.document_path,
+	NULL AS data,
+	NULL AS ranges,
+	NULL AS hovers,
+	NULL AS monikers,
+	NULL AS packages,
+	NULL AS diagnostics,
+	sd.raw_scip_payload AS scip_document
+FROM codeintel_scip_document_lookup sdl
+JOIN codeintel_scip_documents sd ON sd.id = sdl.document_id
+WHERE
+	sdl.upload_id = %s AND
+	sdl.document_path = %s
+LIMIT 1
+`
+
+// callableSymbolCache memoizes whether symbols name a function or method, which requires
+// parsing the symbol.
+type callableSymbolCache map[string]bool
+
+func newCallableSymbolCache() callableSymbolCache {
+	return callableSymbolCache{}
+}
+
+// isCallable returns true if the given symbol is a global symbol whose last descriptor is a method
+// descriptor. SCIP indexers use method descriptors for both functions and methods.
+func (c callableSymbolCache) isCallable(symbol string) bool {
+	if callable, ok := c[symbol]; ok {
+		return callable
+	}
+
+	callable := false
+	if symbol != "" && !scip.IsLocalSymbol(symbol) {
+		if parsed, err := scip.ParseSymbol(symbol); err == nil && len(parsed.Descriptors) > 0 {
+			callable = parsed.Descriptors[len(parsed.Descriptors)-1].Suffix == scip.Descriptor_Method
+		}
+	}
+
+	c[symbol] = callable
+	return callable
+}
+
+// callableSymbolsAtPosition returns the distinct callable symbols of the occurrences containing the
+// given position.
+func callableSymbolsAtPosition(document *scip.Document, cache callableSymbolCache, position scip.Position) []string {
+	var symbols []string
+	seen := map[string]struct{}{}
+	for _, occurrence := range document.Occurrences {
+		if len(occurrence.Range) < 3 || !cache.isCallable(occurrence.Symbol) || !rangeContains(scip.NewRange(occurrence.Range), position) {
+			continue
+		}
+		if _, ok := seen[occurrence.Symbol]; ok {
+			continue
+		}
+
+		seen[occurrence.Symbol] = struct{}{}
+		symbols = append(symbols, occurrence.Symbol)
+	}
+
+	return symbols
+}
+
+// incomingCalls returns the calls to the given symbols within the given document, grouped by
+// the enclosing definition. Call sites outside of any definition are ignored.
+func incomingCalls(uploadID int, path string, document *scip.Document, cache callableSymbolCache, symbols map[string]struct{}) []shared.Call {
+	occurrences := sortedOccurrences(document)
+	definitions := callableDefinitions(occurrences, cache)
+
+	var calls []shared.Call
+	callIndexes := map[string]int{}
+	for _, occurrence := range occurrences {
+		if isDefinition(occurrence) {
+			continue
+		}
+		if _, ok := symbols[occurrence.Symbol]; !ok {
+			continue
+		}
+
+		r := scip.NewRange(occurrence.Range)
+		i := enclosingDefinition(definitions, r.Start)
+		if i < 0 {
+			continue
+		}
+		caller := definitions[i].Symbol
+
+		if _, ok := callIndexes[caller]; !ok {
+			callIndexes[caller] = len(calls)
+			calls = append(calls, shared.Call{DumpID: uploadID, Path: path, Symbol: caller})
+		}
+		calls[callIndexes[caller]].CallSites = append(calls[callIndexes[caller]].CallSites, translateRange(r))
+	}
+
+	return calls
+}
+
+// outgoingCalls returns the calls made from within the definition at or enclosing the given position,
+// grouped by the called symbol.
+func outgoingCalls(uploadID int, path string, document *scip.Document, cache callableSymbolCache, position scip.Position) []shared.Call {
+	occurrences := sortedOccurrences(document)
+	definitions := callableDefinitions(occurrences, cache)
+
+	i := enclosingDefinition(definitions, position)
+	if i < 0 {
+		return nil
+	}
+	start := scip.NewRange(definitions[i].Range).Start
+	var end *scip.Position
+	if i+1 < len(definitions) {
+		end = &scip.NewRange(definitions[i+1].Range).Start
+	}
+
+	var calls []shared.Call
+	callIndexes := map[string]int{}
+	for _, occurrence := range occurrences {
+		if isDefinition(occurrence) || !cache.isCallable(occurrence.Symbol) {
+			continue
+		}
+
+		r := scip.NewRange(occurrence.Range)
+		if comparePositions(r.Start, start) < 0 || (end != nil && comparePositions(r.Start, *end) >= 0) {
+			continue
+		}
+
+		if _, ok := callIndexes[occurrence.Symbol]; !ok {
+			callIndexes[occurrence.Symbol] = len(calls)
+			calls = append(calls, shared.Call{DumpID: uploadID, Path: path, Symbol: occurrence.Symbol})
+		}
+		calls[callIndexes[occurrence.Symbol]].CallSites = append(calls[callIndexes[occurrence.Symbol]].CallSites, translateRange(r))
+	}
+
+	return calls
+}
+
+// sortedOccurrences returns the occurrences of the given document ordered by their start position.
+func sortedOccurrences(document *scip.Document) []*scip.Occurrence {
+	occurrences := make([]*scip.Occurrence, 0, len(document.Occurrences))
+	for _, occurrence := range document.Occurrences {
+		if len(occurrence.Range) >= 3 {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return comparePositions(scip.NewRange(occurrences[i].Range).Start, scip.NewRange(occurrences[j].Range).Start) < 0
+	})
+
+	return occurrences
+}
+
+// callableDefinitions returns the definitions of callable symbols within the given sorted occurrences.
+func callableDefinitions(occurrences []*scip.Occurrence, cache callableSymbolCache) []*scip.Occurrence {
+	var definitions []*scip.Occurrence
+	for _, occurrence := range occurrences {
+		if isDefinition(occurrence) && cache.isCallable(occurrence.Symbol) {
+			definitions = append(definitions, occurrence)
+		}
+	}
+
+	return definitions
+}
+
+// enclosingDefinition returns the index of the last of the given sorted definitions that starts at
+// or before the given position, or -1 if there is no such definition.
+func enclosingDefinition(definitions []*scip.Occurrence, position scip.Position) int {
+	return sort.Search(len(definitions), func(i int) bool {
+		return comparePositions(scip.NewRange(definitions[i].Range).Start, position) > 0
+	}) - 1
+}
+
+func isDefinition(occurrence *scip.Occurrence) bool {
+	return occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0
+}
+
+func rangeContains(r *scip.Range, position scip.Position) bool {
+	return comparePositions(r.Start, position) <= 0 && comparePositions(position, r.End) <= 0
+}
+
+func comparePositions(a, b scip.Position) int {
+	if a.Line != b.Line {
+		if a.Line < b.Line {
+			return -1
+		}
+		return 1
+	}
+	if a.Character != b.Character {
+		if a.Character < b.Character {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+)
+
+const (
+	testSymbolMain    = "scip-go gomod github.com/sourcegraph/sourcegraph 0.1.0 `main`/main()."
+	testSymbolHelper  = "scip-go gomod github.com/sourcegraph/sourcegraph 0.1.0 `main`/helper()."
+	testSymbolPadLeft = "scip-go gomod github.com/sourcegraph/leftpad v1.0.0 `leftpad`/PadLeft()."
+	testSymbolPrintln = "scip-go gomod github.com/golang/go go1.19 fmt/Println()."
+	testSymbolConfig  = "scip-go gomod github.com/sourcegraph/sourcegraph 0.1.0 `main`/config."
+)
+
+// testCallsDocument describes the following source file:
+//
+//	var config = leftpad.PadLeft("")
+//
+//	func main() {
+//		leftpad.PadLeft(config)
+//		fmt.Println(x)
+//		leftpad.PadLeft(x)
+//	}
+//
+//	func helper() {
+//		leftpad.PadLeft(x)
+//	}
+var testCallsDocument = &scip.Document{
+	RelativePath: "main.go",
+	Occurrences: []*scip.Occurrence{
+		{Range: []int32{9, 5, 11}, Symbol: testSymbolHelper, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{0, 4, 10}, Symbol: testSymbolConfig, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{0, 21, 28}, Symbol: testSymbolPadLeft},
+		{Range: []int32{2, 5, 9}, Symbol: testSymbolMain, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{3, 9, 16}, Symbol: testSymbolPadLeft},
+		{Range: []int32{3, 17, 23}, Symbol: testSymbolConfig},
+		{Range: []int32{4, 5, 12}, Symbol: testSymbolPrintln},
+		{Range: []int32{4, 13, 14}, Symbol: "local 1"},
+		{Range: []int32{5, 9, 16}, Symbol: testSymbolPadLeft},
+		{Range: []int32{10, 9, 16}, Symbol: testSymbolPadLeft},
+	},
+}
+
+func TestCallableSymbolsAtPosition(t *testing.T) {
+	testCases := []struct {
+		position scip.Position
+		expected []string
+	}{
+		{scip.Position{Line: 3, Character: 12}, []string{testSymbolPadLeft}},
+		{scip.Position{Line: 2, Character: 5}, []string{testSymbolMain}},
+		{scip.Position{Line: 3, Character: 20}, nil}, // not callable
+		{scip.Position{Line: 4, Character: 13}, nil}, // local symbol
+		{scip.Position{Line: 7, Character: 0}, nil},  // no occurrence
+	}
+
+	for _, testCase := range testCases {
+		symbols := callableSymbolsAtPosition(testCallsDocument, newCallableSymbolCache(), testCase.position)
+		if diff := cmp.Diff(testCase.expected, symbols); diff != "" {
+			t.Errorf("unexpected symbols at %v (-want +got):\n%s", testCase.position, diff)
+		}
+	}
+}
+
+func TestIncomingCalls(t *testing.T) {
+	calls := incomingCalls(42, "main.go", testCallsDocument, newCallableSymbolCache(), map[string]struct{}{testSymbolPadLeft: {}})
+
+	// The call initializing config is not made from within a function
+	expectedCalls := []shared.Call{
+		{DumpID: 42, Path: "main.go", Symbol: testSymbolMain, CallSites: []types.Range{
+			newRange(3, 9, 3, 16),
+			newRange(5, 9, 5, 16),
+		}},
+		{DumpID: 42, Path: "main.go", Symbol: testSymbolHelper, CallSites: []types.Range{
+			newRange(10, 9, 10, 16),
+		}},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	expectedMainCalls := []shared.Call{
+		{DumpID: 42, Path: "main.go", Symbol: testSymbolPadLeft, CallSites: []types.Range{
+			newRange(3, 9, 3, 16),
+			newRange(5, 9, 5, 16),
+		}},
+		{DumpID: 42, Path: "main.go", Symbol: testSymbolPrintln, CallSites: []types.Range{
+			newRange(4, 5, 4, 12),
+		}},
+	}
+	expectedHelperCalls := []shared.Call{
+		{DumpID: 42, Path: "main.go", Symbol: testSymbolPadLeft, CallSites: []types.Range{
+			newRange(10, 9, 10, 16),
+		}},
+	}
+
+	testCases := []struct {
+		position scip.Position
+		expected []shared.Call
+	}{
+		{scip.Position{Line: 2, Character: 6}, expectedMainCalls},   // at definition
+		{scip.Position{Line: 4, Character: 0}, expectedMainCalls},   // within body
+		{scip.Position{Line: 9, Character: 6}, expectedHelperCalls}, // at definition
+		{scip.Position{Line: 0, Character: 22}, nil},                // outside of any function
+	}
+
+	for _, testCase := range testCases {
+		calls := outgoingCalls(42, "main.go", testCallsDocument, newCallableSymbolCache(), testCase.position)
+		if diff := cmp.Diff(testCase.expected, calls); diff != "" {
+			t.Errorf("unexpected calls at %v (-want +got):\n%s", testCase.position, diff)
+		}
+	}
+}
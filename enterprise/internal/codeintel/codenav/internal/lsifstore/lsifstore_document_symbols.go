@@ -0,0 +1,226 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// GetDocumentSymbols returns the definitions within the given document ordered by their position.
+//
+// SCIP symbols describe their kind and their enclosing symbol, so definitions of SCIP documents are
+// returned as a hierarchy. LSIF documents carry neither, so only the definitions attached to an export
+// moniker are returned (the moniker identifier being the only available name), and the hierarchy is
+// inferred from identifiers of the form `package:Outer.Inner`.
+func (s *store) GetDocumentSymbols(ctx context.Context, bundleID int, path string) (_ []shared.DocumentSymbol, err error) {
+	ctx, trace, endObservation := s.operations.getDocumentSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		documentSymbolsDocumentQuery,
+		bundleID,
+		path,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		// Documents of SCIP uploads are stored in a separate set of tables
+		documentData, exists, err = s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+			scipDocumentQuery,
+			bundleID,
+			path,
+		)))
+		if err != nil || !exists {
+			return nil, err
+		}
+	}
+
+	if documentData.SCIPData != nil {
+		symbols := scipDocumentSymbols(documentData.SCIPData)
+		trace.Log(log.Int("numSymbols", len(symbols)))
+		return symbols, nil
+	}
+
+	ranges := make([]precise.RangeData, 0, len(documentData.LSIFData.Ranges))
+	for _, r := range documentData.LSIFData.Ranges {
+		ranges = append(ranges, r)
+	}
+	trace.Log(log.Int("numRanges", len(ranges)))
+
+	definitionResultIDs := extractResultIDs(ranges, func(r precise.RangeData) precise.ID { return r.DefinitionResultID })
+	definitionLocations, err := s.getLocationsWithinFile(ctx, bundleID, definitionResultIDs, path, *documentData.LSIFData)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := lsifDocumentSymbols(*documentData.LSIFData, definitionLocations)
+	trace.Log(log.Int("numSymbols", len(symbols)))
+
+	return symbols, nil
+}
+
+const documentSymbolsDocumentQuery = `
+SELECT
+	dump_id,
+	path,
+	data,
+	ranges,
+	NULL AS hovers,
+	monikers,
+	NULL AS packages,
+	NULL AS diagnostics,
+	NULL AS scip_document
+FROM
+	lsif_data_documents
+WHERE
+	dump_id = %s AND
+	path = %s
+LIMIT 1
+`
+
+// scipDocumentSymbols returns the definitions of global symbols within the given document. Parameters
+// and other symbols that don't make sense in an outline are skipped.
+func scipDocumentSymbols(document *scip.Document) []shared.DocumentSymbol {
+	var symbols []shared.DocumentSymbol
+	defined := map[string]struct{}{}
+
+	for _, occurrence := range sortedOccurrences(document) {
+		if !isDefinition(occurrence) || occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+			continue
+		}
+		if _, ok := defined[occurrence.Symbol]; ok {
+			continue
+		}
+
+		parsed, err := scip.ParseSymbol(occurrence.Symbol)
+		if err != nil || len(parsed.Descriptors) == 0 {
+			continue
+		}
+		kind := scipSymbolKind(parsed.Descriptors)
+		if kind == "" {
+			continue
+		}
+
+		defined[occurrence.Symbol] = struct{}{}
+		symbols = append(symbols, shared.DocumentSymbol{
+			Symbol: occurrence.Symbol,
+			Name:   parsed.Descriptors[len(parsed.Descriptors)-1].Name,
+			Kind:   kind,
+			Range:  translateRange(scip.NewRange(occurrence.Range)),
+		})
+	}
+
+	// The descriptors of a symbol extend the descriptors of its enclosing symbol, so the closest
+	// enclosing definition is the longest defined symbol that ends a descriptor prefix.
+	for i := range symbols {
+		symbol := symbols[i].Symbol
+		for j := len(symbol) - 2; j >= 0; j-- {
+			if !strings.ContainsRune("/#.:!)]", rune(symbol[j])) {
+				continue
+			}
+			if _, ok := defined[symbol[:j+1]]; ok {
+				symbols[i].Parent = symbol[:j+1]
+				break
+			}
+		}
+	}
+
+	return symbols
+}
+
+// scipSymbolKind returns the ctags kind of a symbol with the given descriptors, or an empty string
+// if the symbol should not be part of an outline.
+func scipSymbolKind(descriptors []*scip.Descriptor) string {
+	withinType := len(descriptors) > 1 && descriptors[len(descriptors)-2].Suffix == scip.Descriptor_Type
+
+	switch descriptors[len(descriptors)-1].Suffix {
+	case scip.Descriptor_Namespace:
+		return "namespace"
+	case scip.Descriptor_Type:
+		return "type"
+	case scip.Descriptor_Method:
+		if withinType {
+			return "method"
+		}
+		return "function"
+	case scip.Descriptor_Term:
+		if withinType {
+			return "field"
+		}
+		return "variable"
+	case scip.Descriptor_TypeParameter:
+		return "type parameter"
+	case scip.Descriptor_Macro:
+		return "macro"
+	}
+
+	return ""
+}
+
+// lsifDocumentSymbols returns the ranges of the given document that are one of their own definitions
+// and are attached to an export moniker. The given map holds the definitions within the document of
+// each definition result.
+func lsifDocumentSymbols(document precise.DocumentData, definitionLocations map[precise.ID][]shared.Location) []shared.DocumentSymbol {
+	var symbols []shared.DocumentSymbol
+	defined := map[string]struct{}{}
+
+	for _, r := range document.Ranges {
+		rn := newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter)
+
+		isDefinition := false
+		for _, location := range definitionLocations[r.DefinitionResultID] {
+			if location.Range == rn {
+				isDefinition = true
+				break
+			}
+		}
+		if !isDefinition {
+			continue
+		}
+
+		for _, monikerID := range r.MonikerIDs {
+			moniker, ok := document.Monikers[monikerID]
+			if !ok || moniker.Kind != "export" {
+				continue
+			}
+			if _, ok := defined[moniker.Identifier]; ok {
+				break
+			}
+
+			defined[moniker.Identifier] = struct{}{}
+			symbols = append(symbols, shared.DocumentSymbol{
+				Symbol: moniker.Identifier,
+				Name:   moniker.Identifier[strings.LastIndexAny(moniker.Identifier, ":.")+1:],
+				Range:  rn,
+			})
+			break
+		}
+	}
+
+	for i := range symbols {
+		identifier := symbols[i].Symbol
+		if j := strings.LastIndexAny(identifier, ":."); j >= 0 && identifier[j] == '.' {
+			if _, ok := defined[identifier[:j]]; ok {
+				symbols[i].Parent = identifier[:j]
+			}
+		}
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		return compareBundleRanges(symbols[i].Range, symbols[j].Range)
+	})
+
+	return symbols
+}
@@ -0,0 +1,90 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestSCIPDocumentSymbols(t *testing.T) {
+	const (
+		pkg      = "scip-go gomod github.com/sourcegraph/sourcegraph 0.1.0 `main`/"
+		server   = pkg + "Server#"
+		serve    = server + "Serve()."
+		addr     = server + "addr."
+		tParam   = server + "[T]"
+		param    = serve + "(ctx)"
+		newFunc  = pkg + "NewServer()."
+		config   = pkg + "config."
+		embedded = "scip-go gomod github.com/sourcegraph/sourcegraph 0.1.0 `other`/Embedded#"
+	)
+
+	document := &scip.Document{
+		RelativePath: "server.go",
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 8, 12}, Symbol: pkg, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{2, 4, 10}, Symbol: config, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{4, 5, 11}, Symbol: server, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{4, 12, 13}, Symbol: tParam, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{5, 1, 5}, Symbol: addr, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{6, 1, 9}, Symbol: embedded},
+			{Range: []int32{9, 16, 21}, Symbol: serve, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{9, 22, 25}, Symbol: param, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{10, 1, 2}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{10, 6, 10}, Symbol: addr},
+			{Range: []int32{13, 5, 14}, Symbol: newFunc, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{14, 9, 15}, Symbol: server},
+		},
+	}
+
+	expectedSymbols := []shared.DocumentSymbol{
+		{Symbol: pkg, Name: "main", Kind: "namespace", Range: newRange(0, 8, 0, 12)},
+		{Symbol: config, Name: "config", Kind: "variable", Range: newRange(2, 4, 2, 10), Parent: pkg},
+		{Symbol: server, Name: "Server", Kind: "type", Range: newRange(4, 5, 4, 11), Parent: pkg},
+		{Symbol: tParam, Name: "T", Kind: "type parameter", Range: newRange(4, 12, 4, 13), Parent: server},
+		{Symbol: addr, Name: "addr", Kind: "field", Range: newRange(5, 1, 5, 5), Parent: server},
+		{Symbol: serve, Name: "Serve", Kind: "method", Range: newRange(9, 16, 9, 21), Parent: server},
+		{Symbol: newFunc, Name: "NewServer", Kind: "function", Range: newRange(13, 5, 13, 14), Parent: pkg},
+	}
+	if diff := cmp.Diff(expectedSymbols, scipDocumentSymbols(document)); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestLSIFDocumentSymbols(t *testing.T) {
+	document := precise.DocumentData{
+		Ranges: map[precise.ID]precise.RangeData{
+			"r1": {StartLine: 4, StartCharacter: 5, EndLine: 4, EndCharacter: 11, DefinitionResultID: "d1", MonikerIDs: []precise.ID{"m1"}},
+			"r2": {StartLine: 9, StartCharacter: 16, EndLine: 9, EndCharacter: 21, DefinitionResultID: "d2", MonikerIDs: []precise.ID{"m0", "m2"}},
+			"r3": {StartLine: 14, StartCharacter: 9, EndLine: 14, EndCharacter: 15, DefinitionResultID: "d1", MonikerIDs: []precise.ID{"m1"}},
+			"r4": {StartLine: 13, StartCharacter: 5, EndLine: 13, EndCharacter: 14, DefinitionResultID: "d3", MonikerIDs: []precise.ID{"m3"}},
+			"r5": {StartLine: 10, StartCharacter: 1, EndLine: 10, EndCharacter: 2, DefinitionResultID: "d4"},
+		},
+		Monikers: map[precise.ID]precise.MonikerData{
+			"m0": {Kind: "import", Scheme: "gomod", Identifier: "github.com/sourcegraph/sourcegraph/other:Serve"},
+			"m1": {Kind: "export", Scheme: "gomod", Identifier: "github.com/sourcegraph/sourcegraph/main:Server"},
+			"m2": {Kind: "export", Scheme: "gomod", Identifier: "github.com/sourcegraph/sourcegraph/main:Server.Serve"},
+			"m3": {Kind: "export", Scheme: "gomod", Identifier: "github.com/sourcegraph/sourcegraph/main:NewServer"},
+		},
+	}
+	definitionLocations := map[precise.ID][]shared.Location{
+		"d1": {{DumpID: 42, Path: "server.go", Range: newRange(4, 5, 4, 11)}},
+		"d2": {{DumpID: 42, Path: "server.go", Range: newRange(9, 16, 9, 21)}},
+		"d3": {{DumpID: 42, Path: "server.go", Range: newRange(13, 5, 13, 14)}},
+		"d4": {{DumpID: 42, Path: "server.go", Range: newRange(10, 1, 10, 2)}},
+	}
+
+	// The reference to Server (r3) and the unexported definition (r5) are skipped
+	expectedSymbols := []shared.DocumentSymbol{
+		{Symbol: "github.com/sourcegraph/sourcegraph/main:Server", Name: "Server", Range: newRange(4, 5, 4, 11)},
+		{Symbol: "github.com/sourcegraph/sourcegraph/main:Server.Serve", Name: "Serve", Range: newRange(9, 16, 9, 21), Parent: "github.com/sourcegraph/sourcegraph/main:Server"},
+		{Symbol: "github.com/sourcegraph/sourcegraph/main:NewServer", Name: "NewServer", Range: newRange(13, 5, 13, 14)},
+	}
+	if diff := cmp.Diff(expectedSymbols, lsifDocumentSymbols(document, definitionLocations)); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
@@ -20,6 +20,10 @@ type operations struct {
 	getPackageInformation  *observation.Operation
 	getBulkMonikerResults  *observation.Operation
 	getLocationsWithinFile *observation.Operation
+	getCallableSymbols     *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
+	getDocumentSymbols     *observation.Operation
 
 	locations *observation.Operation
 }
@@ -62,6 +66,10 @@ func newOperations(observationContext *observation.Context) *operations {
 		getPackageInformation:  op("GetPackageInformation"),
 		getBulkMonikerResults:  op("GetBulkMonikerResults"),
 		getLocationsWithinFile: op("GetLocationsWithinFile"),
+		getCallableSymbols:     op("GetCallableSymbols"),
+		getIncomingCalls:       op("GetIncomingCalls"),
+		getOutgoingCalls:       op("GetOutgoingCalls"),
+		getDocumentSymbols:     op("GetDocumentSymbols"),
 
 		locations: subOp("locations"),
 	}
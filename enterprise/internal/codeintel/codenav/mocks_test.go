@@ -19,6 +19,8 @@ import (
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	authz "github.com/sourcegraph/sourcegraph/internal/authz"
 	database "github.com/sourcegraph/sourcegraph/internal/database"
+	search "github.com/sourcegraph/sourcegraph/internal/search"
+	result "github.com/sourcegraph/sourcegraph/internal/search/result"
 	precise "github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
@@ -172,12 +174,18 @@ type MockLsifStore struct {
 	// GetBulkMonikerLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetBulkMonikerLocations.
 	GetBulkMonikerLocationsFunc *LsifStoreGetBulkMonikerLocationsFunc
+	// GetCallableSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCallableSymbols.
+	GetCallableSymbolsFunc *LsifStoreGetCallableSymbolsFunc
 	// GetDefinitionLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDefinitionLocations.
 	GetDefinitionLocationsFunc *LsifStoreGetDefinitionLocationsFunc
 	// GetDiagnosticsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDiagnostics.
 	GetDiagnosticsFunc *LsifStoreGetDiagnosticsFunc
+	// GetDocumentSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentSymbols.
+	GetDocumentSymbolsFunc *LsifStoreGetDocumentSymbolsFunc
 	// GetHoverFunc is an instance of a mock function object controlling the
 	// behavior of the method GetHover.
 	GetHoverFunc *LsifStoreGetHoverFunc
@@ -185,9 +193,15 @@ type MockLsifStore struct {
 	// object controlling the behavior of the method
 	// GetImplementationLocations.
 	GetImplementationLocationsFunc *LsifStoreGetImplementationLocationsFunc
+	// GetIncomingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIncomingCalls.
+	GetIncomingCallsFunc *LsifStoreGetIncomingCallsFunc
 	// GetMonikersByPositionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetMonikersByPosition.
 	GetMonikersByPositionFunc *LsifStoreGetMonikersByPositionFunc
+	// GetOutgoingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetOutgoingCalls.
+	GetOutgoingCallsFunc *LsifStoreGetOutgoingCallsFunc
 	// GetPackageInformationFunc is an instance of a mock function object
 	// controlling the behavior of the method GetPackageInformation.
 	GetPackageInformationFunc *LsifStoreGetPackageInformationFunc
@@ -214,6 +228,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetCallableSymbolsFunc: &LsifStoreGetCallableSymbolsFunc{
+			defaultHook: func(context.Context, int, string, int, int) (r0 []string, r1 error) {
+				return
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []shared.Location, r1 int, r2 error) {
 				return
@@ -224,6 +243,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetDocumentSymbolsFunc: &LsifStoreGetDocumentSymbolsFunc{
+			defaultHook: func(context.Context, int, string) (r0 []shared.DocumentSymbol, r1 error) {
+				return
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 string, r1 types.Range, r2 bool, r3 error) {
 				return
@@ -234,11 +258,21 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetIncomingCallsFunc: &LsifStoreGetIncomingCallsFunc{
+			defaultHook: func(context.Context, []int, []string, int, int) (r0 []shared.Call, r1 int, r2 error) {
+				return
+			},
+		},
 		GetMonikersByPositionFunc: &LsifStoreGetMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 [][]precise.MonikerData, r1 error) {
 				return
 			},
 		},
+		GetOutgoingCallsFunc: &LsifStoreGetOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) (r0 []shared.Call, r1 error) {
+				return
+			},
+		},
 		GetPackageInformationFunc: &LsifStoreGetPackageInformationFunc{
 			defaultHook: func(context.Context, int, string, string) (r0 precise.PackageInformationData, r1 bool, r2 error) {
 				return
@@ -276,6 +310,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetBulkMonikerLocations")
 			},
 		},
+		GetCallableSymbolsFunc: &LsifStoreGetCallableSymbolsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]string, error) {
+				panic("unexpected invocation of MockLsifStore.GetCallableSymbols")
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetDefinitionLocations")
@@ -286,6 +325,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetDiagnostics")
 			},
 		},
+		GetDocumentSymbolsFunc: &LsifStoreGetDocumentSymbolsFunc{
+			defaultHook: func(context.Context, int, string) ([]shared.DocumentSymbol, error) {
+				panic("unexpected invocation of MockLsifStore.GetDocumentSymbols")
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (string, types.Range, bool, error) {
 				panic("unexpected invocation of MockLsifStore.GetHover")
@@ -296,11 +340,21 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetImplementationLocations")
 			},
 		},
+		GetIncomingCallsFunc: &LsifStoreGetIncomingCallsFunc{
+			defaultHook: func(context.Context, []int, []string, int, int) ([]shared.Call, int, error) {
+				panic("unexpected invocation of MockLsifStore.GetIncomingCalls")
+			},
+		},
 		GetMonikersByPositionFunc: &LsifStoreGetMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 				panic("unexpected invocation of MockLsifStore.GetMonikersByPosition")
 			},
 		},
+		GetOutgoingCallsFunc: &LsifStoreGetOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]shared.Call, error) {
+				panic("unexpected invocation of MockLsifStore.GetOutgoingCalls")
+			},
+		},
 		GetPackageInformationFunc: &LsifStoreGetPackageInformationFunc{
 			defaultHook: func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
 				panic("unexpected invocation of MockLsifStore.GetPackageInformation")
@@ -336,21 +390,33 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetBulkMonikerLocationsFunc: &LsifStoreGetBulkMonikerLocationsFunc{
 			defaultHook: i.GetBulkMonikerLocations,
 		},
+		GetCallableSymbolsFunc: &LsifStoreGetCallableSymbolsFunc{
+			defaultHook: i.GetCallableSymbols,
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: i.GetDefinitionLocations,
 		},
 		GetDiagnosticsFunc: &LsifStoreGetDiagnosticsFunc{
 			defaultHook: i.GetDiagnostics,
 		},
+		GetDocumentSymbolsFunc: &LsifStoreGetDocumentSymbolsFunc{
+			defaultHook: i.GetDocumentSymbols,
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: i.GetHover,
 		},
 		GetImplementationLocationsFunc: &LsifStoreGetImplementationLocationsFunc{
 			defaultHook: i.GetImplementationLocations,
 		},
+		GetIncomingCallsFunc: &LsifStoreGetIncomingCallsFunc{
+			defaultHook: i.GetIncomingCalls,
+		},
 		GetMonikersByPositionFunc: &LsifStoreGetMonikersByPositionFunc{
 			defaultHook: i.GetMonikersByPosition,
 		},
+		GetOutgoingCallsFunc: &LsifStoreGetOutgoingCallsFunc{
+			defaultHook: i.GetOutgoingCalls,
+		},
 		GetPackageInformationFunc: &LsifStoreGetPackageInformationFunc{
 			defaultHook: i.GetPackageInformation,
 		},
@@ -495,6 +561,118 @@ func (c LsifStoreGetBulkMonikerLocationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetCallableSymbolsFunc describes the behavior when the
+// GetCallableSymbols method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetCallableSymbolsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]string, error)
+	hooks       []func(context.Context, int, string, int, int) ([]string, error)
+	history     []LsifStoreGetCallableSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCallableSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetCallableSymbols(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]string, error) {
+	r0, r1 := m.GetCallableSymbolsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetCallableSymbolsFunc.appendCall(LsifStoreGetCallableSymbolsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetCallableSymbols
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetCallableSymbolsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCallableSymbols method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreGetCallableSymbolsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetCallableSymbolsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetCallableSymbolsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetCallableSymbolsFunc) nextHook() func(context.Context, int, string, int, int) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetCallableSymbolsFunc) appendCall(r0 LsifStoreGetCallableSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetCallableSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetCallableSymbolsFunc) History() []LsifStoreGetCallableSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetCallableSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetCallableSymbolsFuncCall is an object that describes an
+// invocation of method GetCallableSymbols on an instance of MockLsifStore.
+type LsifStoreGetCallableSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetCallableSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetCallableSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetDefinitionLocationsFunc describes the behavior when the
 // GetDefinitionLocations method of the parent MockLsifStore instance is
 // invoked.
@@ -744,6 +922,114 @@ func (c LsifStoreGetDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetDocumentSymbolsFunc describes the behavior when the
+// GetDocumentSymbols method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetDocumentSymbolsFunc struct {
+	defaultHook func(context.Context, int, string) ([]shared.DocumentSymbol, error)
+	hooks       []func(context.Context, int, string) ([]shared.DocumentSymbol, error)
+	history     []LsifStoreGetDocumentSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetDocumentSymbols(v0 context.Context, v1 int, v2 string) ([]shared.DocumentSymbol, error) {
+	r0, r1 := m.GetDocumentSymbolsFunc.nextHook()(v0, v1, v2)
+	m.GetDocumentSymbolsFunc.appendCall(LsifStoreGetDocumentSymbolsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentSymbols
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetDocumentSymbolsFunc) SetDefaultHook(hook func(context.Context, int, string) ([]shared.DocumentSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentSymbols method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreGetDocumentSymbolsFunc) PushHook(hook func(context.Context, int, string) ([]shared.DocumentSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetDocumentSymbolsFunc) SetDefaultReturn(r0 []shared.DocumentSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]shared.DocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetDocumentSymbolsFunc) PushReturn(r0 []shared.DocumentSymbol, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]shared.DocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetDocumentSymbolsFunc) nextHook() func(context.Context, int, string) ([]shared.DocumentSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetDocumentSymbolsFunc) appendCall(r0 LsifStoreGetDocumentSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetDocumentSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetDocumentSymbolsFunc) History() []LsifStoreGetDocumentSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetDocumentSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetDocumentSymbolsFuncCall is an object that describes an
+// invocation of method GetDocumentSymbols on an instance of MockLsifStore.
+type LsifStoreGetDocumentSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.DocumentSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetDocumentSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetDocumentSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetHoverFunc describes the behavior when the GetHover method of
 // the parent MockLsifStore instance is invoked.
 type LsifStoreGetHoverFunc struct {
@@ -995,6 +1281,121 @@ func (c LsifStoreGetImplementationLocationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetIncomingCallsFunc describes the behavior when the
+// GetIncomingCalls method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetIncomingCallsFunc struct {
+	defaultHook func(context.Context, []int, []string, int, int) ([]shared.Call, int, error)
+	hooks       []func(context.Context, []int, []string, int, int) ([]shared.Call, int, error)
+	history     []LsifStoreGetIncomingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIncomingCalls delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetIncomingCalls(v0 context.Context, v1 []int, v2 []string, v3 int, v4 int) ([]shared.Call, int, error) {
+	r0, r1, r2 := m.GetIncomingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetIncomingCallsFunc.appendCall(LsifStoreGetIncomingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetIncomingCalls
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetIncomingCallsFunc) SetDefaultHook(hook func(context.Context, []int, []string, int, int) ([]shared.Call, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIncomingCalls method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreGetIncomingCallsFunc) PushHook(hook func(context.Context, []int, []string, int, int) ([]shared.Call, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetIncomingCallsFunc) SetDefaultReturn(r0 []shared.Call, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, []int, []string, int, int) ([]shared.Call, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetIncomingCallsFunc) PushReturn(r0 []shared.Call, r1 int, r2 error) {
+	f.PushHook(func(context.Context, []int, []string, int, int) ([]shared.Call, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LsifStoreGetIncomingCallsFunc) nextHook() func(context.Context, []int, []string, int, int) ([]shared.Call, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetIncomingCallsFunc) appendCall(r0 LsifStoreGetIncomingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetIncomingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetIncomingCallsFunc) History() []LsifStoreGetIncomingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetIncomingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetIncomingCallsFuncCall is an object that describes an invocation
+// of method GetIncomingCalls on an instance of MockLsifStore.
+type LsifStoreGetIncomingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 []string
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Call
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetIncomingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetIncomingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // LsifStoreGetMonikersByPositionFunc describes the behavior when the
 // GetMonikersByPosition method of the parent MockLsifStore instance is
 // invoked.
@@ -1114,6 +1515,118 @@ func (c LsifStoreGetMonikersByPositionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LsifStoreGetOutgoingCallsFunc describes the behavior when the
+// GetOutgoingCalls method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetOutgoingCallsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]shared.Call, error)
+	hooks       []func(context.Context, int, string, int, int) ([]shared.Call, error)
+	history     []LsifStoreGetOutgoingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetOutgoingCalls delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetOutgoingCalls(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]shared.Call, error) {
+	r0, r1 := m.GetOutgoingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetOutgoingCallsFunc.appendCall(LsifStoreGetOutgoingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetOutgoingCalls
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetOutgoingCallsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]shared.Call, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetOutgoingCalls method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreGetOutgoingCallsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]shared.Call, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetOutgoingCallsFunc) SetDefaultReturn(r0 []shared.Call, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]shared.Call, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetOutgoingCallsFunc) PushReturn(r0 []shared.Call, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]shared.Call, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetOutgoingCallsFunc) nextHook() func(context.Context, int, string, int, int) ([]shared.Call, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetOutgoingCallsFunc) appendCall(r0 LsifStoreGetOutgoingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetOutgoingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetOutgoingCallsFunc) History() []LsifStoreGetOutgoingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetOutgoingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetOutgoingCallsFuncCall is an object that describes an invocation
+// of method GetOutgoingCalls on an instance of MockLsifStore.
+type LsifStoreGetOutgoingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Call
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetOutgoingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetOutgoingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetPackageInformationFunc describes the behavior when the
 // GetPackageInformation method of the parent MockLsifStore instance is
 // invoked.
@@ -2447,6 +2960,156 @@ func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// MockSymbolsClient is a mock implementation of the SymbolsClient interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
+// used for unit testing.
+type MockSymbolsClient struct {
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *SymbolsClientSearchFunc
+}
+
+// NewMockSymbolsClient creates a new mock of the SymbolsClient interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockSymbolsClient() *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: func(context.Context, search.SymbolsParameters) (r0 result.Symbols, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSymbolsClient creates a new mock of the SymbolsClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSymbolsClient() *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: func(context.Context, search.SymbolsParameters) (result.Symbols, error) {
+				panic("unexpected invocation of MockSymbolsClient.Search")
+			},
+		},
+	}
+}
+
+// NewMockSymbolsClientFrom creates a new mock of the MockSymbolsClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSymbolsClientFrom(i SymbolsClient) *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: i.Search,
+		},
+	}
+}
+
+// SymbolsClientSearchFunc describes the behavior when the Search method of
+// the parent MockSymbolsClient instance is invoked.
+type SymbolsClientSearchFunc struct {
+	defaultHook func(context.Context, search.SymbolsParameters) (result.Symbols, error)
+	hooks       []func(context.Context, search.SymbolsParameters) (result.Symbols, error)
+	history     []SymbolsClientSearchFuncCall
+	mutex       sync.Mutex
+}
+
+// Search delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSymbolsClient) Search(v0 context.Context, v1 search.SymbolsParameters) (result.Symbols, error) {
+	r0, r1 := m.SearchFunc.nextHook()(v0, v1)
+	m.SearchFunc.appendCall(SymbolsClientSearchFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockSymbolsClient instance is invoked and the hook queue is empty.
+func (f *SymbolsClientSearchFunc) SetDefaultHook(hook func(context.Context, search.SymbolsParameters) (result.Symbols, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Search method of the parent MockSymbolsClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SymbolsClientSearchFunc) PushHook(hook func(context.Context, search.SymbolsParameters) (result.Symbols, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SymbolsClientSearchFunc) SetDefaultReturn(r0 result.Symbols, r1 error) {
+	f.SetDefaultHook(func(context.Context, search.SymbolsParameters) (result.Symbols, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SymbolsClientSearchFunc) PushReturn(r0 result.Symbols, r1 error) {
+	f.PushHook(func(context.Context, search.SymbolsParameters) (result.Symbols, error) {
+		return r0, r1
+	})
+}
+
+func (f *SymbolsClientSearchFunc) nextHook() func(context.Context, search.SymbolsParameters) (result.Symbols, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SymbolsClientSearchFunc) appendCall(r0 SymbolsClientSearchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SymbolsClientSearchFuncCall objects
+// describing the invocations of this function.
+func (f *SymbolsClientSearchFunc) History() []SymbolsClientSearchFuncCall {
+	f.mutex.Lock()
+	history := make([]SymbolsClientSearchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SymbolsClientSearchFuncCall is an object that describes an invocation of
+// method Search on an instance of MockSymbolsClient.
+type SymbolsClientSearchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 search.SymbolsParameters
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 result.Symbols
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SymbolsClientSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SymbolsClientSearchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockUploadService is a mock implementation of the UploadService interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
@@ -19,6 +19,9 @@ type operations struct {
 	getDefinitions         *observation.Operation
 	getRanges              *observation.Operation
 	getStencil             *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
+	getDocumentSymbols     *observation.Operation
 	getDumpsByIDs          *observation.Operation
 	getClosestDumpsForBlob *observation.Operation
 }
@@ -47,6 +50,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		getDefinitions:         op("getDefinitions"),
 		getRanges:              op("getRanges"),
 		getStencil:             op("getStencil"),
+		getIncomingCalls:       op("getIncomingCalls"),
+		getOutgoingCalls:       op("getOutgoingCalls"),
+		getDocumentSymbols:     op("getDocumentSymbols"),
 		getDumpsByIDs:          op("GetDumpsByIDs"),
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
 	}
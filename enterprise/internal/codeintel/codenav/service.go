@@ -2,6 +2,8 @@ package codenav
 
 import (
 	"context"
+	"regexp"
+	"sort"
 	"strings"
 
 	traceLog "github.com/opentracing/opentracing-go/log"
@@ -17,6 +19,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -26,6 +30,7 @@ type Service struct {
 	lsifstore  lsifstore.LsifStore
 	gitserver  GitserverClient
 	uploadSvc  UploadService
+	symbols    SymbolsClient
 	operations *operations
 	logger     log.Logger
 }
@@ -35,6 +40,7 @@ func newService(
 	lsifstore lsifstore.LsifStore,
 	uploadSvc UploadService,
 	gitserver GitserverClient,
+	symbols SymbolsClient,
 	observationContext *observation.Context,
 ) *Service {
 	return &Service{
@@ -42,6 +48,7 @@ func newService(
 		lsifstore:  lsifstore,
 		gitserver:  gitserver,
 		uploadSvc:  uploadSvc,
+		symbols:    symbols,
 		operations: newOperations(observationContext),
 		logger:     log.Scoped("codenav", ""),
 	}
@@ -422,38 +429,8 @@ func (s *Service) getPageRemoteLocations(
 	args shared.RequestArgs,
 	requestState RequestState,
 ) ([]shared.Location, bool, error) {
-	for len(cursor.UploadBatchIDs) == 0 {
-		if cursor.UploadOffset < 0 {
-			// No more batches
-			return nil, false, nil
-		}
-
-		ignoreIDs := []int{}
-		for _, adjustedUpload := range visibleUploads {
-			ignoreIDs = append(ignoreIDs, adjustedUpload.Upload.ID)
-		}
-
-		// Find the next batch of indexes to perform a moniker search over
-		referenceUploadIDs, recordsScanned, totalRecords, err := s.uploadSvc.GetUploadIDsWithReferences(
-			ctx,
-			orderedMonikers,
-			ignoreIDs,
-			args.RepositoryID,
-			args.Commit,
-			requestState.maximumIndexesPerMonikerSearch,
-			cursor.UploadOffset,
-		)
-		if err != nil {
-			return nil, false, err
-		}
-
-		cursor.UploadBatchIDs = referenceUploadIDs
-		cursor.UploadOffset += recordsScanned
-
-		if cursor.UploadOffset >= totalRecords {
-			// Signal no batches remaining
-			cursor.UploadOffset = -1
-		}
+	if ok, err := s.getNextRemoteUploadBatch(ctx, visibleUploads, orderedMonikers, cursor, args, requestState); err != nil || !ok {
+		return nil, false, err
 	}
 
 	// Fetch the upload records we don't currently have hydrated and insert them into the map
@@ -498,6 +475,54 @@ func (s *Service) getPageRemoteLocations(
 	return filtered, hasAnotherPage, nil
 }
 
+// getNextRemoteUploadBatch ensures that the given cursor holds a non-empty batch of indexes referring to
+// one of the given monikers to perform a moniker search over. If there are no more batches left, a
+// false-valued flag is returned.
+func (s *Service) getNextRemoteUploadBatch(
+	ctx context.Context,
+	visibleUploads []visibleUpload,
+	orderedMonikers []precise.QualifiedMonikerData,
+	cursor *shared.RemoteCursor,
+	args shared.RequestArgs,
+	requestState RequestState,
+) (bool, error) {
+	for len(cursor.UploadBatchIDs) == 0 {
+		if cursor.UploadOffset < 0 {
+			// No more batches
+			return false, nil
+		}
+
+		ignoreIDs := []int{}
+		for _, adjustedUpload := range visibleUploads {
+			ignoreIDs = append(ignoreIDs, adjustedUpload.Upload.ID)
+		}
+
+		// Find the next batch of indexes to perform a moniker search over
+		referenceUploadIDs, recordsScanned, totalRecords, err := s.uploadSvc.GetUploadIDsWithReferences(
+			ctx,
+			orderedMonikers,
+			ignoreIDs,
+			args.RepositoryID,
+			args.Commit,
+			requestState.maximumIndexesPerMonikerSearch,
+			cursor.UploadOffset,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		cursor.UploadBatchIDs = referenceUploadIDs
+		cursor.UploadOffset += recordsScanned
+
+		if cursor.UploadOffset >= totalRecords {
+			// Signal no batches remaining
+			cursor.UploadOffset = -1
+		}
+	}
+
+	return true, nil
+}
+
 // getUploadLocations translates a set of locations into an equivalent set of locations in the requested
 // commit. If includeFallbackLocations is true, then any range in the indexed commit that cannot be translated
 // will use the indexed location. Otherwise, such location are dropped.
@@ -1127,6 +1152,442 @@ func (s *Service) GetStencil(ctx context.Context, args shared.RequestArgs, reque
 	return dedupeRanges(sortedRanges), nil
 }
 
+// GetIncomingCalls returns the calls to the function or method at the given position, grouped by the
+// calling function or method. Calls from uploads other than the visible uploads are found by moniker
+// search over the uploads referring to the package defining the function or method.
+func (s *Service) GetIncomingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.CallsCursor) (_ []shared.AdjustedCall, _ shared.CallsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getIncomingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	// Adjust the path and position for each visible upload based on its git difference to
+	// the target commit. This data may already be stashed in the cursor decoded above, in
+	// which case we don't need to hit the database.
+	visibleUploads, cursorsToVisibleUploads, err := s.getVisibleUploadsFromCursor(ctx, args.Line, args.Character, &cursor.CursorsToVisibleUploads, requestState)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Update the cursors with the updated visible uploads.
+	cursor.CursorsToVisibleUploads = cursorsToVisibleUploads
+
+	// Gather the functions and methods at the requested position along with the monikers of the
+	// packages defining them. This data may already be stashed in the cursor decoded above, in
+	// which case we don't need to hit the database.
+	if cursor.Symbols == nil {
+		if cursor.Symbols, err = s.getCallableSymbols(ctx, visibleUploads); err != nil {
+			return nil, cursor, err
+		}
+		cursor.OrderedMonikers = symbolMonikers(cursor.Symbols)
+	}
+	trace.Log(
+		traceLog.Int("numSymbols", len(cursor.Symbols)),
+		traceLog.String("symbols", strings.Join(cursor.Symbols, ", ")),
+		traceLog.Int("numMonikers", len(cursor.OrderedMonikers)),
+		traceLog.String("monikers", monikersToString(cursor.OrderedMonikers)),
+	)
+
+	if cursor.Phase == "" {
+		cursor.Phase = "local"
+	}
+
+	// Phase 1: Gather all calls from within the visible uploads. Pages are counted in documents
+	// containing calls rather than in calls.
+	var calls []shared.Call
+	if cursor.Phase == "local" {
+		uploadIDs := make([]int, 0, len(visibleUploads))
+		for i := range visibleUploads {
+			uploadIDs = append(uploadIDs, visibleUploads[i].Upload.ID)
+		}
+
+		localCalls, totalCount, err := s.lsifstore.GetIncomingCalls(ctx, uploadIDs, cursor.Symbols, args.Limit, cursor.LocalCursor.LocationOffset)
+		if err != nil {
+			return nil, cursor, errors.Wrap(err, "lsifStore.GetIncomingCalls")
+		}
+		calls = append(calls, localCalls...)
+
+		cursor.LocalCursor.LocationOffset += args.Limit
+		if cursor.LocalCursor.LocationOffset >= totalCount {
+			// No more local results, move on to phase 2
+			cursor.Phase = "remote"
+		}
+	}
+
+	// Phase 2: Gather all calls from other uploads via moniker search. We only do this if there are
+	// no more local results. We'll continue to request additional calls until we fill an entire page
+	// or there are no more results remaining.
+	if cursor.Phase == "remote" {
+		if cursor.RemoteCursor.UploadBatchIDs == nil {
+			cursor.RemoteCursor.UploadBatchIDs = []int{}
+			definitionUploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, cursor.OrderedMonikers, requestState)
+			if err != nil {
+				return nil, cursor, err
+			}
+			for i := range definitionUploads {
+				if !isVisibleUpload(visibleUploads, definitionUploads[i].ID) {
+					cursor.RemoteCursor.UploadBatchIDs = append(cursor.RemoteCursor.UploadBatchIDs, definitionUploads[i].ID)
+				}
+			}
+		}
+
+		for len(calls) < args.Limit {
+			remoteCalls, hasMore, err := s.getPageRemoteIncomingCalls(ctx, visibleUploads, cursor.Symbols, cursor.OrderedMonikers, &cursor.RemoteCursor, args.Limit-len(calls), args, requestState)
+			if err != nil {
+				return nil, cursor, err
+			}
+			calls = append(calls, remoteCalls...)
+
+			if !hasMore {
+				cursor.Phase = "done"
+				break
+			}
+		}
+	}
+
+	trace.Log(traceLog.Int("numCalls", len(calls)))
+
+	// Adjust the call sites back to the appropriate range in the target commits.
+	adjustedCalls, err := s.getAdjustedCalls(ctx, args, requestState, calls)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.Log(traceLog.Int("numAdjustedCalls", len(adjustedCalls)))
+
+	return adjustedCalls, cursor, nil
+}
+
+// GetOutgoingCalls returns the calls made from within the function or method at the given position,
+// grouped by the called function or method.
+func (s *Service) GetOutgoingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.CallsCursor) (_ []shared.AdjustedCall, _ shared.CallsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	// Adjust the path and position for each visible upload based on its git difference to
+	// the target commit. This data may already be stashed in the cursor decoded above, in
+	// which case we don't need to hit the database.
+	visibleUploads, cursorsToVisibleUploads, err := s.getVisibleUploadsFromCursor(ctx, args.Line, args.Character, &cursor.CursorsToVisibleUploads, requestState)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Update the cursors with the updated visible uploads.
+	cursor.CursorsToVisibleUploads = cursorsToVisibleUploads
+
+	// All calls made from within a function or method are located in the document defining it,
+	// so there is no remote phase.
+	var calls []shared.Call
+	for i := range visibleUploads {
+		if len(calls) >= args.Limit {
+			// We've filled the page
+			break
+		}
+		if i < cursor.LocalCursor.UploadOffset {
+			// Skip indexes we've searched completely
+			continue
+		}
+
+		uploadCalls, err := s.lsifstore.GetOutgoingCalls(
+			ctx,
+			visibleUploads[i].Upload.ID,
+			visibleUploads[i].TargetPathWithoutRoot,
+			visibleUploads[i].TargetPosition.Line,
+			visibleUploads[i].TargetPosition.Character,
+		)
+		if err != nil {
+			return nil, cursor, errors.Wrap(err, "lsifStore.GetOutgoingCalls")
+		}
+
+		if cursor.LocalCursor.LocationOffset < len(uploadCalls) {
+			uploadCalls = uploadCalls[cursor.LocalCursor.LocationOffset:]
+			if limit := args.Limit - len(calls); len(uploadCalls) > limit {
+				uploadCalls = uploadCalls[:limit]
+			}
+			calls = append(calls, uploadCalls...)
+			cursor.LocalCursor.LocationOffset += len(uploadCalls)
+		}
+
+		if len(calls) < args.Limit {
+			// Skip this index on next request
+			cursor.LocalCursor.LocationOffset = 0
+			cursor.LocalCursor.UploadOffset++
+		}
+	}
+
+	cursor.Phase = "local"
+	if cursor.LocalCursor.UploadOffset >= len(visibleUploads) {
+		cursor.Phase = "done"
+	}
+	trace.Log(traceLog.Int("numCalls", len(calls)))
+
+	// Adjust the call sites back to the appropriate range in the target commits.
+	adjustedCalls, err := s.getAdjustedCalls(ctx, args, requestState, calls)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.Log(traceLog.Int("numAdjustedCalls", len(adjustedCalls)))
+
+	return adjustedCalls, cursor, nil
+}
+
+// getCallableSymbols returns the distinct functions and methods at the target position of the given
+// visible uploads.
+func (s *Service) getCallableSymbols(ctx context.Context, visibleUploads []visibleUpload) ([]string, error) {
+	symbols := []string{}
+	seen := map[string]struct{}{}
+
+	for i := range visibleUploads {
+		uploadSymbols, err := s.lsifstore.GetCallableSymbols(
+			ctx,
+			visibleUploads[i].Upload.ID,
+			visibleUploads[i].TargetPathWithoutRoot,
+			visibleUploads[i].TargetPosition.Line,
+			visibleUploads[i].TargetPosition.Character,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.GetCallableSymbols")
+		}
+
+		for _, symbol := range uploadSymbols {
+			if _, ok := seen[symbol]; ok {
+				continue
+			}
+
+			seen[symbol] = struct{}{}
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols, nil
+}
+
+// getPageRemoteIncomingCalls returns a slice of the (remote) incoming calls denoted by the given cursor
+// fulfilled by searching a batch of indexes referring to one of the given monikers. The given cursor
+// will be adjusted to reflect the offsets required to resolve the next page of results. If there are
+// no more pages left in the result set, a false-valued flag is returned.
+func (s *Service) getPageRemoteIncomingCalls(
+	ctx context.Context,
+	visibleUploads []visibleUpload,
+	symbols []string,
+	orderedMonikers []precise.QualifiedMonikerData,
+	cursor *shared.RemoteCursor,
+	limit int,
+	args shared.RequestArgs,
+	requestState RequestState,
+) ([]shared.Call, bool, error) {
+	if ok, err := s.getNextRemoteUploadBatch(ctx, visibleUploads, orderedMonikers, cursor, args, requestState); err != nil || !ok {
+		return nil, false, err
+	}
+
+	// Fetch the upload records we don't currently have hydrated and insert them into the map
+	uploads, err := s.getUploadsByIDs(ctx, cursor.UploadBatchIDs, requestState)
+	if err != nil {
+		return nil, false, err
+	}
+	uploadIDs := make([]int, 0, len(uploads))
+	for i := range uploads {
+		uploadIDs = append(uploadIDs, uploads[i].ID)
+	}
+
+	calls, totalCount, err := s.lsifstore.GetIncomingCalls(ctx, uploadIDs, symbols, limit, cursor.LocationOffset)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "lsifStore.GetIncomingCalls")
+	}
+
+	cursor.LocationOffset += limit
+	if cursor.LocationOffset >= totalCount {
+		// Require a new batch on next page
+		cursor.LocationOffset = 0
+		cursor.UploadBatchIDs = []int{}
+	}
+
+	// We have another page if we still have results in the current batch of indexes, or if we
+	// can query a next batch of indexes.
+	hasAnotherPage := len(cursor.UploadBatchIDs) > 0 || cursor.UploadOffset >= 0
+
+	return calls, hasAnotherPage, nil
+}
+
+// getAdjustedCalls translates the call sites of the given calls into equivalent locations in the
+// requested commit. Calls without any call sites visible to the current user are dropped.
+func (s *Service) getAdjustedCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, calls []shared.Call) ([]shared.AdjustedCall, error) {
+	adjustedCalls := make([]shared.AdjustedCall, 0, len(calls))
+	for _, call := range calls {
+		locations := make([]shared.Location, 0, len(call.CallSites))
+		for _, callSite := range call.CallSites {
+			locations = append(locations, shared.Location{
+				DumpID: call.DumpID,
+				Path:   call.Path,
+				Range:  callSite,
+			})
+		}
+
+		callSites, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(callSites) == 0 {
+			continue
+		}
+
+		adjustedCalls = append(adjustedCalls, shared.AdjustedCall{
+			Symbol:    call.Symbol,
+			CallSites: callSites,
+		})
+	}
+
+	return adjustedCalls, nil
+}
+
+// GetDocumentSymbols returns the definitions within the requested document, adjusted to the requested
+// commit. Definitions of the first upload containing the document are returned. If no upload contains
+// the document, the definitions are taken from the (search-based) symbols service instead.
+func (s *Service) GetDocumentSymbols(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []shared.DocumentSymbol, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getDocumentSymbols, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+		},
+	})
+	defer endObservation()
+
+	uploadsWithPath, err := s.getUploadPaths(ctx, args.Path, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range uploadsWithPath {
+		trace.Log(traceLog.Int("uploadID", uploadsWithPath[i].Upload.ID))
+
+		symbols, err := s.lsifstore.GetDocumentSymbols(
+			ctx,
+			uploadsWithPath[i].Upload.ID,
+			uploadsWithPath[i].TargetPathWithoutRoot,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.GetDocumentSymbols")
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+
+		adjustedSymbols := make([]shared.DocumentSymbol, 0, len(symbols))
+		for _, symbol := range symbols {
+			// Adjust the definition back to the appropriate range in the target commit, dropping
+			// definitions that were changed since the indexed commit
+			_, adjustedRange, ok, err := s.getSourceRange(ctx, args, requestState, uploadsWithPath[i].Upload.RepositoryID, uploadsWithPath[i].Upload.Commit, uploadsWithPath[i].TargetPath, symbol.Range)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			symbol.Range = adjustedRange
+			adjustedSymbols = append(adjustedSymbols, symbol)
+		}
+		trace.Log(traceLog.Int("numSymbols", len(adjustedSymbols)))
+
+		return adjustedSymbols, nil
+	}
+
+	symbols, err := s.getSearchBasedDocumentSymbols(ctx, args, requestState)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(traceLog.Int("numSearchBasedSymbols", len(symbols)))
+
+	return symbols, nil
+}
+
+// maximumSearchBasedDocumentSymbols is the maximum number of symbols requested from the symbols
+// service for a single document.
+const maximumSearchBasedDocumentSymbols = 1000
+
+// getSearchBasedDocumentSymbols returns the symbols the symbols service finds in the requested document.
+// The symbols service only provides the name of the parent of a symbol, so symbols are identified by their
+// name qualified by their parent's name.
+func (s *Service) getSearchBasedDocumentSymbols(ctx context.Context, args shared.RequestArgs, requestState RequestState) ([]shared.DocumentSymbol, error) {
+	searchSymbols, err := s.symbols.Search(ctx, search.SymbolsParameters{
+		Repo:            requestState.RepositoryName,
+		CommitID:        api.CommitID(args.Commit),
+		IncludePatterns: []string{"^" + regexp.QuoteMeta(args.Path) + "$"},
+		IsCaseSensitive: true,
+		First:           maximumSearchBasedDocumentSymbols,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "symbols.Search")
+	}
+
+	qualifiedName := func(symbol result.Symbol) string {
+		if symbol.Parent == "" {
+			return symbol.Name
+		}
+		return symbol.Parent + "." + symbol.Name
+	}
+
+	qualifiedNames := make(map[string]struct{}, len(searchSymbols))
+	namesToQualifiedNames := make(map[string]string, len(searchSymbols))
+	for _, symbol := range searchSymbols {
+		qualifiedNames[qualifiedName(symbol)] = struct{}{}
+		if _, ok := namesToQualifiedNames[symbol.Name]; !ok {
+			namesToQualifiedNames[symbol.Name] = qualifiedName(symbol)
+		}
+	}
+
+	symbols := make([]shared.DocumentSymbol, 0, len(searchSymbols))
+	for _, symbol := range searchSymbols {
+		// The parent is either named by its qualified name or only by its own name
+		parent := ""
+		if _, ok := qualifiedNames[symbol.Parent]; ok {
+			parent = symbol.Parent
+		} else if qualifiedParent, ok := namesToQualifiedNames[symbol.Parent]; ok {
+			parent = qualifiedParent
+		}
+
+		// Symbol lines are 1-indexed
+		line := symbol.Line - 1
+
+		symbols = append(symbols, shared.DocumentSymbol{
+			Symbol: qualifiedName(symbol),
+			Name:   symbol.Name,
+			Kind:   strings.ToLower(symbol.Kind),
+			Range: types.Range{
+				Start: types.Position{Line: line, Character: symbol.Character},
+				End:   types.Position{Line: line, Character: symbol.Character + len(symbol.Name)},
+			},
+			Parent: parent,
+		})
+	}
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].Range.Start.Line < symbols[j].Range.Start.Line ||
+			(symbols[i].Range.Start.Line == symbols[j].Range.Start.Line && symbols[i].Range.Start.Character < symbols[j].Range.Start.Character)
+	})
+
+	return symbols, nil
+}
+
 func (s *Service) GetDumpsByIDs(ctx context.Context, ids []int) (_ []types.Dump, err error) {
 	ctx, _, endObservation := s.operations.getDumpsByIDs.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -0,0 +1,220 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+const (
+	testCallee  = "scip-go gomod github.com/sourcegraph/leftpad v1.0.0 `leftpad`/PadLeft()."
+	testCaller1 = "scip-go gomod github.com/sourcegraph/sourcegraph 0.1.0 `main`/main()."
+	testCaller2 = "scip-go gomod github.com/sourcegraph/leftpad v1.0.0 `leftpad`/PadRight()."
+	testCaller3 = "scip-go gomod github.com/sourcegraph/tools 0.2.0 `fmt`/Format()."
+)
+
+func TestIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []codeintelgitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	// Both visible uploads know the callee
+	mockLsifStore.GetCallableSymbolsFunc.PushReturn([]string{testCallee}, nil)
+	mockLsifStore.GetCallableSymbolsFunc.PushReturn([]string{testCallee}, nil)
+
+	definitionUploads := []types.Dump{
+		{ID: 150, RepositoryID: 43, Commit: "deadbeef1", Root: "lib/"},
+	}
+	mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.PushReturn(definitionUploads, nil)
+
+	referenceUploads := []types.Dump{
+		{ID: 250, RepositoryID: 44, Commit: "deadbeef2", Root: "cmd/"},
+	}
+	mockUploadSvc.GetDumpsByIDsFunc.PushReturn(referenceUploads, nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{250}, 1, 1, nil)
+
+	mockLsifStore.GetIncomingCallsFunc.PushReturn([]shared.Call{
+		{DumpID: 51, Path: "main.go", Symbol: testCaller1, CallSites: []types.Range{testRange1, testRange2}},
+	}, 1, nil)
+	mockLsifStore.GetIncomingCallsFunc.PushReturn([]shared.Call{
+		{DumpID: 150, Path: "pad.go", Symbol: testCaller2, CallSites: []types.Range{testRange3}},
+	}, 1, nil)
+	mockLsifStore.GetIncomingCallsFunc.PushReturn([]shared.Call{
+		{DumpID: 250, Path: "fmt.go", Symbol: testCaller3, CallSites: []types.Range{testRange4}},
+	}, 1, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        50,
+	}
+	adjustedCalls, cursor, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState, shared.CallsCursor{})
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedCalls := []shared.AdjustedCall{
+		{Symbol: testCaller1, CallSites: []types.UploadLocation{
+			{Dump: uploads[1], Path: "sub2/main.go", TargetCommit: "deadbeef", TargetRange: testRange1},
+			{Dump: uploads[1], Path: "sub2/main.go", TargetCommit: "deadbeef", TargetRange: testRange2},
+		}},
+		{Symbol: testCaller2, CallSites: []types.UploadLocation{
+			{Dump: definitionUploads[0], Path: "lib/pad.go", TargetCommit: "deadbeef1", TargetRange: testRange3},
+		}},
+		{Symbol: testCaller3, CallSites: []types.UploadLocation{
+			{Dump: referenceUploads[0], Path: "cmd/fmt.go", TargetCommit: "deadbeef2", TargetRange: testRange4},
+		}},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected phase. want=%q have=%q", "done", cursor.Phase)
+	}
+	if diff := cmp.Diff([]string{testCallee}, cursor.Symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	expectedMonikers := []precise.QualifiedMonikerData{
+		{
+			MonikerData:            precise.MonikerData{Kind: "export", Scheme: "scip-go", Identifier: testCallee},
+			PackageInformationData: precise.PackageInformationData{Name: "github.com/sourcegraph/leftpad", Version: "v1.0.0"},
+		},
+	}
+	if diff := cmp.Diff(expectedMonikers, cursor.OrderedMonikers); diff != "" {
+		t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetIncomingCallsFunc.History(); len(history) != 3 {
+		t.Fatalf("unexpected number of calls to GetIncomingCalls. want=%d have=%d", 3, len(history))
+	} else {
+		for i, expectedUploadIDs := range [][]int{{50, 51}, {150}, {250}} {
+			if diff := cmp.Diff(expectedUploadIDs, history[i].Arg1); diff != "" {
+				t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+			}
+		}
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	calls := []shared.Call{
+		{DumpID: 50, Path: "main.go", Symbol: testCaller1, CallSites: []types.Range{testRange1}},
+		{DumpID: 50, Path: "main.go", Symbol: testCaller2, CallSites: []types.Range{testRange2, testRange3}},
+		{DumpID: 51, Path: "main.go", Symbol: testCaller3, CallSites: []types.Range{testRange4}},
+	}
+	mockLsifStore.GetOutgoingCallsFunc.SetDefaultHook(func(ctx context.Context, uploadID int, path string, line, character int) ([]shared.Call, error) {
+		if uploadID == 50 {
+			return calls[:2], nil
+		}
+		return calls[2:], nil
+	})
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        2,
+	}
+
+	// First page fills up with the calls of the first upload
+	adjustedCalls, cursor, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, shared.CallsCursor{})
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls := []shared.AdjustedCall{
+		{Symbol: testCaller1, CallSites: []types.UploadLocation{
+			{Dump: uploads[0], Path: "sub1/main.go", TargetCommit: "deadbeef", TargetRange: testRange1},
+		}},
+		{Symbol: testCaller2, CallSites: []types.UploadLocation{
+			{Dump: uploads[0], Path: "sub1/main.go", TargetCommit: "deadbeef", TargetRange: testRange2},
+			{Dump: uploads[0], Path: "sub1/main.go", TargetCommit: "deadbeef", TargetRange: testRange3},
+		}},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if cursor.Phase != "local" {
+		t.Errorf("unexpected phase. want=%q have=%q", "local", cursor.Phase)
+	}
+
+	// Second page resumes with the calls of the second upload
+	adjustedCalls, cursor, err = svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls = []shared.AdjustedCall{
+		{Symbol: testCaller3, CallSites: []types.UploadLocation{
+			{Dump: uploads[1], Path: "sub2/main.go", TargetCommit: "deadbeef", TargetRange: testRange4},
+		}},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected phase. want=%q have=%q", "done", cursor.Phase)
+	}
+}
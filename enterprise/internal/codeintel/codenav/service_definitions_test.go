@@ -23,11 +23,12 @@ func TestDefinitions(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -81,11 +82,12 @@ func TestDefinitionsWithSubRepoPermissions(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -149,11 +151,12 @@ func TestDefinitionsRemote(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -272,11 +275,12 @@ func TestDefinitionsRemoteWithSubRepoPermissions(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -23,11 +23,12 @@ func TestDiagnostics(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -95,11 +96,12 @@ func TestDiagnosticsWithSubRepoPermissions(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
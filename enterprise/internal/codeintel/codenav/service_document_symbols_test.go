@@ -0,0 +1,142 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+		{ID: 52, Commit: "deadbeef", Root: "sub3/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	expectedSymbols := []shared.DocumentSymbol{
+		{Symbol: "github.com/sourcegraph/sourcegraph:Server", Name: "Server", Kind: "type", Range: testRange1},
+		{Symbol: "github.com/sourcegraph/sourcegraph:Server.Serve", Name: "Serve", Kind: "method", Range: testRange2, Parent: "github.com/sourcegraph/sourcegraph:Server"},
+	}
+	mockLsifStore.GetDocumentSymbolsFunc.PushReturn(nil, nil)
+	mockLsifStore.GetDocumentSymbolsFunc.PushReturn(expectedSymbols, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+	}
+	symbols, err := svc.GetDocumentSymbols(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying document symbols: %s", err)
+	}
+
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	// Only the first upload with symbols is used
+	if history := mockLsifStore.GetDocumentSymbolsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of calls to GetDocumentSymbols. want=%d have=%d", 2, len(history))
+	}
+	if history := mockSymbolsClient.SearchFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected number of calls to Search. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestDocumentSymbolsSearchBasedFallback(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
+
+	// Set up request state without any uploads
+	mockRequestState := RequestState{RepositoryName: "github.com/sourcegraph/sourcegraph"}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	mockRequestState.SetUploadsDataLoader(nil)
+
+	mockSymbolsClient.SearchFunc.SetDefaultReturn(result.Symbols{
+		{Name: "Serve", Path: mockPath, Line: 10, Character: 16, Kind: "method", Parent: "Server", ParentKind: "struct"},
+		{Name: "Server", Path: mockPath, Line: 5, Character: 5, Kind: "struct"},
+		{Name: "addr", Path: mockPath, Line: 6, Character: 1, Kind: "member", Parent: "Server", ParentKind: "struct"},
+		{Name: "main", Path: mockPath, Line: 1, Character: 8, Kind: "package"},
+		{Name: "NewServer", Path: mockPath, Line: 14, Character: 5, Kind: "func", Parent: "main", ParentKind: "package"},
+	}, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+	}
+	symbols, err := svc.GetDocumentSymbols(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying document symbols: %s", err)
+	}
+
+	newRange := func(line, character, length int) types.Range {
+		return types.Range{
+			Start: types.Position{Line: line, Character: character},
+			End:   types.Position{Line: line, Character: character + length},
+		}
+	}
+	expectedSymbols := []shared.DocumentSymbol{
+		{Symbol: "main", Name: "main", Kind: "package", Range: newRange(0, 8, 4)},
+		{Symbol: "Server", Name: "Server", Kind: "struct", Range: newRange(4, 5, 6)},
+		{Symbol: "Server.addr", Name: "addr", Kind: "member", Range: newRange(5, 1, 4), Parent: "Server"},
+		{Symbol: "Server.Serve", Name: "Serve", Kind: "method", Range: newRange(9, 16, 5), Parent: "Server"},
+		{Symbol: "main.NewServer", Name: "NewServer", Kind: "func", Range: newRange(13, 5, 9), Parent: "main"},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if history := mockSymbolsClient.SearchFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to Search. want=%d have=%d", 1, len(history))
+	} else {
+		expectedArgs := search.SymbolsParameters{
+			Repo:            "github.com/sourcegraph/sourcegraph",
+			CommitID:        api.CommitID(mockCommit),
+			IncludePatterns: []string{`^s1/main\.go$`},
+			IsCaseSensitive: true,
+			First:           maximumSearchBasedDocumentSymbols,
+		}
+		if diff := cmp.Diff(expectedArgs, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected search arguments (-want +got):\n%s", diff)
+		}
+	}
+}
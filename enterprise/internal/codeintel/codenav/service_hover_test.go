@@ -21,11 +21,12 @@ func TestHover(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -76,11 +77,12 @@ func TestHoverRemote(t *testing.T) {
 	mockLsifStore := NewMockLsifStore()
 	mockUploadSvc := NewMockUploadService()
 	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
 	mockGitServer := codeintelgitserver.New(database.NewMockDB(), &observation.TestContext)
 	hunkCache, _ := NewHunkCache(50)
 
 	// Init service
-	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, &observation.TestContext)
 
 	// Set up request state
 	mockRequestState := RequestState{}
@@ -59,6 +59,36 @@ type CodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// Call describes the calls between a symbol and the symbol the call hierarchy was requested
+// for within a single document of a particular dump. For incoming calls, Symbol is the calling
+// symbol; for outgoing calls, Symbol is the called symbol. The call sites are the ranges of
+// the calls within the document.
+type Call struct {
+	DumpID    int
+	Path      string
+	Symbol    string
+	CallSites []types.Range
+}
+
+// AdjustedCall is a call whose call sites have been adjusted to fit the target (originally
+// requested) commit.
+type AdjustedCall struct {
+	Symbol    string
+	CallSites []types.UploadLocation
+}
+
+// DocumentSymbol is a definition within a single document. The kind of a symbol uses the vocabulary
+// of ctags (e.g., function, method, type) so that precise and search-based symbols can be displayed
+// alike, and is empty when unknown. Parent is the Symbol of the closest enclosing definition within
+// the same document, or empty for top-level definitions.
+type DocumentSymbol struct {
+	Symbol string
+	Name   string
+	Kind   string
+	Range  types.Range
+	Parent string
+}
+
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
 type ReferencesCursor struct {
@@ -80,6 +110,17 @@ type ImplementationsCursor struct {
 	RemoteCursor                  RemoteCursor                   `json:"remoteCursor"`
 }
 
+// CallsCursor stores (enough of) the state of a previous incoming or outgoing calls request used to
+// calculate the offset into the result set to be returned by the current request.
+type CallsCursor struct {
+	CursorsToVisibleUploads []CursorToVisibleUpload        `json:"visibleUploads"`
+	Symbols                 []string                       `json:"symbols"`
+	OrderedMonikers         []precise.QualifiedMonikerData `json:"orderedMonikers"`
+	Phase                   string                         `json:"phase"`
+	LocalCursor             LocalCursor                    `json:"localCursor"`
+	RemoteCursor            RemoteCursor                   `json:"remoteCursor"`
+}
+
 // cursorAdjustedUpload
 type CursorToVisibleUpload struct {
 	DumpID                int            `json:"dumpID"`
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
@@ -38,6 +40,43 @@ func uploadIDsToString(vs []types.Dump) string {
 	return strings.Join(ids, ", ")
 }
 
+// symbolMonikers returns monikers identifying the given SCIP symbols along with the packages
+// defining them. The package information is used to find uploads referring to these packages.
+func symbolMonikers(symbols []string) []precise.QualifiedMonikerData {
+	monikerSet := newQualifiedMonikerSet()
+	for _, symbol := range symbols {
+		parsed, err := scip.ParseSymbol(symbol)
+		if err != nil || parsed.Package == nil {
+			continue
+		}
+
+		monikerSet.add(precise.QualifiedMonikerData{
+			MonikerData: precise.MonikerData{
+				Kind:       "export",
+				Scheme:     parsed.Scheme,
+				Identifier: symbol,
+			},
+			PackageInformationData: precise.PackageInformationData{
+				Name:    parsed.Package.Name,
+				Version: parsed.Package.Version,
+			},
+		})
+	}
+
+	return monikerSet.monikers
+}
+
+// isVisibleUpload returns true if the upload with the given identifier is one of the visible uploads.
+func isVisibleUpload(visibleUploads []visibleUpload, id int) bool {
+	for i := range visibleUploads {
+		if visibleUploads[i].Upload.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isSourceLocation returns true if the given location encloses the source position within one of the visible uploads.
 func isSourceLocation(visibleUploads []visibleUpload, location shared.Location) bool {
 	for i := range visibleUploads {
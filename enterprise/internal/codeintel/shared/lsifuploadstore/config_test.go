@@ -106,6 +106,92 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigFilesystem(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":         "Filesystem",
+		"PRECISE_CODE_INTEL_UPLOAD_TTL":             "8h",
+		"PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_PATH": "/data/uploads",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Bucket != "lsif-uploads" {
+		t.Errorf("unexpected value for Filesystem.Bucket. want=%s have=%s", "lsif-uploads", config.Bucket)
+	}
+	if config.TTL != 8*time.Hour {
+		t.Errorf("unexpected value for Filesystem.TTL. want=%v have=%v", 8*time.Hour, config.TTL)
+	}
+	if config.FilesystemPath != "/data/uploads" {
+		t.Errorf("unexpected value for Filesystem.Path. want=%s have=%s", "/data/uploads", config.FilesystemPath)
+	}
+}
+
+func TestConfigFilesystemMissingPath(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND": "Filesystem",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestConfigAzure(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":            "Azure",
+		"PRECISE_CODE_INTEL_UPLOAD_BUCKET":             "lsif-uploads",
+		"PRECISE_CODE_INTEL_UPLOAD_MANAGE_BUCKET":      "true",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_NAME": "test-account",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_KEY":  "dGVzdC1rZXk=",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ENDPOINT":     "http://azurite:10000/test-account",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Bucket != "lsif-uploads" {
+		t.Errorf("unexpected value for Azure.Bucket. want=%s have=%s", "lsif-uploads", config.Bucket)
+	}
+	if config.AzureAccountName != "test-account" {
+		t.Errorf("unexpected value for Azure.AccountName. want=%s have=%s", "test-account", config.AzureAccountName)
+	}
+	if config.AzureAccountKey != "dGVzdC1rZXk=" {
+		t.Errorf("unexpected value for Azure.AccountKey. want=%s have=%s", "dGVzdC1rZXk=", config.AzureAccountKey)
+	}
+	if config.AzureEndpoint != "http://azurite:10000/test-account" {
+		t.Errorf("unexpected value for Azure.Endpoint. want=%s have=%s", "http://azurite:10000/test-account", config.AzureEndpoint)
+	}
+}
+
+func TestConfigInvalidBackend(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND": "FTP",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
@@ -21,6 +21,11 @@ const StalledUploadMaxAge = time.Second * 25
 // "queued" on its next reset.
 const UploadMaxNumResets = 3
 
+// UploadMaxNumRetries is the maximum number of times an upload is processed before it is
+// marked as failed. Processing errors are frequently caused by transient failures of the
+// upload store or the database, so errored uploads are retried with an increasing delay.
+const UploadMaxNumRetries = 3
+
 var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.id"),
 	sqlf.Sprintf("u.commit"),
@@ -59,6 +64,8 @@ var UploadWorkerStoreOptions = dbworkerstore.Options[types.Upload]{
 	`),
 	StalledMaxAge: StalledUploadMaxAge,
 	MaxNumResets:  UploadMaxNumResets,
+	MaxNumRetries: UploadMaxNumRetries,
+	Backoff:       dbworkerstore.JitteredBackoff(dbworkerstore.ExponentialBackoff(time.Minute, time.Hour), 0.2),
 }
 
 func (s *store) WorkerutilStore(observationContext *observation.Context) dbworkerstore.Store[types.Upload] {
@@ -38,6 +38,8 @@ type operations struct {
 	getUploadDocumentsForPath            *observation.Operation
 	updateUploadsVisibleToCommits        *observation.Operation
 	deleteUploadByID                     *observation.Operation
+	getFailedUploads                     *observation.Operation
+	requeueFailedUploads                 *observation.Operation
 	inferClosestUploads                  *observation.Operation
 	deleteUploadsWithoutRepository       *observation.Operation
 	deleteUploadsStuckUploading          *observation.Operation
@@ -134,6 +136,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		getUploadDocumentsForPath:            op("GetUploadDocumentsForPath"),
 		updateUploadsVisibleToCommits:        op("UpdateUploadsVisibleToCommits"),
 		deleteUploadByID:                     op("DeleteUploadByID"),
+		getFailedUploads:                     op("GetFailedUploads"),
+		requeueFailedUploads:                 op("RequeueFailedUploads"),
 		inferClosestUploads:                  op("InferClosestUploads"),
 		deleteUploadsWithoutRepository:       op("DeleteUploadsWithoutRepository"),
 		deleteUploadsStuckUploading:          op("DeleteUploadsStuckUploading"),
@@ -7,6 +7,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/derision-test/glock"
+	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
 	logger "github.com/sourcegraph/log"
 
@@ -173,6 +174,47 @@ func (s *Service) DeleteUploads(ctx context.Context, opts shared.DeleteUploadsOp
 	return s.store.DeleteUploads(ctx, opts)
 }
 
+// GetFailedUploads returns uploads that failed processing, most recently failed uploads first. If a repository
+// identifier is supplied, only uploads of that repository are returned. A zero limit returns all matching uploads.
+func (s *Service) GetFailedUploads(ctx context.Context, repositoryID, limit, offset int) (_ []types.Upload, err error) {
+	ctx, _, endObservation := s.operations.getFailedUploads.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("limit", limit),
+		log.Int("offset", offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.workerutilStore.DeadLetters(ctx, dbworkerstore.DeadLetterOptions{
+		Conditions: failedUploadConditions(repositoryID),
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// RequeueFailedUploads moves uploads that failed processing back into the queue and returns their
+// identifiers. If a repository identifier is supplied, only uploads of that repository are requeued.
+// A zero limit requeues all matching uploads.
+func (s *Service) RequeueFailedUploads(ctx context.Context, repositoryID, limit int) (_ []int, err error) {
+	ctx, _, endObservation := s.operations.requeueFailedUploads.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.workerutilStore.RequeueDeadLetters(ctx, dbworkerstore.DeadLetterOptions{
+		Conditions: failedUploadConditions(repositoryID),
+		Limit:      limit,
+	})
+}
+
+// failedUploadConditions returns the conditions restricting failed uploads to the given repository.
+func failedUploadConditions(repositoryID int) []*sqlf.Query {
+	if repositoryID == 0 {
+		return nil
+	}
+	return []*sqlf.Query{sqlf.Sprintf("u.repository_id = %s", repositoryID)}
+}
+
 func (s *Service) SetRepositoriesForRetentionScan(ctx context.Context, processDelay time.Duration, limit int) (_ []int, err error) {
 	ctx, _, endObservation := s.operations.setRepositoriesForRetentionScan.With(ctx, &err, observation.Args{
 		LogFields: []log.Field{
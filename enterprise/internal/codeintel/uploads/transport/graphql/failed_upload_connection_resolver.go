@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	sharedresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// failedUploadConnectionResolver resolves a page of uploads that failed processing. The total
+// number of failed uploads is not known, so only the next page cursor is reported.
+type failedUploadConnectionResolver struct {
+	uploadSvc    UploadService
+	autoindexSvc AutoIndexingService
+	policySvc    PolicyService
+	uploads      []types.Upload
+	nextOffset   *int
+	prefetcher   *sharedresolvers.Prefetcher
+	traceErrs    *observation.ErrCollector
+}
+
+func (r *failedUploadConnectionResolver) Nodes(ctx context.Context) ([]resolverstubs.LSIFUploadResolver, error) {
+	resolvers := make([]resolverstubs.LSIFUploadResolver, 0, len(r.uploads))
+	for i := range r.uploads {
+		resolvers = append(resolvers, sharedresolvers.NewUploadResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, r.uploads[i], r.prefetcher, r.traceErrs))
+	}
+	return resolvers, nil
+}
+
+func (r *failedUploadConnectionResolver) TotalCount(ctx context.Context) (*int32, error) {
+	return nil, nil
+}
+
+func (r *failedUploadConnectionResolver) PageInfo(ctx context.Context) (resolverstubs.PageInfo, error) {
+	if r.nextOffset == nil {
+		return EncodeCursor(nil), nil
+	}
+
+	cursor := strconv.Itoa(*r.nextOffset)
+	return EncodeCursor(&cursor), nil
+}
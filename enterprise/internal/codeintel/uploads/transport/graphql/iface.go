@@ -21,6 +21,8 @@ type UploadService interface {
 	GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Upload, err error)
 	DeleteUploadByID(ctx context.Context, id int) (_ bool, err error)
 	DeleteUploads(ctx context.Context, opts uploadsshared.DeleteUploadsOptions) (err error)
+	GetFailedUploads(ctx context.Context, repositoryID, limit, offset int) (_ []types.Upload, err error)
+	RequeueFailedUploads(ctx context.Context, repositoryID, limit int) (_ []int, err error)
 }
 
 type AutoIndexingService interface {
@@ -889,6 +889,9 @@ type MockUploadService struct {
 	// GetCommitGraphMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method GetCommitGraphMetadata.
 	GetCommitGraphMetadataFunc *UploadServiceGetCommitGraphMetadataFunc
+	// GetFailedUploadsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetFailedUploads.
+	GetFailedUploadsFunc *UploadServiceGetFailedUploadsFunc
 	// GetListTagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetListTags.
 	GetListTagsFunc *UploadServiceGetListTagsFunc
@@ -902,6 +905,9 @@ type MockUploadService struct {
 	// GetUploadsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsByIDs.
 	GetUploadsByIDsFunc *UploadServiceGetUploadsByIDsFunc
+	// RequeueFailedUploadsFunc is an instance of a mock function object
+	// controlling the behavior of the method RequeueFailedUploads.
+	RequeueFailedUploadsFunc *UploadServiceRequeueFailedUploadsFunc
 }
 
 // NewMockUploadService creates a new mock of the UploadService interface.
@@ -928,6 +934,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		GetFailedUploadsFunc: &UploadServiceGetFailedUploadsFunc{
+			defaultHook: func(context.Context, int, int, int) (r0 []types.Upload, r1 error) {
+				return
+			},
+		},
 		GetListTagsFunc: &UploadServiceGetListTagsFunc{
 			defaultHook: func(context.Context, api.RepoName, ...string) (r0 []*gitdomain.Tag, r1 error) {
 				return
@@ -948,6 +959,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		RequeueFailedUploadsFunc: &UploadServiceRequeueFailedUploadsFunc{
+			defaultHook: func(context.Context, int, int) (r0 []int, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -975,6 +991,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetCommitGraphMetadata")
 			},
 		},
+		GetFailedUploadsFunc: &UploadServiceGetFailedUploadsFunc{
+			defaultHook: func(context.Context, int, int, int) ([]types.Upload, error) {
+				panic("unexpected invocation of MockUploadService.GetFailedUploads")
+			},
+		},
 		GetListTagsFunc: &UploadServiceGetListTagsFunc{
 			defaultHook: func(context.Context, api.RepoName, ...string) ([]*gitdomain.Tag, error) {
 				panic("unexpected invocation of MockUploadService.GetListTags")
@@ -995,6 +1016,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetUploadsByIDs")
 			},
 		},
+		RequeueFailedUploadsFunc: &UploadServiceRequeueFailedUploadsFunc{
+			defaultHook: func(context.Context, int, int) ([]int, error) {
+				panic("unexpected invocation of MockUploadService.RequeueFailedUploads")
+			},
+		},
 	}
 }
 
@@ -1015,6 +1041,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetCommitGraphMetadataFunc: &UploadServiceGetCommitGraphMetadataFunc{
 			defaultHook: i.GetCommitGraphMetadata,
 		},
+		GetFailedUploadsFunc: &UploadServiceGetFailedUploadsFunc{
+			defaultHook: i.GetFailedUploads,
+		},
 		GetListTagsFunc: &UploadServiceGetListTagsFunc{
 			defaultHook: i.GetListTags,
 		},
@@ -1027,6 +1056,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetUploadsByIDsFunc: &UploadServiceGetUploadsByIDsFunc{
 			defaultHook: i.GetUploadsByIDs,
 		},
+		RequeueFailedUploadsFunc: &UploadServiceRequeueFailedUploadsFunc{
+			defaultHook: i.RequeueFailedUploads,
+		},
 	}
 }
 
@@ -1470,6 +1502,122 @@ func (c UploadServiceGetCommitGraphMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// UploadServiceGetFailedUploadsFunc describes the behavior when the
+// GetFailedUploads method of the parent MockUploadService instance is
+// invoked.
+type UploadServiceGetFailedUploadsFunc struct {
+	defaultHook func(context.Context, int, int, int) ([]types.Upload, error)
+	hooks       []func(context.Context, int, int, int) ([]types.Upload, error)
+	history     []UploadServiceGetFailedUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetFailedUploads delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetFailedUploads(v0 context.Context, v1 int, v2 int, v3 int) ([]types.Upload, error) {
+	r0, r1 := m.GetFailedUploadsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetFailedUploadsFunc.appendCall(UploadServiceGetFailedUploadsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetFailedUploads
+// method of the parent MockUploadService instance is invoked and the hook
+// queue is empty.
+func (f *UploadServiceGetFailedUploadsFunc) SetDefaultHook(hook func(context.Context, int, int, int) ([]types.Upload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetFailedUploads method of the parent MockUploadService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UploadServiceGetFailedUploadsFunc) PushHook(hook func(context.Context, int, int, int) ([]types.Upload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetFailedUploadsFunc) SetDefaultReturn(r0 []types.Upload, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int) ([]types.Upload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetFailedUploadsFunc) PushReturn(r0 []types.Upload, r1 error) {
+	f.PushHook(func(context.Context, int, int, int) ([]types.Upload, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadServiceGetFailedUploadsFunc) nextHook() func(context.Context, int, int, int) ([]types.Upload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetFailedUploadsFunc) appendCall(r0 UploadServiceGetFailedUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadServiceGetFailedUploadsFuncCall
+// objects describing the invocations of this function.
+func (f *UploadServiceGetFailedUploadsFunc) History() []UploadServiceGetFailedUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetFailedUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetFailedUploadsFuncCall is an object that describes an
+// invocation of method GetFailedUploads on an instance of
+// MockUploadService.
+type UploadServiceGetFailedUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetFailedUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetFailedUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadServiceGetListTagsFunc describes the behavior when the GetListTags
 // method of the parent MockUploadService instance is invoked.
 type UploadServiceGetListTagsFunc struct {
@@ -1932,3 +2080,117 @@ func (c UploadServiceGetUploadsByIDsFuncCall) Args() []interface{} {
 func (c UploadServiceGetUploadsByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// UploadServiceRequeueFailedUploadsFunc describes the behavior when the
+// RequeueFailedUploads method of the parent MockUploadService instance is
+// invoked.
+type UploadServiceRequeueFailedUploadsFunc struct {
+	defaultHook func(context.Context, int, int) ([]int, error)
+	hooks       []func(context.Context, int, int) ([]int, error)
+	history     []UploadServiceRequeueFailedUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// RequeueFailedUploads delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadService) RequeueFailedUploads(v0 context.Context, v1 int, v2 int) ([]int, error) {
+	r0, r1 := m.RequeueFailedUploadsFunc.nextHook()(v0, v1, v2)
+	m.RequeueFailedUploadsFunc.appendCall(UploadServiceRequeueFailedUploadsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RequeueFailedUploads
+// method of the parent MockUploadService instance is invoked and the hook
+// queue is empty.
+func (f *UploadServiceRequeueFailedUploadsFunc) SetDefaultHook(hook func(context.Context, int, int) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequeueFailedUploads method of the parent MockUploadService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadServiceRequeueFailedUploadsFunc) PushHook(hook func(context.Context, int, int) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceRequeueFailedUploadsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceRequeueFailedUploadsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadServiceRequeueFailedUploadsFunc) nextHook() func(context.Context, int, int) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceRequeueFailedUploadsFunc) appendCall(r0 UploadServiceRequeueFailedUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadServiceRequeueFailedUploadsFuncCall
+// objects describing the invocations of this function.
+func (f *UploadServiceRequeueFailedUploadsFunc) History() []UploadServiceRequeueFailedUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceRequeueFailedUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceRequeueFailedUploadsFuncCall is an object that describes an
+// invocation of method RequeueFailedUploads on an instance of
+// MockUploadService.
+type UploadServiceRequeueFailedUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceRequeueFailedUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceRequeueFailedUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
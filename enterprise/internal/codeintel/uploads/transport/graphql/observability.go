@@ -9,10 +9,12 @@ import (
 
 type operations struct {
 	// LSIF Uploads
-	lsifUploadByID    *observation.Operation
-	lsifUploadsByRepo *observation.Operation
-	deleteLsifUpload  *observation.Operation
-	deleteLsifUploads *observation.Operation
+	lsifUploadByID           *observation.Operation
+	lsifUploadsByRepo        *observation.Operation
+	failedLsifUploads        *observation.Operation
+	deleteLsifUpload         *observation.Operation
+	deleteLsifUploads        *observation.Operation
+	requeueFailedLsifUploads *observation.Operation
 
 	// Commit Graph
 	commitGraph *observation.Operation
@@ -36,10 +38,12 @@ func newOperations(observationContext *observation.Context) *operations {
 
 	return &operations{
 		// LSIF Uploads
-		lsifUploadByID:    op("LSIFUploadByID"),
-		lsifUploadsByRepo: op("LSIFUploadsByRepo"),
-		deleteLsifUpload:  op("DeleteLSIFUpload"),
-		deleteLsifUploads: op("DeleteLSIFUploads"),
+		lsifUploadByID:           op("LSIFUploadByID"),
+		lsifUploadsByRepo:        op("LSIFUploadsByRepo"),
+		failedLsifUploads:        op("FailedLSIFUploads"),
+		deleteLsifUpload:         op("DeleteLSIFUpload"),
+		deleteLsifUploads:        op("DeleteLSIFUploads"),
+		requeueFailedLsifUploads: op("RequeueFailedLSIFUploads"),
 
 		// Commit Graph
 		commitGraph: op("CommitGraph"),
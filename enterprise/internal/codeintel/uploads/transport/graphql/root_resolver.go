@@ -99,6 +99,52 @@ func (r *rootResolver) LSIFUploadsByRepo(ctx context.Context, args *resolverstub
 	return sharedresolvers.NewUploadConnectionResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, uploadsResolver, prefetcher, traceErrs), nil
 }
 
+// 🚨 SECURITY: Only site admins may list failed code intelligence uploads
+func (r *rootResolver) FailedLSIFUploads(ctx context.Context, args *resolverstubs.FailedLSIFUploadsQueryArgs) (_ resolverstubs.LSIFUploadConnectionResolver, err error) {
+	ctx, traceErrs, endObservation := r.operations.failedLsifUploads.WithErrors(ctx, &err, observation.Args{})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+		return nil, err
+	}
+
+	var repositoryID int
+	if args.Repository != nil {
+		if repositoryID, err = resolveRepositoryID(*args.Repository); err != nil {
+			return nil, err
+		}
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+	limit := derefInt32(args.First, DefaultUploadPageSize)
+
+	// Request one more upload than the page size to determine whether there is a next page.
+	uploads, err := r.uploadSvc.GetFailedUploads(ctx, repositoryID, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextOffset *int
+	if len(uploads) > limit {
+		uploads = uploads[:limit]
+		val := offset + limit
+		nextOffset = &val
+	}
+
+	return &failedUploadConnectionResolver{
+		uploadSvc:    r.uploadSvc,
+		autoindexSvc: r.autoindexSvc,
+		policySvc:    r.policySvc,
+		uploads:      uploads,
+		nextOffset:   nextOffset,
+		prefetcher:   sharedresolvers.NewPrefetcher(r.autoindexSvc, r.uploadSvc),
+		traceErrs:    traceErrs,
+	}, nil
+}
+
 // 🚨 SECURITY: Only site admins may modify code intelligence upload data
 func (r *rootResolver) DeleteLSIFUpload(ctx context.Context, args *struct{ ID graphql.ID }) (_ *resolverstubs.EmptyResponse, err error) {
 	ctx, _, endObservation := r.operations.deleteLsifUpload.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -141,3 +187,26 @@ func (r *rootResolver) DeleteLSIFUploads(ctx context.Context, args *resolverstub
 
 	return &resolverstubs.EmptyResponse{}, nil
 }
+
+// 🚨 SECURITY: Only site admins may modify code intelligence upload data
+func (r *rootResolver) RequeueFailedLSIFUploads(ctx context.Context, args *resolverstubs.RequeueFailedLSIFUploadsArgs) (_ *resolverstubs.EmptyResponse, err error) {
+	ctx, _, endObservation := r.operations.requeueFailedLsifUploads.With(ctx, &err, observation.Args{})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.autoindexSvc.GetUnsafeDB()); err != nil {
+		return nil, err
+	}
+
+	var repositoryID int
+	if args.Repository != nil {
+		if repositoryID, err = resolveRepositoryID(*args.Repository); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := r.uploadSvc.RequeueFailedUploads(ctx, repositoryID, derefInt32(args.Limit, 0)); err != nil {
+		return nil, err
+	}
+
+	return &resolverstubs.EmptyResponse{}, nil
+}
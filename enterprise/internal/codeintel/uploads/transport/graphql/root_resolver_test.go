@@ -8,7 +8,10 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	codeinteltypes "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -59,3 +62,137 @@ func TestDeleteLSIFUploadUnauthenticated(t *testing.T) {
 		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
 	}
 }
+
+func TestFailedLSIFUploads(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	repositoryID := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50")))
+	first := int32(2)
+	after := base64.StdEncoding.EncodeToString([]byte("4"))
+
+	mockUploadService := NewMockUploadService()
+	mockUploadService.GetFailedUploadsFunc.SetDefaultReturn([]codeinteltypes.Upload{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(mockUploadService, mockAutoIndexingService, mockPolicyService, &observation.TestContext)
+
+	connection, err := rootResolver.FailedLSIFUploads(context.Background(), &resolverstubs.FailedLSIFUploadsQueryArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+		Repository:     &repositoryID,
+		After:          &after,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockUploadService.GetFailedUploadsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockUploadService.GetFailedUploadsFunc.History()))
+	}
+	call := mockUploadService.GetFailedUploadsFunc.History()[0]
+	if call.Arg1 != 50 {
+		t.Fatalf("unexpected repository id. want=%d have=%d", 50, call.Arg1)
+	}
+	if call.Arg2 != 3 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 3, call.Arg2)
+	}
+	if call.Arg3 != 4 {
+		t.Fatalf("unexpected offset. want=%d have=%d", 4, call.Arg3)
+	}
+
+	nodes, err := connection.Nodes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("unexpected number of nodes. want=%d have=%d", 2, len(nodes))
+	}
+
+	pageInfo, err := connection.PageInfo(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !pageInfo.HasNextPage() {
+		t.Fatalf("expected a next page")
+	}
+	if want := base64.StdEncoding.EncodeToString([]byte("6")); *pageInfo.EndCursor() != want {
+		t.Fatalf("unexpected end cursor. want=%q have=%q", want, *pageInfo.EndCursor())
+	}
+}
+
+func TestFailedLSIFUploadsUnauthenticated(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(mockUploadService, mockAutoIndexingService, mockPolicyService, &observation.TestContext)
+
+	if _, err := rootResolver.FailedLSIFUploads(context.Background(), &resolverstubs.FailedLSIFUploadsQueryArgs{}); err != auth.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
+	}
+	if len(mockUploadService.GetFailedUploadsFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockUploadService.GetFailedUploadsFunc.History()))
+	}
+}
+
+func TestRequeueFailedLSIFUploads(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	repositoryID := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50")))
+	limit := int32(10)
+
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(mockUploadService, mockAutoIndexingService, mockPolicyService, &observation.TestContext)
+
+	if _, err := rootResolver.RequeueFailedLSIFUploads(context.Background(), &resolverstubs.RequeueFailedLSIFUploadsArgs{Repository: &repositoryID, Limit: &limit}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockUploadService.RequeueFailedUploadsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockUploadService.RequeueFailedUploadsFunc.History()))
+	}
+
+	call := mockUploadService.RequeueFailedUploadsFunc.History()[0]
+	if call.Arg1 != 50 {
+		t.Fatalf("unexpected repository id. want=%d have=%d", 50, call.Arg1)
+	}
+	if call.Arg2 != 10 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 10, call.Arg2)
+	}
+}
+
+func TestRequeueFailedLSIFUploadsUnauthenticated(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockUploadService := NewMockUploadService()
+	mockPolicyService := NewMockPolicyService()
+	mockAutoIndexingService := NewMockAutoIndexingService()
+	mockAutoIndexingService.GetUnsafeDBFunc.SetDefaultReturn(db)
+
+	rootResolver := NewRootResolver(mockUploadService, mockAutoIndexingService, mockPolicyService, &observation.TestContext)
+
+	if _, err := rootResolver.RequeueFailedLSIFUploads(context.Background(), &resolverstubs.RequeueFailedLSIFUploadsArgs{}); err != auth.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", auth.ErrNotAuthenticated, err)
+	}
+	if len(mockUploadService.RequeueFailedUploadsFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockUploadService.RequeueFailedUploadsFunc.History()))
+	}
+}
@@ -16,6 +16,6 @@ type actionArgs struct {
 	MonitorOwnerName   string
 
 	Query          string
-	Results        []*result.CommitMatch
+	Results        []result.Match
 	IncludeResults bool
 }
@@ -82,7 +82,10 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 
 	displayResults := make([]*DisplayResult, len(truncatedResults))
 	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+		displayResults[i], err = toDisplayResult(result, args.ExternalURL)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -151,6 +154,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, fm *result.FileMatch, utmSource string) string {
+	return sourcegraphURL(externalURL, fm.File.URL().Path, "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -201,24 +208,25 @@ type DisplayResult struct {
 	Content    string
 }
 
-func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayResult {
-	resultType := "Message"
-	if result.DiffPreview != nil {
-		resultType = "Diff"
-	}
-
-	var content string
-	if result.DiffPreview != nil {
-		content = truncateString(result.DiffPreview.Content, 10)
-	} else {
-		content = truncateString(result.MessagePreview.Content, 10)
-	}
-
-	return &DisplayResult{
-		ResultType: resultType,
-		CommitURL:  getCommitURL(externalURL, string(result.Repo.Name), string(result.Commit.ID), utmSourceEmail),
-		RepoName:   string(result.Repo.Name),
-		CommitID:   result.Commit.ID.Short(),
-		Content:    content,
+func toDisplayResult(match result.Match, externalURL *url.URL) (*DisplayResult, error) {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		return &DisplayResult{
+			ResultType: "File",
+			CommitURL:  getFileURL(externalURL, m, utmSourceEmail),
+			RepoName:   string(m.Repo.Name),
+			CommitID:   m.CommitID.Short(),
+			Content:    fileMatchContent(m),
+		}, nil
+	case *result.CommitMatch:
+		return &DisplayResult{
+			ResultType: commitMatchResultType(m),
+			CommitURL:  getCommitURL(externalURL, string(m.Repo.Name), string(m.Commit.ID), utmSourceEmail),
+			RepoName:   string(m.Repo.Name),
+			CommitID:   m.Commit.ID.Short(),
+			Content:    commitMatchContent(m),
+		}, nil
+	default:
+		return nil, errors.Errorf("unexpected code monitor result type %T", match)
 	}
 }
@@ -37,25 +37,27 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	}
 
 	if args.IncludeResults {
-		for _, result := range truncatedResults {
-			resultType := "Message"
-			if result.DiffPreview != nil {
-				resultType = "Diff"
+		for _, res := range truncatedResults {
+			switch m := res.(type) {
+			case *result.FileMatch:
+				blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+					"File match: <%s|%s@%s:%s>",
+					getFileURL(args.ExternalURL, m, args.UTMSource),
+					m.Repo.Name,
+					m.CommitID.Short(),
+					m.Path,
+				)))
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(fileMatchContent(m))))
+			case *result.CommitMatch:
+				blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+					"%s match: <%s|%s@%s>",
+					commitMatchResultType(m),
+					getCommitURL(args.ExternalURL, string(m.Repo.Name), string(m.Commit.ID), args.UTMSource),
+					m.Repo.Name,
+					m.Commit.ID.Short(),
+				)))
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(commitMatchContent(m))))
 			}
-			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
-				"%s match: <%s|%s@%s>",
-				resultType,
-				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
-				result.Repo.Name,
-				result.Commit.ID.Short(),
-			)))
-			var contentRaw string
-			if result.DiffPreview != nil {
-				contentRaw = truncateString(result.DiffPreview.Content, 10)
-			} else {
-				contentRaw = truncateString(result.MessagePreview.Content, 10)
-			}
-			blocks = append(blocks, newMarkdownSection(formatCodeBlock(contentRaw)))
 		}
 		if truncatedCount > 0 {
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
@@ -94,24 +96,40 @@ func truncateString(input string, lines int) string {
 	return strings.Join(splitLines, "")
 }
 
-func truncateResults(results []*result.CommitMatch, maxResults int) (_ []*result.CommitMatch, totalCount, truncatedCount int) {
-	// Convert to type result.Matches
-	matches := make(result.Matches, len(results))
-	for i, res := range results {
-		matches[i] = res
-	}
-
+func truncateResults(results []result.Match, maxResults int) (_ []result.Match, totalCount, truncatedCount int) {
+	matches := result.Matches(results)
 	totalCount = matches.ResultCount()
 	matches.Limit(maxResults)
 	outputCount := matches.ResultCount()
+	return matches, totalCount, totalCount - outputCount
+}
 
-	// Convert back type []*result.CommitMatch
-	output := make([]*result.CommitMatch, len(matches))
-	for i, match := range matches {
-		output[i] = match.(*result.CommitMatch)
+func commitMatchResultType(cm *result.CommitMatch) string {
+	if cm.DiffPreview != nil {
+		return "Diff"
 	}
+	return "Message"
+}
 
-	return output, totalCount, totalCount - outputCount
+func commitMatchContent(cm *result.CommitMatch) string {
+	if cm.DiffPreview != nil {
+		return truncateString(cm.DiffPreview.Content, 10)
+	}
+	return truncateString(cm.MessagePreview.Content, 10)
+}
+
+func fileMatchContent(fm *result.FileMatch) string {
+	if len(fm.ChunkMatches) == 0 {
+		return fm.Path
+	}
+	var content strings.Builder
+	for _, cm := range fm.ChunkMatches {
+		content.WriteString(cm.Content)
+		if !strings.HasSuffix(cm.Content, "\n") {
+			content.WriteString("\n")
+		}
+	}
+	return truncateString(content.String(), 10)
 }
 
 // adapted from slack.PostWebhookCustomHTTPContext
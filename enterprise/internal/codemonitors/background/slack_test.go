@@ -25,7 +25,7 @@ func TestSlackWebhook(t *testing.T) {
 		MonitorOwnerName:   "Camden Cheek",
 		ExternalURL:        eu,
 		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
-		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		Results:            []result.Match{&diffResultMock, &commitResultMock},
 		IncludeResults:     false,
 	}
 
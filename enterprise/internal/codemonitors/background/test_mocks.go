@@ -30,7 +30,7 @@ var diffResultMock = result.CommitMatch{
 		}},
 	}}
 
-var diffDisplayResultMock = toDisplayResult(&diffResultMock, externalURLMock)
+var diffDisplayResultMock = mustToDisplayResult(&diffResultMock)
 
 var commitResultMock = result.CommitMatch{
 	Commit: gitdomain.Commit{
@@ -49,4 +49,35 @@ var commitResultMock = result.CommitMatch{
 	},
 }
 
-var commitDisplayResultMock = toDisplayResult(&commitResultMock, externalURLMock)
+var commitDisplayResultMock = mustToDisplayResult(&commitResultMock)
+
+var fileResultMock = result.FileMatch{
+	File: result.File{
+		Repo: types.MinimalRepo{
+			Name: api.RepoName("github.com/test/test"),
+		},
+		CommitID: api.CommitID("7815187511872asbasdfgasd"),
+		Path:     "config/secrets.yaml",
+	},
+	ChunkMatches: result.ChunkMatches{{
+		Content:      "aws:\n  AWS_SECRET: hunter2\n",
+		ContentStart: result.Location{Line: 10, Offset: 120, Column: 0},
+		Ranges: result.Ranges{{
+			Start: result.Location{Line: 11, Offset: 127, Column: 2},
+			End:   result.Location{Line: 11, Offset: 137, Column: 12},
+		}, {
+			Start: result.Location{Line: 11, Offset: 139, Column: 14},
+			End:   result.Location{Line: 11, Offset: 146, Column: 21},
+		}},
+	}},
+}
+
+var fileDisplayResultMock = mustToDisplayResult(&fileResultMock)
+
+func mustToDisplayResult(match result.Match) *DisplayResult {
+	displayResult, err := toDisplayResult(match, externalURLMock)
+	if err != nil {
+		panic(err)
+	}
+	return displayResult
+}
@@ -78,34 +78,69 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 }
 
 type webhookResult struct {
-	Repository           string   `json:"repository"`
-	Commit               string   `json:"commit"`
-	Message              string   `json:"message,omitempty"`
-	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
-	Diff                 string   `json:"diff,omitempty"`
-	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+	Repository           string         `json:"repository"`
+	Commit               string         `json:"commit"`
+	Message              string         `json:"message,omitempty"`
+	MatchedMessageRanges [][2]int       `json:"matchedMessageRanges,omitempty"`
+	Diff                 string         `json:"diff,omitempty"`
+	MatchedDiffRanges    [][2]int       `json:"matchedDiffRanges,omitempty"`
+	Path                 string         `json:"path,omitempty"`
+	Chunks               []webhookChunk `json:"chunks,omitempty"`
 }
 
-func generateResults(in []*result.CommitMatch) []webhookResult {
+// webhookChunk is a matched section of a file. Matched ranges are relative to
+// the start of Content.
+type webhookChunk struct {
+	Content       string   `json:"content"`
+	StartLine     int      `json:"startLine"`
+	MatchedRanges [][2]int `json:"matchedRanges"`
+}
+
+func generateResults(in []result.Match) []webhookResult {
 	out := make([]webhookResult, len(in))
 	for i, match := range in {
-		res := webhookResult{
-			Repository: string(match.Repo.Name),
-			Commit:     string(match.Commit.ID),
-		}
-		if match.MessagePreview != nil {
-			res.Message = match.MessagePreview.Content
-			res.MatchedMessageRanges = rangesToInts(match.MessagePreview.MatchedRanges)
-		}
-		if match.DiffPreview != nil {
-			res.Diff = match.DiffPreview.Content
-			res.MatchedDiffRanges = rangesToInts(match.DiffPreview.MatchedRanges)
+		switch m := match.(type) {
+		case *result.FileMatch:
+			out[i] = generateFileResult(m)
+		case *result.CommitMatch:
+			out[i] = generateCommitResult(m)
 		}
-		out[i] = res
 	}
 	return out
 }
 
+func generateCommitResult(match *result.CommitMatch) webhookResult {
+	res := webhookResult{
+		Repository: string(match.Repo.Name),
+		Commit:     string(match.Commit.ID),
+	}
+	if match.MessagePreview != nil {
+		res.Message = match.MessagePreview.Content
+		res.MatchedMessageRanges = rangesToInts(match.MessagePreview.MatchedRanges)
+	}
+	if match.DiffPreview != nil {
+		res.Diff = match.DiffPreview.Content
+		res.MatchedDiffRanges = rangesToInts(match.DiffPreview.MatchedRanges)
+	}
+	return res
+}
+
+func generateFileResult(match *result.FileMatch) webhookResult {
+	res := webhookResult{
+		Repository: string(match.Repo.Name),
+		Commit:     string(match.CommitID),
+		Path:       match.Path,
+	}
+	for _, cm := range match.ChunkMatches {
+		res.Chunks = append(res.Chunks, webhookChunk{
+			Content:       cm.Content,
+			StartLine:     cm.ContentStart.Line,
+			MatchedRanges: rangesToInts(cm.Ranges.Sub(cm.ContentStart)),
+		})
+	}
+	return res
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
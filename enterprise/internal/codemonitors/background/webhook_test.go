@@ -24,7 +24,7 @@ func TestWebhook(t *testing.T) {
 		ExternalURL:        eu,
 		MonitorID:          42,
 		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
-		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		Results:            []result.Match{&diffResultMock, &commitResultMock},
 		IncludeResults:     false,
 	}
 
@@ -69,6 +69,21 @@ func TestWebhook(t *testing.T) {
 	})
 }
 
+func TestGenerateFileResult(t *testing.T) {
+	got := generateResults([]result.Match{&fileResultMock})
+	want := []webhookResult{{
+		Repository: "github.com/test/test",
+		Commit:     "7815187511872asbasdfgasd",
+		Path:       "config/secrets.yaml",
+		Chunks: []webhookChunk{{
+			Content:       "aws:\n  AWS_SECRET: hunter2\n",
+			StartLine:     10,
+			MatchedRanges: [][2]int{{7, 17}, {19, 26}},
+		}},
+	}}
+	require.Equal(t, want, got)
+}
+
 func TestTriggerTestWebhookAction(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
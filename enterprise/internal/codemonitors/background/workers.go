@@ -167,8 +167,9 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	}
 
 	query := q.QueryString
-	if !featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
-		// Only add an after filter when repo-aware monitors is disabled
+	if !featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) && !codemonitors.IsFileMonitorQuery(query) {
+		// Only add an after filter when repo-aware monitors is disabled. File
+		// monitor queries don't search commits, so they never need one.
 		query = newQueryWithAfterFilter(q)
 	}
 	results, searchErr := codemonitors.Search(ctx, logger, r.db, query, m.ID, settings)
@@ -383,7 +384,7 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
-func latestResultTime(previousLastResult *time.Time, results []*result.CommitMatch, searchErr error) time.Time {
+func latestResultTime(previousLastResult *time.Time, results []result.Match, searchErr error) time.Time {
 	if searchErr != nil || len(results) == 0 {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
@@ -393,8 +394,8 @@ func latestResultTime(previousLastResult *time.Time, results []*result.CommitMat
 		return time.Now()
 	}
 
-	if results[0].Commit.Committer != nil {
-		return results[0].Commit.Committer.Date
+	if cm, ok := results[0].(*result.CommitMatch); ok && cm.Commit.Committer != nil {
+		return cm.Commit.Committer.Date
 	}
 	return time.Now()
 }
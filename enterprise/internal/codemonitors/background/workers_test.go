@@ -20,22 +20,28 @@ func TestActionRunner(t *testing.T) {
 	logger := logtest.Scoped(t)
 	tests := []struct {
 		name           string
-		results        []*result.CommitMatch
+		results        []result.Match
 		wantNumResults int
 		wantResults    []*DisplayResult
 	}{
 		{
 			name:           "9 results",
-			results:        []*result.CommitMatch{&diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock},
+			results:        []result.Match{&diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock, &diffResultMock, &commitResultMock},
 			wantNumResults: 9,
 			wantResults:    []*DisplayResult{diffDisplayResultMock, commitDisplayResultMock, diffDisplayResultMock},
 		},
 		{
 			name:           "1 result",
-			results:        []*result.CommitMatch{&commitResultMock},
+			results:        []result.Match{&commitResultMock},
 			wantNumResults: 1,
 			wantResults:    []*DisplayResult{commitDisplayResultMock},
 		},
+		{
+			name:           "file and commit results",
+			results:        []result.Match{&fileResultMock, &commitResultMock},
+			wantNumResults: 3,
+			wantResults:    []*DisplayResult{fileDisplayResultMock, commitDisplayResultMock},
+		},
 	}
 
 	for _, tt := range tests {
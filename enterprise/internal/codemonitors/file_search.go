@@ -0,0 +1,166 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// isFileMonitorQuery returns whether every query in the plan explicitly
+// searches file contents with type:file. Such code monitors trigger when the
+// set of matched files changes between runs rather than on new commits.
+func isFileMonitorQuery(plan query.Plan) bool {
+	if len(plan) == 0 {
+		return false
+	}
+	for _, b := range plan {
+		types, _ := b.IncludeExcludeValues(query.FieldType)
+		if len(types) != 1 || types[0] != "file" {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFileMonitorQuery returns whether the given code monitor query searches
+// file contents, see isFileMonitorQuery. Queries that fail to parse are not
+// file monitor queries.
+func IsFileMonitorQuery(q string) bool {
+	plan, err := query.Pipeline(query.InitRegexp(q))
+	if err != nil {
+		return false
+	}
+	return isFileMonitorQuery(plan)
+}
+
+// searchNewFileMatches runs a content search for a code monitor and returns the
+// file matches that were not found by the previous run.
+func searchNewFileMatches(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]result.Match, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFileMatches(ctx, db, monitorID, agg.Results, agg.Stats)
+}
+
+// incompleteRepoStatus is the set of repo statuses for which a search did not
+// return all matches of a repo.
+const incompleteRepoStatus = search.RepoStatusCloning | search.RepoStatusMissing | search.RepoStatusLimitHit | search.RepoStatusTimedout
+
+// newFileMatches returns the file matches that are not part of the "last
+// searched" state of a code monitor, and updates that state with the keys of
+// the given matches. The first run of a monitor should be a snapshot.
+//
+// Matches missing from a partial search may still exist, so the state of repos
+// whose search didn't complete is only ever added to, and repos without any
+// matches are only cleared when the whole search completed.
+func newFileMatches(ctx context.Context, db database.DB, monitorID int64, matches result.Matches, stats streaming.Stats) ([]result.Match, error) {
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	lastSearched, err := cm.ListLastSearchedFileMatches(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousKeys := make(map[api.RepoID]map[string]struct{}, len(lastSearched))
+	for repoID, keys := range lastSearched {
+		previousKeys[repoID] = make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			previousKeys[repoID][key] = struct{}{}
+		}
+	}
+
+	var (
+		results     []result.Match
+		currentKeys = make(map[api.RepoID][]string)
+	)
+	for _, res := range matches {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			return nil, errors.Errorf("expected search to only return file matches, but got type %T", res)
+		}
+
+		isNew := false
+		for _, key := range fileMatchKeys(fm) {
+			if _, ok := previousKeys[fm.Repo.ID][key]; !ok {
+				isNew = true
+			}
+			currentKeys[fm.Repo.ID] = append(currentKeys[fm.Repo.ID], key)
+		}
+		if isNew {
+			results = append(results, fm)
+		}
+	}
+
+	incomplete := false
+	stats.Status.Filter(incompleteRepoStatus, func(repoID api.RepoID) {
+		incomplete = true
+		if _, ok := currentKeys[repoID]; !ok {
+			return
+		}
+		seen := make(map[string]struct{}, len(currentKeys[repoID]))
+		for _, key := range currentKeys[repoID] {
+			seen[key] = struct{}{}
+		}
+		for key := range previousKeys[repoID] {
+			if _, ok := seen[key]; !ok {
+				currentKeys[repoID] = append(currentKeys[repoID], key)
+			}
+		}
+	})
+
+	// Clear the state of repos that no longer have any matches so that
+	// matches reappearing in them are reported again.
+	if !incomplete && !stats.IsLimitHit {
+		for repoID := range previousKeys {
+			if _, ok := currentKeys[repoID]; !ok {
+				currentKeys[repoID] = nil
+			}
+		}
+	}
+
+	for repoID, keys := range currentKeys {
+		if err := cm.UpsertLastSearchedFileMatches(ctx, monitorID, repoID, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// fileMatchKeys returns the keys that identify the matches of a file match
+// across runs. Keys are derived from the path and the content of the matched
+// lines rather than from line numbers so that edits elsewhere in a file don't
+// cause its existing matches to be reported again.
+func fileMatchKeys(fm *result.FileMatch) []string {
+	if len(fm.ChunkMatches) == 0 {
+		return []string{hashKey(fm.Path)}
+	}
+
+	lineMatches := fm.ChunkMatches.AsLineMatches()
+	keys := make([]string, 0, len(lineMatches))
+	for _, lm := range lineMatches {
+		keys = append(keys, hashKey(fm.Path, lm.Preview))
+	}
+	return keys
+}
+
+func hashKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
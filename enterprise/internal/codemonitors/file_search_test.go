@@ -0,0 +1,143 @@
+package codemonitors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIsFileMonitorQuery(t *testing.T) {
+	cases := []struct {
+		query string
+		want  bool
+	}{
+		{"type:file AWS_SECRET", true},
+		{"type:file AWS_SECRET repo:a or type:file AWS_KEY repo:b", true},
+		{"type:commit AWS_SECRET", false},
+		{"type:diff AWS_SECRET", false},
+		{"AWS_SECRET", false},
+		{"type:file AWS_SECRET or type:diff AWS_KEY", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitRegexp(tc.query))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, isFileMonitorQuery(plan))
+			require.Equal(t, tc.want, IsFileMonitorQuery(tc.query))
+		})
+	}
+}
+
+func TestFileMatchKeys(t *testing.T) {
+	chunkMatch := func(content string, line int) result.ChunkMatch {
+		start := result.Location{Line: line}
+		return result.ChunkMatch{
+			Content:      content,
+			ContentStart: start,
+			Ranges:       result.Ranges{{Start: start, End: start.Add(result.Location{Offset: 3, Column: 3})}},
+		}
+	}
+
+	fm := func(path string, chunks ...result.ChunkMatch) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Path: path}, ChunkMatches: chunks}
+	}
+
+	t.Run("path matches", func(t *testing.T) {
+		require.Equal(t, fileMatchKeys(fm("a.go")), fileMatchKeys(fm("a.go")))
+		require.NotEqual(t, fileMatchKeys(fm("a.go")), fileMatchKeys(fm("b.go")))
+	})
+
+	t.Run("independent of line numbers", func(t *testing.T) {
+		require.Equal(t,
+			fileMatchKeys(fm("a.go", chunkMatch("AWS_SECRET", 1))),
+			fileMatchKeys(fm("a.go", chunkMatch("AWS_SECRET", 10))),
+		)
+	})
+
+	t.Run("dependent on content", func(t *testing.T) {
+		require.NotEqual(t,
+			fileMatchKeys(fm("a.go", chunkMatch("AWS_SECRET=1", 1))),
+			fileMatchKeys(fm("a.go", chunkMatch("AWS_SECRET=2", 1))),
+		)
+	})
+
+	t.Run("one key per matched line", func(t *testing.T) {
+		keys := fileMatchKeys(fm("a.go", chunkMatch("AWS_SECRET", 1), chunkMatch("AWS_KEY", 5)))
+		require.Len(t, keys, 2)
+	})
+}
+
+func TestNewFileMatches(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	u, err := db.Users().Create(ctx, database.NewUser{Email: "test", Username: "test", EmailVerificationCode: "test"})
+	require.NoError(t, err)
+	err = db.Repos().Create(ctx, &types.Repo{Name: "a"}, &types.Repo{Name: "b"})
+	require.NoError(t, err)
+	repoA, err := db.Repos().GetByName(ctx, "a")
+	require.NoError(t, err)
+	repoB, err := db.Repos().GetByName(ctx, "b")
+	require.NoError(t, err)
+	m, err := edb.NewEnterpriseDB(db).CodeMonitors().CreateMonitor(actor.WithActor(ctx, actor.FromUser(u.ID)), edb.MonitorArgs{NamespaceUserID: &u.ID})
+	require.NoError(t, err)
+
+	fm := func(repo *types.Repo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: types.MinimalRepo{ID: repo.ID, Name: repo.Name}, Path: path}}
+	}
+	paths := func(matches []result.Match) (paths []string) {
+		for _, match := range matches {
+			paths = append(paths, match.(*result.FileMatch).Path)
+		}
+		return paths
+	}
+	run := func(stats streaming.Stats, matches ...result.Match) []string {
+		t.Helper()
+		newMatches, err := newFileMatches(ctx, db, m.ID, matches, stats)
+		require.NoError(t, err)
+		return paths(newMatches)
+	}
+	limitHit := func(repo *types.Repo) streaming.Stats {
+		var status search.RepoStatusMap
+		status.Update(repo.ID, search.RepoStatusLimitHit)
+		return streaming.Stats{Status: status}
+	}
+
+	t.Run("new matches", func(t *testing.T) {
+		require.Equal(t, []string{"a.go", "b.go"}, run(streaming.Stats{}, fm(repoA, "a.go"), fm(repoB, "b.go")))
+	})
+
+	t.Run("seen matches", func(t *testing.T) {
+		require.Equal(t, []string{"c.go"}, run(streaming.Stats{}, fm(repoA, "a.go"), fm(repoA, "c.go"), fm(repoB, "b.go")))
+	})
+
+	t.Run("partial results", func(t *testing.T) {
+		// The search of repo a didn't complete and repo b is missing from
+		// the results, so neither should forget about their matches.
+		require.Empty(t, run(limitHit(repoA), fm(repoA, "a.go")))
+		require.Empty(t, run(streaming.Stats{IsLimitHit: true}))
+		require.Empty(t, run(streaming.Stats{}, fm(repoA, "a.go"), fm(repoA, "c.go"), fm(repoB, "b.go")))
+	})
+
+	t.Run("disappeared then reappeared matches", func(t *testing.T) {
+		require.Empty(t, run(streaming.Stats{}, fm(repoA, "a.go")))
+		require.Equal(t, []string{"c.go", "b.go"}, run(streaming.Stats{}, fm(repoA, "a.go"), fm(repoA, "c.go"), fm(repoB, "b.go")))
+	})
+}
@@ -107,7 +107,7 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 	return &unmarshaledSettings, nil
 }
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
+func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []result.Match, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -130,6 +130,10 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if isFileMonitorQuery(inputs.Plan) {
+		return searchNewFileMatches(ctx, db, clients, planJob, monitorID)
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
@@ -172,7 +176,7 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, err
 	}
 
-	results := make([]*result.CommitMatch, len(agg.Results))
+	results := make([]result.Match, len(agg.Results))
 	for i, res := range agg.Results {
 		cm, ok := res.(*result.CommitMatch)
 		if !ok {
@@ -209,6 +213,11 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if isFileMonitorQuery(inputs.Plan) {
+		_, err := searchNewFileMatches(ctx, db, clients, planJob, monitorID)
+		return err
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -0,0 +1,72 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Aggregate counts the values produced by substituting ValuePattern for each
+// match of SearchPattern. When GroupPattern is set, counts are partitioned by
+// the value it produces for each result. GroupPattern only substitutes meta
+// variables like $repo or $lang, not capture groups.
+type Aggregate struct {
+	SearchPattern MatchPattern
+	ValuePattern  string
+	GroupPattern  string
+	Selector      string
+	TypeValue     string
+	Kind          string
+}
+
+func (c *Aggregate) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Aggregate) String() string {
+	if c.GroupPattern == "" {
+		return fmt.Sprintf("Aggregate: (%s) -> (%s)", c.SearchPattern.String(), c.ValuePattern)
+	}
+	return fmt.Sprintf("Aggregate: (%s) -> (%s) by (%s)", c.SearchPattern.String(), c.ValuePattern, c.GroupPattern)
+}
+
+func (c *Aggregate) Run(ctx context.Context, _ database.DB, r result.Match) (Result, error) {
+	onlyPath := c.TypeValue == "path" // don't read file contents for file matches when we only want type:path
+	chunks := resultChunks(r, c.Kind, onlyPath)
+
+	counts := make(map[aggregationKey]int)
+	for _, content := range chunks {
+		env := NewMetaEnvironment(r, content)
+		valuePattern, err := substituteMetaVariables(c.ValuePattern, env)
+		if err != nil {
+			return nil, err
+		}
+
+		var group string
+		if c.GroupPattern != "" {
+			group, err = substituteMetaVariables(c.GroupPattern, env)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		values, err := toTextResult(ctx, content, c.SearchPattern, valuePattern, "\n", c.Selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range strings.Split(values, "\n") {
+			if value == "" {
+				continue
+			}
+			counts[aggregationKey{Value: value, Group: group}]++
+		}
+	}
+
+	if len(counts) == 0 {
+		return nil, nil
+	}
+	return toAggregation(counts, 0, false), nil
+}
@@ -0,0 +1,95 @@
+package compute
+
+import "sort"
+
+// AggregationCount is the number of times an Aggregate command produced Value
+// for results in Group.
+type AggregationCount struct {
+	Value string `json:"value"`
+	Group string `json:"group,omitempty"`
+	Count int    `json:"count"`
+}
+
+// Aggregation is the result of an Aggregate command. Counts are ordered by
+// decreasing count.
+type Aggregation struct {
+	Kind   string             `json:"kind"`
+	Counts []AggregationCount `json:"counts"`
+	// LimitHit is true when values were not counted because the maximum
+	// number of distinct values was reached.
+	LimitHit bool `json:"limitHit"`
+}
+
+type aggregationKey struct {
+	Value string
+	Group string
+}
+
+// toAggregation returns the top n counts as an Aggregation, or all counts if
+// n is not positive.
+func toAggregation(counts map[aggregationKey]int, n int, limitHit bool) *Aggregation {
+	sorted := make([]AggregationCount, 0, len(counts))
+	for key, count := range counts {
+		sorted = append(sorted, AggregationCount{Value: key.Value, Group: key.Group, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		if sorted[i].Group != sorted[j].Group {
+			return sorted[i].Group < sorted[j].Group
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+	if n > 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return &Aggregation{Kind: "aggregate", Counts: sorted, LimitHit: limitHit}
+}
+
+// Aggregator merges the Aggregation results of individual search results
+// into running totals. It is not safe for concurrent use.
+type Aggregator struct {
+	counts   map[aggregationKey]int
+	limit    int
+	limitHit bool
+	dirty    bool
+}
+
+// NewAggregator returns an Aggregator that tracks at most limit distinct
+// values.
+func NewAggregator(limit int) *Aggregator {
+	return &Aggregator{
+		counts: make(map[aggregationKey]int),
+		limit:  limit,
+	}
+}
+
+// Add merges the counts of agg into the running totals. Once limit distinct
+// values are tracked, counts for new values are dropped.
+func (a *Aggregator) Add(agg *Aggregation) {
+	for _, c := range agg.Counts {
+		key := aggregationKey{Value: c.Value, Group: c.Group}
+		if _, ok := a.counts[key]; !ok && len(a.counts) >= a.limit {
+			a.limitHit = true
+			continue
+		}
+		a.counts[key] += c.Count
+		a.dirty = true
+	}
+	if agg.LimitHit {
+		a.limitHit = true
+	}
+}
+
+// Dirty returns true if the totals changed since the last call to Snapshot.
+func (a *Aggregator) Dirty() bool {
+	return a.dirty
+}
+
+// Snapshot returns the top n running totals, or all totals if n is not
+// positive.
+func (a *Aggregator) Snapshot(n int) *Aggregation {
+	a.dirty = false
+	return toAggregation(a.counts, n, a.limitHit)
+}
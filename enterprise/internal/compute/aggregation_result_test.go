@@ -0,0 +1,52 @@
+package compute
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+)
+
+func TestAggregator(t *testing.T) {
+	test := func(limit, display int, aggregations ...*Aggregation) string {
+		aggregator := NewAggregator(limit)
+		for _, aggregation := range aggregations {
+			aggregator.Add(aggregation)
+		}
+		result, _ := json.Marshal(aggregator.Snapshot(display))
+		return string(result)
+	}
+
+	counts := func(values ...string) *Aggregation {
+		aggregation := &Aggregation{Kind: "aggregate"}
+		for _, value := range values {
+			aggregation.Counts = append(aggregation.Counts, AggregationCount{Value: value, Count: 1})
+		}
+		return aggregation
+	}
+
+	autogold.Want(
+		"merges counts",
+		`{"kind":"aggregate","counts":[{"value":"b","count":3},{"value":"a","count":2},{"value":"c","count":1}],"limitHit":false}`).
+		Equal(t, test(10, 0, counts("a", "b"), counts("b", "c"), counts("a", "b")))
+
+	autogold.Want(
+		"returns top counts",
+		`{"kind":"aggregate","counts":[{"value":"b","count":3}],"limitHit":false}`).
+		Equal(t, test(10, 1, counts("a", "b"), counts("b", "c"), counts("a", "b")))
+
+	autogold.Want(
+		"drops new values over the limit",
+		`{"kind":"aggregate","counts":[{"value":"a","count":2},{"value":"b","count":2}],"limitHit":true}`).
+		Equal(t, test(2, 0, counts("a", "b"), counts("b", "c"), counts("a")))
+
+	aggregator := NewAggregator(10)
+	aggregator.Add(counts("a"))
+	if !aggregator.Dirty() {
+		t.Fatal("expected aggregator to be dirty after Add")
+	}
+	aggregator.Snapshot(0)
+	if aggregator.Dirty() {
+		t.Fatal("expected aggregator to be clean after Snapshot")
+	}
+}
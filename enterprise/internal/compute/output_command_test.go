@@ -95,6 +95,9 @@ func TestRun(t *testing.T) {
 		case *TextExtra:
 			result, _ := json.Marshal(r)
 			return string(result)
+		case *Aggregation:
+			result, _ := json.Marshal(r)
+			return string(result)
 		}
 		return "Error, unrecognized result type returned"
 	}
@@ -124,6 +127,16 @@ func TestRun(t *testing.T) {
 		"test\nstring\n").
 		Equal(t, test(`content:output((\b\w+\b) -> $1)`, fileMatch("test", "string")))
 
+	autogold.Want(
+		"aggregate counts values",
+		`{"kind":"aggregate","counts":[{"value":"a","count":2},{"value":"b","count":1}],"limitHit":false}`).
+		Equal(t, test(`content:aggregate((\w) -> $1)`, fileMatch("a b a")))
+
+	autogold.Want(
+		"aggregate counts values by group",
+		`{"kind":"aggregate","counts":[{"value":"1","group":"my/awesome/repo","count":1},{"value":"2","group":"my/awesome/repo","count":1}],"limitHit":false}`).
+		Equal(t, test(`content:aggregate((\d) -> $1 by $repo)`, fileMatch("a 1 b 2")))
+
 	// If we are not on CI skip the test if comby is not installed.
 	if os.Getenv("CI") == "" && !comby.Exists() {
 		t.Skip("comby is not installed on the PATH. Try running 'bash <(curl -sL get.comby.dev)'.")
@@ -97,13 +97,16 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
-		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":              func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":   func() query.Predicate { return query.EmptyPredicate{} },
+		"output":               func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":        func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":    func() query.Predicate { return query.EmptyPredicate{} },
+		"output.extra":         func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate":            func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.structural": func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -217,6 +220,75 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+var groupBySyntax = lazyregexp.New(`(?:^|\s+)by\s+`)
+
+// parseGroupBy splits the right hand side of an aggregate command into a value
+// template and an optional group template, separated by the last `by`.
+func parseGroupBy(args string) (string, string) {
+	indices := groupBySyntax.Re().FindAllStringIndex(args, -1)
+	if len(indices) == 0 {
+		return args, ""
+	}
+	last := indices[len(indices)-1]
+	return args[:last[0]], args[last[1]:]
+}
+
+func parseAggregate(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var matchPattern MatchPattern
+	switch name {
+	case "aggregate", "aggregate.regexp":
+		var err error
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "aggregate command")
+		}
+	case "aggregate.structural":
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	value, group := parseGroupBy(right)
+	if value == "" {
+		return nil, false, errors.New("aggregate command expects a value to count on the right hand side of `->`")
+	}
+
+	var typeValue string
+	query.VisitField(q.ToParseTree(), query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		typeValue = value
+	})
+
+	var selector string
+	query.VisitField(q.ToParseTree(), query.FieldSelect, func(value string, _ bool, _ query.Annotation) {
+		selector = value
+	})
+
+	return &Aggregate{
+		SearchPattern: matchPattern,
+		ValuePattern:  value,
+		GroupPattern:  group,
+		TypeValue:     typeValue,
+		Selector:      selector,
+		Kind:          name,
+	}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +332,7 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseAggregate,
 	parseMatchOnly,
 )
 
@@ -50,6 +50,22 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("aggregate",
+		"Command: `Aggregate: (import (\\w+)) -> ($1)`").
+		Equal(t, test("content:aggregate(import (\\w+) -> $1)"))
+
+	autogold.Want("aggregate by group",
+		"Command: `Aggregate: (import (\\w+)) -> ($1) by ($repo)`").
+		Equal(t, test("content:aggregate(import (\\w+) -> $1 by $repo)"))
+
+	autogold.Want("aggregate groups by the last `by`",
+		"Command: `Aggregate: (a) -> (written by $author) by ($lang)`").
+		Equal(t, test("content:aggregate(a -> written by $author by $lang)"))
+
+	autogold.Want("aggregate no value",
+		"aggregate command expects a value to count on the right hand side of `->`").
+		Equal(t, test("content:aggregate(a -> by $repo)"))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -3,7 +3,6 @@ package database
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
@@ -37,7 +36,7 @@ func (a *ActionJob) RecordID() int {
 type ActionJobMetadata struct {
 	Description string
 	MonitorID   int64
-	Results     []*result.CommitMatch
+	Results     []result.Match
 	OwnerName   string
 
 	// The query with after: filter.
@@ -246,7 +245,8 @@ func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
+	m.Results, err = result.UnmarshalStoredMatches(resultsJSON)
+	if err != nil {
 		return nil, err
 	}
 	return m, nil
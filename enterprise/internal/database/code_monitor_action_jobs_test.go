@@ -7,7 +7,10 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
@@ -50,8 +53,30 @@ func TestGetActionJobMetadata(t *testing.T) {
 	triggerJobID := triggerJobs[0].ID
 
 	var (
-		wantResults = make([]*result.CommitMatch, 42)
-		wantQuery   = testQuery + " after:\"" + s.Now().UTC().Format(time.RFC3339) + "\""
+		wantResults = []result.Match{
+			&result.CommitMatch{
+				Commit: gitdomain.Commit{
+					ID:      "deadbeef",
+					Author:  gitdomain.Signature{Name: "alice", Email: "alice@example.com", Date: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC)},
+					Message: "fix the thing",
+					Parents: []api.CommitID{"cafebabe"},
+				},
+				Repo: types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+			},
+			&result.FileMatch{
+				File: result.File{
+					Repo:     types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+					CommitID: "deadbeef",
+					Path:     "main.go",
+				},
+				ChunkMatches: result.ChunkMatches{{
+					Content:      "func main() {",
+					ContentStart: result.Location{Line: 2},
+					Ranges:       result.Ranges{{Start: result.Location{Line: 2, Column: 5}, End: result.Location{Offset: 4, Line: 2, Column: 9}}},
+				}},
+			},
+		}
+		wantQuery = testQuery + " after:\"" + s.Now().UTC().Format(time.RFC3339) + "\""
 	)
 	err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, wantQuery, wantResults)
 	require.NoError(t, err)
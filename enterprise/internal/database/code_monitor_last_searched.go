@@ -56,3 +56,47 @@ func (s *codeMonitorStore) GetLastSearched(ctx context.Context, monitorID int64,
 	}
 	return commitOIDs, err
 }
+
+func (s *codeMonitorStore) UpsertLastSearchedFileMatches(ctx context.Context, monitorID int64, repoID api.RepoID, fileMatchKeys []string) error {
+	rawQuery := `
+	INSERT INTO cm_last_searched (monitor_id, repo_id, commit_oids, file_match_keys)
+	VALUES (%s, %s, '{}', %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET file_match_keys = %s
+	`
+
+	// Appease non-null constraint on column
+	if fileMatchKeys == nil {
+		fileMatchKeys = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), pq.StringArray(fileMatchKeys), pq.StringArray(fileMatchKeys))
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) ListLastSearchedFileMatches(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error) {
+	rawQuery := `
+	SELECT repo_id, file_match_keys
+	FROM cm_last_searched
+	WHERE monitor_id = %s
+		AND cardinality(file_match_keys) > 0
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fileMatchKeys := make(map[api.RepoID][]string)
+	for rows.Next() {
+		var (
+			repoID int32
+			keys   []string
+		)
+		if err := rows.Scan(&repoID, (*pq.StringArray)(&keys)); err != nil {
+			return nil, err
+		}
+		fileMatchKeys[api.RepoID(repoID)] = keys
+	}
+	return fileMatchKeys, rows.Err()
+}
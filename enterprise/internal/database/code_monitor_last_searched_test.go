@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 )
@@ -116,3 +117,44 @@ func TestCodeMonitorHasAnyLastSearched(t *testing.T) {
 		require.True(t, hasLastSearched)
 	})
 }
+
+func TestCodeMonitorLastSearchedFileMatches(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+
+	ctx := context.Background()
+	db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+	fixtures := populateCodeMonitorFixtures(t, db)
+	cm := db.CodeMonitors()
+
+	// List with nothing stored
+	fileMatches, err := cm.ListLastSearchedFileMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Empty(t, fileMatches)
+
+	// Insert
+	err = cm.UpsertLastSearchedFileMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"a", "b"})
+	require.NoError(t, err)
+
+	fileMatches, err = cm.ListLastSearchedFileMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: {"a", "b"}}, fileMatches)
+
+	// Storing file matches should not clobber the commit OIDs
+	err = cm.UpsertLastSearched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"commit1"})
+	require.NoError(t, err)
+	err = cm.UpsertLastSearchedFileMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"c"})
+	require.NoError(t, err)
+
+	lastSearched, err := cm.GetLastSearched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"commit1"}, lastSearched)
+
+	// Repos without any remaining matches are omitted
+	err = cm.UpsertLastSearchedFileMatches(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, nil)
+	require.NoError(t, err)
+
+	fileMatches, err = cm.ListLastSearchedFileMatches(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.Empty(t, fileMatches)
+}
@@ -19,7 +19,7 @@ type TriggerJob struct {
 	// The query we ran including after: filter.
 	QueryString *string
 
-	SearchResults []*result.CommitMatch
+	SearchResults []result.Match
 
 	// Fields demanded for any dbworker.
 	State          string
@@ -69,10 +69,10 @@ SET query_string = %s,
 WHERE id = %s
 `
 
-func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error {
+func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []result.Match) error {
 	if results == nil {
 		// appease db non-null constraint
-		results = []*result.CommitMatch{}
+		results = []result.Match{}
 	}
 
 	resultsJSON, err := json.Marshal(results)
@@ -191,7 +191,8 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 	}
 
 	if len(resultsJSON) > 0 {
-		if err := json.Unmarshal(resultsJSON, &m.SearchResults); err != nil {
+		m.SearchResults, err = result.UnmarshalStoredMatches(resultsJSON)
+		if err != nil {
 			return nil, err
 		}
 	}
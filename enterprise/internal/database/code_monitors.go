@@ -49,7 +49,7 @@ type CodeMonitorStore interface {
 	ListQueryTriggerJobs(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
-	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []result.Match) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -90,6 +90,12 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// UpsertLastSearchedFileMatches and ListLastSearchedFileMatches store and
+	// retrieve the keys of the file matches found by the previous run of a
+	// content code monitor so that only new matches trigger actions.
+	UpsertLastSearchedFileMatches(ctx context.Context, monitorID int64, repoID api.RepoID, fileMatchKeys []string) error
+	ListLastSearchedFileMatches(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error)
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -139,6 +139,10 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListLastSearchedFileMatchesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListLastSearchedFileMatches.
+	ListLastSearchedFileMatchesFunc *CodeMonitorStoreListLastSearchedFileMatchesFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -192,6 +196,10 @@ type MockCodeMonitorStore struct {
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
+	// UpsertLastSearchedFileMatchesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpsertLastSearchedFileMatches.
+	UpsertLastSearchedFileMatchesFunc *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc
 }
 
 // NewMockCodeMonitorStore creates a new mock of the CodeMonitorStore
@@ -379,6 +387,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListLastSearchedFileMatchesFunc: &CodeMonitorStoreListLastSearchedFileMatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 map[api.RepoID][]string, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) (r0 []*Monitor, r1 error) {
 				return
@@ -450,7 +463,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
-			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
+			defaultHook: func(context.Context, int32, string, []result.Match) (r0 error) {
 				return
 			},
 		},
@@ -464,6 +477,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertLastSearchedFileMatchesFunc: &CodeMonitorStoreUpsertLastSearchedFileMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -651,6 +669,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListLastSearchedFileMatchesFunc: &CodeMonitorStoreListLastSearchedFileMatchesFunc{
+			defaultHook: func(context.Context, int64) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListLastSearchedFileMatches")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -722,7 +745,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
-			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
+			defaultHook: func(context.Context, int32, string, []result.Match) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
 			},
 		},
@@ -736,6 +759,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
 			},
 		},
+		UpsertLastSearchedFileMatchesFunc: &CodeMonitorStoreUpsertLastSearchedFileMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearchedFileMatches")
+			},
+		},
 	}
 }
 
@@ -852,6 +880,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListLastSearchedFileMatchesFunc: &CodeMonitorStoreListLastSearchedFileMatchesFunc{
+			defaultHook: i.ListLastSearchedFileMatches,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -903,6 +934,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
+		UpsertLastSearchedFileMatchesFunc: &CodeMonitorStoreUpsertLastSearchedFileMatchesFunc{
+			defaultHook: i.UpsertLastSearchedFileMatches,
+		},
 	}
 }
 
@@ -4903,6 +4937,118 @@ func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListLastSearchedFileMatchesFunc describes the behavior
+// when the ListLastSearchedFileMatches method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreListLastSearchedFileMatchesFunc struct {
+	defaultHook func(context.Context, int64) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, int64) (map[api.RepoID][]string, error)
+	history     []CodeMonitorStoreListLastSearchedFileMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListLastSearchedFileMatches delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListLastSearchedFileMatches(v0 context.Context, v1 int64) (map[api.RepoID][]string, error) {
+	r0, r1 := m.ListLastSearchedFileMatchesFunc.nextHook()(v0, v1)
+	m.ListLastSearchedFileMatchesFunc.appendCall(CodeMonitorStoreListLastSearchedFileMatchesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListLastSearchedFileMatches method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) SetDefaultHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListLastSearchedFileMatches method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) PushHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) nextHook() func(context.Context, int64) (map[api.RepoID][]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) appendCall(r0 CodeMonitorStoreListLastSearchedFileMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListLastSearchedFileMatchesFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreListLastSearchedFileMatchesFunc) History() []CodeMonitorStoreListLastSearchedFileMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListLastSearchedFileMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListLastSearchedFileMatchesFuncCall is an object that
+// describes an invocation of method ListLastSearchedFileMatches on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreListLastSearchedFileMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListLastSearchedFileMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListLastSearchedFileMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListMonitorsFunc describes the behavior when the
 // ListMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6463,15 +6609,15 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
 type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
-	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
-	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
+	defaultHook func(context.Context, int32, string, []result.Match) error
+	hooks       []func(context.Context, int32, string, []result.Match) error
 	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateTriggerJobWithResults delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []result.Match) error {
 	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
 	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
 	return r0
@@ -6480,7 +6626,7 @@ func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v
 // SetDefaultHook sets function that is called when the
 // UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []result.Match) error) {
 	f.defaultHook = hook
 }
 
@@ -6489,7 +6635,7 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook fu
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []result.Match) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6498,19 +6644,19 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.SetDefaultHook(func(context.Context, int32, string, []result.Match) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.PushHook(func(context.Context, int32, string, []result.Match) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []result.Match) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6556,7 +6702,7 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 []*result.CommitMatch
+	Arg3 []result.Match
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -6808,6 +6954,121 @@ func (c CodeMonitorStoreUpsertLastSearchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreUpsertLastSearchedFileMatchesFunc describes the behavior
+// when the UpsertLastSearchedFileMatches method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpsertLastSearchedFileMatchesFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string) error
+	history     []CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertLastSearchedFileMatches delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertLastSearchedFileMatches(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string) error {
+	r0 := m.UpsertLastSearchedFileMatchesFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertLastSearchedFileMatchesFunc.appendCall(CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertLastSearchedFileMatches method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertLastSearchedFileMatches method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) nextHook() func(context.Context, int64, api.RepoID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) appendCall(r0 CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpsertLastSearchedFileMatchesFunc) History() []CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall is an object that
+// describes an invocation of method UpsertLastSearchedFileMatches on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastSearchedFileMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockEnterpriseDB is a mock implementation of the EnterpriseDB interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/database) used for
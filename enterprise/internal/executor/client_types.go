@@ -55,6 +55,15 @@ type Job struct {
 	// environment variables, as well as secret values passed along with the dequeued job
 	// payload, which may be sensitive (e.g. shared API tokens, URLs with credentials).
 	RedactedValues map[string]string `json:"redactedValues"`
+
+	// Queue is the name of the queue the job was dequeued from. It is set by the
+	// executor and is not part of the API payload.
+	Queue string `json:"-"`
+
+	// WorkerRecordID, if non-zero, identifies the job among the jobs of all queues
+	// processed by an executor, as job IDs are only unique within a queue. It is set
+	// by the executor and is not part of the API payload.
+	WorkerRecordID int `json:"-"`
 }
 
 // UnmarshalJSON unmarshal the JSON into Job. This custom unmarshaler is needed to support the different Job structures
@@ -194,6 +203,9 @@ type VirtualMachineFile struct {
 }
 
 func (j Job) RecordID() int {
+	if j.WorkerRecordID != 0 {
+		return j.WorkerRecordID
+	}
 	return j.ID
 }
 
@@ -213,6 +225,29 @@ type DockerStep struct {
 
 	// Env specifies a set of NAME=value pairs to supply to the docker command.
 	Env []string `json:"env"`
+
+	// Timeout is the maximum duration a single run of the step may take. If zero,
+	// the step is only bound by the job deadline.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// Retries is the number of times the step is rerun if it fails.
+	Retries int `json:"retries,omitempty"`
+
+	// RetryBackoff is the duration to wait before the first retry. It's doubled
+	// for every following retry.
+	RetryBackoff time.Duration `json:"retryBackoff,omitempty"`
+
+	// ContinueOnError determines if the job continues with the next step if the
+	// step fails after all retries.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+
+	// CPUs is the number of CPUs the container can use. It can't exceed the limit
+	// of the executor.
+	CPUs float64 `json:"cpus,omitempty"`
+
+	// Memory is the maximum amount of memory the container can use. It can't
+	// exceed the limit of the executor.
+	Memory string `json:"memory,omitempty"`
 }
 
 type CliStep struct {
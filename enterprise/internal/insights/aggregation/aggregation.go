@@ -2,7 +2,9 @@ package aggregation
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -91,6 +94,8 @@ func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	switch match := r.(type) {
 	case *result.CommitMatch:
 		author = match.Commit.Author.Name
+	case *result.CommitAuthorMatch:
+		author = match.Author.Name
 	default:
 	}
 	if author != "" {
@@ -103,6 +108,121 @@ func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+// NewDateCountFunc returns a count function that groups commit and diff matches
+// by their author date. Dates are bucketed by day (2006-01-02), ISO week
+// (2006-W01) or month (2006-01) in UTC.
+func NewDateCountFunc(interval types.AggregationDateInterval) (AggregationCountFunc, error) {
+	switch interval {
+	case types.DAY_AGGREGATION_INTERVAL, types.WEEK_AGGREGATION_INTERVAL, types.MONTH_AGGREGATION_INTERVAL:
+	default:
+		return nil, errors.Newf("unsupported date interval: %s", interval)
+	}
+
+	return func(r result.Match) (map[MatchKey]int, error) {
+		var date time.Time
+		switch match := r.(type) {
+		case *result.CommitMatch:
+			date = match.Commit.Author.Date
+		default:
+		}
+		if date.IsZero() {
+			return nil, nil
+		}
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  dateLabel(date, interval),
+		}: r.ResultCount()}, nil
+	}, nil
+}
+
+func dateLabel(date time.Time, interval types.AggregationDateInterval) string {
+	date = date.UTC()
+	switch interval {
+	case types.DAY_AGGREGATION_INTERVAL:
+		return date.Format("2006-01-02")
+	case types.WEEK_AGGREGATION_INTERVAL:
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return date.Format("2006-01")
+	}
+}
+
+// NewDirectoryCountFunc returns a count function that groups file matches by
+// the directory containing them, rolled up to at most depth path components.
+// Files at the root of a repository are grouped under "/".
+func NewDirectoryCountFunc(depth int) (AggregationCountFunc, error) {
+	if depth < 1 {
+		return nil, errors.Newf("directory depth must be at least 1, got %d", depth)
+	}
+
+	return func(r result.Match) (map[MatchKey]int, error) {
+		var directory string
+		switch match := r.(type) {
+		case *result.FileMatch:
+			directory = directoryLabel(match.Path, depth)
+		default:
+		}
+		if directory != "" {
+			return map[MatchKey]int{{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  directory,
+			}: r.ResultCount()}, nil
+		}
+		return nil, nil
+	}, nil
+}
+
+func directoryLabel(path string, depth int) string {
+	if path == "" {
+		return ""
+	}
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "/"
+	}
+	components := strings.Split(path[:i], "/")
+	if len(components) > depth {
+		components = components[:depth]
+	}
+	return strings.Join(components, "/")
+}
+
+// NewOwnerCountFunc returns a count function that groups file matches by the
+// owners declared in the CODEOWNERS file of their repository. Matches of
+// `select:file.owners` searches are grouped by their handle.
+func NewOwnerCountFunc(ctx context.Context, resolver *codeowners.Resolver) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		var owners []string
+		switch match := r.(type) {
+		case *result.FileMatch:
+			var err error
+			owners, err = resolver.Owners(ctx, match.Repo.Name, match.CommitID, match.Path)
+			if err != nil {
+				return nil, err
+			}
+		case *result.OwnerMatch:
+			owners = []string{match.Handle}
+		default:
+		}
+		if len(owners) == 0 {
+			return nil, nil
+		}
+
+		counts := make(map[MatchKey]int, len(owners))
+		for _, owner := range owners {
+			counts[MatchKey{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  owner,
+			}] += r.ResultCount()
+		}
+		return counts, nil
+	}
+}
+
 func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	pattern, err := getCasedPattern(querystring)
 	if err != nil {
@@ -168,9 +288,10 @@ func matchContent(event result.Match) []string {
 
 func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
-		types.REPO_AGGREGATION_MODE:   countRepo,
-		types.PATH_AGGREGATION_MODE:   countPath,
-		types.AUTHOR_AGGREGATION_MODE: countAuthor,
+		types.REPO_AGGREGATION_MODE:     countRepo,
+		types.PATH_AGGREGATION_MODE:     countPath,
+		types.AUTHOR_AGGREGATION_MODE:   countAuthor,
+		types.LANGUAGE_AGGREGATION_MODE: countLang,
 	}
 
 	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
@@ -2,6 +2,7 @@ package aggregation
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -94,7 +98,7 @@ func commitMatch(repo, author string, date time.Time, repoID, numRanges int32, c
 
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
-			Author:    gitdomain.Signature{Name: author},
+			Author:    gitdomain.Signature{Name: author, Date: date},
 			Committer: &gitdomain.Signature{},
 			Message:   gitdomain.Message(content),
 		},
@@ -407,6 +411,237 @@ func TestPathAggregation(t *testing.T) {
 	}
 }
 
+func TestDateAggregation(t *testing.T) {
+	laterDate := time.Date(2022, time.April, 12, 23, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		interval    types.AggregationDateInterval
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.MONTH_AGGREGATION_INTERVAL, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.MONTH_AGGREGATION_INTERVAL,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("No date for content and repo matches", map[string]int{}),
+		},
+		{
+			types.DAY_AGGREGATION_INTERVAL,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", laterDate, 1, 2, "a"),
+					commitMatch("repoB", "Author B", laterDate, 2, 2, "a"),
+				},
+			},
+			autogold.Want("counts by day", map[string]int{"2022-04-01": 2, "2022-04-12": 4}),
+		},
+		{
+			types.WEEK_AGGREGATION_INTERVAL,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", laterDate, 1, 2, "a"),
+				},
+			},
+			autogold.Want("counts by week", map[string]int{"2022-W13": 2, "2022-W15": 2}),
+		},
+		{
+			types.MONTH_AGGREGATION_INTERVAL,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", laterDate, 1, 2, "a"),
+				},
+			},
+			autogold.Want("counts by month", map[string]int{"2022-04": 4}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := NewDateCountFunc(tc.interval)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	if _, err := NewDateCountFunc("YEAR"); err == nil {
+		t.Error("expected error for unsupported date interval")
+	}
+}
+
+func TestDirectoryAggregation(t *testing.T) {
+	testCases := []struct {
+		depth       int
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{1, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("No directory for commit and repo matches", map[string]int{}),
+		},
+		{
+			1,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/server/main.go", 1, "a", "b"),
+					pathMatch("myRepo", "cmd/cli/main.go", 1),
+					symbolMatch("myRepoB", "internal/db.go", 2, "c"),
+					pathMatch("myRepo", "README.md", 1),
+				},
+			},
+			autogold.Want("Roll up to top level directories", map[string]int{"/": 1, "cmd": 3, "internal": 1}),
+		},
+		{
+			2,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/server/main.go", 1, "a", "b"),
+					pathMatch("myRepo", "cmd/cli/main.go", 1),
+					pathMatch("myRepo", "cmd/cli/internal/flags.go", 1),
+					symbolMatch("myRepoB", "internal/db.go", 2, "c"),
+				},
+			},
+			autogold.Want("Roll up to depth two", map[string]int{"cmd/cli": 2, "cmd/server": 2, "internal": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := NewDirectoryCountFunc(tc.depth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	if _, err := NewDirectoryCountFunc(0); err == nil {
+		t.Error("expected error for directory depth 0")
+	}
+}
+
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.LANGUAGE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("No language for commit and repo matches", map[string]int{}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "main.go", 1, "a", "b"),
+					pathMatch("myRepo", "util.go", 1),
+					symbolMatch("myRepoB", "index.ts", 2, "c"),
+					pathMatch("myRepo", "no-extension", 1),
+				},
+			},
+			autogold.Want("Count languages on file matches", map[string]int{"Go": 3, "TypeScript": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestOwnerAggregation(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "myRepo" && name == "CODEOWNERS" {
+			return []byte("*.go @backend\n/docs/ @docs @backend"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+
+	testCases := []struct {
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("myRepo", "Author A", sampleDate, 1, 2, "a"),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("no owners for commit and repo matches", map[string]int{}),
+		},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo", "docs/index.md", 1),
+					pathMatch("myRepo", "README.md", 1),
+				},
+			},
+			autogold.Want("Count owners on file matches", map[string]int{"@backend": 3, "@docs": 1}),
+		},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepoB", "file.go", 2, "a", "b"),
+				},
+			},
+			autogold.Want("No owners without CODEOWNERS", map[string]int{}),
+		},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					&result.OwnerMatch{Handle: "@backend", Repo: internaltypes.MinimalRepo{Name: "myRepo", ID: 1}},
+					&result.OwnerMatch{Handle: "@backend", Repo: internaltypes.MinimalRepo{Name: "myRepoB", ID: 2}},
+				},
+			},
+			autogold.Want("Count owner matches", map[string]int{"@backend": 2}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc := NewOwnerCountFunc(context.Background(), codeowners.NewResolver(gitserverClient))
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
 func TestCaptureGroupAggregation(t *testing.T) {
 	longCaptureGroup := "111111111|222222222|333333333|444444444|555555555|666666666|777777777|888888888|999999999|000000000|"
 	testCases := []struct {
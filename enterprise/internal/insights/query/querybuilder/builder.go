@@ -3,6 +3,7 @@ package querybuilder
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/grafana/regexp"
 
@@ -274,6 +275,114 @@ func AddFileFilter(query BasicQuery, file string) (BasicQuery, error) {
 	return addFilterSimple(query, searchquery.FieldFile, file)
 }
 
+// AddOwnerFilter restricts the query to files owned by owner with the
+// file:has.owner() predicate.
+func AddOwnerFilter(query BasicQuery, owner string) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified = append(modified, basic.Parameters...)
+		modified = append(modified, searchquery.Parameter{
+			Field:      searchquery.FieldFile,
+			Value:      fmt.Sprintf("has.owner(%s)", owner),
+			Negated:    false,
+			Annotation: searchquery.Annotation{Labels: searchquery.IsPredicate},
+		})
+		return basic.MapParameters(modified)
+	})
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
+// AddDirectoryFilter restricts the query to files under directory. The
+// directory "/" restricts the query to files at the root of a repository.
+func AddDirectoryFilter(query BasicQuery, directory string) (BasicQuery, error) {
+	if directory == "/" {
+		return addParameters(query, searchquery.Parameter{Field: searchquery.FieldFile, Value: "/", Negated: true})
+	}
+	return addParameters(query, quotedParameter(searchquery.FieldFile, fmt.Sprintf("^%s/", regexp.QuoteMeta(directory))))
+}
+
+// AddLanguageFilter restricts the query to files in language.
+func AddLanguageFilter(query BasicQuery, language string) (BasicQuery, error) {
+	return addParameters(query, quotedParameter(searchquery.FieldLang, language))
+}
+
+// AddDateFilter restricts a commit or diff query to the time period described
+// by label: a day (2006-01-02), an ISO week (2006-W01) or a month (2006-01).
+func AddDateFilter(query BasicQuery, label string) (BasicQuery, error) {
+	after, before, err := parseDateLabel(label)
+	if err != nil {
+		return "", err
+	}
+	return addParameters(query,
+		searchquery.Parameter{Field: searchquery.FieldAfter, Value: after.Format("2006-01-02")},
+		searchquery.Parameter{Field: searchquery.FieldBefore, Value: before.Format("2006-01-02")},
+	)
+}
+
+// AddDateRangeFilter restricts a commit or diff query to commits after and
+// before the given times, when they are set.
+func AddDateRangeFilter(query BasicQuery, after, before *time.Time) (BasicQuery, error) {
+	var parameters []searchquery.Parameter
+	if after != nil {
+		parameters = append(parameters, searchquery.Parameter{Field: searchquery.FieldAfter, Value: after.UTC().Format(time.RFC3339)})
+	}
+	if before != nil {
+		parameters = append(parameters, searchquery.Parameter{Field: searchquery.FieldBefore, Value: before.UTC().Format(time.RFC3339)})
+	}
+	if len(parameters) == 0 {
+		return query, nil
+	}
+	return addParameters(query, parameters...)
+}
+
+func parseDateLabel(label string) (time.Time, time.Time, error) {
+	if day, err := time.Parse("2006-01-02", label); err == nil {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+	if month, err := time.Parse("2006-01", label); err == nil {
+		return month, month.AddDate(0, 1, 0), nil
+	}
+	var year, week int
+	if _, err := fmt.Sscanf(label, "%d-W%d", &year, &week); err == nil && week >= 1 && week <= 53 {
+		// January 4th is always in the first ISO week of its year.
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		firstMonday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		start := firstMonday.AddDate(0, 0, 7*(week-1))
+		return start, start.AddDate(0, 0, 7), nil
+	}
+	return time.Time{}, time.Time{}, errors.Newf("unrecognized date %q", label)
+}
+
+// quotedParameter returns a parameter for field and value, quoting the value
+// if it contains whitespace.
+func quotedParameter(field, value string) searchquery.Parameter {
+	parameter := searchquery.Parameter{Field: field, Value: value}
+	if strings.ContainsAny(value, " \t") {
+		parameter.Annotation.Labels = searchquery.Quoted
+	}
+	return parameter
+}
+
+func addParameters(query BasicQuery, parameters ...searchquery.Parameter) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+len(parameters))
+		modified = append(modified, basic.Parameters...)
+		modified = append(modified, parameters...)
+		return basic.MapParameters(modified)
+	})
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
@@ -2,6 +2,7 @@ package querybuilder
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
@@ -568,6 +569,179 @@ func Test_addRepoFilter(t *testing.T) {
 	}
 }
 
+func Test_addOwnerFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		owner string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery",
+			owner: "@sourcegraph/search",
+			want:  autogold.Want("no initial filter", BasicQuery("file:has.owner(@sourcegraph/search) myquery")),
+		},
+		{
+			input: "myquery repo:supergreat",
+			owner: "@sourcegraph/search",
+			want:  autogold.Want("one initial repo filter", BasicQuery("repo:supergreat file:has.owner(@sourcegraph/search) myquery")),
+		},
+		{
+			input: "(myquery repo:supergreat) or (big repo:asdf)",
+			owner: "alice@example.com",
+			want:  autogold.Want("compound query adding owner", BasicQuery("(repo:supergreat file:has.owner(alice@example.com) myquery OR repo:asdf file:has.owner(alice@example.com) big)")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddOwnerFilter(BasicQuery(test.input), test.owner)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addDateFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		date  string
+		want  autogold.Value
+	}{
+		{
+			input: "type:commit myquery",
+			date:  "2022-04-12",
+			want:  autogold.Want("day", BasicQuery("type:commit after:2022-04-12 before:2022-04-13 myquery")),
+		},
+		{
+			input: "type:commit myquery",
+			date:  "2022-W13",
+			want:  autogold.Want("iso week", BasicQuery("type:commit after:2022-03-28 before:2022-04-04 myquery")),
+		},
+		{
+			input: "type:diff myquery",
+			date:  "2022-12",
+			want:  autogold.Want("month", BasicQuery("type:diff after:2022-12-01 before:2023-01-01 myquery")),
+		},
+		{
+			input: "type:diff myquery",
+			date:  "last week",
+			want:  autogold.Want("unrecognized date", `unrecognized date "last week"`),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddDateFilter(BasicQuery(test.input), test.date)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addDateRangeFilter(t *testing.T) {
+	after := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2022, 7, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		input  string
+		after  *time.Time
+		before *time.Time
+		want   autogold.Value
+	}{
+		{
+			input:  "type:diff myquery",
+			after:  &after,
+			before: &before,
+			want:   autogold.Want("after and before", BasicQuery("type:diff after:2022-01-01T00:00:00Z before:2022-07-01T10:00:00Z myquery")),
+		},
+		{
+			input: "type:diff myquery",
+			after: &after,
+			want:  autogold.Want("after only", BasicQuery("type:diff after:2022-01-01T00:00:00Z myquery")),
+		},
+		{
+			input: "type:diff myquery",
+			want:  autogold.Want("no range", BasicQuery("type:diff myquery")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddDateRangeFilter(BasicQuery(test.input), test.after, test.before)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addDirectoryFilter(t *testing.T) {
+	tests := []struct {
+		input     string
+		directory string
+		want      autogold.Value
+	}{
+		{
+			input:     "myquery",
+			directory: "cmd/server",
+			want:      autogold.Want("directory", BasicQuery("file:^cmd/server/ myquery")),
+		},
+		{
+			input:     "myquery",
+			directory: "/",
+			want:      autogold.Want("root directory", BasicQuery("-file:/ myquery")),
+		},
+		{
+			input:     "myquery",
+			directory: "my dir.d",
+			want:      autogold.Want("directory with whitespace", BasicQuery(`file:"^my dir\\.d/" myquery`)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddDirectoryFilter(BasicQuery(test.input), test.directory)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addLanguageFilter(t *testing.T) {
+	tests := []struct {
+		input    string
+		language string
+		want     autogold.Value
+	}{
+		{
+			input:    "myquery repo:supergreat",
+			language: "Go",
+			want:     autogold.Want("language", BasicQuery("repo:supergreat lang:Go myquery")),
+		},
+		{
+			input:    "myquery",
+			language: "Protocol Buffer",
+			want:     autogold.Want("language with whitespace", BasicQuery(`lang:"Protocol Buffer" myquery`)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddLanguageFilter(BasicQuery(test.input), test.language)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
 func Test_addFileFilter(t *testing.T) {
 	tests := []struct {
 		input string
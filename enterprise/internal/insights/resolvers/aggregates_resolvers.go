@@ -14,8 +14,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
@@ -36,6 +38,10 @@ const (
 const invalidQueryMsg = "Grouping is disabled because the search query is not valid."
 const fileUnsupportedFieldValueFmt = `Grouping by file is not available for searches with "%s:%s".`
 const authNotCommitDiffMsg = "Grouping by author is only available for diff and commit searches."
+const ownerUnsupportedFieldValueFmt = `Grouping by owner is not available for searches with "%s:%s".`
+const dateNotCommitDiffMsg = "Grouping by date is only available for diff and commit searches."
+const directoryUnsupportedFieldValueFmt = `Grouping by directory is not available for searches with "%s:%s".`
+const languageUnsupportedFieldValueFmt = `Grouping by language is not available for searches with "%s:%s".`
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
 const cgUnsupportedSelectFmt = `Grouping by capture group is not available for searches with "%s:%s".`
@@ -139,7 +145,17 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		cappedAggregator.Add(amr.Key.Group, int32(amr.Count))
 	}
 
-	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	var countingFunc aggregation.AggregationCountFunc
+	switch aggregationMode {
+	case types.OWNER_AGGREGATION_MODE:
+		countingFunc = aggregation.NewOwnerCountFunc(ctx, codeowners.NewResolver(gitserver.NewClient(r.postgresDB)))
+	case types.DATE_AGGREGATION_MODE:
+		countingFunc, err = aggregation.NewDateCountFunc(types.AggregationDateInterval(args.DateInterval))
+	case types.DIRECTORY_AGGREGATION_MODE:
+		countingFunc, err = aggregation.NewDirectoryCountFunc(int(args.DirectoryDepth))
+	default:
+		countingFunc, err = aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	}
 	if err != nil {
 		r.getLogger().Debug("no aggregation counting function for mode", log.String("mode", string(aggregationMode)), log.Error(err))
 		return &searchAggregationResultResolver{
@@ -369,6 +385,10 @@ func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 		types.PATH_AGGREGATION_MODE:          canAggregateByPath,
 		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
 		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
+		types.OWNER_AGGREGATION_MODE:         canAggregateByOwner,
+		types.DATE_AGGREGATION_MODE:          canAggregateByDate,
+		types.DIRECTORY_AGGREGATION_MODE:     canAggregateByDirectory,
+		types.LANGUAGE_AGGREGATION_MODE:      canAggregateByLanguage,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -394,6 +414,27 @@ func canAggregateByRepo(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, fileUnsupportedFieldValueFmt)
+}
+
+func canAggregateByOwner(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	// owners are resolved from file paths
+	return canAggregateByFile(searchQuery, patternType, ownerUnsupportedFieldValueFmt)
+}
+
+func canAggregateByDirectory(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, directoryUnsupportedFieldValueFmt)
+}
+
+func canAggregateByLanguage(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	// languages are detected from file paths
+	return canAggregateByFile(searchQuery, patternType, languageUnsupportedFieldValueFmt)
+}
+
+// canAggregateByFile checks that a query returns file results for modes that
+// group by a property of the file. unsupportedFieldValueFmt formats the reason
+// given for queries that don't.
+func canAggregateByFile(searchQuery, patternType, unsupportedFieldValueFmt string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -404,7 +445,7 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 	for _, parameter := range parameters {
 		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
 			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
-				reason := fmt.Sprintf(fileUnsupportedFieldValueFmt,
+				reason := fmt.Sprintf(unsupportedFieldValueFmt,
 					parameter.Field, parameter.Value)
 				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 			}
@@ -414,6 +455,17 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, authNotCommitDiffMsg)
+}
+
+func canAggregateByDate(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, dateNotCommitDiffMsg)
+}
+
+// canAggregateByCommit checks that a query returns commit or diff results for
+// modes that group by a property of the commit. notCommitDiffMsg is the reason
+// given for queries that don't.
+func canAggregateByCommit(searchQuery, patternType, notCommitDiffMsg string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -428,7 +480,7 @@ func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableR
 			}
 		}
 	}
-	return false, &notAvailableReason{reason: authNotCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+	return false, &notAvailableReason{reason: notCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 }
 
 func canAggregateByCaptureGroup(searchQuery, patternType string) (bool, *notAvailableReason, error) {
@@ -586,6 +638,14 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.OWNER_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddOwnerFilter
+	case types.DATE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddDateFilter
+	case types.DIRECTORY_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddDirectoryFilter
+	case types.LANGUAGE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddLanguageFilter
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
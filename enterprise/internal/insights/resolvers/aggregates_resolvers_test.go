@@ -128,6 +128,46 @@ func Test_canAggregateByPath(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByOwner(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query selecting owners",
+			query:        "func(t *testing.T) select:file.owners",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(ownerUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "insights type:diff",
+			reason:       fmt.Sprintf(ownerUnsupportedFieldValueFmt, "type", "diff"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for invalid query",
+			query:        "insights type:commit fork:test",
+			canAggregate: false,
+			reason:       invalidQueryMsg,
+			err:          errors.Newf("ParseQuery"),
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByOwner,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByAuthor(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -189,6 +229,95 @@ func Test_canAggregateByAuthor(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByDate(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "cannot aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			reason:       dateNotCommitDiffMsg,
+			canAggregate: false,
+		},
+		{
+			name:         "can aggregate for query with type:commit parameter",
+			query:        "repo:contains.path(README) type:commit fix",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with type:diff parameter",
+			query:        "repo:contains.path(README) type:diff fix",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for invalid query",
+			query:        "type:diff fork:leo",
+			reason:       invalidQueryMsg,
+			canAggregate: false,
+			err:          errors.Newf("ParseQuery"),
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByDate,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByDirectory(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(directoryUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for query with type:commit parameter",
+			query:        "insights type:commit",
+			reason:       fmt.Sprintf(directoryUnsupportedFieldValueFmt, "type", "commit"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByDirectory,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByLanguage(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with type:path parameter",
+			query:        "insights type:path",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "insights type:diff",
+			reason:       fmt.Sprintf(languageUnsupportedFieldValueFmt, "type", "diff"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByLanguage,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByCaptureGroup(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -456,6 +585,48 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.PATH_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Want("owner", "file:has.owner(@sourcegraph/search) findme"),
+			query:       "findme",
+			drilldown:   "@sourcegraph/search",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("date month", "type:commit after:2022-02-01 before:2022-03-01 findme"),
+			query:       "type:commit findme",
+			drilldown:   "2022-02",
+			patternType: "standard",
+			mode:        types.DATE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("date week", "type:diff after:2022-01-03 before:2022-01-10 findme"),
+			query:       "type:diff findme",
+			drilldown:   "2022-W01",
+			patternType: "standard",
+			mode:        types.DATE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("directory", "file:^cmd/server/ findme"),
+			query:       "findme",
+			drilldown:   "cmd/server",
+			patternType: "standard",
+			mode:        types.DIRECTORY_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("root directory", "-file:/ findme"),
+			query:       "findme",
+			drilldown:   "/",
+			patternType: "standard",
+			mode:        types.DIRECTORY_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("language", `lang:"Protocol Buffer" findme`),
+			query:       "findme",
+			drilldown:   "Protocol Buffer",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
 		{
 			want:        autogold.Want("capturegroup_with_whitespace", "case:yes /fin(?:d m)e/"),
 			query:       "/fin(.*)e/",
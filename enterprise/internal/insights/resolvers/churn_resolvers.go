@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *AggregationResolver) FileChurn(ctx context.Context, args graphqlbackend.FileChurnArgs) ([]graphqlbackend.FileChurnResolver, error) {
+	if args.First <= 0 {
+		return nil, errors.New("first must be greater than zero")
+	}
+
+	// 🚨 SECURITY: The repository is looked up through the database to ensure
+	// the user may access it.
+	repo, err := r.postgresDB.Repos().GetByName(ctx, api.RepoName(args.Repository))
+	if err != nil {
+		return nil, err
+	}
+
+	req := protocol.ChurnRequest{Repo: repo.Name}
+	if args.Revision != nil {
+		req.Revision = *args.Revision
+	}
+	var after, before *time.Time
+	if args.After != nil {
+		after = &args.After.Time
+		req.After = after.Format(time.RFC3339)
+	}
+	if args.Before != nil {
+		before = &args.Before.Time
+		req.Before = before.Format(time.RFC3339)
+	}
+	if args.Paths != nil {
+		req.Paths = *args.Paths
+	}
+
+	// The client omits paths hidden by sub-repo permissions, so gitserver can
+	// only limit the number of paths when there are none.
+	checker := authz.DefaultSubRepoPermsChecker
+	if !authz.SubRepoEnabled(checker) {
+		req.Limit = int(args.First)
+	}
+
+	var stats []protocol.ChurnStat
+	err = gitserver.NewClient(r.postgresDB).Churn(ctx, checker, &req, func(batch []protocol.ChurnStat) {
+		stats = append(stats, batch...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) > int(args.First) {
+		stats = stats[:args.First]
+	}
+
+	resolvers := make([]graphqlbackend.FileChurnResolver, 0, len(stats))
+	for _, stat := range stats {
+		query, err := fileChurnQuery(string(repo.Name), stat.Path, after, before)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &fileChurnResolver{stat: stat, query: query})
+	}
+	return resolvers, nil
+}
+
+// fileChurnQuery returns a search query for the diffs of path in repo that
+// are counted as churn.
+func fileChurnQuery(repo, path string, after, before *time.Time) (string, error) {
+	query, err := querybuilder.AddRepoFilter("type:diff", repo)
+	if err != nil {
+		return "", err
+	}
+	query, err = querybuilder.AddFileFilter(query, path)
+	if err != nil {
+		return "", err
+	}
+	query, err = querybuilder.AddDateRangeFilter(query, after, before)
+	if err != nil {
+		return "", err
+	}
+	return string(query), nil
+}
+
+type fileChurnResolver struct {
+	stat  protocol.ChurnStat
+	query string
+}
+
+func (r *fileChurnResolver) Path() string        { return r.stat.Path }
+func (r *fileChurnResolver) Commits() int32      { return int32(r.stat.Commits) }
+func (r *fileChurnResolver) LinesAdded() int32   { return int32(r.stat.LinesAdded) }
+func (r *fileChurnResolver) LinesDeleted() int32 { return int32(r.stat.LinesDeleted) }
+func (r *fileChurnResolver) Authors() int32      { return int32(r.stat.Authors) }
+func (r *fileChurnResolver) Query() string       { return r.query }
@@ -0,0 +1,40 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+)
+
+func Test_fileChurnQuery(t *testing.T) {
+	after := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		path  string
+		after *time.Time
+		want  autogold.Value
+	}{
+		{
+			path: "cmd/main.go",
+			want: autogold.Want("file", `type:diff repo:^github\.com/sourcegraph/sourcegraph$ file:^cmd/main\.go$`),
+		},
+		{
+			path:  "cmd/main.go",
+			after: &after,
+			want:  autogold.Want("file after date", `type:diff repo:^github\.com/sourcegraph/sourcegraph$ file:^cmd/main\.go$ after:2022-01-01T00:00:00Z`),
+		},
+		{
+			path: "docs/my file.md",
+			want: autogold.Want("file with whitespace", `type:diff repo:^github\.com/sourcegraph/sourcegraph$ file:(^docs/my file\.md$)`),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := fileChurnQuery("github.com/sourcegraph/sourcegraph", test.path, test.after, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.want.Equal(t, got)
+		})
+	}
+}
@@ -83,6 +83,10 @@ func (r *disabledResolver) SearchQueryAggregate(ctx context.Context, args graphq
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) FileChurn(ctx context.Context, args graphqlbackend.FileChurnArgs) ([]graphqlbackend.FileChurnResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) InsightViewDebug(ctx context.Context, args graphqlbackend.InsightViewDebugArgs) (graphqlbackend.InsightViewDebugResolver, error) {
 	return nil, errors.New(r.reason)
 }
@@ -228,9 +228,23 @@ const (
 	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
+	DATE_AGGREGATION_MODE          SearchAggregationMode = "DATE"
+	DIRECTORY_AGGREGATION_MODE     SearchAggregationMode = "DIRECTORY"
+	LANGUAGE_AGGREGATION_MODE      SearchAggregationMode = "LANGUAGE"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, OWNER_AGGREGATION_MODE, DATE_AGGREGATION_MODE, DIRECTORY_AGGREGATION_MODE, LANGUAGE_AGGREGATION_MODE}
+
+// AggregationDateInterval is the size of the buckets that DATE aggregations
+// group results into.
+type AggregationDateInterval string
+
+const (
+	DAY_AGGREGATION_INTERVAL   AggregationDateInterval = "DAY"
+	WEEK_AGGREGATION_INTERVAL  AggregationDateInterval = "WEEK"
+	MONTH_AGGREGATION_INTERVAL AggregationDateInterval = "MONTH"
+)
 
 type AggregationNotAvailableReasonType string
 
@@ -55,6 +55,8 @@ type UploadsServiceResolver interface {
 	LSIFUploadsByRepo(ctx context.Context, args *LSIFRepositoryUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	DeleteLSIFUpload(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
 	DeleteLSIFUploads(ctx context.Context, args *DeleteLSIFUploadsArgs) (*EmptyResponse, error)
+	FailedLSIFUploads(ctx context.Context, args *FailedLSIFUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
+	RequeueFailedLSIFUploads(ctx context.Context, args *RequeueFailedLSIFUploadsArgs) (*EmptyResponse, error)
 }
 type PoliciesServiceResolver interface {
 	CodeIntelligenceConfigurationPolicies(ctx context.Context, args *CodeIntelligenceConfigurationPoliciesArgs) (CodeIntelligenceConfigurationPolicyConnectionResolver, error)
@@ -581,6 +583,17 @@ type DeleteLSIFUploadsArgs struct {
 	Repository      *graphql.ID
 }
 
+type FailedLSIFUploadsQueryArgs struct {
+	graphqlutil.ConnectionArgs
+	Repository *graphql.ID
+	After      *string
+}
+
+type RequeueFailedLSIFUploadsArgs struct {
+	Repository *graphql.ID
+	Limit      *int32
+}
+
 type LSIFIndexesWithRepositoryNamespaceResolver interface {
 	Root() string
 	Indexer() CodeIntelIndexerResolver
@@ -0,0 +1,157 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of paths
+// in a repository.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Rule is a single line of a CODEOWNERS file: a path pattern and the owners of
+// the paths matching it.
+type Rule struct {
+	Pattern string
+	Owners  []string
+
+	matcher *regexp.Regexp
+}
+
+// Match returns whether the rule's pattern matches the given path. The path is
+// relative to the root of the repository.
+func (r *Rule) Match(path string) bool {
+	return r.matcher.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	Rules []*Rule
+}
+
+// Parse parses the contents of a CODEOWNERS file. Blank lines and comments are
+// ignored. Patterns follow the gitignore syntax used by GitHub and GitLab.
+func Parse(r io.Reader) (*Ruleset, error) {
+	var rules []*Rule
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// GitLab section headers, e.g. "[Documentation]", carry no rule.
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		matcher, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern on line %d", lineNumber)
+		}
+		rules = append(rules, &Rule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			matcher: matcher,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Ruleset{Rules: rules}, nil
+}
+
+// FindOwners returns the owners of the given path. As with GitHub, the last
+// matching rule takes precedence, and a matching rule without owners means
+// that the path has no owners.
+func (rs *Ruleset) FindOwners(path string) []string {
+	if rs == nil {
+		return nil
+	}
+	for i := len(rs.Rules) - 1; i >= 0; i-- {
+		if rs.Rules[i].Match(path) {
+			return rs.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// IsOwnedBy returns whether owner is one of the owners of the given path.
+func (rs *Ruleset) IsOwnedBy(path, owner string) bool {
+	for _, o := range rs.FindOwners(path) {
+		if SameOwner(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// SameOwner returns whether a and b refer to the same owner. Owners are
+// compared case-insensitively, and the leading "@" of user and team handles is
+// optional so that both "@sourcegraph/search" and "sourcegraph/search" match.
+func SameOwner(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "@"), strings.TrimPrefix(b, "@"))
+}
+
+// compilePattern converts a gitignore-style CODEOWNERS pattern into a regular
+// expression that matches repository-relative paths.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	// A pattern is anchored to the root of the repository if it starts with a
+	// slash or contains a slash anywhere but at its end. Otherwise it matches
+	// at any depth.
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		return nil, errors.Errorf("empty pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '*':
+			if i+1 < len(trimmed) && trimmed[i+1] == '*' {
+				i++
+				if i+1 < len(trimmed) && trimmed[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	// A pattern matching a directory also matches everything inside it.
+	if directoryOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,99 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+const testCodeowners = `
+# Default owners
+*                   @sourcegraph/everyone
+
+*.go                @sourcegraph/backend # Go code
+/client/            @sourcegraph/frontend
+docs/**/*.md        @sourcegraph/docs writer@sourcegraph.com
+internal/search     @sourcegraph/search
+testdata/
+
+[GitLab section]
+/ops/?.yaml         @sourcegraph/ops
+`
+
+func TestFindOwners(t *testing.T) {
+	rs, err := Parse(strings.NewReader(testCodeowners))
+	require.NoError(t, err)
+
+	cases := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@sourcegraph/everyone"}},
+		{"main.go", []string{"@sourcegraph/backend"}},
+		{"cmd/frontend/main.go", []string{"@sourcegraph/backend"}},
+		{"client/web/index.ts", []string{"@sourcegraph/frontend"}},
+		{"nested/client/index.ts", []string{"@sourcegraph/everyone"}},
+		{"docs/index.md", []string{"@sourcegraph/docs", "writer@sourcegraph.com"}},
+		{"docs/admin/config/index.md", []string{"@sourcegraph/docs", "writer@sourcegraph.com"}},
+		{"internal/search/job/job.go", []string{"@sourcegraph/search"}},
+		{"internal/search", []string{"@sourcegraph/search"}},
+		{"internal/searcher/main.go", []string{"@sourcegraph/backend"}},
+		{"internal/search/testdata/fixture.go", []string{}},
+		{"ops/a.yaml", []string{"@sourcegraph/ops"}},
+		{"ops/ab.yaml", []string{"@sourcegraph/everyone"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			require.Equal(t, tc.want, rs.FindOwners(tc.path))
+		})
+	}
+}
+
+func TestIsOwnedBy(t *testing.T) {
+	rs, err := Parse(strings.NewReader(testCodeowners))
+	require.NoError(t, err)
+
+	require.True(t, rs.IsOwnedBy("main.go", "@sourcegraph/backend"))
+	require.True(t, rs.IsOwnedBy("main.go", "sourcegraph/Backend"))
+	require.False(t, rs.IsOwnedBy("main.go", "@sourcegraph/frontend"))
+	require.True(t, rs.IsOwnedBy("docs/index.md", "writer@sourcegraph.com"))
+
+	var empty *Ruleset
+	require.False(t, empty.IsOwnedBy("main.go", "@sourcegraph/backend"))
+}
+
+func TestResolver(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "github.com/sourcegraph/sourcegraph" && name == ".github/CODEOWNERS" {
+			return []byte("*.go @sourcegraph/backend"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+
+	resolver := NewResolver(gitserverClient)
+	ctx := context.Background()
+
+	owners, err := resolver.Owners(ctx, "github.com/sourcegraph/sourcegraph", "deadbeef", "main.go")
+	require.NoError(t, err)
+	require.Equal(t, []string{"@sourcegraph/backend"}, owners)
+
+	// The ruleset is cached per repo and commit.
+	calls := len(gitserverClient.ReadFileFunc.History())
+	_, err = resolver.Owners(ctx, "github.com/sourcegraph/sourcegraph", "deadbeef", "README.md")
+	require.NoError(t, err)
+	require.Len(t, gitserverClient.ReadFileFunc.History(), calls)
+
+	// Repos without a CODEOWNERS file have no owners.
+	owners, err = resolver.Owners(ctx, "github.com/sourcegraph/zoekt", "deadbeef", "main.go")
+	require.NoError(t, err)
+	require.Empty(t, owners)
+}
@@ -0,0 +1,89 @@
+package codeowners
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// Paths are the locations that are checked for a CODEOWNERS file, in order of
+// precedence. Only the first file found is used.
+var Paths = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Resolver loads CODEOWNERS files from gitserver. Rulesets are cached per repo
+// and commit for the lifetime of the resolver, so a resolver should be scoped
+// to a single request, such as a search.
+type Resolver struct {
+	client gitserver.Client
+
+	mu    sync.Mutex
+	cache map[repoCommit]*Ruleset
+}
+
+type repoCommit struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+func NewResolver(client gitserver.Client) *Resolver {
+	return &Resolver{
+		client: client,
+		cache:  make(map[repoCommit]*Ruleset),
+	}
+}
+
+// Ruleset returns the CODEOWNERS ruleset of the repo at the given commit. If
+// the repo has no CODEOWNERS file, an empty ruleset is returned.
+func (r *Resolver) Ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := repoCommit{repo: repo, commit: commit}
+
+	r.mu.Lock()
+	rs, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok {
+		return rs, nil
+	}
+
+	rs, err := r.load(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = rs
+	r.mu.Unlock()
+	return rs, nil
+}
+
+// Owners returns the owners of path in the repo at the given commit.
+func (r *Resolver) Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error) {
+	rs, err := r.Ruleset(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+	return rs.FindOwners(path), nil
+}
+
+func (r *Resolver) load(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range Paths {
+		content, err := r.client.ReadFile(ctx, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		return Parse(bytes.NewReader(content))
+	}
+	return &Ruleset{}, nil
+}
@@ -20,6 +20,8 @@ func AuthProviderType(p schema.AuthProviders) string {
 		return p.Github.Type
 	case p.Gitlab != nil:
 		return p.Gitlab.Type
+	case p.Ldap != nil:
+		return p.Ldap.Type
 	default:
 		return ""
 	}
@@ -206,6 +206,7 @@ var siteConfigSecrets = []struct {
 	editPaths []string
 }{
 	{readPath: `executors\.accessToken`, editPaths: []string{"executors.accessToken"}},
+	{readPath: `scim\.authToken`, editPaths: []string{"scim.authToken"}},
 	{readPath: `email\.smtp.username`, editPaths: []string{"email.smtp", "username"}},
 	{readPath: `email\.smtp.password`, editPaths: []string{"email.smtp", "password"}},
 	{readPath: `organizationInvitations.signingKey`, editPaths: []string{"organizationInvitations", "signingKey"}},
@@ -240,6 +241,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.Gitlab != nil {
 			oldSecrets[ap.Gitlab.ClientID] = ap.Gitlab.ClientSecret
 		}
+		if ap.Ldap != nil {
+			oldSecrets[ap.Ldap.Url+ap.Ldap.BindDN] = ap.Ldap.BindPassword
+		}
 	}
 
 	newCfg, err := ParseConfig(conftypes.RawUnified{
@@ -258,6 +262,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.Gitlab != nil && ap.Gitlab.ClientSecret == redactedSecret {
 			ap.Gitlab.ClientSecret = oldSecrets[ap.Gitlab.ClientID]
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword == redactedSecret {
+			ap.Ldap.BindPassword = oldSecrets[ap.Ldap.Url+ap.Ldap.BindDN]
+		}
 	}
 	unredactedSite, err := jsonc.Edit(input, newCfg.AuthProviders, "auth.providers")
 	if err != nil {
@@ -305,6 +312,9 @@ func RedactSecrets(raw conftypes.RawUnified) (empty conftypes.RawUnified, err er
 		if ap.Gitlab != nil {
 			ap.Gitlab.ClientSecret = redactedSecret
 		}
+		if ap.Ldap != nil && ap.Ldap.BindPassword != "" {
+			ap.Ldap.BindPassword = redactedSecret
+		}
 	}
 	redactedSite := raw.Site
 	if len(cfg.AuthProviders) > 0 {
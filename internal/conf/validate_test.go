@@ -240,6 +240,29 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_LDAPBindPassword(t *testing.T) {
+	const cfgWithLDAPAuthProvider = `{
+  "auth.providers": [
+    {
+      "bindDN": "cn=sourcegraph,dc=example,dc=com",
+      "bindPassword": "%s",
+      "searchBase": "dc=example,dc=com",
+      "type": "ldap",
+      "url": "ldaps://ldap.example.com"
+    }
+  ]
+}`
+	site := fmt.Sprintf(cfgWithLDAPAuthProvider, "hunter2")
+
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfgWithLDAPAuthProvider, redactedSecret), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Equal(t, site, unredacted)
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
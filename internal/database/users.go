@@ -78,6 +78,7 @@ type UserStore interface {
 	List(context.Context, *UsersListOptions) (_ []*types.User, err error)
 	ListDates(context.Context) ([]types.UserDates, error)
 	RandomizePasswordAndClearPasswordResetRateLimit(context.Context, int32) error
+	RecoverUsersList(context.Context, []int32) (_ []int32, err error)
 	RenewPasswordResetCode(context.Context, int32) (string, error)
 	SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool) error
 	SetPassword(ctx context.Context, id int32, resetCode, newPassword string) (bool, error)
@@ -559,6 +560,59 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	return nil
 }
 
+// RecoverUsersList restores the soft-deleted users with the given IDs, along with their usernames and the
+// external accounts that were deleted with them, and returns the IDs of the users that were restored. Users
+// that are not soft-deleted, or whose username has since been taken by another user or organization, are not
+// restored. Email addresses and access tokens removed by the soft-delete are not restored.
+func (u *userStore) RecoverUsersList(ctx context.Context, ids []int32) (_ []int32, err error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	tx, err := u.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	recoverableIDs, err := basestore.ScanInt32s(tx.Query(ctx, sqlf.Sprintf(recoverableUsersQuery, pq.Array(ids))))
+	if err != nil || len(recoverableIDs) == 0 {
+		return nil, err
+	}
+
+	// External accounts are soft-deleted in the same transaction as their user, so they share the
+	// deletion timestamp. Accounts that were deleted before the user are not restored.
+	if err := tx.Exec(ctx, sqlf.Sprintf(`
+UPDATE user_external_accounts
+SET deleted_at = NULL
+FROM users
+WHERE
+	user_external_accounts.user_id = users.id AND
+	users.id = ANY(%s) AND
+	user_external_accounts.deleted_at = users.deleted_at
+`, pq.Array(recoverableIDs))); err != nil {
+		return nil, err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE users SET deleted_at = NULL, updated_at = now() WHERE id = ANY(%s)", pq.Array(recoverableIDs))); err != nil {
+		return nil, err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf("INSERT INTO names (name, user_id) SELECT username, id FROM users WHERE id = ANY(%s)", pq.Array(recoverableIDs))); err != nil {
+		return nil, err
+	}
+
+	return recoverableIDs, nil
+}
+
+const recoverableUsersQuery = `
+SELECT id FROM users
+WHERE
+	id = ANY(%s) AND
+	deleted_at IS NOT NULL AND
+	NOT EXISTS (SELECT 1 FROM names WHERE names.name = users.username)
+ORDER BY id
+FOR UPDATE
+`
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *userStore) HardDelete(ctx context.Context, id int32) (err error) {
 	return u.HardDeleteList(ctx, []int32{id})
@@ -752,6 +752,61 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_RecoverUsersList(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	ctx = actor.WithActor(ctx, &actor.Actor{UID: 1, Internal: true})
+
+	var ids []int32
+	for _, username := range []string{"u1", "u2", "u3"} {
+		user, err := db.Users().Create(ctx, NewUser{Username: username})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, user.ID)
+	}
+
+	if err := db.Users().DeleteList(ctx, ids[:2]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Take the username of the second user, so that it cannot be recovered.
+	if _, err := db.Orgs().Create(ctx, "u2", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	recoveredIDs, err := db.Users().RecoverUsersList(ctx, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int32{ids[0]}, recoveredIDs); diff != "" {
+		t.Fatalf("unexpected recovered users (-want +got):\n%s", diff)
+	}
+
+	if user, err := db.Users().GetByUsername(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	} else if user.ID != ids[0] {
+		t.Errorf("unexpected user. want=%d have=%d", ids[0], user.ID)
+	}
+	if _, err := db.Users().GetByID(ctx, ids[1]); !errcode.IsNotFound(err) {
+		t.Errorf("expected user %d to remain deleted, got err=%v", ids[1], err)
+	}
+
+	// Recovering users that are not deleted is a no-op.
+	recoveredIDs, err = db.Users().RecoverUsersList(ctx, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recoveredIDs) != 0 {
+		t.Errorf("unexpected recovered users: %v", recoveredIDs)
+	}
+}
+
 func TestUsers_HasTag(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -1566,6 +1566,34 @@ func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest,
 	return err
 }
 
+// AddPullRequestReviewer adds the user with the given name as a reviewer of
+// the given pull request. Adding a user that already is a reviewer is a noop.
+func (c *Client) AddPullRequestReviewer(ctx context.Context, pr *PullRequest, username string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	payload := map[string]any{
+		"user": map[string]string{"name": username},
+		"role": "REVIEWER",
+	}
+
+	var resp Reviewer
+	_, err := c.send(ctx, "POST", path, nil, &payload, &resp)
+	return err
+}
+
 func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
 	if pr.ToRef.Repository.Slug == "" {
 		return errors.New("repository slug empty")
@@ -0,0 +1,263 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Change is a change (the equivalent of a pull request) on Gerrit, as
+// returned by the changes endpoints. See
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	ID              string                   `json:"id"`
+	Project         string                   `json:"project"`
+	Branch          string                   `json:"branch"`
+	ChangeID        string                   `json:"change_id"`
+	Subject         string                   `json:"subject"`
+	Status          ChangeStatus             `json:"status"`
+	Created         Timestamp                `json:"created"`
+	Updated         Timestamp                `json:"updated"`
+	Number          int64                    `json:"_number"`
+	Owner           Account                  `json:"owner"`
+	WorkInProgress  bool                     `json:"work_in_progress,omitempty"`
+	Submittable     bool                     `json:"submittable,omitempty"`
+	Labels          map[string]LabelInfo     `json:"labels,omitempty"`
+	CurrentRevision string                   `json:"current_revision,omitempty"`
+	Revisions       map[string]*RevisionInfo `json:"revisions,omitempty"`
+}
+
+// ChangeStatus is the status of a change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// LabelInfo contains the votes on a label of a change. Approved, Rejected,
+// Recommended and Disliked are set to one of the accounts that voted with the
+// maximum, minimum, a positive or a negative value, respectively.
+type LabelInfo struct {
+	Approved    *Account       `json:"approved,omitempty"`
+	Rejected    *Account       `json:"rejected,omitempty"`
+	Recommended *Account       `json:"recommended,omitempty"`
+	Disliked    *Account       `json:"disliked,omitempty"`
+	All         []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is the vote of an account on a label.
+type ApprovalInfo struct {
+	Account
+	Value int       `json:"value"`
+	Date  Timestamp `json:"date"`
+}
+
+// RevisionInfo is a patch set of a change.
+type RevisionInfo struct {
+	Number int64       `json:"_number"`
+	Ref    string      `json:"ref"`
+	Commit *CommitInfo `json:"commit,omitempty"`
+}
+
+// CommitInfo is the commit of a patch set.
+type CommitInfo struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// CurrentRevisionInfo returns the current patch set of the change, or nil if
+// it was not requested.
+func (c *Change) CurrentRevisionInfo() *RevisionInfo {
+	return c.Revisions[c.CurrentRevision]
+}
+
+// Timestamp is a timestamp in the format used by the Gerrit REST API, which is
+// always in UTC.
+type Timestamp struct {
+	time.Time
+}
+
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	ts, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = ts
+	return nil
+}
+
+// changeOptions are the additional fields requested for every change returned
+// by the client.
+var changeOptions = []string{"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_LABELS", "DETAILED_ACCOUNTS", "SUBMITTABLE"}
+
+// GetChange returns the change with the given ID, which can be a Change-Id or
+// any other identifier accepted by the Gerrit REST API.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	qs := url.Values{"o": changeOptions}
+	u := url.URL{Path: fmt.Sprintf("a/changes/%s", url.PathEscape(changeID)), RawQuery: qs.Encode()}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the change with the given ID.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.changeAction(ctx, changeID, "abandon")
+}
+
+// RestoreChange restores the abandoned change with the given ID.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.changeAction(ctx, changeID, "restore")
+}
+
+// ErrNotMergeable is returned by SubmitChange when the change cannot be
+// submitted, because it is in conflict with its branch or a submit
+// requirement isn't met.
+var ErrNotMergeable = errors.New("change is not in a submittable state")
+
+// SubmitChange submits the change with the given ID, which merges it into its
+// branch.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) (*Change, error) {
+	change, err := c.changeAction(ctx, changeID, "submit")
+	if err != nil {
+		var e *httpError
+		if errors.As(err, &e) && e.StatusCode == http.StatusConflict {
+			return nil, errors.Wrap(ErrNotMergeable, err.Error())
+		}
+		return nil, err
+	}
+	return change, nil
+}
+
+// SetWorkInProgress marks the change with the given ID as work in progress.
+func (c *Client) SetWorkInProgress(ctx context.Context, changeID string) error {
+	_, err := c.post(ctx, fmt.Sprintf("a/changes/%s/wip", url.PathEscape(changeID)), nil, nil)
+	return err
+}
+
+// SetReadyForReview marks the work in progress change with the given ID as
+// ready for review.
+func (c *Client) SetReadyForReview(ctx context.Context, changeID string) error {
+	_, err := c.post(ctx, fmt.Sprintf("a/changes/%s/ready", url.PathEscape(changeID)), nil, nil)
+	return err
+}
+
+// ReviewInput is the input to SetReview.
+type ReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+}
+
+// SetReview posts a review, such as a comment, on the current patch set of
+// the change with the given ID.
+func (c *Client) SetReview(ctx context.Context, changeID string, input ReviewInput) error {
+	var result json.RawMessage
+	_, err := c.post(ctx, fmt.Sprintf("a/changes/%s/revisions/current/review", url.PathEscape(changeID)), input, &result)
+	return err
+}
+
+// AddReviewer adds the account, group or email address given as reviewer to
+// the reviewers of the change with the given ID.
+func (c *Client) AddReviewer(ctx context.Context, changeID, reviewer string) error {
+	input := struct {
+		Reviewer string `json:"reviewer"`
+	}{Reviewer: reviewer}
+
+	var result json.RawMessage
+	_, err := c.post(ctx, fmt.Sprintf("a/changes/%s/reviewers", url.PathEscape(changeID)), input, &result)
+	return err
+}
+
+// GetAuthenticatedAccount returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err = c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Authenticator returns the basic auth credentials the client authenticates
+// with.
+func (c *Client) Authenticator() *auth.BasicAuth {
+	return &auth.BasicAuth{Username: c.Config.Username, Password: c.Config.Password}
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client and rate limiter as the current Client, except authenticated
+// with the given credentials.
+func (c *Client) WithAuthenticator(a *auth.BasicAuth) *Client {
+	config := *c.Config
+	config.Username = a.Username
+	config.Password = a.Password
+
+	return &Client{
+		httpClient: c.httpClient,
+		Config:     &config,
+		URL:        c.URL,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+func (c *Client) changeAction(ctx context.Context, changeID, action string) (*Change, error) {
+	var change Change
+	if _, err := c.post(ctx, fmt.Sprintf("a/changes/%s/%s", url.PathEscape(changeID), action), nil, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *Client) post(ctx context.Context, urlPath string, input any, result any) (*http.Response, error) {
+	var body bytes.Buffer
+	if input != nil {
+		if err := json.NewEncoder(&body).Encode(input); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest("POST", urlPath, &body)
+	if err != nil {
+		return nil, err
+	}
+	if input != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(ctx, req, result)
+}
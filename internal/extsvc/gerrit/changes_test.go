@@ -0,0 +1,98 @@
+package gerrit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_Changes(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+user+":"+password+" "+string(body))
+
+		switch r.URL.Path {
+		case "/a/changes/Ideadbeef":
+			_, _ = io.WriteString(w, `)]}'
+{
+  "id": "repo~main~Ideadbeef",
+  "project": "repo",
+  "branch": "main",
+  "change_id": "Ideadbeef",
+  "subject": "Fix it",
+  "status": "NEW",
+  "created": "2022-11-01 10:00:00.000000000",
+  "updated": "2022-11-02 11:30:00.500000000",
+  "_number": 42,
+  "labels": {"Code-Review": {"approved": {"_account_id": 1}}},
+  "current_revision": "abc",
+  "revisions": {"abc": {"_number": 2, "ref": "refs/changes/42/42/2", "commit": {"subject": "Fix it", "message": "Fix it\n\nChange-Id: Ideadbeef\n"}}}
+}`)
+		case "/a/changes/Ideadbeef/submit":
+			_, _ = io.WriteString(w, ")]}'\n"+`{"change_id": "Ideadbeef", "status": "MERGED"}`)
+		case "/a/changes/Iconflict/submit":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, "change is new")
+		case "/a/changes/Ibroken/submit":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/a/changes/Ideadbeef/wip":
+			w.WriteHeader(http.StatusNoContent)
+		case "/a/changes/Ideadbeef/reviewers":
+			_, _ = io.WriteString(w, ")]}'\n"+`{"input": "alice", "reviewers": [{"_account_id": 2}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("gerrit", &schema.GerritConnection{Url: srv.URL + "/", Username: "admin", Password: "secret"}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	change, err := cli.GetChange(ctx, "Ideadbeef")
+	require.NoError(t, err)
+	require.Equal(t, int64(42), change.Number)
+	require.Equal(t, ChangeStatusNew, change.Status)
+	require.Equal(t, time.Date(2022, 11, 2, 11, 30, 0, 500000000, time.UTC), change.Updated.Time)
+	require.NotNil(t, change.Labels["Code-Review"].Approved)
+	require.Equal(t, "refs/changes/42/42/2", change.CurrentRevisionInfo().Ref)
+
+	change, err = cli.SubmitChange(ctx, "Ideadbeef")
+	require.NoError(t, err)
+	require.Equal(t, ChangeStatusMerged, change.Status)
+
+	_, err = cli.SubmitChange(ctx, "Iconflict")
+	require.ErrorIs(t, err, ErrNotMergeable)
+
+	_, err = cli.SubmitChange(ctx, "Ibroken")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotMergeable)
+
+	require.NoError(t, cli.WithAuthenticator(&auth.BasicAuth{Username: "user", Password: "token"}).SetWorkInProgress(ctx, "Ideadbeef"))
+
+	require.NoError(t, cli.AddReviewer(ctx, "Ideadbeef", "alice"))
+
+	_, err = cli.GetChange(ctx, "Imissing")
+	require.True(t, errcode.IsNotFound(err))
+
+	require.Equal(t, []string{
+		"GET /a/changes/Ideadbeef?o=CURRENT_REVISION&o=CURRENT_COMMIT&o=DETAILED_LABELS&o=DETAILED_ACCOUNTS&o=SUBMITTABLE admin:secret ",
+		"POST /a/changes/Ideadbeef/submit admin:secret ",
+		"POST /a/changes/Iconflict/submit admin:secret ",
+		"POST /a/changes/Ibroken/submit admin:secret ",
+		"POST /a/changes/Ideadbeef/wip user:token ",
+		"POST /a/changes/Ideadbeef/reviewers admin:secret {\"reviewer\":\"alice\"}\n",
+		"GET /a/changes/Imissing?o=CURRENT_REVISION&o=CURRENT_COMMIT&o=DETAILED_LABELS&o=DETAILED_ACCOUNTS&o=SUBMITTABLE admin:secret ",
+	}, requests)
+}
@@ -199,6 +199,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints, such as marking a change as work in progress, do not
+	// return a response body.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
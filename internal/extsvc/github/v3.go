@@ -731,6 +731,45 @@ func (c *V3Client) Fork(ctx context.Context, owner, repo string, org *string) (*
 	return convertRestRepo(restRepo), nil
 }
 
+// RequestReviewers requests a review of the given pull request from the users
+// with the given logins.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, logins []string) error {
+	payload := struct {
+		Reviewers []string `json:"reviewers"`
+	}{Reviewers: logins}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// AddAssignees adds the users with the given logins to the assignees of the
+// given issue or pull request. Existing assignees are kept.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#add-assignees-to-an-issue
+func (c *V3Client) AddAssignees(ctx context.Context, owner, repo string, number int64, logins []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: logins}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// AddLabels adds the labels with the given names to the given issue or pull
+// request. Labels that don't exist in the repository yet are created.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#add-labels-to-an-issue
+func (c *V3Client) AddLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	payload := struct {
+		Labels []string `json:"labels"`
+	}{Labels: labels}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, number), payload, &[]Label{})
+	return err
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
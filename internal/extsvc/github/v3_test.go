@@ -942,3 +942,46 @@ func TestSyncWebhook_webhookURLBuilderWithID(t *testing.T) {
 		})
 	}
 }
+
+func TestV3Client_AssignPullRequest(t *testing.T) {
+	rcache.SetupForTest(t)
+
+	requests := map[string]map[string][]string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string][]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+		requests[r.Method+" "+r.URL.Path] = payload
+
+		if strings.HasSuffix(r.URL.Path, "/labels") {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(testServer.Close)
+
+	uri, _ := url.Parse(testServer.URL)
+	cli := NewV3Client(logtest.Scoped(t), "Test", uri, gheToken, testServer.Client())
+
+	ctx := context.Background()
+	if err := cli.RequestReviewers(ctx, "sourcegraph", "sourcegraph", 42, []string{"alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.AddAssignees(ctx, "sourcegraph", "sourcegraph", 42, []string{"bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.AddLabels(ctx, "sourcegraph", "sourcegraph", 42, []string{"automation"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]map[string][]string{
+		"POST /repos/sourcegraph/sourcegraph/pulls/42/requested_reviewers": {"reviewers": {"alice"}},
+		"POST /repos/sourcegraph/sourcegraph/issues/42/assignees":          {"assignees": {"bob"}},
+		"POST /repos/sourcegraph/sourcegraph/issues/42/labels":             {"labels": {"automation"}},
+	}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
@@ -603,6 +603,29 @@ func (c *V4Client) Fork(ctx context.Context, owner, repo string, org *string) (*
 	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).Fork(ctx, owner, repo, org)
 }
 
+// RequestReviewers requests a review of the given pull request from the users
+// with the given logins.
+func (c *V4Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, logins []string) error {
+	// The GraphQL API only accepts node IDs of users, so we fall back to the
+	// REST API, which accepts logins.
+	logger := c.log.Scoped("RequestReviewers", "temporary client for requesting reviewers")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RequestReviewers(ctx, owner, repo, number, logins)
+}
+
+// AddAssignees adds the users with the given logins to the assignees of the
+// given pull request.
+func (c *V4Client) AddAssignees(ctx context.Context, owner, repo string, number int64, logins []string) error {
+	logger := c.log.Scoped("AddAssignees", "temporary client for adding assignees")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).AddAssignees(ctx, owner, repo, number, logins)
+}
+
+// AddLabels adds the labels with the given names to the given pull request.
+func (c *V4Client) AddLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	// Unlike the GraphQL API, the REST API creates labels that don't exist yet.
+	logger := c.log.Scoped("AddLabels", "temporary client for adding labels")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).AddLabels(ctx, owner, repo, number, labels)
+}
+
 type RecentCommittersParams struct {
 	// Repository name
 	Name string
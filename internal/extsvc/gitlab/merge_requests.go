@@ -47,6 +47,8 @@ type MergeRequest struct {
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
 	Author                 User              `json:"author"`
+	Assignees              []User            `json:"assignees"`
+	Reviewers              []User            `json:"reviewers"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -221,6 +223,13 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	// AddLabels is a comma-separated list of labels to add to the merge
+	// request.
+	AddLabels string `json:"add_labels,omitempty"`
+	// AssigneeIDs and ReviewerIDs replace the assignees and reviewers of the
+	// merge request, if set.
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
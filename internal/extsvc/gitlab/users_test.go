@@ -0,0 +1,41 @@
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestGetUserByUsername(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `[{"id": 42, "username": "alice"}]`,
+		}
+
+		user, err := client.GetUserByUsername(ctx, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&User{ID: 42, Username: "alice"}, user); diff != "" {
+			t.Errorf("unexpected user: %s", diff)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `[]`,
+		}
+
+		_, err := client.GetUserByUsername(ctx, "alice")
+		if !errcode.IsNotFound(err) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+}
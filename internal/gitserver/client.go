@@ -445,6 +445,12 @@ type Client interface {
 	// ContributorCount returns the number of commits grouped by contributor
 	ContributorCount(ctx context.Context, repo api.RepoName, opt ContributorOptions) ([]*gitdomain.ContributorCount, error)
 
+	// Churn computes per-path churn statistics from the history of a
+	// repository and calls onStats with batches of statistics, ordered by
+	// decreasing number of commits. Paths that the actor may not read
+	// because of sub-repo permissions are omitted.
+	Churn(ctx context.Context, checker authz.SubRepoPermissionChecker, args *protocol.ChurnRequest, onStats func([]protocol.ChurnStat)) error
+
 	// LogReverseEach runs git log in reverse order and calls the given callback for each entry.
 	LogReverseEach(ctx context.Context, repo string, commit string, n int, onLogEntry func(entry gitdomain.LogEntry) error) error
 
@@ -714,6 +720,87 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	return eventDone.LimitHit, eventDone.Err()
 }
 
+func (c *clientImplementor) Churn(ctx context.Context, checker authz.SubRepoPermissionChecker, args *protocol.ChurnRequest, onStats func([]protocol.ChurnStat)) (err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "GitserverClient.Churn")
+	span.SetTag("repo", string(args.Repo))
+	span.SetTag("after", args.After)
+	span.SetTag("before", args.Before)
+	span.SetTag("limit", args.Limit)
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	repoName := protocol.NormalizeRepo(args.Repo)
+
+	body, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	addrForRepo, err := c.AddrForRepo(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	uri := "http://" + addrForRepo + "/churn"
+	resp, err := c.do(ctx, repoName, "POST", uri, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	a := actor.FromContext(ctx)
+	var (
+		filterErr error
+		decodeErr error
+		eventDone protocol.SearchEventDone
+	)
+	dec := StreamChurnDecoder{
+		OnStats: func(e protocol.ChurnEventStats) {
+			if filterErr != nil {
+				return
+			}
+			stats := make([]protocol.ChurnStat, 0, len(e))
+			for _, stat := range e {
+				canRead, err := authz.FilterActorPath(ctx, checker, a, repoName, stat.Path)
+				if err != nil {
+					filterErr = err
+					return
+				}
+				if canRead {
+					stats = append(stats, stat)
+				}
+			}
+			if len(stats) > 0 {
+				onStats(stats)
+			}
+		},
+		OnDone: func(e protocol.SearchEventDone) {
+			eventDone = e
+		},
+		OnUnknown: func(event, _ []byte) {
+			decodeErr = errors.Errorf("unknown event %s", event)
+		},
+	}
+
+	if err := dec.ReadAll(resp.Body); err != nil {
+		return err
+	}
+
+	if filterErr != nil {
+		return filterErr
+	}
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	return eventDone.Err()
+}
+
 func (c *clientImplementor) P4Exec(ctx context.Context, host, user, password string, args ...string) (_ io.ReadCloser, _ http.Header, errRes error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.P4Exec")
 	defer func() {
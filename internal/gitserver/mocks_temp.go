@@ -44,6 +44,9 @@ type MockClient struct {
 	// BranchesContainingFunc is an instance of a mock function object
 	// controlling the behavior of the method BranchesContaining.
 	BranchesContainingFunc *ClientBranchesContainingFunc
+	// ChurnFunc is an instance of a mock function object controlling the
+	// behavior of the method Churn.
+	ChurnFunc *ClientChurnFunc
 	// CommitDateFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitDate.
 	CommitDateFunc *ClientCommitDateFunc
@@ -224,6 +227,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		ChurnFunc: &ClientChurnFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) (r0 error) {
+				return
+			},
+		},
 		CommitDateFunc: &ClientCommitDateFunc{
 			defaultHook: func(context.Context, api.RepoName, api.CommitID, authz.SubRepoPermissionChecker) (r0 string, r1 time.Time, r2 bool, r3 error) {
 				return
@@ -501,6 +509,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.BranchesContaining")
 			},
 		},
+		ChurnFunc: &ClientChurnFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error {
+				panic("unexpected invocation of MockClient.Churn")
+			},
+		},
 		CommitDateFunc: &ClientCommitDateFunc{
 			defaultHook: func(context.Context, api.RepoName, api.CommitID, authz.SubRepoPermissionChecker) (string, time.Time, bool, error) {
 				panic("unexpected invocation of MockClient.CommitDate")
@@ -766,6 +779,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		BranchesContainingFunc: &ClientBranchesContainingFunc{
 			defaultHook: i.BranchesContaining,
 		},
+		ChurnFunc: &ClientChurnFunc{
+			defaultHook: i.Churn,
+		},
 		CommitDateFunc: &ClientCommitDateFunc{
 			defaultHook: i.CommitDate,
 		},
@@ -1568,6 +1584,116 @@ func (c ClientBranchesContainingFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientChurnFunc describes the behavior when the Churn method of the
+// parent MockClient instance is invoked.
+type ClientChurnFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error
+	history     []ClientChurnFuncCall
+	mutex       sync.Mutex
+}
+
+// Churn delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) Churn(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 *protocol.ChurnRequest, v3 func([]protocol.ChurnStat)) error {
+	r0 := m.ChurnFunc.nextHook()(v0, v1, v2, v3)
+	m.ChurnFunc.appendCall(ClientChurnFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Churn method of the
+// parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientChurnFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Churn method of the parent MockClient instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ClientChurnFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientChurnFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientChurnFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error {
+		return r0
+	})
+}
+
+func (f *ClientChurnFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, *protocol.ChurnRequest, func([]protocol.ChurnStat)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientChurnFunc) appendCall(r0 ClientChurnFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientChurnFuncCall objects describing the
+// invocations of this function.
+func (f *ClientChurnFunc) History() []ClientChurnFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientChurnFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientChurnFuncCall is an object that describes an invocation of method
+// Churn on an instance of MockClient.
+type ClientChurnFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *protocol.ChurnRequest
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 func([]protocol.ChurnStat)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientChurnFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientChurnFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ClientCommitDateFunc describes the behavior when the CommitDate method of
 // the parent MockClient instance is invoked.
 type ClientCommitDateFunc struct {
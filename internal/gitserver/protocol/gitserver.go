@@ -130,6 +130,61 @@ type BatchLogResult struct {
 	CommandError  string         `json:"error,omitempty"`
 }
 
+// ChurnRequest is a request to compute per-path churn statistics from the
+// history of a repository, as reported by `git log --numstat`.
+type ChurnRequest struct {
+	Repo api.RepoName `json:"repo"`
+
+	// Revision is the revision whose history is walked. It defaults to HEAD.
+	Revision string `json:"revision,omitempty"`
+
+	// After and Before restrict the commits to a time range. They accept
+	// any date format understood by git.
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+
+	// Paths restricts the statistics to paths matching these pathspecs.
+	Paths []string `json:"paths,omitempty"`
+
+	// Limit is the maximum number of paths to return, ordered by decreasing
+	// number of commits. Zero means no limit.
+	Limit int `json:"limit,omitempty"`
+}
+
+func (req ChurnRequest) LogFields() []log.Field {
+	return []log.Field{
+		log.String("repo", string(req.Repo)),
+		log.String("revision", req.Revision),
+		log.String("after", req.After),
+		log.String("before", req.Before),
+		log.Int("numPaths", len(req.Paths)),
+		log.Int("limit", req.Limit),
+	}
+}
+
+// ChurnStat is the churn of a single path over the commits of a ChurnRequest.
+// Merge commits are not counted.
+type ChurnStat struct {
+	Path string `json:"path"`
+
+	// Commits is the number of commits that touched the path.
+	Commits int `json:"commits"`
+
+	// LinesAdded and LinesDeleted are the total number of lines added to and
+	// deleted from the path. They are zero for binary files.
+	LinesAdded   int `json:"linesAdded"`
+	LinesDeleted int `json:"linesDeleted"`
+
+	// Authors is the number of distinct author emails of the commits that
+	// touched the path.
+	Authors int `json:"authors"`
+}
+
+// ChurnEventStats is the payload of "stats" events streamed in response to a
+// ChurnRequest. The response ends with a "done" event with a SearchEventDone
+// payload.
+type ChurnEventStats []ChurnStat
+
 // P4ExecRequest is a request to execute a p4 command with given arguments.
 //
 // Note that this request is deserialized by both gitserver and the frontend's
@@ -264,6 +319,10 @@ type CreateCommitFromPatchRequest struct {
 	// Push specifies whether the target ref will be pushed to the code host: if
 	// nil, no push will be attempted, if non-nil, a push will be attempted.
 	Push *PushConfig
+	// PushRef is the ref on the code host that the commit is pushed to, if it
+	// differs from TargetRef. For example, Gerrit creates changes from commits
+	// pushed to refs/for/<branch>.
+	PushRef *string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
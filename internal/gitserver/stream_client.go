@@ -43,3 +43,39 @@ func (s StreamSearchDecoder) ReadAll(r io.Reader) error {
 
 	return dec.Err()
 }
+
+type StreamChurnDecoder struct {
+	OnStats   func(protocol.ChurnEventStats)
+	OnDone    func(protocol.SearchEventDone)
+	OnUnknown func(event, data []byte)
+}
+
+func (s StreamChurnDecoder) ReadAll(r io.Reader) error {
+	dec := http.NewDecoder(r)
+
+	for dec.Scan() {
+		event := dec.Event()
+		data := dec.Data()
+
+		if bytes.Equal(event, []byte("stats")) {
+			if s.OnStats == nil {
+				continue
+			}
+			var e protocol.ChurnEventStats
+			if err := json.Unmarshal(data, &e); err != nil {
+				return errors.Errorf("failed to decode stats payload: %w", err)
+			}
+			s.OnStats(e)
+		} else if bytes.Equal(event, []byte("done")) {
+			var e protocol.SearchEventDone
+			if err := json.Unmarshal(data, &e); err != nil {
+				return errors.Errorf("failed to decode done payload: %w", err)
+			}
+			s.OnDone(e)
+		} else if s.OnUnknown != nil {
+			s.OnUnknown(event, data)
+		}
+	}
+
+	return dec.Err()
+}
@@ -0,0 +1,151 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UseEnhancedLanguageDetection is the feature flag for enhanced (but much slower) language
+// detection that uses file contents, not just filenames. Enabled by default.
+var UseEnhancedLanguageDetection, _ = strconv.ParseBool(env.Get("USE_ENHANCED_LANGUAGE_DETECTION", "true", "Enable more accurate but slower language detection that uses file contents"))
+
+var inventoryCache = rcache.New(fmt.Sprintf("inv:v2:enhanced_%v", UseEnhancedLanguageDetection))
+
+var (
+	MockCachedInventory  func(ctx context.Context, repo api.RepoName, commitID api.CommitID) (*Inventory, bool, error)
+	MockComputeInventory func(ctx context.Context, repo api.RepoName, commitID api.CommitID) (*Inventory, error)
+)
+
+// GitserverContext returns the inventory context for computing the inventory for the repository at
+// the given commit. Sub-tree inventories are cached based on the OID of the Git tree.
+func GitserverContext(logger log.Logger, repo api.RepoName, gsClient gitserver.Client, commitID api.CommitID, forceEnhancedLanguageDetection bool) (Context, error) {
+	if !gitserver.IsAbsoluteRevision(string(commitID)) {
+		return Context{}, errors.Errorf("refusing to compute inventory for non-absolute commit ID %q", commitID)
+	}
+
+	cacheKey := func(e fs.FileInfo) string {
+		info, ok := e.Sys().(gitdomain.ObjectInfo)
+		if !ok {
+			return "" // not cacheable
+		}
+		return info.OID().String()
+	}
+
+	logger = logger.Scoped("InventoryContext", "returns the inventory context for computing the inventory for the repository at the given commit").
+		With(log.String("repo", string(repo)), log.String("commitID", string(commitID)))
+	invCtx := Context{
+		ReadTree: func(ctx context.Context, path string) ([]fs.FileInfo, error) {
+			// TODO: As a perf optimization, we could read multiple levels of the Git tree at once
+			// to avoid sequential tree traversal calls.
+			return gsClient.ReadDir(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, path, false)
+		},
+		NewFileReader: func(ctx context.Context, path string) (io.ReadCloser, error) {
+			return gsClient.NewFileReader(ctx, repo, commitID, path, authz.DefaultSubRepoPermsChecker)
+		},
+		CacheGet: func(e fs.FileInfo) (Inventory, bool) {
+			cacheKey := cacheKey(e)
+			if cacheKey == "" {
+				return Inventory{}, false // not cacheable
+			}
+			if b, ok := inventoryCache.Get(cacheKey); ok {
+				var inv Inventory
+				if err := json.Unmarshal(b, &inv); err != nil {
+					logger.Warn("Failed to unmarshal cached JSON inventory.", log.String("path", e.Name()), log.Error(err))
+					return Inventory{}, false
+				}
+				return inv, true
+			}
+			return Inventory{}, false
+		},
+		CacheSet: func(e fs.FileInfo, inv Inventory) {
+			cacheKey := cacheKey(e)
+			if cacheKey == "" {
+				return // not cacheable
+			}
+			b, err := json.Marshal(&inv)
+			if err != nil {
+				logger.Warn("Failed to marshal JSON inventory for cache.", log.String("path", e.Name()), log.Error(err))
+				return
+			}
+			inventoryCache.Set(cacheKey, b)
+		},
+	}
+
+	if !UseEnhancedLanguageDetection && !forceEnhancedLanguageDetection {
+		// If USE_ENHANCED_LANGUAGE_DETECTION is disabled, do not read file contents to determine
+		// the language. This means we won't calculate the number of lines per language.
+		invCtx.NewFileReader = func(ctx context.Context, path string) (io.ReadCloser, error) {
+			return nil, nil
+		}
+	}
+
+	return invCtx, nil
+}
+
+// ComputeInventory computes the inventory of the repository at the given commit, reusing and
+// populating the cached inventories of its sub-trees.
+func ComputeInventory(ctx context.Context, logger log.Logger, gsClient gitserver.Client, repo api.RepoName, commitID api.CommitID, forceEnhancedLanguageDetection bool) (*Inventory, error) {
+	if MockComputeInventory != nil {
+		return MockComputeInventory(ctx, repo, commitID)
+	}
+
+	invCtx, err := GitserverContext(logger, repo, gsClient, commitID, forceEnhancedLanguageDetection)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := gsClient.Stat(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// In computing the inventory, sub-tree inventories are cached based on the OID of the Git
+	// tree. Compared to per-blob caching, this creates many fewer cache entries, which means fewer
+	// stores, fewer lookups, and less cache storage overhead. Compared to per-commit caching, this
+	// yields a higher cache hit rate because most trees are unchanged across commits.
+	inv, err := invCtx.Entries(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// CachedInventory returns the inventory of the repository at the given commit if it has already
+// been computed and cached, and false otherwise. Unlike ComputeInventory, it never reads the
+// contents of the repository.
+func CachedInventory(ctx context.Context, logger log.Logger, gsClient gitserver.Client, repo api.RepoName, commitID api.CommitID) (*Inventory, bool, error) {
+	if MockCachedInventory != nil {
+		return MockCachedInventory(ctx, repo, commitID)
+	}
+
+	invCtx, err := GitserverContext(logger, repo, gsClient, commitID, false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	root, err := gsClient.Stat(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, "")
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The inventory of a commit is cached under the OID of its root tree.
+	inv, ok := invCtx.CacheGet(root)
+	if !ok {
+		return nil, false, nil
+	}
+	return &inv, true, nil
+}
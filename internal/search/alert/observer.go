@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -227,6 +228,7 @@ func (o *Observer) errorToAlert(ctx context.Context, err error) (*search.Alert,
 
 	var (
 		mErr *searchrepos.MissingRepoRevsError
+		iErr *searchrepos.MissingRepoInventoryError
 		oErr *errOverRepoLimit
 		lErr *ErrLuckyQueries
 	)
@@ -261,6 +263,12 @@ func (o *Observer) errorToAlert(ctx context.Context, err error) (*search.Alert,
 		return a, nil
 	}
 
+	if errors.As(err, &iErr) {
+		a := AlertForMissingRepoInventory(iErr.Missing)
+		a.Priority = 6
+		return a, nil
+	}
+
 	if errors.As(err, &lErr) {
 		title := "Also showing additional results"
 		description := "We returned all the results for your query. We also added results for similar queries that might interest you."
@@ -396,3 +404,17 @@ func AlertForMissingRepoRevs(missingRepoRevs []searchrepos.RepoRevSpecs) *search
 		Description:    description,
 	}
 }
+
+func AlertForMissingRepoInventory(missing []types.MinimalRepo) *search.Alert {
+	var description string
+	if len(missing) == 1 {
+		description = fmt.Sprintf("The repository %s matched by your repo: filter was skipped because its language breakdown has not been computed yet. Try again later.", missing[0].Name)
+	} else {
+		description = fmt.Sprintf("%d repositories matched by your repo: filter were skipped because their language breakdown has not been computed yet. Try again later.", len(missing))
+	}
+	return &search.Alert{
+		PrometheusType: "missing_repo_inventory",
+		Title:          "Some repositories could not be checked for languages",
+		Description:    description,
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -48,6 +50,21 @@ func TestErrorToAlertStructuralSearch(t *testing.T) {
 	}
 }
 
+func TestErrorToAlertMissingRepoInventory(t *testing.T) {
+	err := errors.Append(nil, &searchrepos.MissingRepoInventoryError{Missing: []types.MinimalRepo{
+		{ID: 1, Name: "example.com/1"},
+		{ID: 2, Name: "example.com/2"},
+	}})
+	haveAlert, haveErr := (&Observer{
+		Logger: logtest.Scoped(t),
+	}).errorToAlert(context.Background(), err)
+
+	require.NoError(t, haveErr)
+	require.NotNil(t, haveAlert)
+	require.Equal(t, "missing_repo_inventory", haveAlert.PrometheusType)
+	require.Contains(t, haveAlert.Description, "2 repositories")
+}
+
 func TestAlertForNoResolvedReposWithNonGlobalSearchContext(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
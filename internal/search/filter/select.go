@@ -34,15 +34,19 @@ type object map[string]object
 
 var validSelectors = object{
 	Commit: object{
+		"author": nil,
 		"diff": object{
 			"added":   nil,
 			"removed": nil,
 		},
+		"files":   nil,
+		"message": nil,
 	},
 	Content: nil,
 	File: {
 		"directory": nil,
 		"path":      nil,
+		"owners":    nil,
 	},
 	Repository: nil,
 	Symbol: object{
@@ -0,0 +1,316 @@
+package jobutil
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// BlameFilter describes how the results of a content search are filtered by
+// git blame information.
+type BlameFilter struct {
+	// IncludeLineAuthors and ExcludeLineAuthors are the patterns of
+	// line.author: parameters. A matched line is kept if its author
+	// matches all the included patterns and none of the excluded ones.
+	IncludeLineAuthors []string
+	ExcludeLineAuthors []string
+
+	// LineAfter is the value of the line.after: parameter. If set, a
+	// matched line is kept if it was authored after LineAfter.
+	LineAfter time.Time
+
+	// IncludeFileAuthors and ExcludeFileAuthors are the patterns of
+	// file:has.author() predicates. A file is kept if each included
+	// pattern matches the author of at least one of its lines, and no
+	// excluded pattern matches the author of any of its lines.
+	IncludeFileAuthors []string
+	ExcludeFileAuthors []string
+}
+
+func (f BlameFilter) filtersLines() bool {
+	return len(f.IncludeLineAuthors) > 0 || len(f.ExcludeLineAuthors) > 0 || !f.LineAfter.IsZero()
+}
+
+func (f BlameFilter) filtersFiles() bool {
+	return len(f.IncludeFileAuthors) > 0 || len(f.ExcludeFileAuthors) > 0
+}
+
+// IsEmpty returns true if the filter does not filter any results.
+func (f BlameFilter) IsEmpty() bool {
+	return !f.filtersLines() && !f.filtersFiles()
+}
+
+// NewBlameFilterJob creates a filter job to post-filter file matches by git
+// blame, for the line.author: and line.after: parameters and the
+// file:has.author() predicate. Each file is blamed at most once at the commit
+// of the match, and only over the lines that matched unless the whole file
+// needs to be inspected. Author patterns match the name or email of the
+// author of a line.
+//
+// Only file matches can be blamed, so all other results are dropped. Files
+// that the actor cannot read because of sub-repo permissions are dropped as
+// well.
+func NewBlameFilterJob(filter BlameFilter, caseSensitive bool, child job.Job) job.Job {
+	compile := func(patterns []string) []*regexp.Regexp {
+		matchers := make([]*regexp.Regexp, 0, len(patterns))
+		for _, pattern := range patterns {
+			if !caseSensitive {
+				pattern = "(?i:" + pattern + ")"
+			}
+			matchers = append(matchers, regexp.MustCompile(pattern))
+		}
+		return matchers
+	}
+
+	return &blameFilterJob{
+		filter:             filter,
+		includeLineAuthors: compile(filter.IncludeLineAuthors),
+		excludeLineAuthors: compile(filter.ExcludeLineAuthors),
+		includeFileAuthors: compile(filter.IncludeFileAuthors),
+		excludeFileAuthors: compile(filter.ExcludeFileAuthors),
+		child:              child,
+	}
+}
+
+type blameFilterJob struct {
+	filter BlameFilter
+
+	includeLineAuthors []*regexp.Regexp
+	excludeLineAuthors []*regexp.Regexp
+	includeFileAuthors []*regexp.Regexp
+	excludeFileAuthors []*regexp.Regexp
+
+	child job.Job
+}
+
+// blameConcurrency is the maximum number of files that are blamed
+// concurrently by a single blame filter job.
+const blameConcurrency = 16
+
+func (j *blameFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var errs error
+
+	// Blaming a file takes a round trip to gitserver, so files are blamed
+	// concurrently and their matches are sent as they are filtered instead of
+	// blocking the child job until all files of an event are blamed. Callbacks
+	// are called from a single goroutine, so errs needs no lock.
+	g := group.NewWithStreaming[*result.FileMatch]().WithContext(ctx).WithMaxConcurrency(blameConcurrency)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+
+			g.Go(func(ctx context.Context) (*result.FileMatch, error) {
+				return j.filterFileMatch(ctx, clients.Gitserver, fm)
+			}, func(_ context.Context, fm *result.FileMatch, err error) {
+				if err != nil {
+					errs = errors.Append(errs, err)
+					return
+				}
+				if fm != nil {
+					stream.Send(streaming.SearchEvent{Results: result.Matches{fm}})
+				}
+			})
+		}
+
+		// Forward the stats of the event right away.
+		event.Results = nil
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	g.Wait()
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// filterFileMatch returns fm with only the matched lines that satisfy the
+// filter, or nil if the file does not satisfy the filter.
+func (j *blameFilterJob) filterFileMatch(ctx context.Context, client gitserver.Client, fm *result.FileMatch) (*result.FileMatch, error) {
+	opts := &gitserver.BlameOptions{NewestCommit: fm.CommitID}
+	if j.filter.filtersLines() {
+		startLine, endLine, ok := matchedLineRange(fm)
+		if !ok {
+			// Path matches have no lines to filter.
+			return nil, nil
+		}
+		if !j.filter.filtersFiles() {
+			opts.StartLine, opts.EndLine = startLine, endLine
+		}
+	}
+
+	hunks, err := client.BlameFile(ctx, authz.DefaultSubRepoPermsChecker, fm.Repo.Name, fm.Path, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(hunks) == 0 {
+		// Either the file is empty or the actor may not read it.
+		return nil, nil
+	}
+
+	if j.filter.filtersFiles() && !j.isFileKept(hunks) {
+		return nil, nil
+	}
+
+	if j.filter.filtersLines() {
+		hunkForLine := make(map[int]*gitserver.Hunk)
+		for _, hunk := range hunks {
+			for line := hunk.StartLine; line < hunk.EndLine; line++ {
+				hunkForLine[line] = hunk
+			}
+		}
+		isKept := func(line int) bool {
+			hunk, ok := hunkForLine[line]
+			return ok && j.isLineKept(hunk)
+		}
+
+		filteredChunks := fm.ChunkMatches[:0]
+		for _, chunk := range fm.ChunkMatches {
+			filteredRanges := chunk.Ranges[:0]
+			for _, rr := range chunk.Ranges {
+				// Range lines are 0-indexed, blame lines are 1-indexed.
+				if isKept(rr.Start.Line + 1) {
+					filteredRanges = append(filteredRanges, rr)
+				}
+			}
+			if len(filteredRanges) == 0 {
+				continue
+			}
+			chunk.Ranges = filteredRanges
+			filteredChunks = append(filteredChunks, chunk)
+		}
+		fm.ChunkMatches = filteredChunks
+
+		filteredSymbols := fm.Symbols[:0]
+		for _, sym := range fm.Symbols {
+			if isKept(sym.Symbol.Line) {
+				filteredSymbols = append(filteredSymbols, sym)
+			}
+		}
+		fm.Symbols = filteredSymbols
+
+		if len(fm.ChunkMatches) == 0 && len(fm.Symbols) == 0 {
+			return nil, nil
+		}
+	}
+
+	return fm, nil
+}
+
+// matchedLineRange returns the 1-indexed range of lines that contain the
+// matched ranges and symbols of fm, and false if there are none.
+func matchedLineRange(fm *result.FileMatch) (startLine, endLine int, ok bool) {
+	add := func(line int) {
+		if !ok || line < startLine {
+			startLine = line
+		}
+		if !ok || line > endLine {
+			endLine = line
+		}
+		ok = true
+	}
+	for _, chunk := range fm.ChunkMatches {
+		for _, rr := range chunk.Ranges {
+			add(rr.Start.Line + 1)
+		}
+	}
+	for _, sym := range fm.Symbols {
+		add(sym.Symbol.Line)
+	}
+	return startLine, endLine, ok
+}
+
+func (j *blameFilterJob) isLineKept(hunk *gitserver.Hunk) bool {
+	if !j.filter.LineAfter.IsZero() && !hunk.Author.Date.After(j.filter.LineAfter) {
+		return false
+	}
+	for _, re := range j.includeLineAuthors {
+		if !authorMatches(re, hunk) {
+			return false
+		}
+	}
+	for _, re := range j.excludeLineAuthors {
+		if authorMatches(re, hunk) {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *blameFilterJob) isFileKept(hunks []*gitserver.Hunk) bool {
+	anyAuthorMatches := func(re *regexp.Regexp) bool {
+		for _, hunk := range hunks {
+			if authorMatches(re, hunk) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, re := range j.includeFileAuthors {
+		if !anyAuthorMatches(re) {
+			return false
+		}
+	}
+	for _, re := range j.excludeFileAuthors {
+		if anyAuthorMatches(re) {
+			return false
+		}
+	}
+	return true
+}
+
+func authorMatches(re *regexp.Regexp, hunk *gitserver.Hunk) bool {
+	return re.MatchString(hunk.Author.Name) || re.MatchString(hunk.Author.Email)
+}
+
+func (j *blameFilterJob) Name() string {
+	return "BlameFilterJob"
+}
+
+func (j *blameFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeLineAuthors", j.filter.IncludeLineAuthors),
+			trace.Strings("excludeLineAuthors", j.filter.ExcludeLineAuthors),
+			trace.Strings("includeFileAuthors", j.filter.IncludeFileAuthors),
+			trace.Strings("excludeFileAuthors", j.filter.ExcludeFileAuthors),
+		)
+		if !j.filter.LineAfter.IsZero() {
+			res = append(res, otlog.String("lineAfter", j.filter.LineAfter.Format(time.RFC3339)))
+		}
+	}
+	return res
+}
+
+func (j *blameFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *blameFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
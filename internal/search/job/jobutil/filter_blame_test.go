@@ -0,0 +1,205 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestBlameFilterJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	alice := gitdomain.Signature{Name: "Alice", Email: "alice@example.com", Date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bob := gitdomain.Signature{Name: "Bob", Email: "bob@example.com", Date: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	// main.go: lines 1-2 by alice, line 3 by bob.
+	// README.md: lines 1-2 by alice.
+	blames := map[string][]*gitserver.Hunk{
+		"main.go": {
+			{StartLine: 1, EndLine: 3, Author: alice},
+			{StartLine: 3, EndLine: 4, Author: bob},
+		},
+		"README.md": {
+			{StartLine: 1, EndLine: 3, Author: alice},
+		},
+	}
+
+	// matchLines returns a file match with a range on each of the given
+	// 0-indexed lines.
+	matchLines := func(path string, lines ...int) *result.FileMatch {
+		var chunks result.ChunkMatches
+		for _, line := range lines {
+			chunks = append(chunks, result.ChunkMatch{
+				Content:      "match",
+				ContentStart: result.Location{Line: line},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: line},
+					End:   result.Location{Line: line, Column: 5},
+				}},
+			})
+		}
+		return &result.FileMatch{
+			File:         result.File{Repo: repo, CommitID: "deadbeef", Path: path},
+			ChunkMatches: chunks,
+		}
+	}
+
+	newChildJob := func(matches ...result.Match) job.Job {
+		childJob := mockjob.NewMockJob()
+		childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: matches})
+			return nil, nil
+		})
+		return childJob
+	}
+
+	run := func(t *testing.T, filter BlameFilter, matches ...result.Match) (map[string][]int, map[string]*gitserver.BlameOptions) {
+		t.Helper()
+
+		var mu sync.Mutex
+		blameOpts := make(map[string]*gitserver.BlameOptions)
+		gitserverClient := gitserver.NewMockClient()
+		gitserverClient.BlameFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, path string, opts *gitserver.BlameOptions) ([]*gitserver.Hunk, error) {
+			mu.Lock()
+			blameOpts[path] = opts
+			mu.Unlock()
+			return blames[path], nil
+		})
+
+		got := make(map[string][]int)
+		streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			for _, m := range ev.Results {
+				fm := m.(*result.FileMatch)
+				got[fm.Path] = []int{}
+				for _, chunk := range fm.ChunkMatches {
+					for _, rr := range chunk.Ranges {
+						got[fm.Path] = append(got[fm.Path], rr.Start.Line)
+					}
+				}
+			}
+		})
+		j := NewBlameFilterJob(filter, false, newChildJob(matches...))
+		alert, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitserverClient}, streamCollector)
+		require.Nil(t, alert)
+		require.NoError(t, err)
+		return got, blameOpts
+	}
+
+	t.Run("line.author", func(t *testing.T) {
+		got, blameOpts := run(t,
+			BlameFilter{IncludeLineAuthors: []string{"bob"}},
+			matchLines("main.go", 0, 2),
+			matchLines("README.md", 1),
+		)
+		require.Equal(t, map[string][]int{"main.go": {2}}, got)
+		// Only the matched lines are blamed.
+		require.Equal(t, map[string]*gitserver.BlameOptions{
+			"main.go":   {NewestCommit: "deadbeef", StartLine: 1, EndLine: 3},
+			"README.md": {NewestCommit: "deadbeef", StartLine: 2, EndLine: 2},
+		}, blameOpts)
+	})
+
+	t.Run("negated line.author matches email", func(t *testing.T) {
+		got, _ := run(t,
+			BlameFilter{ExcludeLineAuthors: []string{`bob@example\.com`}},
+			matchLines("main.go", 0, 2),
+		)
+		require.Equal(t, map[string][]int{"main.go": {0}}, got)
+	})
+
+	t.Run("line.after", func(t *testing.T) {
+		got, _ := run(t,
+			BlameFilter{LineAfter: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)},
+			matchLines("main.go", 0, 1, 2),
+			matchLines("README.md", 0),
+		)
+		require.Equal(t, map[string][]int{"main.go": {2}}, got)
+	})
+
+	t.Run("file:has.author", func(t *testing.T) {
+		got, blameOpts := run(t,
+			BlameFilter{IncludeFileAuthors: []string{"bob"}},
+			matchLines("main.go", 0),
+			matchLines("README.md", 0),
+		)
+		require.Equal(t, map[string][]int{"main.go": {0}}, got)
+		// The whole file is blamed.
+		require.Equal(t, &gitserver.BlameOptions{NewestCommit: "deadbeef"}, blameOpts["main.go"])
+	})
+
+	t.Run("file:has.author keeps path matches", func(t *testing.T) {
+		got, _ := run(t,
+			BlameFilter{ExcludeFileAuthors: []string{"bob"}},
+			&result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "main.go"}},
+			&result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "README.md"}},
+		)
+		require.Equal(t, map[string][]int{"README.md": {}}, got)
+	})
+
+	t.Run("line filters drop path matches and other results", func(t *testing.T) {
+		got, blameOpts := run(t,
+			BlameFilter{IncludeLineAuthors: []string{"alice"}},
+			&result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "main.go"}},
+			&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+		)
+		require.Empty(t, got)
+		require.Empty(t, blameOpts)
+	})
+
+	t.Run("unreadable files are dropped", func(t *testing.T) {
+		got, _ := run(t,
+			BlameFilter{IncludeLineAuthors: []string{"alice"}},
+			matchLines("secret.go", 0),
+		)
+		require.Empty(t, got)
+	})
+
+	t.Run("files are blamed concurrently", func(t *testing.T) {
+		var started sync.WaitGroup
+		started.Add(2)
+		allStarted := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(allStarted)
+		}()
+
+		// Each blame only returns once both files are being blamed.
+		gitserverClient := gitserver.NewMockClient()
+		gitserverClient.BlameFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, path string, _ *gitserver.BlameOptions) ([]*gitserver.Hunk, error) {
+			started.Done()
+			select {
+			case <-allStarted:
+				return blames[path], nil
+			case <-time.After(10 * time.Second):
+				return nil, errors.New("files were not blamed concurrently")
+			}
+		})
+
+		var paths []string
+		streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			for _, m := range ev.Results {
+				paths = append(paths, m.(*result.FileMatch).Path)
+			}
+		})
+		j := NewBlameFilterJob(BlameFilter{IncludeLineAuthors: []string{"alice"}}, false, newChildJob(matchLines("main.go", 0), matchLines("README.md", 0)))
+		_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitserverClient}, streamCollector)
+		require.NoError(t, err)
+		// Matches are still sent in the order they were streamed.
+		require.Equal(t, []string{"main.go", "README.md"}, paths)
+	})
+}
@@ -0,0 +1,129 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileHasOwnerFilterJob creates a filter job to post-filter results for
+// the file:has.owner() predicate. Owners are resolved from the CODEOWNERS file
+// of each repository at the commit of the match. A file is kept if it is
+// owned by all the included owners and none of the excluded owners.
+//
+// Only file matches can be filtered by owner, so all other results are
+// dropped.
+func NewFileHasOwnerFilterJob(includeOwners, excludeOwners []string, child job.Job) job.Job {
+	return &fileHasOwnerFilterJob{
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+		child:         child,
+	}
+}
+
+type fileHasOwnerFilterJob struct {
+	includeOwners []string
+	excludeOwners []string
+	child         job.Job
+}
+
+func (j *fileHasOwnerFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	resolver := codeowners.NewResolver(clients.Gitserver)
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = j.filterMatches(ctx, resolver, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *fileHasOwnerFilterJob) filterMatches(ctx context.Context, resolver *codeowners.Resolver, matches []result.Match) ([]result.Match, error) {
+	var errs error
+
+	filtered := matches[:0]
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		rs, err := resolver.Ruleset(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		if j.isOwned(rs, fm.Path) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, errs
+}
+
+func (j *fileHasOwnerFilterJob) isOwned(rs *codeowners.Ruleset, path string) bool {
+	for _, owner := range j.includeOwners {
+		if !rs.IsOwnedBy(path, owner) {
+			return false
+		}
+	}
+	for _, owner := range j.excludeOwners {
+		if rs.IsOwnedBy(path, owner) {
+			return false
+		}
+	}
+	return true
+}
+
+func (j *fileHasOwnerFilterJob) Name() string {
+	return "FileHasOwnerFilterJob"
+}
+
+func (j *fileHasOwnerFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", j.includeOwners),
+			trace.Strings("excludeOwners", j.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (j *fileHasOwnerFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasOwnerFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
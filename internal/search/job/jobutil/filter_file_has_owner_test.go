@@ -0,0 +1,124 @@
+package jobutil
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testCodeowners = `
+*.go      @sourcegraph/backend
+/client/  @sourcegraph/frontend @alice
+`
+
+func newCodeownersClients() job.RuntimeClients {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if name == "CODEOWNERS" {
+			return []byte(testCodeowners), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	return job.RuntimeClients{Gitserver: gitserverClient}
+}
+
+func runWithMatches(t *testing.T, j func(job.Job) job.Job, matches ...result.Match) result.Matches {
+	t.Helper()
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: matches})
+		return nil, nil
+	})
+
+	var results result.Matches
+	streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		results = append(results, ev.Results...)
+	})
+	alert, err := j(childJob).Run(context.Background(), newCodeownersClients(), streamCollector)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	return results
+}
+
+func TestFileHasOwnerFilterJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: path}}
+	}
+
+	cases := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{{
+		name:    "include team",
+		include: []string{"@sourcegraph/backend"},
+		want:    []string{"cmd/main.go"},
+	}, {
+		name:    "include without @",
+		include: []string{"alice"},
+		want:    []string{"client/main.go", "client/index.ts"},
+	}, {
+		name:    "include multiple",
+		include: []string{"@sourcegraph/frontend", "@alice"},
+		want:    []string{"client/main.go", "client/index.ts"},
+	}, {
+		name:    "exclude",
+		exclude: []string{"@sourcegraph/backend"},
+		want:    []string{"client/main.go", "client/index.ts", "README.md"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := runWithMatches(t, func(child job.Job) job.Job {
+				return NewFileHasOwnerFilterJob(tc.include, tc.exclude, child)
+			},
+				fm("cmd/main.go"),
+				fm("client/main.go"),
+				fm("client/index.ts"),
+				fm("README.md"),
+				&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+			)
+
+			var paths []string
+			for _, r := range results {
+				paths = append(paths, r.(*result.FileMatch).Path)
+			}
+			require.Equal(t, tc.want, paths)
+		})
+	}
+}
+
+func TestSelectOwnersJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: path}}
+	}
+
+	results := runWithMatches(t, NewSelectOwnersJob,
+		fm("cmd/main.go"),
+		fm("internal/main.go"),
+		fm("client/index.ts"),
+		fm("README.md"),
+	)
+
+	var handles []string
+	for _, r := range results {
+		handles = append(handles, r.(*result.OwnerMatch).Handle)
+	}
+	require.Equal(t, []string{"@sourcegraph/backend", "@sourcegraph/frontend", "@alice"}, handles)
+}
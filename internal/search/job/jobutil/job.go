@@ -152,7 +152,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 				RepoOpts:             repoOptionsCopy,
 				Diff:                 diff,
 				Limit:                int(fileMatchLimit),
-				IncludeModifiedFiles: authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker),
+				IncludeModifiedFiles: authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker) || isSelectCommitFiles(selector),
 				Concurrency:          4,
 			})
 		}
@@ -181,20 +181,46 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
-	{ // Apply selectors
-		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
-			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+	{ // Apply file:has.owner() post-filter
+		if includeOwners, excludeOwners := b.FileHasOwner(); len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = NewFileHasOwnerFilterJob(includeOwners, excludeOwners, basicJob)
+		}
+	}
+
+	{ // Apply line.author:, line.after: and file:has.author() post-filter
+		var filter BlameFilter
+		filter.IncludeLineAuthors, filter.ExcludeLineAuthors = b.LineAuthor()
+		filter.IncludeFileAuthors, filter.ExcludeFileAuthors = b.FileHasAuthor()
+		if v := b.LineAfter(); v != "" {
+			filter.LineAfter, _ = query.ParseGitDate(v, time.Now) // field already validated
+		}
+		if !filter.IsEmpty() {
+			basicJob = NewBlameFilterJob(filter, b.IsCaseSensitive(), basicJob)
 		}
 	}
 
 	{ // Apply subrepo permissions checks
+		// Selectors derive results like owners or commit authors from file
+		// and commit matches, so those must be filtered before selecting.
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
 			basicJob = NewFilterJob(basicJob)
 		}
 	}
 
+	{ // Apply selectors
+		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
+			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
+			if isSelectOwners(sp) {
+				basicJob = NewSelectOwnersJob(basicJob)
+			} else if isSelectCommitFiles(sp) {
+				basicJob = NewSelectCommitFilesJob(basicJob)
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
+		}
+	}
+
 	{ // Apply search result sanitization post-filter if enabled
 		if len(inputs.SanitizeSearchPatterns) > 0 {
 			basicJob = NewSanitizeJob(inputs.SanitizeSearchPatterns, basicJob)
@@ -690,6 +716,7 @@ func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOpti
 		CommitAfter:         b.RepoContainsCommitAfter(),
 		UseIndex:            b.Index(),
 		HasKVPs:             b.RepoHasKVPs(),
+		HasLanguages:        b.RepoHasLanguage(),
 	}
 }
 
@@ -958,6 +985,12 @@ func isGlobal(op search.RepoOptions) bool {
 		return false
 	}
 
+	// repo:has.language() is handled during the repo resolution step using
+	// inventory data, which Zoekt does not know about.
+	if len(op.HasLanguages) > 0 {
+		return false
+	}
+
 	// If a search context is specified, we do not know ahead of time whether
 	// the repos in the context are indexed and we need to go through the repo
 	// resolution process.
@@ -0,0 +1,123 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// NewSelectCommitFilesJob creates a job that transforms the commit matches
+// streamed by its child into path matches for the files changed by those
+// commits. It implements `select:commit.files`. Files are deduplicated per
+// repository, and are matched at the first commit that changed them.
+func NewSelectCommitFilesJob(child job.Job) job.Job {
+	return &selectCommitFilesJob{child: child}
+}
+
+type selectCommitFilesJob struct {
+	child job.Job
+}
+
+type repoPath struct {
+	repo api.RepoID
+	path string
+}
+
+func (j *selectCommitFilesJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[repoPath]struct{})
+	)
+
+	filesStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		event.Results = toCommitFileMatches(event.Results, seen)
+		mu.Unlock()
+		stream.Send(event)
+	})
+
+	return j.child.Run(ctx, clients, filesStream)
+}
+
+// toCommitFileMatches replaces each commit match with a path match for each
+// of the files changed by the commit that is not in seen yet. All other
+// results are dropped.
+func toCommitFileMatches(matches []result.Match, seen map[repoPath]struct{}) []result.Match {
+	var files []result.Match
+	for _, m := range matches {
+		cm, ok := m.(*result.CommitMatch)
+		if !ok {
+			continue
+		}
+
+		for _, path := range commitFiles(cm) {
+			key := repoPath{repo: cm.Repo.ID, path: path}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			commitID := string(cm.Commit.ID)
+			files = append(files, &result.FileMatch{
+				File: result.File{
+					Repo:     cm.Repo,
+					CommitID: cm.Commit.ID,
+					InputRev: &commitID,
+					Path:     path,
+				},
+			})
+		}
+	}
+	return files
+}
+
+// commitFiles returns the paths of the files changed by a commit. The
+// modified files are only set when requested from gitserver, otherwise we fall
+// back to the files of the matched diff.
+func commitFiles(cm *result.CommitMatch) []string {
+	if len(cm.ModifiedFiles) > 0 {
+		return cm.ModifiedFiles
+	}
+
+	paths := make([]string, 0, len(cm.Diff))
+	for _, diff := range cm.Diff {
+		if diff.NewName == "/dev/null" {
+			paths = append(paths, diff.OrigName)
+		} else {
+			paths = append(paths, diff.NewName)
+		}
+	}
+	return paths
+}
+
+func (j *selectCommitFilesJob) Name() string {
+	return "SelectCommitFilesJob"
+}
+
+func (j *selectCommitFilesJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (j *selectCommitFilesJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *selectCommitFilesJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// isSelectCommitFiles returns whether the select path is `select:commit.files`.
+func isSelectCommitFiles(sp filter.SelectPath) bool {
+	return len(sp) == 2 && sp.Root() == filter.Commit && sp[1] == "files"
+}
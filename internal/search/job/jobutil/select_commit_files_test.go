@@ -0,0 +1,66 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSelectCommitFilesJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			&result.CommitMatch{
+				Commit: gitdomain.Commit{ID: "c2"},
+				Repo:   repo,
+				Diff: []result.DiffFile{
+					{OrigName: "main.go", NewName: "main.go"},
+					{OrigName: "old.go", NewName: "/dev/null"},
+				},
+			},
+			&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+		}})
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			&result.CommitMatch{
+				Commit:        gitdomain.Commit{ID: "c1"},
+				Repo:          repo,
+				ModifiedFiles: []string{"main.go", "README.md"},
+			},
+		}})
+		return nil, nil
+	})
+
+	type file struct {
+		Path   string
+		Commit string
+	}
+	var got []file
+	stream := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		for _, m := range ev.Results {
+			fm := m.(*result.FileMatch)
+			require.True(t, fm.IsPathMatch())
+			got = append(got, file{Path: fm.Path, Commit: string(fm.CommitID)})
+		}
+	})
+
+	j := NewSelectCommitFilesJob(childJob)
+	alert, err := j.Run(context.Background(), job.RuntimeClients{}, stream)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	require.Equal(t, []file{
+		{Path: "main.go", Commit: "c2"},
+		{Path: "old.go", Commit: "c2"},
+		{Path: "README.md", Commit: "c1"},
+	}, got)
+}
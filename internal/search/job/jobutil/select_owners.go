@@ -0,0 +1,108 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectOwnersJob creates a job that transforms the file matches streamed
+// by its child into the owners of those files, as declared in the CODEOWNERS
+// file of their repository. It implements `select:file.owners`. Owners are
+// deduplicated per repository.
+func NewSelectOwnersJob(child job.Job) job.Job {
+	return &selectOwnersJob{child: child}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (j *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	resolver := codeowners.NewResolver(clients.Gitserver)
+	selectingStream := newSelectingStream(stream, filter.SelectPath{filter.File, "owners"})
+	ownersStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = toOwnerMatches(ctx, resolver, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		selectingStream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, ownersStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// toOwnerMatches replaces each file match with a match for each of the
+// file's owners. Files without owners and all other results are dropped.
+func toOwnerMatches(ctx context.Context, resolver *codeowners.Resolver, matches []result.Match) ([]result.Match, error) {
+	var (
+		errs   error
+		owners []result.Match
+	)
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		handles, err := resolver.Owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		for _, handle := range handles {
+			owners = append(owners, &result.OwnerMatch{
+				Handle:   handle,
+				Repo:     fm.Repo,
+				CommitID: fm.CommitID,
+				InputRev: fm.InputRev,
+			})
+		}
+	}
+	return owners, errs
+}
+
+func (j *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (j *selectOwnersJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (j *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// isSelectOwners returns whether the select path is `select:file.owners`.
+func isSelectOwners(sp filter.SelectPath) bool {
+	return len(sp) == 2 && sp.Root() == filter.File && sp[1] == "owners"
+}
@@ -117,6 +117,19 @@ func applySubRepoFiltering(ctx context.Context, logger log.Logger, checker authz
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.CommitAuthorMatch:
+			allowed, err := authz.CanReadAnyPath(ctx, checker, mm.Repo.Name, mm.ModifiedFiles)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+			if allowed {
+				filtered = append(filtered, m)
+			}
+		case *result.OwnerMatch:
+			// Owners are selected from file matches that were already
+			// filtered, and don't reveal any path themselves.
+			filtered = append(filtered, m)
 		}
 
 	}
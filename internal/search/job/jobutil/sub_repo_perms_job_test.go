@@ -8,13 +8,23 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestApplySubRepoFiltering(t *testing.T) {
@@ -181,6 +191,28 @@ func TestApplySubRepoFiltering(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should filter commit author matches where the user doesn't have access to any file in the ModifiedFiles",
+			args: args{
+				ctxActor: actor.FromUser(userWithSubRepoPerms),
+				matches: []result.Match{
+					&result.CommitAuthorMatch{
+						Author:        gitdomain.Signature{Email: "alice@example.com"},
+						ModifiedFiles: []string{unauthorizedFileName},
+					},
+					&result.CommitAuthorMatch{
+						Author:        gitdomain.Signature{Email: "bob@example.com"},
+						ModifiedFiles: []string{unauthorizedFileName, "another-file.txt"},
+					},
+				},
+			},
+			wantMatches: []result.Match{
+				&result.CommitAuthorMatch{
+					Author:        gitdomain.Signature{Email: "bob@example.com"},
+					ModifiedFiles: []string{unauthorizedFileName, "another-file.txt"},
+				},
+			},
+		},
 		{
 			name: "should filter commit matches where the diff is empty",
 			args: args{
@@ -214,3 +246,112 @@ func TestApplySubRepoFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestSubRepoFilteringSelect(t *testing.T) {
+	var userWithSubRepoPerms int32 = 1234
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, user int32, rc authz.RepoContent) (authz.Perms, error) {
+		if user == userWithSubRepoPerms && strings.HasPrefix(rc.Path, "client/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+	checker.FilePermissionsFuncFunc.SetDefaultHook(func(ctx context.Context, userID int32, repo api.RepoName) (authz.FilePermissionFunc, error) {
+		return func(path string) (authz.Perms, error) {
+			return checker.Permissions(ctx, userID, authz.RepoContent{Repo: repo, Path: path})
+		}, nil
+	})
+
+	defaultChecker := authz.DefaultSubRepoPermsChecker
+	authz.DefaultSubRepoPermsChecker = checker
+	t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = defaultChecker })
+
+	t.Run("sub-repo permissions are applied before selecting", func(t *testing.T) {
+		plan, err := query.Pipeline(query.InitLiteral("foo select:file.owners"))
+		require.NoError(t, err)
+
+		inputs := &search.Inputs{
+			UserSettings: &schema.Settings{},
+			PatternType:  query.SearchTypeLiteral,
+			Protocol:     search.Streaming,
+			Features:     &search.Features{},
+		}
+		j, err := NewBasicJob(inputs, plan[0])
+		require.NoError(t, err)
+		require.Regexp(t, `(?s)SELECTOWNERS.*SUBREPOPERMSFILTER`, printer.SexpPretty(j))
+	})
+
+	t.Run("owners of restricted files are not selected", func(t *testing.T) {
+		repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+		fm := func(path string) *result.FileMatch {
+			return &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: path}}
+		}
+
+		childJob := mockjob.NewMockJob()
+		childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{fm("cmd/main.go"), fm("client/index.ts")}})
+			return nil, nil
+		})
+
+		var results result.Matches
+		streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			results = append(results, ev.Results...)
+		})
+
+		clients := newCodeownersClients()
+		clients.Logger = logtest.Scoped(t)
+		ctx := actor.WithActor(context.Background(), actor.FromUser(userWithSubRepoPerms))
+		_, err := NewSelectOwnersJob(NewFilterJob(childJob)).Run(ctx, clients, streamCollector)
+		require.NoError(t, err)
+
+		var handles []string
+		for _, r := range results {
+			handles = append(handles, r.(*result.OwnerMatch).Handle)
+		}
+		require.Equal(t, []string{"@sourcegraph/backend"}, handles)
+
+		// Owner matches are passed through when filtered again.
+		matches, err := applySubRepoFiltering(ctx, logtest.Scoped(t), checker, results)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+	})
+
+	t.Run("authors of commits to restricted files are not selected", func(t *testing.T) {
+		repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+		cm := func(email string, modifiedFiles ...string) *result.CommitMatch {
+			return &result.CommitMatch{
+				Commit:         gitdomain.Commit{ID: api.CommitID(email), Author: gitdomain.Signature{Email: email}},
+				Repo:           repo,
+				MessagePreview: &result.MatchedString{Content: "fix"},
+				ModifiedFiles:  modifiedFiles,
+			}
+		}
+
+		childJob := mockjob.NewMockJob()
+		childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{
+				cm("alice@example.com", "client/index.ts"),
+				cm("bob@example.com", "client/index.ts", "cmd/main.go"),
+			}})
+			return nil, nil
+		})
+
+		var results result.Matches
+		streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+			results = append(results, ev.Results...)
+		})
+
+		ctx := actor.WithActor(context.Background(), actor.FromUser(userWithSubRepoPerms))
+		j := NewSelectJob(filter.SelectPath{filter.Commit, "author"}, NewFilterJob(childJob))
+		_, err := j.Run(ctx, job.RuntimeClients{Logger: logtest.Scoped(t)}, streamCollector)
+		require.NoError(t, err)
+
+		var emails []string
+		for _, r := range results {
+			emails = append(emails, r.(*result.CommitAuthorMatch).Author.Email)
+		}
+		require.Equal(t, []string{"bob@example.com"}, emails)
+	})
+}
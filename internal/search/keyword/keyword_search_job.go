@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/group"
 )
 
 func NewKeywordSearchJob(b query.Basic, newJob func(query.Basic) (job.Job, error)) (job.Job, error) {
@@ -25,12 +26,34 @@ func NewKeywordSearchJob(b query.Basic, newJob func(query.Basic) (job.Job, error
 	if err != nil {
 		return nil, err
 	}
-	return &keywordSearchJob{child: child, patterns: keywordQuery.patterns}, nil
+
+	symbolQuery, err := symbolQueryToKeywordQuery(b)
+	if err != nil || symbolQuery == nil {
+		return &keywordSearchJob{child: child, patterns: keywordQuery.patterns}, nil
+	}
+
+	symbolChild, err := newJob(symbolQuery.query)
+	if err != nil {
+		// The inferred symbol search is only a complement, search without it.
+		return &keywordSearchJob{child: child, patterns: keywordQuery.patterns}, nil
+	}
+	return &keywordSearchJob{
+		child:          child,
+		patterns:       keywordQuery.patterns,
+		symbolChild:    symbolChild,
+		symbolPatterns: symbolQuery.patterns,
+	}, nil
 }
 
 type keywordSearchJob struct {
 	child    job.Job
 	patterns []string
+
+	// symbolChild, if set, searches for the symbols inferred from the
+	// structure of the query. Its results are ranked alongside the results
+	// of child.
+	symbolChild    job.Job
+	symbolPatterns []string
 }
 
 func (j *keywordSearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
@@ -39,7 +62,25 @@ func (j *keywordSearchJob) Run(ctx context.Context, clients job.RuntimeClients,
 
 	// TODO(novoselrok): Use NewBatchingStream to batch the events before processing them.
 	keywordSearchStream := newKeywordSearchStream(stream, j.patterns)
-	return j.child.Run(ctx, clients, keywordSearchStream)
+	if j.symbolChild == nil {
+		return j.child.Run(ctx, clients, keywordSearchStream)
+	}
+
+	var (
+		g          = group.New().WithContext(ctx)
+		maxAlerter search.MaxAlerter
+	)
+	g.Go(func(ctx context.Context) error {
+		alert, err := j.child.Run(ctx, clients, keywordSearchStream)
+		maxAlerter.Add(alert)
+		return err
+	})
+	g.Go(func(ctx context.Context) error {
+		alert, err := j.symbolChild.Run(ctx, clients, newKeywordSearchStream(stream, j.symbolPatterns))
+		maxAlerter.Add(alert)
+		return err
+	})
+	return maxAlerter.Alert, g.Wait()
 }
 
 func (j *keywordSearchJob) Name() string {
@@ -59,12 +100,18 @@ func (j *keywordSearchJob) Fields(v job.Verbosity) (res []log.Field) {
 }
 
 func (j *keywordSearchJob) Children() []job.Describer {
-	return []job.Describer{j.child}
+	if j.symbolChild == nil {
+		return []job.Describer{j.child}
+	}
+	return []job.Describer{j.child, j.symbolChild}
 }
 
 func (j *keywordSearchJob) MapChildren(fn job.MapFunc) job.Job {
 	cp := *j
 	cp.child = job.Map(j.child, fn)
+	if j.symbolChild != nil {
+		cp.symbolChild = job.Map(j.symbolChild, fn)
+	}
 	return &cp
 }
 
@@ -85,6 +132,8 @@ func newKeywordSearchStream(parent streaming.Sender, patterns []string) streamin
 						relevantGroups = append(relevantGroups, group)
 					}
 				}
+			} else if isFileMatch && len(fm.Symbols) > 0 {
+				relevantGroups = append(relevantGroups, newSymbolMatchGroup(fm, getFileScore(fm.Path, patterns)))
 			}
 		}
 
@@ -98,6 +147,7 @@ func newKeywordSearchStream(parent streaming.Sender, patterns []string) streamin
 			selected = append(selected, &result.FileMatch{
 				File:         group.fileMatch.File,
 				ChunkMatches: group.group,
+				Symbols:      group.symbols,
 				LimitHit:     group.fileMatch.LimitHit,
 			})
 		}
@@ -10,6 +10,8 @@ import (
 type matchGroup struct {
 	fileMatch *result.FileMatch
 	group     result.ChunkMatches
+	// symbols are the symbol matches of the group, if the group comes from a symbol search.
+	symbols []*result.SymbolMatch
 	// fileScore is the pre-calculated score based on file metadata (e.g., file name).
 	fileScore float64
 	// distinctMatchesRatio is the ratio between the number of distinct pattern matches in the group and
@@ -63,7 +65,21 @@ func newChunkMatchGroup(fileMatch *result.FileMatch, fileScore float64, group re
 	distinctMatchesPerLineRatio := float64(distinctMatchesPerLineCount) / (lineCount * numPatterns)
 	keywordsPerLine := float64(keywordCount) / lineCount
 
-	return matchGroup{fileMatch, group, fileScore, distinctMatchesRatio, distinctMatchesPerLineRatio, keywordsPerLine}
+	return matchGroup{fileMatch, group, nil, fileScore, distinctMatchesRatio, distinctMatchesPerLineRatio, keywordsPerLine}
+}
+
+// newSymbolMatchGroup groups the symbols of a file match. The symbols are
+// definitions of the kind of symbol that the query asked for, so the group is
+// always relevant and scores as high as the best possible chunk match group.
+func newSymbolMatchGroup(fileMatch *result.FileMatch, fileScore float64) matchGroup {
+	return matchGroup{
+		fileMatch:                   fileMatch,
+		symbols:                     fileMatch.Symbols,
+		fileScore:                   fileScore,
+		distinctMatchesRatio:        1,
+		distinctMatchesPerLineRatio: 1,
+		keywordsPerLineRatio:        1,
+	}
 }
 
 func groupChunkMatches(fileMatch *result.FileMatch, fileScore float64, chunkMatches result.ChunkMatches, numPatterns float64) []matchGroup {
@@ -6,6 +6,7 @@ import (
 	"github.com/go-enry/go-enry/v2"
 	"github.com/kljensen/snowball"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/lucky"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 )
 
@@ -161,3 +162,68 @@ func queryStringToKeywordQuery(queryString string) (*keywordQuery, error) {
 func basicQueryToKeywordQuery(basicQuery query.Basic) (*keywordQuery, error) {
 	return queryStringToKeywordQuery(query.StringHuman(basicQuery.ToParseTree()))
 }
+
+// symbolQueryToKeywordQuery returns the most specific symbol search inferred
+// from the structure of the query by the semantic rules of lucky search. For
+// example, `go function parseQuery` is rewritten to `lang:Go
+// select:symbol.function type:symbol parsequery`. The remaining patterns are
+// transformed like keyword patterns, but are and-ed together. It returns nil
+// if no symbol search with patterns can be inferred.
+func symbolQueryToKeywordQuery(basicQuery query.Basic) (*keywordQuery, error) {
+	var rewrite *query.Basic
+	for _, r := range lucky.SemanticRewrites(basicQuery) {
+		types, _ := r.Query.IncludeExcludeValues(query.FieldType)
+		if isSymbolSearch(types) {
+			rewrite = &r.Query
+			break
+		}
+	}
+	if rewrite == nil || rewrite.Pattern == nil {
+		return nil, nil
+	}
+
+	patterns := []string{}
+	query.VisitPattern([]query.Node{rewrite.Pattern}, func(value string, negated bool, _ query.Annotation) {
+		if !negated {
+			// Rewriting joins adjacent patterns, split them into words again.
+			patterns = append(patterns, strings.Fields(value)...)
+		}
+	})
+	transformedPatterns := transformPatterns(patterns)
+	if len(transformedPatterns) == 0 {
+		// Without patterns we would return every symbol of a kind.
+		return nil, nil
+	}
+
+	nodes := []query.Node{}
+	for _, p := range rewrite.Parameters {
+		nodes = append(nodes, p)
+	}
+
+	patternNodes := make([]query.Node, 0, len(transformedPatterns))
+	for _, p := range transformedPatterns {
+		patternNodes = append(patternNodes, query.Pattern{Value: p})
+	}
+	nodes = append(nodes, query.NewOperator(patternNodes, query.And)...)
+
+	newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	newBasic, err := query.ToBasicQuery(newNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keywordQuery{newBasic, transformedPatterns}, nil
+}
+
+func isSymbolSearch(types []string) bool {
+	for _, t := range types {
+		if t == "symbol" {
+			return true
+		}
+	}
+	return false
+}
@@ -85,3 +85,64 @@ func TestQueryStringToKeywordQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestSymbolQueryToKeywordQuery(t *testing.T) {
+	tests := []struct {
+		query        string
+		wantQuery    autogold.Value
+		wantPatterns autogold.Value
+	}{
+		{
+			query:        "go function parseQuery",
+			wantQuery:    autogold.Want("query with language and symbol kind", "lang:Go select:symbol.function type:symbol parsequery"),
+			wantPatterns: autogold.Want("patterns for query with language and symbol kind", []string{"parsequery"}),
+		},
+		{
+			query:        `file:\.ts$ how to find class Parser`,
+			wantQuery:    autogold.Want("query with file filter and symbol kind", `file:\.ts$ lang:TypeScript select:symbol.class type:symbol (find AND parser)`),
+			wantPatterns: autogold.Want("patterns for query with file filter and symbol kind", []string{"find", "parser"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			keywordQuery, err := symbolQueryToKeywordQuery(parseBasic(t, tt.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if keywordQuery == nil {
+				t.Fatal("keywordQuery == nil")
+			}
+
+			tt.wantPatterns.Equal(t, keywordQuery.patterns)
+			tt.wantQuery.Equal(t, query.StringHuman(keywordQuery.query.ToParseTree()))
+		})
+	}
+}
+
+func TestSymbolQueryToKeywordQueryNoRewrite(t *testing.T) {
+	for _, q := range []string{"parse query", "go function", "python parse query"} {
+		t.Run(q, func(t *testing.T) {
+			keywordQuery, err := symbolQueryToKeywordQuery(parseBasic(t, q))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if keywordQuery != nil {
+				t.Fatalf("expected no symbol query, got %q", query.StringHuman(keywordQuery.query.ToParseTree()))
+			}
+		})
+	}
+}
+
+func parseBasic(t *testing.T, q string) query.Basic {
+	t.Helper()
+	nodes, err := query.ParseStandard(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := query.ToBasicQuery(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
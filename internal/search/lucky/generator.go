@@ -213,3 +213,28 @@ func applyTransformation(b query.Basic, transform []transform) *query.Basic {
 	}
 	return &b
 }
+
+// Rewrite is a query generated from a seed query, with the description of the
+// rules that produced it.
+type Rewrite struct {
+	Description string
+	Query       query.Basic
+}
+
+// SemanticRewrites returns the queries generated by inferring `lang:`
+// filters from patterns and `file:` filters, and symbol selects from patterns
+// naming a kind of symbol. For example, `go function parseQuery` is rewritten
+// to `lang:Go select:symbol.function type:symbol parseQuery`. Rewrites that
+// apply more rules, and are thus more specific, come first.
+func SemanticRewrites(seed query.Basic) []Rewrite {
+	var rewrites []Rewrite
+	var autoQ *autoQuery
+	for g := NewGenerator(seed, rulesSemantic, nil); g != nil; {
+		autoQ, g = g()
+		rewrites = append(rewrites, Rewrite{
+			Description: autoQ.description,
+			Query:       autoQ.query,
+		})
+	}
+	return rewrites
+}
@@ -52,6 +52,35 @@ func TestSkippedRules(t *testing.T) {
 	})
 }
 
+func TestSemanticRewrites(t *testing.T) {
+	test := func(input string) string {
+		q, _ := query.ParseStandard(input)
+		b, _ := query.ToBasicQuery(q)
+		generated := []want{}
+		for _, r := range SemanticRewrites(b) {
+			generated = append(generated, want{
+				Description: r.Description,
+				Input:       input,
+				Query:       query.StringHuman(r.Query.ToParseTree()),
+			})
+		}
+		result, _ := json.MarshalIndent(generated, "", "  ")
+		return string(result)
+	}
+
+	cases := []string{
+		`go function parseQuery`,
+		`file:\.ts$ class Parser`,
+		`parse query`,
+	}
+
+	for _, c := range cases {
+		t.Run("semantic rewrites", func(t *testing.T) {
+			autogold.Equal(t, autogold.Raw(test(c)))
+		})
+	}
+}
+
 func generateAll(g next, input string) []want {
 	var autoQ *autoQuery
 	generated := []want{}
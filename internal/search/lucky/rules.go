@@ -3,9 +3,11 @@ package lucky
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
 	"regexp/syntax"
 	"strings"
+	"unicode"
 
 	"github.com/go-enry/go-enry/v2"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -45,6 +47,23 @@ var rulesNarrow = []rule{
 	},
 }
 
+// rulesSemantic are the narrowing rules that infer the language and kind of
+// symbol that a query is looking for. See SemanticRewrites.
+var rulesSemantic = []rule{
+	{
+		description: "apply language filter for pattern",
+		transform:   []transform{langPatterns},
+	},
+	{
+		description: "apply language filter for file filter",
+		transform:   []transform{fileFilterLang},
+	},
+	{
+		description: "apply symbol select for pattern",
+		transform:   []transform{symbolPatterns},
+	},
+}
+
 var rulesWiden = []rule{
 	{
 		description: "patterns as regular expressions",
@@ -423,6 +442,57 @@ func langPatterns(b query.Basic) *query.Basic {
 	}
 }
 
+// fileFilterLang adds a `lang:` filter for the language of the file extension
+// matched by a `file:` filter, like `file:\.go$`. It does not apply if the
+// query already has a `lang:` filter, or if the extension is ambiguous.
+func fileFilterLang(b query.Basic) *query.Basic {
+	if b.Exists(query.FieldLang) {
+		return nil
+	}
+
+	files, _ := b.IncludeExcludeValues(query.FieldFile)
+	var lang string
+	for _, file := range files {
+		ext := path.Ext(strings.ReplaceAll(strings.TrimSuffix(file, "$"), `\.`, "."))
+		if ext == "" || strings.IndexFunc(ext[1:], func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) >= 0 {
+			continue
+		}
+		// Extensions like .ts are shared with data formats, so only consider
+		// programming languages.
+		var langs []string
+		for _, l := range enry.GetLanguagesByExtension("file"+ext, nil, nil) {
+			if enry.GetLanguageType(l) == enry.Programming {
+				langs = append(langs, l)
+			}
+		}
+		if len(langs) != 1 {
+			continue
+		}
+		lang = langs[0]
+		break
+	}
+
+	if lang == "" {
+		return nil
+	}
+
+	langParam := query.Parameter{
+		Field:      query.FieldLang,
+		Value:      lang,
+		Negated:    false,
+		Annotation: query.Annotation{},
+	}
+
+	// Copy the parameters so that the query we were given isn't modified.
+	params := make([]query.Parameter, 0, len(b.Parameters)+1)
+	params = append(params, b.Parameters...)
+
+	return &query.Basic{
+		Parameters: append(params, langParam),
+		Pattern:    b.Pattern,
+	}
+}
+
 func typePatterns(b query.Basic) *query.Basic {
 	rawPatternTree, err := query.Parse(query.StringHuman([]query.Node{b.Pattern}), query.SearchTypeStandard)
 	if err != nil {
@@ -103,6 +103,26 @@ func Test_symbolPatterns(t *testing.T) {
 
 }
 
+func Test_fileFilterLang(t *testing.T) {
+	rule := []transform{fileFilterLang}
+	test := func(input string) string {
+		return apply(input, rule)
+	}
+
+	cases := []string{
+		`file:\.go$ parse`,
+		`file:internal/.*\.py parse`,
+		`file:\.go$ lang:python parse`,
+		`file:internal parse`,
+	}
+
+	for _, c := range cases {
+		t.Run("file filter lang", func(t *testing.T) {
+			autogold.Equal(t, autogold.Raw(test(c)))
+		})
+	}
+}
+
 func Test_typePatterns(t *testing.T) {
 	rule := []transform{typePatterns}
 	test := func(input string) string {
@@ -165,3 +185,18 @@ func Test_patternsToCodeHostFilters(t *testing.T) {
 		})
 	}
 }
+
+func Test_fileFilterLangCopiesParameters(t *testing.T) {
+	q, _ := query.ParseStandard(`file:\.go$ parse`)
+	b, _ := query.ToBasicQuery(q)
+	// Leave room in the parameters of the input so that appending to them
+	// would write into the same backing array.
+	b.Parameters = append(make([]query.Parameter, 0, len(b.Parameters)+1), b.Parameters...)
+
+	got := fileFilterLang(b)
+	_ = append(b.Parameters, query.Parameter{Field: query.FieldRepo, Value: "sourcegraph"})
+
+	if want := `file:\.go$ lang:Go parse`; query.StringHuman(got.ToParseTree()) != want {
+		t.Fatalf("got %q, want %q", query.StringHuman(got.ToParseTree()), want)
+	}
+}
@@ -289,6 +289,10 @@ func TestScanField(t *testing.T) {
 	autogold.Want("-repo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("-repo"))
 	autogold.Want("--repo:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("--repo:"))
 	autogold.Want(":foo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test(":foo"))
+	autogold.Want("line.author:foo", `{"Field":"line.author","Negated":false,"Advance":12}`).Equal(t, test("line.author:foo"))
+	autogold.Want("-line.author:foo", `{"Field":"line.author","Negated":true,"Advance":13}`).Equal(t, test("-line.author:foo"))
+	autogold.Want("line..author:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("line..author:"))
+	autogold.Want("foo.bar:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("foo.bar:"))
 }
 
 func parseAndOrGrammar(in string) ([]Node, error) {
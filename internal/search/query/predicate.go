@@ -2,8 +2,10 @@ package query
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
 
@@ -36,11 +38,14 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.commit.after":      func() Predicate { return &RepoContainsCommitAfterPredicate{} },
 		"has.description":       func() Predicate { return &RepoHasDescriptionPredicate{} },
 		"has.tag":               func() Predicate { return &RepoHasTagPredicate{} },
+		"has.language":          func() Predicate { return &RepoHasLanguagePredicate{} },
 		"has":                   func() Predicate { return &RepoHasKVPPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.author":       func() Predicate { return &FileHasAuthorPredicate{} },
 	},
 }
 
@@ -280,6 +285,47 @@ func (f *RepoHasTagPredicate) Unmarshal(params string, negated bool) (err error)
 func (f *RepoHasTagPredicate) Field() string { return FieldRepo }
 func (f *RepoHasTagPredicate) Name() string  { return "has.tag" }
 
+/* repo:has.language(language[, >N%]) */
+
+type RepoHasLanguagePredicate struct {
+	Language string
+	// MinPercent is the share of the bytes of code in the repository that
+	// must be written in Language. Zero means any share.
+	MinPercent float64
+	Negated    bool
+}
+
+func (f *RepoHasLanguagePredicate) Unmarshal(params string, negated bool) error {
+	language, share, hasShare := strings.Cut(params, ",")
+	language = strings.TrimSpace(language)
+	if language == "" {
+		return errors.New("repo:has.language() argument should not be empty")
+	}
+	canonical, ok := enry.GetLanguageByAlias(language)
+	if !ok {
+		return errors.Errorf("unknown language %q in repo:has.language()", language)
+	}
+
+	if hasShare {
+		share = strings.TrimSpace(share)
+		if !strings.HasPrefix(share, ">") || !strings.HasSuffix(share, "%") {
+			return errors.Errorf("invalid repo:has.language() share %q, expected a percentage like >50%%", share)
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSpace(share[1:len(share)-1]), 64)
+		if err != nil || percent < 0 || percent >= 100 {
+			return errors.Errorf("invalid repo:has.language() share %q, expected a percentage between 0 and 100", share)
+		}
+		f.MinPercent = percent
+	}
+
+	f.Language = canonical
+	f.Negated = negated
+	return nil
+}
+
+func (f *RepoHasLanguagePredicate) Field() string { return FieldRepo }
+func (f *RepoHasLanguagePredicate) Name() string  { return "has.language" }
+
 type RepoHasKVPPredicate struct {
 	Key     string
 	Value   string
@@ -323,3 +369,48 @@ func (f *FileContainsContentPredicate) Unmarshal(params string, negated bool) er
 
 func (f FileContainsContentPredicate) Field() string { return FieldFile }
 func (f FileContainsContentPredicate) Name() string  { return "contains.content" }
+
+/* file:has.owner(owner) */
+
+type FileHasOwnerPredicate struct {
+	Owner   string
+	Negated bool
+}
+
+func (f *FileHasOwnerPredicate) Unmarshal(params string, negated bool) error {
+	owner := strings.TrimSpace(params)
+	if owner == "" {
+		return errors.Errorf("file:has.owner argument should not be empty")
+	}
+	if strings.ContainsAny(owner, " \t") {
+		return errors.Errorf("file:has.owner argument should be a single owner, got %q", params)
+	}
+	f.Owner = owner
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.author(pattern) */
+
+type FileHasAuthorPredicate struct {
+	Author  string
+	Negated bool
+}
+
+func (f *FileHasAuthorPredicate) Unmarshal(params string, negated bool) error {
+	if _, err := syntax.Parse(params, syntax.Perl); err != nil {
+		return errors.Errorf("file:has.author argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("file:has.author argument should not be empty")
+	}
+	f.Author = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasAuthorPredicate) Field() string { return FieldFile }
+func (f FileHasAuthorPredicate) Name() string  { return "has.author" }
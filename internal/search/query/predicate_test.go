@@ -170,3 +170,145 @@ func TestRepoHasKVPPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestRepoHasLanguagePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *RepoHasLanguagePredicate
+		}
+
+		valid := []test{
+			{`language`, `go`, &RepoHasLanguagePredicate{Language: "Go"}},
+			{`alias`, `golang`, &RepoHasLanguagePredicate{Language: "Go"}},
+			{`share`, `python, >50%`, &RepoHasLanguagePredicate{Language: "Python", MinPercent: 50}},
+			{`fractional share`, `go,>12.5%`, &RepoHasLanguagePredicate{Language: "Go", MinPercent: 12.5}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasLanguagePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`unknown language`, `notalanguage`, nil},
+			{`missing comparison`, `go, 50%`, nil},
+			{`missing percent sign`, `go, >50`, nil},
+			{`not a number`, `go, >half%`, nil},
+			{`out of range`, `go, >100%`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasLanguagePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasOwnerPredicate
+		}
+
+		valid := []test{
+			{`team`, `@sourcegraph/search`, false, &FileHasOwnerPredicate{Owner: "@sourcegraph/search"}},
+			{`email`, `alice@example.com`, false, &FileHasOwnerPredicate{Owner: "alice@example.com"}},
+			{`negated`, `@alice`, true, &FileHasOwnerPredicate{Owner: "@alice", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`multiple owners`, `@alice @bob`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestFileHasAuthorPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasAuthorPredicate
+		}
+
+		valid := []test{
+			{`name`, `alice`, false, &FileHasAuthorPredicate{Author: "alice"}},
+			{`regexp`, `alice|bob@example\.com`, false, &FileHasAuthorPredicate{Author: `alice|bob@example\.com`}},
+			{`negated`, `alice`, true, &FileHasAuthorPredicate{Author: "alice", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasAuthorPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`invalid regexp`, `(alice`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasAuthorPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
@@ -393,6 +393,53 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+func (p Parameters) FileHasOwner() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Owner)
+		} else {
+			include = append(include, pred.Owner)
+		}
+	})
+	return include, exclude
+}
+
+// FileHasAuthor returns the author patterns of the file:has.author()
+// predicates.
+func (p Parameters) FileHasAuthor() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasAuthorPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Author)
+		} else {
+			include = append(include, pred.Author)
+		}
+	})
+	return include, exclude
+}
+
+// LineAuthor returns the author patterns of line.author: parameters.
+func (p Parameters) LineAuthor() (include, exclude []string) {
+	for _, parameter := range p {
+		if parameter.Field != FieldLineAuthor {
+			continue
+		}
+		if parameter.Negated {
+			exclude = append(exclude, parameter.Value)
+		} else {
+			include = append(include, parameter.Value)
+		}
+	}
+	return include, exclude
+}
+
+// LineAfter returns the value of the line.after: parameter, if any.
+func (p Parameters) LineAfter() (value string) {
+	p.FindParameter(FieldLineAfter, func(v string, _ bool, _ Annotation) {
+		value = v
+	})
+	return value
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
@@ -444,6 +491,27 @@ func (p Parameters) RepoHasKVPs() (res []RepoKVPFilter) {
 	return res
 }
 
+// RepoHasLanguageArgs represents the args of the repo:has.language()
+// predicate.
+type RepoHasLanguageArgs struct {
+	Language string
+	// MinPercent is the share of the bytes of code that must be written in
+	// Language. Zero means any share.
+	MinPercent float64
+	Negated    bool
+}
+
+func (p Parameters) RepoHasLanguage() (res []RepoHasLanguageArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasLanguagePredicate) {
+		res = append(res, RepoHasLanguageArgs{
+			Language:   pred.Language,
+			MinPercent: pred.MinPercent,
+			Negated:    pred.Negated,
+		})
+	})
+	return res
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
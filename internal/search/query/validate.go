@@ -272,6 +272,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldCommitter,
 		FieldMessage:
 		return satisfies(isValidRegexp)
+	case
+		FieldLineAuthor:
+		return satisfies(isValidRegexp)
+	case
+		FieldLineAfter:
+		return satisfies(isSingular, isNotNegated, isValidGitDate)
 	case
 		FieldIndex,
 		FieldFork,
@@ -352,6 +358,25 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// Queries containing line parameters are only valid for content searches,
+// since only content matches have lines that can be blamed.
+func validateLineParameters(nodes []Node) error {
+	var seenLineParam string
+	var typeNotContent string
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		if field == FieldLineAuthor || field == FieldLineAfter {
+			seenLineParam = field
+		}
+		if field == FieldType && value != "file" && value != "symbol" {
+			typeNotContent = value
+		}
+	})
+	if seenLineParam != "" && typeNotContent != "" {
+		return errors.Errorf(`your query contains the field '%s', which only applies to content searches and is not supported for type:%s`, seenLineParam, typeNotContent)
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -492,6 +517,7 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateLineParameters,
 		validateTypeStructural,
 		validateRefGlobs,
 	)
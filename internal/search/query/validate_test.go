@@ -83,6 +83,18 @@ func TestValidation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "foo line.author:alice type:commit",
+			want:  `your query contains the field 'line.author', which only applies to content searches and is not supported for type:commit`,
+		},
+		{
+			input: "foo line.after:yesterday line.after:today",
+			want:  `field "line.after" may not be used more than once`,
+		},
+		{
+			input: "foo line.after:nonsense",
+			want:  "invalid date format",
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -87,7 +88,7 @@ func (r *Resolver) Paginate(ctx context.Context, opts search.RepoOptions, handle
 		page, err := r.Resolve(ctx, opts)
 		if err != nil {
 			errs = errors.Append(errs, err)
-			if !errors.Is(err, &MissingRepoRevsError{}) { // Non-fatal errors
+			if !errors.Is(err, &MissingRepoRevsError{}) && !errors.Is(err, &MissingRepoInventoryError{}) { // Non-fatal errors
 				break
 			}
 		}
@@ -250,6 +251,11 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	if err != nil {
 		return Resolved{}, errors.Wrap(err, "filter has commit after")
 	}
+	filteredRepoRevs, err = r.filterHasLanguage(ctx, filteredRepoRevs, op)
+	var missingInventoryErr *MissingRepoInventoryError
+	if err != nil && !errors.As(err, &missingInventoryErr) {
+		return Resolved{}, errors.Wrap(err, "filter has language")
+	}
 	tr.LazyPrintf("completed rev filtering")
 
 	tr.LazyPrintf("starting contains filtering")
@@ -263,6 +269,9 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	if len(missingRepoRevs) > 0 {
 		err = errors.Append(err, &MissingRepoRevsError{Missing: missingRepoRevs})
 	}
+	if missingInventoryErr != nil {
+		err = errors.Append(err, missingInventoryErr)
+	}
 
 	return Resolved{
 		RepoRevs:        filteredRepoRevs,
@@ -504,6 +513,175 @@ func (r *Resolver) filterHasCommitAfter(
 	return filteredRepoRevs, nil
 }
 
+// hasLanguageComputeBudget is the time spent computing the inventories of revisions that are not
+// cached yet when filtering by repo:has.language() predicates. Sub-tree inventories are cached as
+// they are computed, so revisions that run out of budget are likely to be cached by a later search.
+var hasLanguageComputeBudget = 5 * time.Second
+
+// hasLanguageComputeConcurrency is the maximum number of inventories that are computed at once.
+const hasLanguageComputeConcurrency = 8
+
+// filterHasLanguage filters the revisions on each of a set of RepositoryRevisions to ensure that
+// the language breakdown of each revision satisfies the repo:has.language() predicates. The
+// breakdown is read from the cached inventory of the revision, or computed within a bounded budget
+// on a cache miss. Revisions whose breakdown is still unknown are only kept if all predicates are
+// negated, and are otherwise reported in a MissingRepoInventoryError.
+func (r *Resolver) filterHasLanguage(
+	ctx context.Context,
+	repoRevs []*search.RepositoryRevisions,
+	op search.RepoOptions,
+) (
+	[]*search.RepositoryRevisions,
+	error,
+) {
+	// Early return if HasLanguages is not set
+	if len(op.HasLanguages) == 0 {
+		return repoRevs, nil
+	}
+
+	onlyNegated := true
+	for _, arg := range op.HasLanguages {
+		if !arg.Negated {
+			onlyNegated = false
+		}
+	}
+
+	computeCtx, cancel := context.WithTimeout(ctx, hasLanguageComputeBudget)
+	defer cancel()
+	computeSem := make(chan struct{}, hasLanguageComputeConcurrency)
+
+	// getInventory returns the inventory of the revision and true, or false if it is neither
+	// cached nor computed within the budget.
+	getInventory := func(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID) (*inventory.Inventory, bool, error) {
+		inv, ok, err := inventory.CachedInventory(ctx, r.logger, r.gitserver, repo.Name, commitID)
+		if err != nil || ok {
+			return inv, ok, err
+		}
+
+		select {
+		case computeSem <- struct{}{}:
+			defer func() { <-computeSem }()
+		case <-computeCtx.Done():
+			return nil, false, nil
+		}
+
+		inv, err = inventory.ComputeInventory(computeCtx, r.logger, r.gitserver, repo.Name, commitID, false)
+		if err != nil {
+			if computeCtx.Err() != nil && ctx.Err() == nil {
+				// Out of budget.
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		return inv, true, nil
+	}
+
+	var (
+		mu      sync.Mutex
+		missing []types.MinimalRepo
+	)
+
+	g := group.New().WithContext(ctx).WithMaxConcurrency(128)
+
+	for _, repoRev := range repoRevs {
+		repoRev := repoRev
+
+		allRevs := repoRev.Revs
+
+		var revsMu sync.Mutex
+		repoRev.Revs = make([]string, 0, len(allRevs))
+
+		for _, rev := range allRevs {
+			rev := rev
+			g.Go(func(ctx context.Context) error {
+				commitID, err := r.gitserver.ResolveRevision(ctx, repoRev.Repo.Name, rev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+				if err != nil {
+					if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) || gitdomain.IsRepoNotExist(err) {
+						// If the revision does not exist or the repo does not exist,
+						// it certainly does not contain any language. Ignore the
+						// error, but filter this repo out.
+						return nil
+					}
+					return err
+				}
+
+				inv, ok, err := getInventory(ctx, repoRev.Repo, commitID)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					// The language breakdown of this revision is unknown. A revision
+					// can only be known not to contain a language, so keep it if all
+					// predicates are negated.
+					if !onlyNegated {
+						mu.Lock()
+						missing = append(missing, repoRev.Repo)
+						mu.Unlock()
+						return nil
+					}
+				} else {
+					for _, arg := range op.HasLanguages {
+						if hasLanguage(inv, arg) == arg.Negated {
+							return nil
+						}
+					}
+				}
+
+				revsMu.Lock()
+				repoRev.Revs = append(repoRev.Revs, rev)
+				revsMu.Unlock()
+				return nil
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Filter out any repo revs with empty revs
+	filteredRepoRevs := repoRevs[:0]
+	for _, repoRev := range repoRevs {
+		if len(repoRev.Revs) > 0 {
+			filteredRepoRevs = append(filteredRepoRevs, repoRev)
+		}
+	}
+
+	if len(missing) > 0 {
+		return filteredRepoRevs, &MissingRepoInventoryError{Missing: dedupRepos(missing)}
+	}
+	return filteredRepoRevs, nil
+}
+
+// dedupRepos returns the distinct repos in repos, sorted by ID.
+func dedupRepos(repos []types.MinimalRepo) []types.MinimalRepo {
+	sort.Slice(repos, func(i, j int) bool { return repos[i].ID < repos[j].ID })
+	deduped := repos[:0]
+	for i, repo := range repos {
+		if i == 0 || repo.ID != repos[i-1].ID {
+			deduped = append(deduped, repo)
+		}
+	}
+	return deduped
+}
+
+// hasLanguage returns true if the share of the bytes of code in inv that are
+// written in the language of arg is greater than arg.MinPercent. If
+// arg.MinPercent is zero, any code written in the language is enough.
+func hasLanguage(inv *inventory.Inventory, arg query.RepoHasLanguageArgs) bool {
+	var total, bytes uint64
+	for _, lang := range inv.Languages {
+		total += lang.TotalBytes
+		if strings.EqualFold(lang.Name, arg.Language) {
+			bytes += lang.TotalBytes
+		}
+	}
+	if bytes == 0 {
+		return false
+	}
+	return float64(bytes)*100/float64(total) > arg.MinPercent
+}
+
 // filterRepoHasFileContent filters a page of repos to only those that match the
 // given contains predicates in RepoOptions.HasFileContent.
 // Brief overview of the method:
@@ -962,6 +1140,14 @@ type MissingRepoRevsError struct {
 
 func (MissingRepoRevsError) Error() string { return "missing repo revs" }
 
+// MissingRepoInventoryError is returned when repositories are filtered out by repo:has.language()
+// predicates because their language breakdown is not computed yet.
+type MissingRepoInventoryError struct {
+	Missing []types.MinimalRepo
+}
+
+func (MissingRepoInventoryError) Error() string { return "missing repo inventory" }
+
 type RepoRevSpecs struct {
 	Repo types.MinimalRepo
 	Revs []search.RevisionSpecifier
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
@@ -763,3 +764,135 @@ func TestRepoHasCommitAfter(t *testing.T) {
 		})
 	}
 }
+
+func TestRepoHasLanguage(t *testing.T) {
+	repoA := types.MinimalRepo{ID: 1, Name: "example.com/1"}
+	repoB := types.MinimalRepo{ID: 2, Name: "example.com/2"}
+	repoC := types.MinimalRepo{ID: 3, Name: "example.com/3"}
+	repoD := types.MinimalRepo{ID: 4, Name: "example.com/4"}
+	repoE := types.MinimalRepo{ID: 5, Name: "example.com/5"}
+
+	mkHead := func(repo types.MinimalRepo) *search.RepositoryRevisions {
+		return &search.RepositoryRevisions{
+			Repo: repo,
+			Revs: []string{""},
+		}
+	}
+
+	mockGitserver := gitserver.NewMockClient()
+	mockGitserver.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, repoName api.RepoName, _ string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		if repoName == repoC.Name {
+			return "", &gitdomain.RevisionNotFoundError{}
+		}
+		return api.CommitID("deadbeef"), nil
+	})
+
+	inventory.MockCachedInventory = func(_ context.Context, repo api.RepoName, _ api.CommitID) (*inventory.Inventory, bool, error) {
+		switch repo {
+		case repoA.Name:
+			// 75% Python
+			return &inventory.Inventory{Languages: []inventory.Lang{
+				{Name: "Python", TotalBytes: 300},
+				{Name: "Go", TotalBytes: 100},
+			}}, true, nil
+		case repoB.Name:
+			// 20% Python
+			return &inventory.Inventory{Languages: []inventory.Lang{
+				{Name: "Go", TotalBytes: 400},
+				{Name: "Python", TotalBytes: 100},
+			}}, true, nil
+		case repoD.Name, repoE.Name:
+			// Not computed yet
+			return nil, false, nil
+		default:
+			panic("unreachable")
+		}
+	}
+	inventory.MockComputeInventory = func(ctx context.Context, repo api.RepoName, _ api.CommitID) (*inventory.Inventory, error) {
+		switch repo {
+		case repoD.Name:
+			// 100% Go, computed within the budget
+			return &inventory.Inventory{Languages: []inventory.Lang{
+				{Name: "Go", TotalBytes: 100},
+			}}, nil
+		case repoE.Name:
+			// Too large to be computed within the budget
+			<-ctx.Done()
+			return nil, ctx.Err()
+		default:
+			panic("unreachable")
+		}
+	}
+	origBudget := hasLanguageComputeBudget
+	hasLanguageComputeBudget = 500 * time.Millisecond
+	t.Cleanup(func() {
+		inventory.MockCachedInventory = nil
+		inventory.MockComputeInventory = nil
+		hasLanguageComputeBudget = origBudget
+	})
+
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{repoA, repoB, repoC, repoD, repoE}, nil)
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	cases := []struct {
+		name        string
+		languages   []query.RepoHasLanguageArgs
+		expected    []*search.RepositoryRevisions
+		wantMissing []types.MinimalRepo
+	}{{
+		name:        "any share",
+		languages:   []query.RepoHasLanguageArgs{{Language: "Python"}},
+		expected:    []*search.RepositoryRevisions{mkHead(repoA), mkHead(repoB)},
+		wantMissing: []types.MinimalRepo{repoE},
+	}, {
+		name:        "min percent",
+		languages:   []query.RepoHasLanguageArgs{{Language: "Python", MinPercent: 50}},
+		expected:    []*search.RepositoryRevisions{mkHead(repoA)},
+		wantMissing: []types.MinimalRepo{repoE},
+	}, {
+		name:      "negated",
+		languages: []query.RepoHasLanguageArgs{{Language: "Python", MinPercent: 50, Negated: true}},
+		// Repos without a known language breakdown are kept for negated predicates.
+		expected: []*search.RepositoryRevisions{mkHead(repoB), mkHead(repoD), mkHead(repoE)},
+	}, {
+		name: "multiple",
+		languages: []query.RepoHasLanguageArgs{
+			{Language: "Go", MinPercent: 50},
+			{Language: "Python"},
+		},
+		expected:    []*search.RepositoryRevisions{mkHead(repoB)},
+		wantMissing: []types.MinimalRepo{repoE},
+	}, {
+		name:        "computed on cache miss",
+		languages:   []query.RepoHasLanguageArgs{{Language: "Go", MinPercent: 90}},
+		expected:    []*search.RepositoryRevisions{mkHead(repoD)},
+		wantMissing: []types.MinimalRepo{repoE},
+	}, {
+		name:        "missing language",
+		languages:   []query.RepoHasLanguageArgs{{Language: "Rust"}},
+		expected:    []*search.RepositoryRevisions{},
+		wantMissing: []types.MinimalRepo{repoE},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := NewResolver(logtest.Scoped(t), db, nil, endpoint.Static("test"), nil)
+			res.gitserver = mockGitserver
+			resolved, err := res.Resolve(context.Background(), search.RepoOptions{
+				RepoFilters:  []string{".*"},
+				HasLanguages: tc.languages,
+			})
+			if tc.wantMissing == nil {
+				require.NoError(t, err)
+			} else {
+				var missingErr *MissingRepoInventoryError
+				require.True(t, errors.As(err, &missingErr))
+				require.Equal(t, tc.wantMissing, missingErr.Missing)
+			}
+			require.Equal(t, tc.expected, resolved.RepoRevs)
+		})
+	}
+}
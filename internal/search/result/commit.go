@@ -41,6 +41,11 @@ type CommitMatch struct {
 	// ModifiedFiles will include the list of files modified in the commit when
 	// sub-repo permissions filtering has been enabled.
 	ModifiedFiles []string
+
+	// MessageOnly is set on matches selected with `select:commit.message`.
+	// Such a match stands for the message of its commit, so matches of
+	// commits with identical messages are deduplicated.
+	MessageOnly bool
 }
 
 func (cm *CommitMatch) Body() MatchedString {
@@ -130,6 +135,30 @@ func (cm *CommitMatch) Select(path filter.SelectPath) Match {
 			}
 			return nil
 		}
+		if len(fields) > 0 && fields[0] == "author" {
+			return &CommitAuthorMatch{
+				Author:        cm.Commit.Author,
+				Repo:          cm.Repo,
+				Commit:        cm.Commit.ID,
+				ModifiedFiles: cm.ModifiedFiles,
+			}
+		}
+		if len(fields) > 0 && fields[0] == "message" {
+			messagePreview := cm.MessagePreview
+			if cm.DiffPreview != nil {
+				// Diff matches have no highlights in the message.
+				messagePreview = &MatchedString{Content: string(cm.Commit.Message)}
+			}
+			return &CommitMatch{
+				Commit:         cm.Commit,
+				Repo:           cm.Repo,
+				Refs:           cm.Refs,
+				SourceRefs:     cm.SourceRefs,
+				MessagePreview: messagePreview,
+				ModifiedFiles:  cm.ModifiedFiles,
+				MessageOnly:    true,
+			}
+		}
 		return cm
 	}
 	return nil
@@ -147,6 +176,14 @@ func (cm *CommitMatch) AppendMatches(src *CommitMatch) {
 
 // Key implements Match interface's Key() method
 func (cm *CommitMatch) Key() Key {
+	if cm.MessageOnly {
+		return Key{
+			TypeRank: rankCommitMatch,
+			Repo:     cm.Repo.Name,
+			Message:  string(cm.Commit.Message),
+		}
+	}
+
 	typeRank := rankCommitMatch
 	if cm.DiffPreview != nil {
 		typeRank = rankDiffMatch
@@ -0,0 +1,74 @@
+package result
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// CommitAuthorMatch is the author of matched commits. Author matches are
+// produced by `select:commit.author`, and there is a single match per author
+// email and repository.
+type CommitAuthorMatch struct {
+	Author gitdomain.Signature
+	Repo   types.MinimalRepo
+
+	// Commit is the first matched commit of the author that was streamed.
+	// Commits are streamed newest first for each repository.
+	Commit api.CommitID
+
+	// ModifiedFiles are the files modified in Commit when sub-repo
+	// permissions filtering has been enabled.
+	ModifiedFiles []string
+}
+
+func (a *CommitAuthorMatch) RepoName() types.MinimalRepo {
+	return a.Repo
+}
+
+func (a *CommitAuthorMatch) ResultCount() int {
+	return 1
+}
+
+func (a *CommitAuthorMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (a *CommitAuthorMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: a.Repo.Name,
+			ID:   a.Repo.ID,
+		}
+	case filter.Commit:
+		if len(path) > 1 && path[1] == "author" {
+			return a
+		}
+	}
+	return nil
+}
+
+// URL returns a URL to the matched commit of the author.
+func (a *CommitAuthorMatch) URL() *url.URL {
+	u := (&RepoMatch{Name: a.Repo.Name, ID: a.Repo.ID}).URL()
+	u.Path = u.Path + "/-/commit/" + string(a.Commit)
+	return u
+}
+
+func (a *CommitAuthorMatch) Key() Key {
+	return Key{
+		TypeRank: rankCommitAuthorMatch,
+		Repo:     a.Repo.Name,
+		// Emails are case-insensitive, and commits of the same author
+		// often differ in case.
+		Author: strings.ToLower(a.Author.Email),
+	}
+}
+
+func (a *CommitAuthorMatch) searchResultMarker() {}
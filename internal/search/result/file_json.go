@@ -0,0 +1,142 @@
+package result
+
+import (
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// fileMatchJSONType is the value of the "type" field in a serialized file
+// match. It allows stored file matches to be distinguished from stored commit
+// matches, which predate it and carry no type.
+const fileMatchJSONType = "file"
+
+// stableFileMatchJSON is a type that is used to marshal and unmarshal a
+// FileMatch. Like stableCommitMatchJSON, it is a stable representation of the
+// serialized match so that changes to the shape of FileMatch don't break
+// stored, serialized results.
+//
+// Specifically, this representation of file matches is stored in the database
+// as the results of code monitor runs.
+type stableFileMatchJSON struct {
+	Type         string                 `json:"type"`
+	RepoID       int32                  `json:"repoID"`
+	RepoName     string                 `json:"repoName"`
+	RepoStars    int                    `json:"repoStars"`
+	InputRev     *string                `json:"inputRev,omitempty"`
+	CommitID     string                 `json:"commitID"`
+	Path         string                 `json:"path"`
+	ChunkMatches []stableChunkMatchJSON `json:"chunkMatches,omitempty"`
+	PathMatches  []Range                `json:"pathMatches,omitempty"`
+	LimitHit     bool                   `json:"limitHit,omitempty"`
+}
+
+type stableChunkMatchJSON struct {
+	Content      string   `json:"content"`
+	ContentStart Location `json:"contentStart"`
+	Ranges       Ranges   `json:"ranges"`
+}
+
+func (fm FileMatch) MarshalJSON() ([]byte, error) {
+	var chunkMatches []stableChunkMatchJSON
+	if len(fm.ChunkMatches) > 0 {
+		chunkMatches = make([]stableChunkMatchJSON, len(fm.ChunkMatches))
+		for i, cm := range fm.ChunkMatches {
+			chunkMatches[i] = stableChunkMatchJSON{
+				Content:      cm.Content,
+				ContentStart: cm.ContentStart,
+				Ranges:       cm.Ranges,
+			}
+		}
+	}
+
+	marshaler := stableFileMatchJSON{
+		Type:         fileMatchJSONType,
+		RepoID:       int32(fm.Repo.ID),
+		RepoName:     string(fm.Repo.Name),
+		RepoStars:    fm.Repo.Stars,
+		InputRev:     fm.InputRev,
+		CommitID:     string(fm.CommitID),
+		Path:         fm.Path,
+		ChunkMatches: chunkMatches,
+		PathMatches:  fm.PathMatches,
+		LimitHit:     fm.LimitHit,
+	}
+
+	return json.Marshal(marshaler)
+}
+
+func (fm *FileMatch) UnmarshalJSON(input []byte) error {
+	var unmarshaler stableFileMatchJSON
+	if err := json.Unmarshal(input, &unmarshaler); err != nil {
+		return err
+	}
+
+	var chunkMatches ChunkMatches
+	if len(unmarshaler.ChunkMatches) > 0 {
+		chunkMatches = make(ChunkMatches, len(unmarshaler.ChunkMatches))
+		for i, cm := range unmarshaler.ChunkMatches {
+			chunkMatches[i] = ChunkMatch{
+				Content:      cm.Content,
+				ContentStart: cm.ContentStart,
+				Ranges:       cm.Ranges,
+			}
+		}
+	}
+
+	*fm = FileMatch{
+		File: File{
+			InputRev: unmarshaler.InputRev,
+			Repo: types.MinimalRepo{
+				ID:    api.RepoID(unmarshaler.RepoID),
+				Name:  api.RepoName(unmarshaler.RepoName),
+				Stars: unmarshaler.RepoStars,
+			},
+			CommitID: api.CommitID(unmarshaler.CommitID),
+			Path:     unmarshaler.Path,
+		},
+		ChunkMatches: chunkMatches,
+		PathMatches:  unmarshaler.PathMatches,
+		LimitHit:     unmarshaler.LimitHit,
+	}
+	return nil
+}
+
+// UnmarshalStoredMatches unmarshals a JSON list of matches that were
+// serialized with the stable representations of CommitMatch and FileMatch.
+// Elements without a type are assumed to be commit matches, which is the
+// format code monitor results were stored in before file matches were
+// supported.
+func UnmarshalStoredMatches(input []byte) ([]Match, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return nil, err
+	}
+
+	matches := make([]Match, 0, len(raw))
+	for _, r := range raw {
+		var typed struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(r, &typed); err != nil {
+			return nil, err
+		}
+
+		switch typed.Type {
+		case fileMatchJSONType:
+			var fm FileMatch
+			if err := json.Unmarshal(r, &fm); err != nil {
+				return nil, err
+			}
+			matches = append(matches, &fm)
+		default:
+			var cm CommitMatch
+			if err := json.Unmarshal(r, &cm); err != nil {
+				return nil, err
+			}
+			matches = append(matches, &cm)
+		}
+	}
+	return matches, nil
+}
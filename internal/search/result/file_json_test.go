@@ -0,0 +1,61 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileMatchMarshaling(t *testing.T) {
+	rev := "main"
+	fm1 := FileMatch{
+		File: File{
+			InputRev: &rev,
+			Repo: types.MinimalRepo{
+				ID:    42,
+				Name:  api.RepoName("github.com/historyofconsumption/beverages"),
+				Stars: 7,
+			},
+			CommitID: api.CommitID("saccolabium"),
+			Path:     "drinks/coffee.md",
+		},
+		ChunkMatches: ChunkMatches{{
+			Content:      "coffee with milk\ncoffee without milk",
+			ContentStart: Location{Offset: 10, Line: 1, Column: 0},
+			Ranges:       Ranges{{Start: Location{Offset: 10, Line: 1, Column: 0}, End: Location{Offset: 16, Line: 1, Column: 6}}},
+		}},
+		PathMatches: []Range{{Start: Location{Offset: 7, Line: 0, Column: 7}, End: Location{Offset: 13, Line: 0, Column: 13}}},
+		LimitHit:    true,
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		marshaled, err := json.Marshal(fm1)
+		require.NoError(t, err)
+
+		var fm2 FileMatch
+		err = json.Unmarshal(marshaled, &fm2)
+		require.NoError(t, err)
+		require.Equal(t, fm1, fm2)
+	})
+
+	t.Run("stored matches", func(t *testing.T) {
+		cm := &CommitMatch{
+			Commit: gitdomain.Commit{ID: api.CommitID("arabica")},
+			Repo:   types.MinimalRepo{ID: 42, Name: "github.com/historyofconsumption/beverages"},
+		}
+
+		marshaled, err := json.Marshal([]Match{cm, &fm1})
+		require.NoError(t, err)
+
+		matches, err := UnmarshalStoredMatches(marshaled)
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		require.IsType(t, &CommitMatch{}, matches[0])
+		require.Equal(t, &fm1, matches[1])
+	})
+}
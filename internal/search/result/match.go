@@ -34,16 +34,20 @@ var (
 	_ Match = (*RepoMatch)(nil)
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
+	_ Match = (*OwnerMatch)(nil)
+	_ Match = (*CommitAuthorMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
 // Match types with lower ranks will be sorted before match types
 // with higher ranks.
 const (
-	rankFileMatch   = 0
-	rankCommitMatch = 1
-	rankDiffMatch   = 2
-	rankRepoMatch   = 3
+	rankFileMatch         = 0
+	rankCommitMatch       = 1
+	rankDiffMatch         = 2
+	rankRepoMatch         = 3
+	rankOwnerMatch        = 4
+	rankCommitAuthorMatch = 5
 )
 
 // Key is a sorting or deduplicating key for a Match. It contains all the
@@ -74,6 +78,18 @@ type Key struct {
 	// Empty if there is no file associated with the match (e.g. RepoMatch or CommitMatch)
 	Path string
 
+	// Owner is the owner handle of the match.
+	// Empty if there is no owner associated with the match (e.g. FileMatch)
+	Owner string
+
+	// Author is the lowercased author email of the match.
+	// Empty if the match is not an author (e.g. CommitMatch)
+	Author string
+
+	// Message is the commit message of the match.
+	// Empty unless the match was selected with `select:commit.message`
+	Message string
+
 	// TypeRank is the sorting rank of the type this key belongs to.
 	TypeRank int
 }
@@ -100,6 +116,18 @@ func (k Key) Less(other Key) bool {
 		return k.Path < other.Path
 	}
 
+	if k.Owner != other.Owner {
+		return k.Owner < other.Owner
+	}
+
+	if k.Author != other.Author {
+		return k.Author < other.Author
+	}
+
+	if k.Message != other.Message {
+		return k.Message < other.Message
+	}
+
 	return k.TypeRank < other.TypeRank
 }
 
@@ -86,6 +86,18 @@ func TestSelect(t *testing.T) {
 				input:      testMessageMatch,
 				selectPath: []string{filter.Content},
 				output:     nil,
+			}, {
+				input:      testMessageMatch,
+				selectPath: []string{filter.Commit, "message"},
+				output: &CommitMatch{
+					Repo:           types.MinimalRepo{Name: "testrepo"},
+					MessagePreview: &MatchedString{Content: "test"},
+					MessageOnly:    true,
+				},
+			}, {
+				input:      testMessageMatch,
+				selectPath: []string{filter.Commit, "author"},
+				output:     &CommitAuthorMatch{Repo: types.MinimalRepo{Name: "testrepo"}},
 			}}
 
 			for _, tc := range cases {
@@ -103,6 +115,11 @@ func TestSelect(t *testing.T) {
 
 			testDiffMatch := func() CommitMatch {
 				return CommitMatch{
+					Commit: gitdomain.Commit{
+						ID:      "deadbeef",
+						Author:  gitdomain.Signature{Name: "Alice", Email: "alice@example.com"},
+						Message: "fix things",
+					},
 					Repo: types.MinimalRepo{Name: "testrepo"},
 					DiffPreview: &MatchedString{
 						Content:       diffContent,
@@ -135,7 +152,8 @@ func TestSelect(t *testing.T) {
 				input:      testDiffMatch(),
 				selectPath: []string{filter.Commit, "diff", "added"},
 				output: &CommitMatch{
-					Repo: types.MinimalRepo{Name: "testrepo"},
+					Commit: testDiffMatch().Commit,
+					Repo:   types.MinimalRepo{Name: "testrepo"},
 					DiffPreview: &MatchedString{
 						Content:       diffContent,
 						MatchedRanges: Ranges{addedRange},
@@ -145,12 +163,30 @@ func TestSelect(t *testing.T) {
 				input:      testDiffMatch(),
 				selectPath: []string{filter.Commit, "diff", "removed"},
 				output: &CommitMatch{
-					Repo: types.MinimalRepo{Name: "testrepo"},
+					Commit: testDiffMatch().Commit,
+					Repo:   types.MinimalRepo{Name: "testrepo"},
 					DiffPreview: &MatchedString{
 						Content:       diffContent,
 						MatchedRanges: Ranges{removedRange},
 					},
 				},
+			}, {
+				input:      testDiffMatch(),
+				selectPath: []string{filter.Commit, "message"},
+				output: &CommitMatch{
+					Commit:         testDiffMatch().Commit,
+					Repo:           types.MinimalRepo{Name: "testrepo"},
+					MessagePreview: &MatchedString{Content: "fix things"},
+					MessageOnly:    true,
+				},
+			}, {
+				input:      testDiffMatch(),
+				selectPath: []string{filter.Commit, "author"},
+				output: &CommitAuthorMatch{
+					Author: gitdomain.Signature{Name: "Alice", Email: "alice@example.com"},
+					Repo:   types.MinimalRepo{Name: "testrepo"},
+					Commit: "deadbeef",
+				},
 			}}
 
 			for _, tc := range cases {
@@ -159,6 +195,12 @@ func TestSelect(t *testing.T) {
 					require.Equal(t, tc.output, result)
 				})
 			}
+
+			t.Run("commit.message does not modify the match", func(t *testing.T) {
+				input := testDiffMatch()
+				input.Select([]string{filter.Commit, "message"})
+				require.Equal(t, testDiffMatch(), input)
+			})
 		})
 	})
 }
@@ -184,6 +226,22 @@ func TestKeyEquality(t *testing.T) {
 		match1:   &CommitMatch{Commit: gitdomain.Commit{ID: "test1"}},
 		match2:   &CommitMatch{Commit: gitdomain.Commit{ID: "test2"}},
 		areEqual: false,
+	}, {
+		match1:   &CommitAuthorMatch{Author: gitdomain.Signature{Email: "alice@example.com"}, Commit: "test1"},
+		match2:   &CommitAuthorMatch{Author: gitdomain.Signature{Email: "Alice@example.com"}, Commit: "test2"},
+		areEqual: true,
+	}, {
+		match1:   &CommitAuthorMatch{Author: gitdomain.Signature{Email: "alice@example.com"}, Repo: types.MinimalRepo{Name: "repo1"}},
+		match2:   &CommitAuthorMatch{Author: gitdomain.Signature{Email: "alice@example.com"}, Repo: types.MinimalRepo{Name: "repo2"}},
+		areEqual: false,
+	}, {
+		match1:   &CommitMatch{Commit: gitdomain.Commit{ID: "test1", Message: "fix"}, MessageOnly: true},
+		match2:   &CommitMatch{Commit: gitdomain.Commit{ID: "test2", Message: "fix"}, MessageOnly: true},
+		areEqual: true,
+	}, {
+		match1:   &CommitMatch{Commit: gitdomain.Commit{ID: "test1", Message: "fix"}, MessageOnly: true},
+		match2:   &CommitMatch{Commit: gitdomain.Commit{ID: "test2", Message: "fix more"}, MessageOnly: true},
+		areEqual: false,
 	}}
 
 	for _, tc := range cases {
@@ -0,0 +1,72 @@
+package result
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of matched files, as declared in the CODEOWNERS file
+// of a repository. Owner matches are produced by `select:file.owners`, and
+// there is a single match per owner and repository.
+type OwnerMatch struct {
+	// Handle is the owner as written in the CODEOWNERS file, for example a
+	// team handle like "@sourcegraph/search" or an email address.
+	Handle string
+
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+
+	// InputRev is the revision provided in the search query, if any.
+	InputRev *string
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: o.Repo.Name,
+			ID:   o.Repo.ID,
+		}
+	case filter.File:
+		if len(path) > 1 && path[1] == "owners" {
+			return o
+		}
+	}
+	return nil
+}
+
+// URL returns a URL to the repository of the owner match.
+func (o *OwnerMatch) URL() *url.URL {
+	path := "/" + string(o.Repo.Name)
+	if o.InputRev != nil && *o.InputRev != "" {
+		path += "@" + *o.InputRev
+	}
+	return &url.URL{Path: path}
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Repo:     o.Repo.Name,
+		Commit:   o.CommitID,
+		Owner:    o.Handle,
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -135,6 +135,10 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case OwnerMatchType:
+		r.EventMatch = &EventOwnerMatch{}
+	case CommitAuthorMatchType:
+		r.EventMatch = &EventCommitAuthorMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
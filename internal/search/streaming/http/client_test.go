@@ -48,6 +48,14 @@ func TestFrontendClient(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventOwnerMatch{
+				Type:   OwnerMatchType,
+				Handle: "@test",
+			},
+			&EventCommitAuthorMatch{
+				Type:        CommitAuthorMatchType,
+				AuthorEmail: "test@example.com",
+			},
 		},
 	}, {
 		Name: "filters",
@@ -166,6 +166,37 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventOwnerMatch is an owner of matched files, as declared in a CODEOWNERS
+// file. It is the result type of `select:file.owners`.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Handle       string   `json:"handle"`
+	RepositoryID int32    `json:"repositoryID"`
+	Repository   string   `json:"repository"`
+	Branches     []string `json:"branches,omitempty"`
+	Commit       string   `json:"commit,omitempty"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
+// EventCommitAuthorMatch is an author of matched commits. It is the result
+// type of `select:commit.author`.
+type EventCommitAuthorMatch struct {
+	// Type is always CommitAuthorMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	AuthorName   string `json:"authorName"`
+	AuthorEmail  string `json:"authorEmail"`
+	URL          string `json:"url"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	OID          string `json:"oid"`
+}
+
+func (e *EventCommitAuthorMatch) eventMatch() {}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -211,6 +242,8 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
+	CommitAuthorMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -225,6 +258,10 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
+	case CommitAuthorMatchType:
+		return []byte(`"commit.author"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -241,6 +278,10 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
+	} else if bytes.Equal(b, []byte(`"commit.author"`)) {
+		*t = CommitAuthorMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
@@ -381,6 +381,7 @@ type RepoOptions struct {
 	UseIndex       query.YesNoOnly
 	HasFileContent []query.RepoHasFileContentArgs
 	HasKVPs        []query.RepoKVPFilter
+	HasLanguages   []query.RepoHasLanguageArgs
 
 	// ForkSet indicates whether `fork:` was set explicitly in the query,
 	// or whether the values were set from defaults.
@@ -464,6 +465,18 @@ func (op *RepoOptions) Tags() []otlog.Field {
 			add(trace.Scoped(fmt.Sprintf("hasKVPs[%d]", i), nondefault...))
 		}
 	}
+	if len(op.HasLanguages) > 0 {
+		for i, arg := range op.HasLanguages {
+			nondefault := []otlog.Field{otlog.String("language", arg.Language)}
+			if arg.MinPercent > 0 {
+				nondefault = append(nondefault, otlog.Float64("minPercent", arg.MinPercent))
+			}
+			if arg.Negated {
+				nondefault = append(nondefault, otlog.Bool("negated", arg.Negated))
+			}
+			add(trace.Scoped(fmt.Sprintf("hasLanguages[%d]", i), nondefault...))
+		}
+	}
 	if op.ForkSet {
 		add(otlog.Bool("forkSet", op.ForkSet))
 	}
@@ -540,6 +553,17 @@ func (op *RepoOptions) String() string {
 			}
 		}
 	}
+	if len(op.HasLanguages) > 0 {
+		for i, arg := range op.HasLanguages {
+			fmt.Fprintf(&b, "HasLanguages[%d].language: %s\n", i, arg.Language)
+			if arg.MinPercent > 0 {
+				fmt.Fprintf(&b, "HasLanguages[%d].minPercent: %g\n", i, arg.MinPercent)
+			}
+			if arg.Negated {
+				fmt.Fprintf(&b, "HasLanguages[%d].negated: %t\n", i, arg.Negated)
+			}
+		}
+	}
 
 	if op.CaseSensitiveRepoFilters {
 		fmt.Fprintf(&b, "CaseSensitiveRepoFilters: %t\n", op.CaseSensitiveRepoFilters)
@@ -0,0 +1,439 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type azureStore struct {
+	container    string
+	manageBucket bool
+	config       AzureConfig
+	endpoint     *url.URL
+	key          []byte
+	client       httpcli.Doer
+	operations   *Operations
+
+	// blockSize is the maximum size of the blocks objects are uploaded in.
+	blockSize int
+}
+
+var _ Store = &azureStore{}
+
+type AzureConfig struct {
+	AccountName string
+	AccountKey  string
+
+	// Endpoint is the URL of the Blob service. It defaults to the endpoint of
+	// the storage account on Azure, and can be set to the URL of an emulator
+	// such as Azurite, e.g. http://127.0.0.1:10000/devstoreaccount1.
+	Endpoint string
+}
+
+const (
+	// azureAPIVersion is the version of the Blob service REST API used.
+	azureAPIVersion = "2020-10-02"
+
+	// azureBlockSize is the size of the blocks objects are uploaded in. Blobs
+	// can have at most 50,000 blocks, which makes for a maximum object size of
+	// about 195GiB.
+	azureBlockSize = 4 * 1024 * 1024
+)
+
+// newAzureFromConfig creates a new store backed by Azure Blob Storage. The
+// bucket is used as the name of the container.
+//
+// Azure Blob Storage doesn't support expiring objects through the Blob service
+// API, so the TTL is not applied to the container. A lifecycle management
+// policy on the storage account must be used to expire objects instead.
+func newAzureFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	return newAzureWithClient(httpcli.ExternalDoer, config.Bucket, config.ManageBucket, config.Azure, operations)
+}
+
+func newAzureWithClient(client httpcli.Doer, container string, manageBucket bool, config AzureConfig, operations *Operations) (*azureStore, error) {
+	if config.AccountName == "" {
+		return nil, errors.New("no account name configured for Azure upload store")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(config.AccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Azure account key")
+	}
+
+	rawEndpoint := config.Endpoint
+	if rawEndpoint == "" {
+		rawEndpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccountName)
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(rawEndpoint, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Azure endpoint")
+	}
+
+	return &azureStore{
+		container:    container,
+		manageBucket: manageBucket,
+		config:       config,
+		endpoint:     endpoint,
+		key:          key,
+		client:       client,
+		operations:   operations,
+		blockSize:    azureBlockSize,
+	}, nil
+}
+
+func (s *azureStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		return nil
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, s.containerURL(url.Values{"restype": {"container"}}), nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create container")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
+		return errors.Wrap(azureResponseError(resp), "failed to create container")
+	}
+
+	return nil
+}
+
+func (s *azureStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rc, err := s.get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return rc, nil
+}
+
+func (s *azureStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	w, err := s.newBlockWriter(key)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	if _, err := w.readFrom(ctx, r); err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	if err := w.commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return w.n, nil
+}
+
+// Compose concatenates the source objects by streaming them into the blocks
+// of the destination object, since blocks can only be copied between objects
+// on the server when the source objects are publicly readable.
+func (s *azureStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	w, err := s.newBlockWriter(destination)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	for _, source := range sources {
+		if err := s.copyObject(ctx, w, source); err != nil {
+			return 0, errors.Wrap(err, "failed to compose objects")
+		}
+	}
+
+	if err := w.commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	// Delete sources on success
+	for _, source := range sources {
+		if err := s.delete(ctx, source); err != nil {
+			log15.Error("Failed to delete source objects", "error", err)
+		}
+	}
+
+	return w.n, nil
+}
+
+func (s *azureStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.delete(ctx, key), "failed to delete object")
+}
+
+func (s *azureStore) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.blobURL(key, nil), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, azureResponseError(resp)
+	}
+
+	return resp.Body, nil
+}
+
+func (s *azureStore) copyObject(ctx context.Context, w *azureBlockWriter, key string) error {
+	rc, err := s.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = w.readFrom(ctx, rc)
+	return err
+}
+
+func (s *azureStore) delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.blobURL(key, nil), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Deleting an object that doesn't exist is not an error
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return azureResponseError(resp)
+	}
+
+	return nil
+}
+
+// azureBlockWriter uploads the content of an object as a list of blocks, which
+// are only committed to the object, replacing its content, once all blocks
+// have been uploaded.
+type azureBlockWriter struct {
+	store    *azureStore
+	key      string
+	prefix   string
+	blockIDs []string
+	n        int64
+}
+
+func (s *azureStore) newBlockWriter(key string) (*azureBlockWriter, error) {
+	// Uncommitted blocks are shared by all writers of the same object, so the
+	// block IDs must be unique to this writer.
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+
+	return &azureBlockWriter{store: s, key: key, prefix: hex.EncodeToString(b[:])}, nil
+}
+
+// readFrom uploads the content of the given reader as blocks.
+func (w *azureBlockWriter) readFrom(ctx context.Context, r io.Reader) (int64, error) {
+	buf := make([]byte, w.store.blockSize)
+
+	var total int64
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := w.putBlock(ctx, buf[:n]); err != nil {
+				return total, err
+			}
+			total += int64(n)
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+func (w *azureBlockWriter) putBlock(ctx context.Context, block []byte) error {
+	// All block IDs of an object must have the same length.
+	blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s-%08d", w.prefix, len(w.blockIDs))))
+
+	resp, err := w.store.do(ctx, http.MethodPut, w.store.blobURL(w.key, url.Values{"comp": {"block"}, "blockid": {blockID}}), nil, block)
+	if err != nil {
+		return errors.Wrap(err, "failed to upload block")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Wrap(azureResponseError(resp), "failed to upload block")
+	}
+
+	w.blockIDs = append(w.blockIDs, blockID)
+	w.n += int64(len(block))
+	return nil
+}
+
+type azureBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+// commit replaces the content of the object with the uploaded blocks.
+func (w *azureBlockWriter) commit(ctx context.Context) error {
+	body, err := xml.Marshal(azureBlockList{Latest: w.blockIDs})
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{"Content-Type": {"application/xml"}}
+	resp, err := w.store.do(ctx, http.MethodPut, w.store.blobURL(w.key, url.Values{"comp": {"blocklist"}}), headers, append([]byte(xml.Header), body...))
+	if err != nil {
+		return errors.Wrap(err, "failed to commit block list")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Wrap(azureResponseError(resp), "failed to commit block list")
+	}
+
+	return nil
+}
+
+func (s *azureStore) containerURL(query url.Values) *url.URL {
+	u := *s.endpoint
+	u.Path = u.Path + "/" + s.container
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (s *azureStore) blobURL(key string, query url.Values) *url.URL {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	u := s.containerURL(query)
+	u.RawPath = s.endpoint.EscapedPath() + "/" + url.PathEscape(s.container) + "/" + strings.Join(segments, "/")
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	return u
+}
+
+// do sends an authorized request to the Blob service.
+func (s *azureStore) do(ctx context.Context, method string, u *url.URL, headers http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("Authorization", "SharedKey "+s.config.AccountName+":"+s.signature(req))
+
+	return s.client.Do(req)
+}
+
+// signature returns the Shared Key signature of the given request. See
+// https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func (s *azureStore) signature(req *http.Request) string {
+	contentLength := req.Header.Get("Content-Length")
+	if contentLength == "0" {
+		contentLength = ""
+	}
+
+	var msHeaders []string
+	for name := range req.Header {
+		if name := strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var b strings.Builder
+	for _, value := range []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date, we set x-ms-date instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	} {
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+	for _, name := range msHeaders {
+		b.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+
+	// The account name is part of the canonicalized resource in addition to the
+	// path, which already contains it when using path-style URLs.
+	b.WriteString("/" + s.config.AccountName + req.URL.EscapedPath())
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		b.WriteString("\n" + strings.ToLower(name) + ":" + strings.Join(values, ","))
+	}
+
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(b.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// azureResponseError returns an error describing the given unsuccessful
+// response of the Blob service.
+func azureResponseError(resp *http.Response) error {
+	if code := resp.Header.Get("x-ms-error-code"); code != "" {
+		return errors.Newf("unexpected status code %d (%s)", resp.StatusCode, code)
+	}
+
+	return errors.Newf("unexpected status code %d", resp.StatusCode)
+}
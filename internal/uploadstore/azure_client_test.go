@@ -0,0 +1,317 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestAzureInit(t *testing.T) {
+	server := newFakeAzureBlobService(t)
+	client := testAzureClient(t, server, true)
+
+	for i := 0; i < 2; i++ {
+		if err := client.Init(context.Background()); err != nil {
+			t.Fatalf("unexpected error initializing client: %s", err)
+		}
+	}
+
+	if !server.containers["test-bucket"] {
+		t.Errorf("expected container to be created")
+	}
+}
+
+func TestAzureUnmanagedInit(t *testing.T) {
+	server := newFakeAzureBlobService(t)
+	client := testAzureClient(t, server, false)
+
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if len(server.requests) != 0 {
+		t.Errorf("unexpected requests. want=%d have=%d", 0, len(server.requests))
+	}
+}
+
+func TestAzureUploadGet(t *testing.T) {
+	server := newFakeAzureBlobService(t)
+	client := testAzureClient(t, server, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if n, err := client.Upload(context.Background(), "foo/bar baz", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	} else if n != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, n)
+	}
+
+	// The payload is split into blocks of at most blockSize bytes
+	if blocks := server.committed["test-bucket/foo/bar baz"]; len(blocks) != 3 {
+		t.Errorf("unexpected number of blocks. want=%d have=%d", 3, len(blocks))
+	}
+
+	rc, err := client.Get(context.Background(), "foo/bar baz")
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+	if string(contents) != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+}
+
+func TestAzureGetMissing(t *testing.T) {
+	server := newFakeAzureBlobService(t)
+	client := testAzureClient(t, server, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Get(context.Background(), "foo"); err == nil {
+		t.Fatalf("expected error getting missing object")
+	} else if !strings.Contains(err.Error(), "BlobNotFound") {
+		t.Errorf("unexpected error. want=%s have=%s", "BlobNotFound", err)
+	}
+}
+
+func TestAzureCompose(t *testing.T) {
+	server := newFakeAzureBlobService(t)
+	client := testAzureClient(t, server, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for key, payload := range map[string]string{"foo.1": "A", "foo.2": "BBBBBB", "foo.3": "CCC"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte(payload))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	if n, err := client.Compose(context.Background(), "foo", "foo.1", "foo.2", "foo.3"); err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	} else if n != 10 {
+		t.Errorf("unexpected size. want=%d have=%d", 10, n)
+	}
+
+	if contents := server.blob("test-bucket/foo"); contents != "ABBBBBBCCC" {
+		t.Errorf("unexpected contents. want=%s have=%s", "ABBBBBBCCC", contents)
+	}
+
+	var keys []string
+	for key := range server.committed {
+		keys = append(keys, key)
+	}
+	if diff := cmp.Diff([]string{"test-bucket/foo"}, keys); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzureDelete(t *testing.T) {
+	server := newFakeAzureBlobService(t)
+	client := testAzureClient(t, server, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Upload(context.Background(), "foo", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := client.Delete(context.Background(), "foo"); err != nil {
+			t.Fatalf("unexpected error deleting object: %s", err)
+		}
+	}
+
+	if _, ok := server.committed["test-bucket/foo"]; ok {
+		t.Errorf("expected object to be deleted")
+	}
+}
+
+func TestAzureSignature(t *testing.T) {
+	client, err := newAzureWithClient(http.DefaultClient, "test-bucket", true, AzureConfig{
+		AccountName: "devstoreaccount1",
+		AccountKey:  base64.StdEncoding.EncodeToString([]byte("secret")),
+		Endpoint:    "http://127.0.0.1:10000/devstoreaccount1",
+	}, NewOperations(&observation.TestContext, "test", "brittlestore"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, client.blobURL("foo/bar", url.Values{"comp": {"block"}, "blockid": {"MDA="}}).String(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %s", err)
+	}
+	req.Header.Set("Content-Length", "5")
+	req.Header.Set("x-ms-date", "Mon, 05 Dec 2022 10:00:00 GMT")
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	if have, want := req.URL.String(), "http://127.0.0.1:10000/devstoreaccount1/test-bucket/foo/bar?blockid=MDA%3D&comp=block"; have != want {
+		t.Errorf("unexpected URL. want=%s have=%s", want, have)
+	}
+	if have, want := client.signature(req), "QXVKrOtoZgqb1khKZ+MyiizClU5GUGk6bDS7blIjvBM="; have != want {
+		t.Errorf("unexpected signature. want=%s have=%s", want, have)
+	}
+}
+
+func testAzureClient(t *testing.T, server *fakeAzureBlobService, manageBucket bool) *azureStore {
+	client, err := newAzureWithClient(http.DefaultClient, "test-bucket", manageBucket, AzureConfig{
+		AccountName: "devstoreaccount1",
+		AccountKey:  base64.StdEncoding.EncodeToString([]byte("secret")),
+		Endpoint:    server.URL + "/devstoreaccount1",
+	}, NewOperations(&observation.TestContext, "test", "brittlestore"))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	client.blockSize = 5
+
+	return client
+}
+
+// fakeAzureBlobService is a minimal in-memory stand-in for the Blob service
+// of a storage account, as exposed with path-style URLs by Azurite.
+type fakeAzureBlobService struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	requests    []string
+	containers  map[string]bool
+	uncommitted map[string]map[string][]byte
+	committed   map[string][][]byte
+}
+
+func newFakeAzureBlobService(t *testing.T) *fakeAzureBlobService {
+	s := &fakeAzureBlobService{
+		containers:  map[string]bool{},
+		uncommitted: map[string]map[string][]byte{},
+		committed:   map[string][][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *fakeAzureBlobService) blob(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return string(bytes.Join(s.committed[name], nil))
+}
+
+func (s *fakeAzureBlobService) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.String())
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey devstoreaccount1:") || r.Header.Get("x-ms-date") == "" || r.Header.Get("x-ms-version") != azureAPIVersion {
+		s.fail(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, "/devstoreaccount1/") {
+		s.fail(w, http.StatusBadRequest, "InvalidUri")
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/devstoreaccount1/")
+	container, name, _ := strings.Cut(path, "/")
+	query := r.URL.Query()
+
+	if name == "" {
+		if r.Method != http.MethodPut || query.Get("restype") != "container" {
+			s.fail(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+			return
+		}
+		if s.containers[container] {
+			s.fail(w, http.StatusConflict, "ContainerAlreadyExists")
+			return
+		}
+		s.containers[container] = true
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	if !s.containers[container] {
+		s.fail(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.fail(w, http.StatusBadRequest, "InvalidInput")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		if s.uncommitted[path] == nil {
+			s.uncommitted[path] = map[string][]byte{}
+		}
+		s.uncommitted[path][query.Get("blockid")] = body
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList azureBlockList
+		if err := xml.Unmarshal(body, &blockList); err != nil {
+			s.fail(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+
+		blocks := make([][]byte, 0, len(blockList.Latest))
+		for _, id := range blockList.Latest {
+			block, ok := s.uncommitted[path][id]
+			if !ok {
+				s.fail(w, http.StatusBadRequest, "InvalidBlockList")
+				return
+			}
+			blocks = append(blocks, block)
+		}
+		s.committed[path] = blocks
+		delete(s.uncommitted, path)
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet:
+		blocks, ok := s.committed[path]
+		if !ok {
+			s.fail(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		_, _ = w.Write(bytes.Join(blocks, nil))
+
+	case r.Method == http.MethodDelete:
+		if _, ok := s.committed[path]; !ok {
+			s.fail(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(s.committed, path)
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		s.fail(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+	}
+}
+
+func (s *fakeAzureBlobService) fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+}
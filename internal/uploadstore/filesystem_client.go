@@ -0,0 +1,304 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type filesystemStore struct {
+	dir        string
+	ttl        time.Duration
+	operations *Operations
+
+	// expiryInterval is the minimum time between two passes that remove
+	// expired objects, which are triggered by uploads.
+	expiryInterval time.Duration
+	expiryMu       sync.Mutex
+	lastExpiry     time.Time
+	now            func() time.Time
+}
+
+var _ Store = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Path is the directory in which a directory per bucket is created.
+	Path string
+}
+
+// filesystemTempDir is the directory in the bucket directory that uploads are
+// written to before they're moved to their key. It's in the same directory so
+// that the final rename is atomic.
+const filesystemTempDir = ".tmp"
+
+// newFilesystemFromConfig creates a new store backed by a directory on the
+// local filesystem.
+func newFilesystemFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Filesystem.Path == "" {
+		return nil, errors.New("no path configured for filesystem upload store")
+	}
+
+	return newFilesystemWithDir(filepath.Join(config.Filesystem.Path, config.Bucket), config.TTL, operations), nil
+}
+
+func newFilesystemWithDir(dir string, ttl time.Duration, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		dir:            dir,
+		ttl:            ttl,
+		operations:     operations,
+		expiryInterval: time.Hour,
+		now:            time.Now,
+	}
+}
+
+// Init creates the bucket directory, regardless of whether the bucket is
+// managed, and removes expired objects.
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Join(s.dir, filesystemTempDir), os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create bucket directory")
+	}
+
+	return s.expire(ctx)
+}
+
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	// Expired objects that have not been removed yet are treated as missing.
+	if info, err := f.Stat(); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "failed to get object")
+	} else if s.expired(info) {
+		f.Close()
+		return nil, errors.Wrap(&fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	s.maybeExpire(ctx)
+
+	n, err := s.write(key, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, err := s.write(destination, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, source := range sources {
+			n, err := s.copyObject(w, source)
+			if err != nil {
+				return 0, err
+			}
+			total += n
+		}
+
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	// Delete sources on success
+	for _, source := range sources {
+		if err := s.remove(source); err != nil {
+			log15.Error("Failed to delete source objects", "error", err)
+		}
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.remove(key), "failed to delete object")
+}
+
+// write writes the content written by the given function to a temporary file
+// and moves it to the given key once it's complete, so that readers never see
+// partially written objects.
+func (s *filesystemStore) write(key string, f func(w io.Writer) (int64, error)) (_ int64, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, filesystemTempDir), "upload-")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	n, err := f(tmp)
+	if err != nil {
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) copyObject(w io.Writer, key string) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
+
+func (s *filesystemStore) remove(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// path returns the path of the file holding the object at the given key.
+// Keys must not escape the bucket directory.
+func (s *filesystemStore) path(key string) (string, error) {
+	name := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid key %q", key)
+	}
+	if first := strings.SplitN(name, string(filepath.Separator), 2)[0]; first == filesystemTempDir || first == "." {
+		return "", errors.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(s.dir, name), nil
+}
+
+func (s *filesystemStore) expired(info fs.FileInfo) bool {
+	return s.ttl > 0 && s.now().Sub(info.ModTime()) > s.ttl
+}
+
+// maybeExpire removes expired objects if that hasn't been done within the
+// expiry interval.
+func (s *filesystemStore) maybeExpire(ctx context.Context) {
+	s.expiryMu.Lock()
+	due := s.now().Sub(s.lastExpiry) >= s.expiryInterval
+	s.expiryMu.Unlock()
+
+	if due {
+		if err := s.expire(ctx); err != nil {
+			log15.Error("Failed to remove expired objects", "error", err)
+		}
+	}
+}
+
+// expire removes all objects, and temporary files of failed uploads, that are
+// older than the TTL.
+func (s *filesystemStore) expire(ctx context.Context) error {
+	if s.ttl <= 0 {
+		return nil
+	}
+
+	s.expiryMu.Lock()
+	s.lastExpiry = s.now()
+	s.expiryMu.Unlock()
+
+	return filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if s.expired(info) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,215 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFilesystemUploadGet(t *testing.T) {
+	client := testFilesystemClient(t, 0)
+
+	if n, err := client.Upload(context.Background(), "foo/bar", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	} else if n != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, n)
+	}
+
+	rc, err := client.Get(context.Background(), "foo/bar")
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+	if string(contents) != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(client.dir, filesystemTempDir))
+	if err != nil {
+		t.Fatalf("unexpected error reading temp directory: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unexpected temporary files. want=%d have=%d", 0, len(entries))
+	}
+}
+
+func TestFilesystemGetMissing(t *testing.T) {
+	client := testFilesystemClient(t, 0)
+
+	if _, err := client.Get(context.Background(), "foo"); err == nil {
+		t.Fatalf("expected error getting missing object")
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("unexpected error. want not exist error, have %s", err)
+	}
+}
+
+func TestFilesystemInvalidKeys(t *testing.T) {
+	client := testFilesystemClient(t, 0)
+
+	for _, key := range []string{"", ".", "..", "../foo", "foo/../../bar", "/etc/passwd", ".tmp/foo"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader(nil)); err == nil {
+			t.Errorf("expected error uploading object with key %q", key)
+		}
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	client := testFilesystemClient(t, 0)
+
+	for key, payload := range map[string]string{"foo.1": "A", "foo.2": "BB", "foo.3": "CCC"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte(payload))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	if n, err := client.Compose(context.Background(), "foo", "foo.1", "foo.2", "foo.3"); err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	} else if n != 6 {
+		t.Errorf("unexpected size. want=%d have=%d", 6, n)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(client.dir, "foo"))
+	if err != nil {
+		t.Fatalf("unexpected error reading composed object: %s", err)
+	}
+	if string(contents) != "ABBCCC" {
+		t.Errorf("unexpected contents. want=%s have=%s", "ABBCCC", contents)
+	}
+
+	for _, key := range []string{"foo.1", "foo.2", "foo.3"} {
+		if _, err := os.Stat(filepath.Join(client.dir, key)); !os.IsNotExist(err) {
+			t.Errorf("expected source object %q to be deleted", key)
+		}
+	}
+}
+
+func TestFilesystemComposeMissingSource(t *testing.T) {
+	client := testFilesystemClient(t, 0)
+
+	if _, err := client.Upload(context.Background(), "foo", bytes.NewReader([]byte("OLD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if _, err := client.Upload(context.Background(), "bar", bytes.NewReader([]byte("BAR"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	if _, err := client.Compose(context.Background(), "foo", "bar", "baz"); err == nil {
+		t.Fatalf("expected error composing missing objects")
+	}
+
+	// Neither the destination nor the sources are touched on failure
+	if contents, err := os.ReadFile(filepath.Join(client.dir, "foo")); err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	} else if string(contents) != "OLD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "OLD", contents)
+	}
+	if _, err := os.Stat(filepath.Join(client.dir, "bar")); err != nil {
+		t.Errorf("unexpected error reading source object: %s", err)
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	client := testFilesystemClient(t, 0)
+
+	if _, err := client.Upload(context.Background(), "foo", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	if err := client.Delete(context.Background(), "foo"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(client.dir, "foo")); !os.IsNotExist(err) {
+		t.Errorf("expected object to be deleted")
+	}
+
+	if err := client.Delete(context.Background(), "foo"); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+}
+
+func TestFilesystemExpiry(t *testing.T) {
+	client := testFilesystemClient(t, time.Hour)
+
+	for _, key := range []string{"old", "new", "nested/old"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"old", "nested/old"} {
+		if err := os.Chtimes(filepath.Join(client.dir, key), old, old); err != nil {
+			t.Fatalf("unexpected error changing modification time: %s", err)
+		}
+	}
+
+	// Expired objects are not returned before they're removed
+	if _, err := client.Get(context.Background(), "old"); err == nil {
+		t.Fatalf("expected error getting expired object")
+	}
+
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for key, exists := range map[string]bool{"old": false, "nested/old": false, "new": true} {
+		if _, err := os.Stat(filepath.Join(client.dir, key)); os.IsNotExist(err) == exists {
+			t.Errorf("unexpected existence of object %q. want=%v", key, exists)
+		}
+	}
+}
+
+func TestFilesystemExpiryOnUpload(t *testing.T) {
+	client := testFilesystemClient(t, time.Hour)
+
+	if _, err := client.Upload(context.Background(), "old", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(client.dir, "old"), old, old); err != nil {
+		t.Fatalf("unexpected error changing modification time: %s", err)
+	}
+
+	// Objects are only removed by uploads once per expiry interval
+	if _, err := client.Upload(context.Background(), "new", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(client.dir, "old")); err != nil {
+		t.Fatalf("expected expired object to still exist")
+	}
+
+	client.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	client.ttl = 3 * time.Hour
+
+	if _, err := client.Upload(context.Background(), "newer", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(client.dir, "old")); !os.IsNotExist(err) {
+		t.Fatalf("expected expired object to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(client.dir, "new")); err != nil {
+		t.Fatalf("expected unexpired object to still exist")
+	}
+}
+
+func testFilesystemClient(t *testing.T, ttl time.Duration) *filesystemStore {
+	client := newFilesystemWithDir(filepath.Join(t.TempDir(), "test-bucket"), ttl, NewOperations(&observation.TestContext, "test", "brittlestore"))
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	return client
+}
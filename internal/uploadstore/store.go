@@ -29,9 +29,11 @@ type Store interface {
 }
 
 var storeConstructors = map[string]func(ctx context.Context, config Config, operations *Operations) (Store, error){
-	"s3":    newS3FromConfig,
-	"minio": newS3FromConfig,
-	"gcs":   newGCSFromConfig,
+	"s3":         newS3FromConfig,
+	"minio":      newS3FromConfig,
+	"gcs":        newGCSFromConfig,
+	"filesystem": newFilesystemFromConfig,
+	"azure":      newAzureFromConfig,
 }
 
 // CreateLazy initialize a new store from the given configuration that is initialized
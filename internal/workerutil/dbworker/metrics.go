@@ -3,6 +3,7 @@ package dbworker
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -34,6 +35,17 @@ func InitPrometheusMetric[T workerutil.Record](observationContext *observation.C
 		return float64(count)
 	}))
 
+	observationContext.Registerer.MustRegister(&queuedCountByPriorityCollector[T]{
+		desc: prometheus.NewDesc(
+			fmt.Sprintf("src_%s_priority_total", teamAndResource),
+			fmt.Sprintf("Total number of %s records in the queued state by priority.", resource),
+			[]string{"priority"},
+			constLabels,
+		),
+		store:  workerStore,
+		logger: logger,
+	})
+
 	observationContext.Registerer.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
 		Name:        fmt.Sprintf("src_%s_queued_duration_seconds_total", teamAndResource),
 		Help:        fmt.Sprintf("The maximum amount of time a %s record has been sitting in the queue.", resource),
@@ -48,3 +60,27 @@ func InitPrometheusMetric[T workerutil.Record](observationContext *observation.C
 		return float64(age) / float64(time.Second)
 	}))
 }
+
+// queuedCountByPriorityCollector reports the number of queued records by priority. Stores
+// that are not prioritized report no values.
+type queuedCountByPriorityCollector[T workerutil.Record] struct {
+	desc   *prometheus.Desc
+	store  store.Store[T]
+	logger log.Logger
+}
+
+func (c *queuedCountByPriorityCollector[T]) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *queuedCountByPriorityCollector[T]) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.store.QueuedCountByPriority(context.Background())
+	if err != nil {
+		c.logger.Error("Failed to determine queue size by priority", log.Error(err))
+		return
+	}
+
+	for priority, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), strconv.Itoa(priority))
+	}
+}
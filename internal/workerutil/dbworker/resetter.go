@@ -21,6 +21,9 @@ import (
 // An unlocked record signifies that it is not actively being processed and records in this
 // state for more than a few seconds are very likely to be stuck after the worker processing
 // them has crashed.
+//
+// If the store tracks dependencies between records, the resetter also marks records that
+// depend on a failed or canceled record as failed, as they would never be dequeued otherwise.
 type Resetter[T workerutil.Record] struct {
 	store    store.Store[T]
 	options  ResetterOptions
@@ -38,7 +41,11 @@ type ResetterOptions struct {
 }
 
 type ResetterMetrics struct {
-	RecordResets        prometheus.Counter
+	RecordResets prometheus.Counter
+
+	// RecordResetFailures counts the records marked as failed by the resetter, which are
+	// stalled records that have been reset too many times and records depending on a
+	// failed record.
 	RecordResetFailures prometheus.Counter
 	Errors              prometheus.Counter
 }
@@ -118,8 +125,23 @@ loop:
 			r.logger.Warn("Reset stalled record to 'failed' state", log.String("name", r.options.Name), log.Int("id", id), log.Duration("timeSinceLastHeartbeat", lastHeartbeatAge))
 		}
 
+		failedDependencyIDsByIDs, err := r.store.FailDependents(r.ctx)
+		if err != nil {
+			if r.ctx.Err() != nil && errors.Is(err, r.ctx.Err()) {
+				// If the error is due to the loop being shut down, just break
+				break loop
+			}
+
+			r.options.Metrics.Errors.Inc()
+			r.logger.Error("Failed to fail records depending on failed records", log.String("name", r.options.Name), log.Error(err))
+		}
+
+		for id, dependencyID := range failedDependencyIDsByIDs {
+			r.logger.Warn("Set record depending on a failed record to 'failed' state", log.String("name", r.options.Name), log.Int("id", id), log.Int("dependencyID", dependencyID))
+		}
+
 		r.options.Metrics.RecordResets.Add(float64(len(resetLastHeartbeatsByIDs)))
-		r.options.Metrics.RecordResetFailures.Add(float64(len(failedLastHeartbeatsByIDs)))
+		r.options.Metrics.RecordResetFailures.Add(float64(len(failedLastHeartbeatsByIDs) + len(failedDependencyIDsByIDs)))
 
 		select {
 		case <-r.clock.After(r.options.Interval):
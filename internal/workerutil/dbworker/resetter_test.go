@@ -43,4 +43,7 @@ func TestResetter(t *testing.T) {
 	if callCount := len(s.ResetStalledFunc.History()); callCount < 1 {
 		t.Errorf("unexpected reset stalled call count. want>=%d have=%d", 1, callCount)
 	}
+	if callCount := len(s.FailDependentsFunc.History()); callCount < 1 {
+		t.Errorf("unexpected fail dependents call count. want>=%d have=%d", 1, callCount)
+	}
 }
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffFunc returns the duration an errored record must wait before it can be dequeued again,
+// given the number of times it has failed (including the current failure) and the error that
+// caused the current failure. The error is nil if the cause of the failure is not known to the
+// store, for example when a record is marked as errored by a remote executor.
+type BackoffFunc func(numFailures int, cause error) time.Duration
+
+// ConstantBackoff returns a backoff strategy that waits the given duration between attempts.
+func ConstantBackoff(delay time.Duration) BackoffFunc {
+	return func(numFailures int, cause error) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff returns a backoff strategy that waits the base duration after the first
+// failure and doubles the delay with each subsequent failure, up to the given maximum. A zero
+// maximum does not limit the delay.
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(numFailures int, cause error) time.Duration {
+		if numFailures < 1 {
+			numFailures = 1
+		}
+
+		delay := float64(base) * math.Pow(2, float64(numFailures-1))
+		if max > 0 && delay > float64(max) {
+			return max
+		}
+		if delay > math.MaxInt64 {
+			return time.Duration(math.MaxInt64)
+		}
+
+		return time.Duration(delay)
+	}
+}
+
+// JitteredBackoff returns a backoff strategy that randomizes the delay of the given strategy by up
+// to the given fraction in either direction, so that records failing at the same time (e.g. due to
+// an outage of a shared dependency) do not all become dequeueable at the same time. A factor of
+// 0.2 yields delays between 80% and 120% of the delay of the wrapped strategy.
+func JitteredBackoff(backoff BackoffFunc, factor float64) BackoffFunc {
+	return func(numFailures int, cause error) time.Duration {
+		delay := backoff(numFailures, cause)
+		jitter := float64(delay) * factor * (2*rand.Float64() - 1)
+
+		if jittered := delay + time.Duration(jitter); jittered > 0 {
+			return jittered
+		}
+		return 0
+	}
+}
+
+// ErrorClass pairs a predicate matching a class of errors with the backoff strategy used for
+// records that failed with an error of that class. The predicates from the errcode package, such
+// as errcode.IsTemporary or errcode.IsNotFound, can be used to match common error classes.
+type ErrorClass struct {
+	Matches func(err error) bool
+	Backoff BackoffFunc
+}
+
+// ErrorClassBackoff returns a backoff strategy that uses the backoff strategy of the first error
+// class matching the cause of the failure, and the given fallback strategy if no error class
+// matches or the cause is not known.
+func ErrorClassBackoff(fallback BackoffFunc, classes ...ErrorClass) BackoffFunc {
+	return func(numFailures int, cause error) time.Duration {
+		if cause != nil {
+			for _, class := range classes {
+				if class.Matches(cause) {
+					return class.Backoff(numFailures, cause)
+				}
+			}
+		}
+
+		return fallback(numFailures, cause)
+	}
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, time.Minute)
+
+	for numFailures, want := range map[int]time.Duration{
+		0:  time.Second,
+		1:  time.Second,
+		2:  2 * time.Second,
+		3:  4 * time.Second,
+		6:  32 * time.Second,
+		7:  time.Minute,
+		50: time.Minute,
+	} {
+		if have := backoff(numFailures, nil); have != want {
+			t.Errorf("unexpected delay for %d failures. want=%s have=%s", numFailures, want, have)
+		}
+	}
+
+	if have, want := ExponentialBackoff(time.Second, 0)(100, nil), time.Duration(1<<63-1); have != want {
+		t.Errorf("unexpected unbounded delay. want=%s have=%s", want, have)
+	}
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	backoff := JitteredBackoff(ConstantBackoff(10*time.Second), 0.2)
+
+	for i := 0; i < 100; i++ {
+		if have := backoff(1, nil); have < 8*time.Second || have > 12*time.Second {
+			t.Fatalf("unexpected delay. want between %s and %s, have=%s", 8*time.Second, 12*time.Second, have)
+		}
+	}
+
+	if have := JitteredBackoff(ConstantBackoff(time.Second), 5)(1, nil); have < 0 {
+		t.Errorf("unexpected negative delay: %s", have)
+	}
+}
+
+func TestErrorClassBackoff(t *testing.T) {
+	backoff := ErrorClassBackoff(
+		ConstantBackoff(time.Minute),
+		ErrorClass{Matches: errcode.IsTemporary, Backoff: ConstantBackoff(time.Second)},
+		ErrorClass{Matches: errcode.IsNotFound, Backoff: ConstantBackoff(time.Hour)},
+	)
+
+	for _, testCase := range []struct {
+		name  string
+		cause error
+		want  time.Duration
+	}{
+		{name: "temporary", cause: errors.Wrap(temporaryTestErr{}, "dequeue"), want: time.Second},
+		{name: "not found", cause: &errcode.Mock{IsNotFound: true}, want: time.Hour},
+		{name: "unmatched", cause: errors.New("oops"), want: time.Minute},
+		{name: "unknown", cause: nil, want: time.Minute},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if have := backoff(1, testCase.cause); have != testCase.want {
+				t.Errorf("unexpected delay. want=%s have=%s", testCase.want, have)
+			}
+		})
+	}
+}
+
+type temporaryTestErr struct{}
+
+func (temporaryTestErr) Error() string   { return "try again later" }
+func (temporaryTestErr) Temporary() bool { return true }
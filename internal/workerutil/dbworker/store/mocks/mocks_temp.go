@@ -25,9 +25,15 @@ type MockStore[T workerutil.Record] struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *StoreAddExecutionLogEntryFunc[T]
+	// DeadLettersFunc is an instance of a mock function object controlling
+	// the behavior of the method DeadLetters.
+	DeadLettersFunc *StoreDeadLettersFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *StoreDequeueFunc[T]
+	// FailDependentsFunc is an instance of a mock function object
+	// controlling the behavior of the method FailDependents.
+	FailDependentsFunc *StoreFailDependentsFunc[T]
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *StoreHandleFunc[T]
@@ -49,9 +55,15 @@ type MockStore[T workerutil.Record] struct {
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *StoreQueuedCountFunc[T]
+	// QueuedCountByPriorityFunc is an instance of a mock function object
+	// controlling the behavior of the method QueuedCountByPriority.
+	QueuedCountByPriorityFunc *StoreQueuedCountByPriorityFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *StoreRequeueFunc[T]
+	// RequeueDeadLettersFunc is an instance of a mock function object
+	// controlling the behavior of the method RequeueDeadLetters.
+	RequeueDeadLettersFunc *StoreRequeueDeadLettersFunc[T]
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *StoreResetStalledFunc[T]
@@ -72,11 +84,21 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		DeadLettersFunc: &StoreDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store.DeadLetterOptions) (r0 []T, r1 error) {
+				return
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
 			},
 		},
+		FailDependentsFunc: &StoreFailDependentsFunc[T]{
+			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &StoreHandleFunc[T]{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -112,11 +134,21 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		QueuedCountByPriorityFunc: &StoreQueuedCountByPriorityFunc[T]{
+			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
+				return
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
 			},
 		},
+		RequeueDeadLettersFunc: &StoreRequeueDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store.DeadLetterOptions) (r0 []int, r1 error) {
+				return
+			},
+		},
 		ResetStalledFunc: &StoreResetStalledFunc[T]{
 			defaultHook: func(context.Context) (r0 map[int]time.Duration, r1 map[int]time.Duration, r2 error) {
 				return
@@ -144,11 +176,21 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.AddExecutionLogEntry")
 			},
 		},
+		DeadLettersFunc: &StoreDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store.DeadLetterOptions) ([]T, error) {
+				panic("unexpected invocation of MockStore.DeadLetters")
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockStore.Dequeue")
 			},
 		},
+		FailDependentsFunc: &StoreFailDependentsFunc[T]{
+			defaultHook: func(context.Context) (map[int]int, error) {
+				panic("unexpected invocation of MockStore.FailDependents")
+			},
+		},
 		HandleFunc: &StoreHandleFunc[T]{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockStore.Handle")
@@ -184,11 +226,21 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.QueuedCount")
 			},
 		},
+		QueuedCountByPriorityFunc: &StoreQueuedCountByPriorityFunc[T]{
+			defaultHook: func(context.Context) (map[int]int, error) {
+				panic("unexpected invocation of MockStore.QueuedCountByPriority")
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockStore.Requeue")
 			},
 		},
+		RequeueDeadLettersFunc: &StoreRequeueDeadLettersFunc[T]{
+			defaultHook: func(context.Context, store.DeadLetterOptions) ([]int, error) {
+				panic("unexpected invocation of MockStore.RequeueDeadLetters")
+			},
+		},
 		ResetStalledFunc: &StoreResetStalledFunc[T]{
 			defaultHook: func(context.Context) (map[int]time.Duration, map[int]time.Duration, error) {
 				panic("unexpected invocation of MockStore.ResetStalled")
@@ -214,9 +266,15 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		AddExecutionLogEntryFunc: &StoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		DeadLettersFunc: &StoreDeadLettersFunc[T]{
+			defaultHook: i.DeadLetters,
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
+		FailDependentsFunc: &StoreFailDependentsFunc[T]{
+			defaultHook: i.FailDependents,
+		},
 		HandleFunc: &StoreHandleFunc[T]{
 			defaultHook: i.Handle,
 		},
@@ -238,9 +296,15 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		QueuedCountByPriorityFunc: &StoreQueuedCountByPriorityFunc[T]{
+			defaultHook: i.QueuedCountByPriority,
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
+		RequeueDeadLettersFunc: &StoreRequeueDeadLettersFunc[T]{
+			defaultHook: i.RequeueDeadLetters,
+		},
 		ResetStalledFunc: &StoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
@@ -367,6 +431,113 @@ func (c StoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreDeadLettersFunc describes the behavior when the DeadLetters method
+// of the parent MockStore instance is invoked.
+type StoreDeadLettersFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, store.DeadLetterOptions) ([]T, error)
+	hooks       []func(context.Context, store.DeadLetterOptions) ([]T, error)
+	history     []StoreDeadLettersFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// DeadLetters delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore[T]) DeadLetters(v0 context.Context, v1 store.DeadLetterOptions) ([]T, error) {
+	r0, r1 := m.DeadLettersFunc.nextHook()(v0, v1)
+	m.DeadLettersFunc.appendCall(StoreDeadLettersFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeadLetters method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreDeadLettersFunc[T]) SetDefaultHook(hook func(context.Context, store.DeadLetterOptions) ([]T, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeadLetters method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreDeadLettersFunc[T]) PushHook(hook func(context.Context, store.DeadLetterOptions) ([]T, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDeadLettersFunc[T]) SetDefaultReturn(r0 []T, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.DeadLetterOptions) ([]T, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDeadLettersFunc[T]) PushReturn(r0 []T, r1 error) {
+	f.PushHook(func(context.Context, store.DeadLetterOptions) ([]T, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreDeadLettersFunc[T]) nextHook() func(context.Context, store.DeadLetterOptions) ([]T, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeadLettersFunc[T]) appendCall(r0 StoreDeadLettersFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeadLettersFuncCall objects describing
+// the invocations of this function.
+func (f *StoreDeadLettersFunc[T]) History() []StoreDeadLettersFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreDeadLettersFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeadLettersFuncCall is an object that describes an invocation of
+// method DeadLetters on an instance of MockStore.
+type StoreDeadLettersFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.DeadLetterOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []T
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeadLettersFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeadLettersFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreDequeueFunc describes the behavior when the Dequeue method of the
 // parent MockStore instance is invoked.
 type StoreDequeueFunc[T workerutil.Record] struct {
@@ -480,6 +651,111 @@ func (c StoreDequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreFailDependentsFunc describes the behavior when the FailDependents
+// method of the parent MockStore instance is invoked.
+type StoreFailDependentsFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []StoreFailDependentsFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// FailDependents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore[T]) FailDependents(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.FailDependentsFunc.nextHook()(v0)
+	m.FailDependentsFunc.appendCall(StoreFailDependentsFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FailDependents
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreFailDependentsFunc[T]) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FailDependents method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreFailDependentsFunc[T]) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreFailDependentsFunc[T]) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreFailDependentsFunc[T]) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreFailDependentsFunc[T]) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreFailDependentsFunc[T]) appendCall(r0 StoreFailDependentsFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreFailDependentsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreFailDependentsFunc[T]) History() []StoreFailDependentsFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreFailDependentsFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreFailDependentsFuncCall is an object that describes an invocation of
+// method FailDependents on an instance of MockStore.
+type StoreFailDependentsFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreFailDependentsFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreFailDependentsFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreHandleFunc describes the behavior when the Handle method of the
 // parent MockStore instance is invoked.
 type StoreHandleFunc[T workerutil.Record] struct {
@@ -1239,6 +1515,111 @@ func (c StoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreQueuedCountByPriorityFunc describes the behavior when the
+// QueuedCountByPriority method of the parent MockStore instance is invoked.
+type StoreQueuedCountByPriorityFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []StoreQueuedCountByPriorityFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueuedCountByPriority delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore[T]) QueuedCountByPriority(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.QueuedCountByPriorityFunc.nextHook()(v0)
+	m.QueuedCountByPriorityFunc.appendCall(StoreQueuedCountByPriorityFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueuedCountByPriority method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreQueuedCountByPriorityFunc[T]) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueuedCountByPriority method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreQueuedCountByPriorityFunc[T]) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreQueuedCountByPriorityFunc[T]) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreQueuedCountByPriorityFunc[T]) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreQueuedCountByPriorityFunc[T]) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreQueuedCountByPriorityFunc[T]) appendCall(r0 StoreQueuedCountByPriorityFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreQueuedCountByPriorityFuncCall objects
+// describing the invocations of this function.
+func (f *StoreQueuedCountByPriorityFunc[T]) History() []StoreQueuedCountByPriorityFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreQueuedCountByPriorityFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreQueuedCountByPriorityFuncCall is an object that describes an
+// invocation of method QueuedCountByPriority on an instance of MockStore.
+type StoreQueuedCountByPriorityFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreQueuedCountByPriorityFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreQueuedCountByPriorityFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreRequeueFunc describes the behavior when the Requeue method of the
 // parent MockStore instance is invoked.
 type StoreRequeueFunc[T workerutil.Record] struct {
@@ -1346,6 +1727,114 @@ func (c StoreRequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreRequeueDeadLettersFunc describes the behavior when the
+// RequeueDeadLetters method of the parent MockStore instance is invoked.
+type StoreRequeueDeadLettersFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, store.DeadLetterOptions) ([]int, error)
+	hooks       []func(context.Context, store.DeadLetterOptions) ([]int, error)
+	history     []StoreRequeueDeadLettersFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// RequeueDeadLetters delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore[T]) RequeueDeadLetters(v0 context.Context, v1 store.DeadLetterOptions) ([]int, error) {
+	r0, r1 := m.RequeueDeadLettersFunc.nextHook()(v0, v1)
+	m.RequeueDeadLettersFunc.appendCall(StoreRequeueDeadLettersFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RequeueDeadLetters
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreRequeueDeadLettersFunc[T]) SetDefaultHook(hook func(context.Context, store.DeadLetterOptions) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequeueDeadLetters method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreRequeueDeadLettersFunc[T]) PushHook(hook func(context.Context, store.DeadLetterOptions) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreRequeueDeadLettersFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.DeadLetterOptions) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreRequeueDeadLettersFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, store.DeadLetterOptions) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreRequeueDeadLettersFunc[T]) nextHook() func(context.Context, store.DeadLetterOptions) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreRequeueDeadLettersFunc[T]) appendCall(r0 StoreRequeueDeadLettersFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreRequeueDeadLettersFuncCall objects
+// describing the invocations of this function.
+func (f *StoreRequeueDeadLettersFunc[T]) History() []StoreRequeueDeadLettersFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreRequeueDeadLettersFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreRequeueDeadLettersFuncCall is an object that describes an invocation
+// of method RequeueDeadLetters on an instance of MockStore.
+type StoreRequeueDeadLettersFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.DeadLetterOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreRequeueDeadLettersFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreRequeueDeadLettersFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreResetStalledFunc describes the behavior when the ResetStalled method
 // of the parent MockStore instance is invoked.
 type StoreResetStalledFunc[T workerutil.Record] struct {
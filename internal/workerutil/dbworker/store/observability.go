@@ -21,6 +21,10 @@ type operations struct {
 	resetStalled            *observation.Operation
 	updateExecutionLogEntry *observation.Operation
 	canceledJobs            *observation.Operation
+	queuedCountByPriority   *observation.Operation
+	failDependents          *observation.Operation
+	deadLetters             *observation.Operation
+	requeueDeadLetters      *observation.Operation
 }
 
 // as newOperations changes based on the store name passed in, and a dbworker store
@@ -70,5 +74,9 @@ func newOperations(storeName string, observationContext *observation.Context) *o
 		resetStalled:            op("ResetStalled"),
 		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
 		canceledJobs:            op("CanceledJobs"),
+		queuedCountByPriority:   op("QueuedCountByPriority"),
+		failDependents:          op("FailDependents"),
+		deadLetters:             op("DeadLetters"),
+		requeueDeadLetters:      op("RequeueDeadLetters"),
 	}
 }
@@ -58,6 +58,9 @@ func (o *ExecutionLogEntryOptions) ToSQLConds(formatQuery func(query string, arg
 type MarkFinalOptions struct {
 	// WorkerHostname, if set, enforces worker_hostname to be set to a specific value.
 	WorkerHostname string
+	// Cause, if set, is the error that caused the record to be marked as errored. It's passed
+	// to the Backoff option of the store to determine when the record will be retried.
+	Cause error
 }
 
 func (o *MarkFinalOptions) ToSQLConds(formatQuery func(query string, args ...any) *sqlf.Query) []*sqlf.Query {
@@ -68,6 +71,27 @@ func (o *MarkFinalOptions) ToSQLConds(formatQuery func(query string, args ...any
 	return conds
 }
 
+type DeadLetterOptions struct {
+	// IDs, if set, restricts the records to the given identifiers.
+	IDs []int
+	// Conditions, if set, are additional conditions the records must match. The conditions may use
+	// the alias provided in `ViewName`, if one was supplied.
+	Conditions []*sqlf.Query
+	// Limit, if non-zero, is the maximum number of records to list or requeue.
+	Limit int
+	// Offset, if non-zero, is the number of matching records to skip. Records are ordered by the
+	// time they failed, most recently failed records first.
+	Offset int
+}
+
+func (o *DeadLetterOptions) ToSQLConds(formatQuery func(query string, args ...any) *sqlf.Query) []*sqlf.Query {
+	conds := []*sqlf.Query{formatQuery("{state} = 'failed'")}
+	if len(o.IDs) > 0 {
+		conds = append(conds, formatQuery("{id} = ANY(%s)", pq.Array(o.IDs)))
+	}
+	return append(conds, o.Conditions...)
+}
+
 // ErrExecutionLogEntryNotUpdated is returned by AddExecutionLogEntry and UpdateExecutionLogEntry, when
 // the log entry was not updated.
 var ErrExecutionLogEntryNotUpdated = errors.New("execution log entry not updated")
@@ -85,6 +109,10 @@ type Store[T workerutil.Record] interface {
 	// is true it returns the number of queued _and_ processing records.
 	QueuedCount(ctx context.Context, includeProcessing bool) (int, error)
 
+	// QueuedCountByPriority returns the number of queued and errored records by priority. If the store is not
+	// prioritized, a nil map is returned.
+	QueuedCountByPriority(ctx context.Context) (map[int]int, error)
+
 	// MaxDurationInQueue returns the maximum age of queued records in this store. Returns 0 if there are no queued records.
 	MaxDurationInQueue(ctx context.Context) (time.Duration, error)
 
@@ -133,6 +161,21 @@ type Store[T workerutil.Record] interface {
 	// identifiers the age of the record's last heartbeat timestamp for each record reset to queued and failed states,
 	// respectively.
 	ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error)
+
+	// FailDependents marks queued and errored records that depend, directly or transitively, on a failed or canceled
+	// record as failed. This method returns a map from the identifiers of the records marked as failed to the identifier
+	// of the failed or canceled record they depend on. If the store does not track dependencies, a nil map is returned.
+	FailDependents(ctx context.Context) (failedDependencyIDsByIDs map[int]int, err error)
+
+	// DeadLetters returns the failed records matching the given options, most recently finished records
+	// first. Failed records are records that exhausted their retries or resets, or that failed with an
+	// error that cannot be retried.
+	DeadLetters(ctx context.Context, options DeadLetterOptions) ([]T, error)
+
+	// RequeueDeadLetters moves the failed records matching the given options back to the queued state and
+	// resets their failure and reset counters, so that they will be processed as if they were newly enqueued.
+	// This method returns the identifiers of the requeued records.
+	RequeueDeadLetters(ctx context.Context, options DeadLetterOptions) ([]int, error)
 }
 
 type ExecutionLogEntry workerutil.ExecutionLogEntry
@@ -253,6 +296,28 @@ type Options[T workerutil.Record] struct {
 	// Setting this value to zero will disable retries entirely.
 	MaxNumRetries int
 
+	// Backoff, if set, determines how long an errored record must wait before it is retried and
+	// replaces RetryAfter. The delay is computed when the record is marked as errored and stored in
+	// the process_after column. See ExponentialBackoff, JitteredBackoff, and ErrorClassBackoff for
+	// common strategies.
+	Backoff BackoffFunc
+
+	// Prioritized determines whether records with a higher priority are dequeued before records with
+	// a lower priority. Records with the same priority are dequeued in the order of OrderByExpression.
+	// If set, the target table (and the target view referenced by `ViewName`) must also have the
+	// following column:
+	//
+	//   - priority: integer not null
+	Prioritized bool
+
+	// TrackDependencies determines whether records are only dequeued once all records they depend on
+	// have been completed. Records depending on a record that failed or was canceled are marked as
+	// failed by FailDependents instead, which is called periodically by the resetter. If set, the
+	// target table (and the target view referenced by `ViewName`) must also have the following column:
+	//
+	//   - depends_on: integer[] (identifiers of records in the target table)
+	TrackDependencies bool
+
 	// clock is used to mock out the wall clock used for heartbeat updates.
 	clock glock.Clock
 }
@@ -361,6 +426,8 @@ var columnNames = []string{
 	"execution_logs",
 	"worker_hostname",
 	"cancel",
+	"priority",
+	"depends_on",
 }
 
 // QueuedCount returns the number of queued records matching the given conditions.
@@ -391,6 +458,37 @@ WHERE
 	{state} IN (%s)
 `
 
+// QueuedCountByPriority returns the number of queued and errored records by priority. If the store is not
+// prioritized, a nil map is returned.
+func (s *store[T]) QueuedCountByPriority(ctx context.Context) (_ map[int]int, err error) {
+	ctx, _, endObservation := s.operations.queuedCountByPriority.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if !s.options.Prioritized {
+		return nil, nil
+	}
+
+	return scanCountsByPriority(s.Query(ctx, s.formatQuery(
+		queuedCountByPriorityQuery,
+		quote(s.options.TableName),
+	)))
+}
+
+var scanCountsByPriority = basestore.NewMapScanner(func(scanner dbutil.Scanner) (priority, count int, err error) {
+	err = scanner.Scan(&priority, &count)
+	return priority, count, err
+})
+
+const queuedCountByPriorityQuery = `
+SELECT
+	{priority},
+	COUNT(*)
+FROM %s
+WHERE
+	{state} IN ('queued', 'errored')
+GROUP BY {priority}
+`
+
 // MaxDurationInQueue returns the longest duration for which a job associated with this store instance has
 // been in the queued state (including errored records that can be retried in the future). This method returns
 // an duration of zero if there are no jobs ready for processing.
@@ -405,7 +503,7 @@ func (s *store[T]) MaxDurationInQueue(ctx context.Context) (_ time.Duration, err
 	defer endObservation(1, observation.Args{})
 
 	now := s.now()
-	retryAfter := int(s.options.RetryAfter / time.Second)
+	retryableCondition, retryableAtExpression := s.retryable(now)
 
 	ageInSeconds, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
 		maxDurationInQueueQuery,
@@ -413,11 +511,9 @@ func (s *store[T]) MaxDurationInQueue(ctx context.Context) (_ time.Duration, err
 		quote(s.options.TableName),
 		now,
 		// oldest_retryable
-		retryAfter,
+		retryableAtExpression,
 		quote(s.options.TableName),
-		retryAfter,
-		now,
-		retryAfter,
+		retryableCondition,
 	)))
 	if err != nil {
 		return 0, err
@@ -443,12 +539,11 @@ oldest_queued AS (
 oldest_retryable AS (
 	SELECT
 		-- Select when the record was most recently dequeueable
-		{finished_at} + (%s * '1 second'::interval) AS last_queued_at
+		%s AS last_queued_at
 	FROM %s
 	WHERE
-		%s > 0 AND
 		{state} = 'errored' AND
-		%s - {finished_at} > (%s * '1 second'::interval)
+		%s
 ),
 oldest_record AS (
 	(
@@ -491,7 +586,20 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 	}
 
 	now := s.now()
-	retryAfter := int(s.options.RetryAfter / time.Second)
+	retryableCondition, _ := s.retryable(now)
+
+	orderByExpression := s.options.OrderByExpression
+	if s.options.Prioritized {
+		orderByExpression = s.formatQuery("{priority} DESC, %s", orderByExpression)
+	}
+
+	if s.options.TrackDependencies {
+		conditions = append([]*sqlf.Query{s.formatQuery(
+			dependenciesCompletedCondition,
+			quote(s.options.TableName),
+			quote(extractTableName(s.options.ViewName)),
+		)}, conditions...)
+	}
 
 	var (
 		processingExpr     = sqlf.Sprintf("%s", "processing")
@@ -514,14 +622,12 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 
 	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
 		dequeueQuery,
-		s.options.OrderByExpression,
+		orderByExpression,
 		quote(s.options.ViewName),
 		now,
-		retryAfter,
-		now,
-		retryAfter,
+		retryableCondition,
 		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
+		orderByExpression,
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
@@ -555,9 +661,8 @@ WITH potential_candidates AS (
 				{state} = 'queued' AND
 				({process_after} IS NULL OR {process_after} <= %s)
 			) OR (
-				%s > 0 AND
 				{state} = 'errored' AND
-				%s - {finished_at} > (%s * '1 second'::interval)
+				%s
 			)
 		)
 		%s
@@ -591,6 +696,31 @@ WHERE
 	{id} IN (SELECT {id} FROM candidate)
 `
 
+// retryable returns a condition matching errored records that can be dequeued again at the given time, and
+// an expression selecting the time at which such records became dequeueable.
+func (s *store[T]) retryable(now time.Time) (condition, retryableAt *sqlf.Query) {
+	if s.options.Backoff != nil {
+		return s.formatQuery("({process_after} IS NULL OR {process_after} <= %s)", now),
+			s.formatQuery("COALESCE({process_after}, {finished_at})")
+	}
+
+	retryAfter := int(s.options.RetryAfter / time.Second)
+
+	return s.formatQuery("%s > 0 AND %s - {finished_at} > (%s * '1 second'::interval)", retryAfter, now, retryAfter),
+		s.formatQuery("{finished_at} + (%s * '1 second'::interval)", retryAfter)
+}
+
+// dependenciesCompletedCondition matches records of which all records they depend on have been completed.
+// The column referencing the dependencies is qualified, as the subquery selects from the same table.
+const dependenciesCompletedCondition = `
+NOT EXISTS (
+	SELECT 1 FROM %s dependency
+	WHERE
+		dependency.{id} = ANY(%s.{depends_on}) AND
+		dependency.{state} != 'completed'
+)
+`
+
 // makeDequeueSelectExpressions constructs the ordered set of SQL expressions that are returned
 // from the dequeue query. This method returns a copy of the configured column expressions slice
 // where expressions referencing one of the column updated by dequeue are replaced by the updated
@@ -882,7 +1012,14 @@ func (s *store[T]) MarkErrored(ctx context.Context, id int, failureMessage strin
 	}
 	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
 
-	q := s.formatQuery(markErroredQuery, quote(s.options.TableName), s.options.MaxNumRetries, failureMessage, sqlf.Join(conds, "AND"))
+	q := s.formatQuery(
+		markErroredQuery,
+		quote(s.options.TableName),
+		s.options.MaxNumRetries,
+		failureMessage,
+		s.makeProcessAfterExpression(options.Cause),
+		sqlf.Join(conds, "AND"),
+	)
 	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
 	return ok, err
 }
@@ -892,11 +1029,37 @@ UPDATE %s
 SET {state} = CASE WHEN {cancel} THEN 'canceled' WHEN {num_failures} + 1 >= %d THEN 'failed' ELSE 'errored' END,
 	{finished_at} = clock_timestamp(),
 	{failure_message} = %s,
+	{process_after} = %s,
 	{num_failures} = CASE WHEN {cancel} THEN {num_failures} ELSE {num_failures} + 1 END
 WHERE %s
 RETURNING {id}
 `
 
+// makeProcessAfterExpression constructs the SQL expression that sets the process_after column of a record
+// that is being marked as errored. If the store has a backoff strategy, the delay for each possible number
+// of failures is computed up front and the expression picks the delay matching the record's failure count.
+// Otherwise the current value is retained.
+func (s *store[T]) makeProcessAfterExpression(cause error) *sqlf.Query {
+	if s.options.Backoff == nil {
+		return s.formatQuery("{process_after}")
+	}
+
+	numRetries := s.options.MaxNumRetries
+	if numRetries < 1 {
+		numRetries = 1
+	}
+
+	delays := make([]float64, 0, numRetries)
+	for numFailures := 1; numFailures <= numRetries; numFailures++ {
+		delays = append(delays, s.options.Backoff(numFailures, cause).Seconds())
+	}
+
+	return s.formatQuery(
+		"clock_timestamp() + ((%s::double precision[])[{num_failures} + 1] * '1 second'::interval)",
+		pq.Array(delays),
+	)
+}
+
 // MarkFailed attempts to update the state of the record to failed. This method will only have an effect
 // if the current state of the record is processing. A requeued record or a record already marked with an
 // error will not be updated. This method returns a boolean flag indicating if the record was updated.
@@ -1041,6 +1204,195 @@ WHERE {id} IN (SELECT {id} FROM stalled)
 RETURNING {id}, {last_heartbeat_at}
 `
 
+const defaultDependencyFailureMessage = "record %s this record depends on failed or was canceled"
+
+// FailDependents marks queued and errored records that depend, directly or transitively, on a failed or canceled
+// record as failed. This method returns a map from the identifiers of the records marked as failed to the identifier
+// of the failed or canceled record they depend on. If the store does not track dependencies, a nil map is returned.
+func (s *store[T]) FailDependents(ctx context.Context) (failedDependencyIDsByIDs map[int]int, err error) {
+	ctx, trace, endObservation := s.operations.failDependents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if !s.options.TrackDependencies {
+		return nil, nil
+	}
+
+	failedDependencyIDsByIDs, err = scanFailedDependencyIDs(s.Query(ctx, s.formatQuery(
+		failDependentsQuery,
+		quote(s.options.TableName),
+		quote(s.options.TableName),
+		quote(s.options.TableName),
+		quote(s.options.TableName),
+		quote(s.options.TableName),
+		defaultDependencyFailureMessage,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(otlog.Int("numFailedIDs", len(failedDependencyIDsByIDs)))
+
+	return failedDependencyIDsByIDs, nil
+}
+
+var scanFailedDependencyIDs = basestore.NewMapScanner(func(scanner dbutil.Scanner) (id, dependencyID int, err error) {
+	err = scanner.Scan(&id, &dependencyID)
+	return id, dependencyID, err
+})
+
+const failDependentsQuery = `
+WITH RECURSIVE failed_dependents AS (
+	SELECT
+		record.{id} AS record_id,
+		dependency.{id} AS dependency_id
+	FROM %s record
+	JOIN %s dependency ON dependency.{id} = ANY(record.{depends_on})
+	WHERE
+		record.{state} IN ('queued', 'errored') AND
+		dependency.{state} IN ('failed', 'canceled')
+
+	UNION
+
+	SELECT
+		record.{id},
+		failed_dependents.dependency_id
+	FROM %s record
+	JOIN failed_dependents ON failed_dependents.record_id = ANY(record.{depends_on})
+	WHERE
+		record.{state} IN ('queued', 'errored')
+),
+candidates AS (
+	SELECT {id} FROM %s
+	WHERE {id} IN (SELECT record_id FROM failed_dependents)
+	ORDER BY {id}
+	FOR UPDATE SKIP LOCKED
+),
+failures AS (
+	SELECT
+		record_id,
+		MIN(dependency_id) AS dependency_id
+	FROM failed_dependents
+	WHERE record_id IN (SELECT {id} FROM candidates)
+	GROUP BY record_id
+)
+UPDATE %s
+SET
+	{state} = 'failed',
+	{finished_at} = clock_timestamp(),
+	{failure_message} = format(%s, failures.dependency_id)
+FROM failures
+WHERE {id} = failures.record_id
+RETURNING {id}, failures.dependency_id
+`
+
+// DeadLetters returns the failed records matching the given options, most recently finished records first.
+// Failed records are records that exhausted their retries or resets, or that failed with an error that cannot
+// be retried.
+func (s *store[T]) DeadLetters(ctx context.Context, options DeadLetterOptions) (_ []T, err error) {
+	ctx, trace, endObservation := s.operations.deadLetters.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numIDs", len(options.IDs)),
+		otlog.Int("limit", options.Limit),
+		otlog.Int("offset", options.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
+		deadLettersQuery,
+		sqlf.Join(s.options.ColumnExpressions, ", "),
+		quote(s.options.ViewName),
+		sqlf.Join(options.ToSQLConds(s.formatQuery), "AND"),
+		makeLimit(options.Limit),
+		makeOffset(options.Offset),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(otlog.Int("numRecords", len(records)))
+
+	return records, nil
+}
+
+const deadLettersQuery = `
+SELECT %s
+FROM %s
+WHERE %s
+ORDER BY {finished_at} DESC, {id} DESC
+%s %s
+`
+
+// RequeueDeadLetters moves the failed records matching the given options back to the queued state and resets
+// their failure and reset counters, so that they will be processed as if they were newly enqueued. This method
+// returns the identifiers of the requeued records.
+func (s *store[T]) RequeueDeadLetters(ctx context.Context, options DeadLetterOptions) (ids []int, err error) {
+	ctx, trace, endObservation := s.operations.requeueDeadLetters.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numIDs", len(options.IDs)),
+		otlog.Int("limit", options.Limit),
+		otlog.Int("offset", options.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	ids, err = basestore.ScanInts(s.Query(ctx, s.formatQuery(
+		requeueDeadLettersQuery,
+		quote(s.options.ViewName),
+		sqlf.Join(options.ToSQLConds(s.formatQuery), "AND"),
+		makeLimit(options.Limit),
+		makeOffset(options.Offset),
+		quote(s.options.TableName),
+		quote(s.options.TableName),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(otlog.Int("numRequeued", len(ids)))
+
+	return ids, nil
+}
+
+const requeueDeadLettersQuery = `
+WITH matches AS (
+	SELECT {id} FROM %s
+	WHERE %s
+	ORDER BY {finished_at} DESC, {id} DESC
+	%s %s
+),
+candidates AS (
+	SELECT {id} FROM %s
+	WHERE
+		{id} IN (SELECT {id} FROM matches) AND
+		-- Recheck state.
+		{state} = 'failed'
+	ORDER BY {id}
+	FOR UPDATE SKIP LOCKED
+)
+UPDATE %s
+SET
+	{state} = 'queued',
+	{queued_at} = clock_timestamp(),
+	{started_at} = NULL,
+	{finished_at} = NULL,
+	{process_after} = NULL,
+	{failure_message} = NULL,
+	{num_resets} = 0,
+	{num_failures} = 0
+WHERE {id} IN (SELECT {id} FROM candidates)
+RETURNING {id}
+`
+
+func makeLimit(limit int) *sqlf.Query {
+	if limit <= 0 {
+		return sqlf.Sprintf("")
+	}
+
+	return sqlf.Sprintf("LIMIT %s", limit)
+}
+
+func makeOffset(offset int) *sqlf.Query {
+	if offset <= 0 {
+		return sqlf.Sprintf("")
+	}
+
+	return sqlf.Sprintf("OFFSET %s", offset)
+}
+
 func (s *store[T]) formatQuery(query string, args ...any) *sqlf.Query {
 	return sqlf.Sprintf(s.columnReplacer.Replace(query), args...)
 }
@@ -14,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestStoreQueuedCount(t *testing.T) {
@@ -216,6 +218,137 @@ func TestStoreDequeueOrder(t *testing.T) {
 	assertDequeueRecordResult(t, 2, record, ok, err)
 }
 
+func TestStoreQueuedCountByPriority(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, priority)
+		VALUES
+			(1, 'queued', 0),
+			(2, 'queued', 10),
+			(3, 'errored', 10),
+			(4, 'processing', 10),
+			(5, 'completed', 5),
+			(6, 'queued', 0)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.Prioritized = true
+
+	counts, err := testStore(db, options).QueuedCountByPriority(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting queued count by priority: %s", err)
+	}
+	if diff := cmp.Diff(map[int]int{0: 2, 10: 2}, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreQueuedCountByPriorityNotPrioritized(t *testing.T) {
+	db := setupStoreTest(t)
+
+	counts, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).QueuedCountByPriority(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting queued count by priority: %s", err)
+	}
+	if counts != nil {
+		t.Errorf("unexpected counts. want=%v have=%v", nil, counts)
+	}
+}
+
+func TestStoreDequeuePriority(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, priority)
+		VALUES
+			(1, 'queued', NOW() - '2 minute'::interval, 0),
+			(2, 'queued', NOW() - '5 minute'::interval, 0),
+			(3, 'queued', NOW() - '3 minute'::interval, 10),
+			(4, 'queued', NOW() - '1 minute'::interval, 10),
+			(5, 'queued', NOW() - '4 minute'::interval, 5)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.Prioritized = true
+	store := testStore(db, options)
+
+	for _, expectedID := range []int{3, 4, 5, 2, 1} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
+func TestStoreDequeueDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, depends_on)
+		VALUES
+			(1, 'queued', NOW() - '5 minute'::interval, '{2}'),    -- depends on queued record
+			(2, 'queued', NOW() - '4 minute'::interval, NULL),
+			(3, 'queued', NOW() - '3 minute'::interval, '{4, 5}'), -- depends on completed records
+			(4, 'completed', NOW() - '2 minute'::interval, NULL),
+			(5, 'completed', NOW() - '1 minute'::interval, NULL)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.TrackDependencies = true
+	store := testStore(db, options)
+
+	record, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 2, record, ok, err)
+
+	record, ok, err = store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 3, record, ok, err)
+
+	if _, ok, err := store.Dequeue(context.Background(), "test", nil); err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	} else if ok {
+		t.Fatalf("expected no record to be dequeueable while its dependency is processing")
+	}
+
+	if _, err := store.MarkComplete(context.Background(), 2, MarkFinalOptions{}); err != nil {
+		t.Fatalf("unexpected error marking record as complete: %s", err)
+	}
+
+	record, ok, err = store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 1, record, ok, err)
+}
+
+func TestStoreDequeueDependenciesView(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, depends_on)
+		VALUES
+			(1, 'queued', NOW() - '2 minute'::interval, '{2}'),
+			(2, 'failed', NOW() - '1 minute'::interval, NULL),
+			(3, 'queued', NOW() - '1 minute'::interval, '{}')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.ViewName = "workerutil_test_view v"
+	options.ColumnExpressions = []*sqlf.Query{
+		sqlf.Sprintf("v.id"),
+		sqlf.Sprintf("v.state"),
+		sqlf.Sprintf("v.execution_logs"),
+	}
+	options.OrderByExpression = sqlf.Sprintf("v.created_at")
+	options.TrackDependencies = true
+
+	record, ok, err := testStore(db, options).Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 3, record, ok, err)
+}
+
 func TestStoreDequeueConditions(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -785,6 +918,68 @@ func TestStoreMarkFailed(t *testing.T) {
 	}
 }
 
+func TestStoreMarkErroredBackoff(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, num_failures)
+		VALUES
+			(1, 'processing', 0),
+			(2, 'processing', 1),
+			(3, 'processing', 0)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.Backoff = ErrorClassBackoff(
+		ExponentialBackoff(time.Hour, 0),
+		ErrorClass{Matches: errcode.IsNotFound, Backoff: ConstantBackoff(24 * time.Hour)},
+	)
+	store := testStore(db, options)
+
+	for _, testCase := range []struct {
+		id    int
+		cause error
+	}{
+		{id: 1, cause: errors.New("oops")},
+		{id: 2, cause: errors.New("oops")},
+		{id: 3, cause: &errcode.Mock{Message: "oops", IsNotFound: true}},
+	} {
+		if marked, err := store.MarkErrored(context.Background(), testCase.id, "oops", MarkFinalOptions{Cause: testCase.cause}); err != nil {
+			t.Fatalf("unexpected error marking record as errored: %s", err)
+		} else if !marked {
+			t.Fatalf("expected record to be marked")
+		}
+	}
+
+	delays, err := basestore.ScanInts(db.QueryContext(context.Background(), `
+		SELECT ROUND(EXTRACT(EPOCH FROM process_after - finished_at) / 3600)::integer
+		FROM workerutil_test
+		ORDER BY id
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error querying records: %s", err)
+	}
+	if diff := cmp.Diff([]int{1, 2, 24}, delays); diff != "" {
+		t.Errorf("unexpected delays in hours (-want +got):\n%s", diff)
+	}
+
+	// Errored records are not dequeued before their process_after timestamp
+	if _, ok, err := store.Dequeue(context.Background(), "test", nil); err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	} else if ok {
+		t.Fatalf("expected no record to be dequeueable")
+	}
+
+	if _, err := db.ExecContext(context.Background(), `UPDATE workerutil_test SET process_after = NOW() - '1 minute'::interval WHERE id = 2`); err != nil {
+		t.Fatalf("unexpected error updating record: %s", err)
+	}
+
+	record, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 2, record, ok, err)
+}
+
 func TestStoreMarkErroredAlreadyCompleted(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -1013,6 +1208,176 @@ func TestStoreResetStalled(t *testing.T) {
 	}
 }
 
+func TestStoreFailDependents(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, depends_on)
+		VALUES
+			(1, 'failed',    NULL),
+			(2, 'canceled',  NULL),
+			(3, 'completed', NULL),
+			(4, 'queued',    '{1}'),    -- depends on failed record
+			(5, 'errored',   '{3, 2}'), -- depends on canceled record
+			(6, 'queued',    '{4}'),    -- depends on failed record transitively
+			(7, 'queued',    '{3}'),    -- depends on completed record
+			(8, 'completed', '{1}'),    -- already in terminal state
+			(9, 'queued',    '{1}')     -- locked
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Row lock record 9 in a transaction which should be skipped by FailDependents
+	if _, err := tx.Exec(`SELECT * FROM workerutil_test WHERE id = 9 FOR UPDATE`); err != nil {
+		t.Fatal(err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.TrackDependencies = true
+
+	failedDependencyIDsByIDs, err := testStore(db, options).FailDependents(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error failing dependents: %s", err)
+	}
+	if diff := cmp.Diff(map[int]int{4: 1, 5: 2, 6: 1}, failedDependencyIDsByIDs); diff != "" {
+		t.Errorf("unexpected failed ids (-want +got):\n%s", diff)
+	}
+
+	var state, failureMessage string
+	if err := db.QueryRowContext(context.Background(), `SELECT state, failure_message FROM workerutil_test WHERE id = 6`).Scan(&state, &failureMessage); err != nil {
+		t.Fatalf("unexpected error querying record: %s", err)
+	}
+	if state != "failed" {
+		t.Errorf("unexpected state. want=%q have=%q", "failed", state)
+	}
+	if want := "record 1 this record depends on failed or was canceled"; failureMessage != want {
+		t.Errorf("unexpected failure message. want=%q have=%q", want, failureMessage)
+	}
+}
+
+func TestStoreFailDependentsNotTracked(t *testing.T) {
+	db := setupStoreTest(t)
+
+	failedDependencyIDsByIDs, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).FailDependents(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error failing dependents: %s", err)
+	}
+	if failedDependencyIDsByIDs != nil {
+		t.Errorf("unexpected failed ids. want=%v have=%v", nil, failedDependencyIDsByIDs)
+	}
+}
+
+func TestStoreDeadLetters(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at)
+		VALUES
+			(1, 'failed',    NOW() - '3 minute'::interval),
+			(2, 'completed', NOW() - '2 minute'::interval),
+			(3, 'failed',    NOW() - '1 minute'::interval),
+			(4, 'errored',   NOW() - '1 minute'::interval),
+			(5, 'failed',    NOW() - '2 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	store := testStore(db, defaultTestStoreOptions(nil, testScanRecord))
+
+	for _, testCase := range []struct {
+		name        string
+		options     DeadLetterOptions
+		expectedIDs []int
+	}{
+		{name: "all", options: DeadLetterOptions{}, expectedIDs: []int{3, 5, 1}},
+		{name: "ids", options: DeadLetterOptions{IDs: []int{1, 2, 5}}, expectedIDs: []int{5, 1}},
+		{name: "conditions", options: DeadLetterOptions{Conditions: []*sqlf.Query{sqlf.Sprintf("id < 5")}}, expectedIDs: []int{3, 1}},
+		{name: "limit", options: DeadLetterOptions{Limit: 2}, expectedIDs: []int{3, 5}},
+		{name: "offset", options: DeadLetterOptions{Limit: 2, Offset: 2}, expectedIDs: []int{1}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			records, err := store.DeadLetters(context.Background(), testCase.options)
+			if err != nil {
+				t.Fatalf("unexpected error listing dead letters: %s", err)
+			}
+
+			ids := make([]int, 0, len(records))
+			for _, record := range records {
+				ids = append(ids, record.ID)
+			}
+			if diff := cmp.Diff(testCase.expectedIDs, ids); diff != "" {
+				t.Errorf("unexpected ids (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStoreRequeueDeadLetters(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, failure_message, num_failures, num_resets)
+		VALUES
+			(1, 'failed',    NOW(), 'oops', 3, 1),
+			(2, 'completed', NOW(), NULL,   0, 0),
+			(3, 'failed',    NOW(), 'oops', 3, 0),
+			(4, 'failed',    NOW(), 'oops', 0, 5) -- locked
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Row lock record 4 in a transaction which should be skipped by RequeueDeadLetters
+	if _, err := tx.Exec(`SELECT * FROM workerutil_test WHERE id = 4 FOR UPDATE`); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).RequeueDeadLetters(context.Background(), DeadLetterOptions{
+		IDs: []int{1, 2, 4},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error requeueing dead letters: %s", err)
+	}
+	if diff := cmp.Diff([]int{1}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+
+	var (
+		state          string
+		failureMessage *string
+		finishedAt     *time.Time
+		numFailures    int
+		numResets      int
+	)
+	if err := db.QueryRowContext(context.Background(), `
+		SELECT state, failure_message, finished_at, num_failures, num_resets
+		FROM workerutil_test
+		WHERE id = 1
+	`).Scan(&state, &failureMessage, &finishedAt, &numFailures, &numResets); err != nil {
+		t.Fatalf("unexpected error querying record: %s", err)
+	}
+	if state != "queued" {
+		t.Errorf("unexpected state. want=%q have=%q", "queued", state)
+	}
+	if failureMessage != nil || finishedAt != nil {
+		t.Errorf("expected failure message and finished at to be cleared")
+	}
+	if numFailures != 0 || numResets != 0 {
+		t.Errorf("unexpected counters. want=%d/%d have=%d/%d", 0, 0, numFailures, numResets)
+	}
+}
+
 func TestStoreHeartbeat(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -16,6 +16,7 @@ type storeShim[T workerutil.Record] struct {
 }
 
 var _ workerutil.Store[workerutil.Record] = &storeShim[workerutil.Record]{}
+var _ workerutil.WithMarkErroredCause = &storeShim[workerutil.Record]{}
 
 // newStoreShim wraps the given store in a shim.
 func newStoreShim[T workerutil.Record](store store.Store[T]) workerutil.Store[T] {
@@ -65,6 +66,10 @@ func (s *storeShim[T]) MarkErrored(ctx context.Context, id int, errorMessage str
 	return s.Store.MarkErrored(ctx, id, errorMessage, store.MarkFinalOptions{})
 }
 
+func (s *storeShim[T]) MarkErroredWithCause(ctx context.Context, id int, errorMessage string, cause error) (bool, error) {
+	return s.Store.MarkErrored(ctx, id, errorMessage, store.MarkFinalOptions{Cause: cause})
+}
+
 // ErrNotConditions occurs when a PreDequeue handler returns non-sql query extra arguments.
 var ErrNotConditions = errors.New("expected slice of *sqlf.Query values")
 
@@ -51,6 +51,14 @@ type Store[T Record] interface {
 	MarkFailed(ctx context.Context, id int, failureMessage string) (bool, error)
 }
 
+// WithMarkErroredCause is an extension of the Store interface.
+type WithMarkErroredCause interface {
+	// MarkErroredWithCause is called, if implemented, instead of MarkErrored with the error returned
+	// by the handler. This allows the store to decide when the record is retried based on the class
+	// of the error. This method returns a boolean flag indicating if the record was updated.
+	MarkErroredWithCause(ctx context.Context, id int, failureMessage string, cause error) (bool, error)
+}
+
 // ExecutionLogEntry represents a command run by the executor.
 type ExecutionLogEntry struct {
 	Key        string    `json:"key"`
@@ -383,7 +383,7 @@ func (w *Worker[T]) handle(ctx, workerContext context.Context, record T) (err er
 			handleLog.Warn("Marked record as failed", log.Error(handleErr))
 		}
 	} else if handleErr != nil {
-		if marked, markErr := w.store.MarkErrored(workerContext, record.RecordID(), handleErr.Error()); markErr != nil {
+		if marked, markErr := w.markErrored(workerContext, record.RecordID(), handleErr); markErr != nil {
 			return errors.Wrap(markErr, "store.MarkErrored")
 		} else if marked {
 			handleLog.Warn("Marked record as errored", log.Error(handleErr))
@@ -400,6 +400,15 @@ func (w *Worker[T]) handle(ctx, workerContext context.Context, record T) (err er
 	return nil
 }
 
+// markErrored marks the record as errored, passing the handler error to the store if it can make use of it.
+func (w *Worker[T]) markErrored(ctx context.Context, id int, handleErr error) (bool, error) {
+	if store, ok := w.store.(WithMarkErroredCause); ok {
+		return store.MarkErroredWithCause(ctx, id, handleErr.Error(), handleErr)
+	}
+
+	return w.store.MarkErrored(ctx, id, handleErr.Error())
+}
+
 // isJobCanceled returns true if the job has been canceled through the Cancel interface.
 // If the context is canceled, and the job is still part of the running ID set,
 // we know that it has been canceled for that reason.
@@ -100,6 +100,51 @@ func TestWorkerHandlerFailure(t *testing.T) {
 	}
 }
 
+type markErroredWithCauseStore struct {
+	*MockStore[*TestRecord]
+	causes []error
+}
+
+func (s *markErroredWithCauseStore) MarkErroredWithCause(ctx context.Context, id int, failureMessage string, cause error) (bool, error) {
+	s.causes = append(s.causes, cause)
+	return s.MarkErrored(ctx, id, failureMessage)
+}
+
+func TestWorkerHandlerFailureWithCause(t *testing.T) {
+	store := &markErroredWithCauseStore{MockStore: NewMockStore[*TestRecord]()}
+	handler := NewMockHandler[*TestRecord]()
+	dequeueClock := glock.NewMockClock()
+	heartbeatClock := glock.NewMockClock()
+	shutdownClock := glock.NewMockClock()
+	options := WorkerOptions{
+		Name:           "test",
+		WorkerHostname: "test",
+		NumHandlers:    1,
+		Interval:       time.Second,
+		Metrics:        NewMetrics(&observation.TestContext, ""),
+	}
+
+	handleErr := errors.Errorf("oops")
+	store.DequeueFunc.PushReturn(&TestRecord{ID: 42}, true, nil)
+	store.DequeueFunc.SetDefaultReturn(nil, false, nil)
+	store.MarkErroredFunc.SetDefaultReturn(true, nil)
+	handler.HandleFunc.SetDefaultReturn(handleErr)
+
+	worker := newWorker(context.Background(), Store[*TestRecord](store), Handler[*TestRecord](handler), options, dequeueClock, heartbeatClock, shutdownClock)
+	go func() { worker.Start() }()
+	dequeueClock.BlockingAdvance(time.Second)
+	worker.Stop()
+
+	if len(store.causes) != 1 {
+		t.Fatalf("unexpected mark errored with cause call count. want=%d have=%d", 1, len(store.causes))
+	} else if !errors.Is(store.causes[0], handleErr) {
+		t.Errorf("unexpected cause argument to mark errored. want=%v have=%v", handleErr, store.causes[0])
+	}
+	if failureMessage := store.MarkErroredFunc.History()[0].Arg2; failureMessage != "oops" {
+		t.Errorf("unexpected failure message argument to mark errored. want=%q have=%q", "oops", failureMessage)
+	}
+}
+
 type nonRetryableTestErr struct{}
 
 func (e nonRetryableTestErr) Error() string      { return "just retry me and see what happens" }
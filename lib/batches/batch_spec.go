@@ -3,6 +3,7 @@ package batches
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
 	"github.com/sourcegraph/sourcegraph/lib/batches/overridable"
@@ -37,6 +38,7 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	AutoMerge         *AutoMerge               `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
 }
 
 type ChangesetTemplate struct {
@@ -45,6 +47,17 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Reviewers []string                     `json:"reviewers,omitempty" yaml:"reviewers"`
+	Assignees []string                     `json:"assignees,omitempty" yaml:"assignees"`
+	Labels    []string                     `json:"labels,omitempty" yaml:"labels"`
+}
+
+// AutoMerge is the policy used to automatically merge the changesets of a
+// batch change once their checks have passed and they have been approved.
+type AutoMerge struct {
+	Squash                bool `json:"squash,omitempty" yaml:"squash"`
+	MaxPerHour            int  `json:"maxPerHour,omitempty" yaml:"maxPerHour"`
+	RespectRolloutWindows bool `json:"respectRolloutWindows,omitempty" yaml:"respectRolloutWindows"`
 }
 
 type GitCommitAuthor struct {
@@ -88,13 +101,43 @@ func (oqor *OnQueryOrRepository) GetBranches() ([]string, error) {
 }
 
 type Step struct {
-	Run       string            `json:"run,omitempty" yaml:"run"`
-	Container string            `json:"container,omitempty" yaml:"container"`
-	Env       env.Environment   `json:"env,omitempty" yaml:"env"`
-	Files     map[string]string `json:"files,omitempty" yaml:"files,omitempty"`
-	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
-	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
-	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+	Run             string            `json:"run,omitempty" yaml:"run"`
+	Container       string            `json:"container,omitempty" yaml:"container"`
+	Env             env.Environment   `json:"env,omitempty" yaml:"env"`
+	Files           map[string]string `json:"files,omitempty" yaml:"files,omitempty"`
+	Outputs         Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Mount           []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
+	If              any               `json:"if,omitempty" yaml:"if,omitempty"`
+	Timeout         string            `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries         int               `json:"retries,omitempty" yaml:"retries,omitempty"`
+	RetryBackoff    string            `json:"retryBackoff,omitempty" yaml:"retryBackoff,omitempty"`
+	ContinueOnError bool              `json:"continueOnError,omitempty" yaml:"continueOnError,omitempty"`
+	CPUs            float64           `json:"cpus,omitempty" yaml:"cpus,omitempty"`
+	Memory          string            `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// DefaultRetryBackoff is the duration to wait before the first retry of a step
+// that doesn't set retryBackoff.
+const DefaultRetryBackoff = 10 * time.Second
+
+// TimeoutDuration returns the timeout of a single run of the step, or 0 if the
+// step has no timeout.
+func (s *Step) TimeoutDuration() time.Duration {
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// RetryBackoffDuration returns the duration to wait before the first retry of
+// the step.
+func (s *Step) RetryBackoffDuration() time.Duration {
+	d, err := time.ParseDuration(s.RetryBackoff)
+	if err != nil || d <= 0 {
+		return DefaultRetryBackoff
+	}
+	return d
 }
 
 func (s *Step) IfCondition() string {
@@ -126,6 +169,9 @@ type Group struct {
 	Directory  string `json:"directory,omitempty" yaml:"directory"`
 	Branch     string `json:"branch,omitempty" yaml:"branch"`
 	Repository string `json:"repository,omitempty" yaml:"repository"`
+	// DependsOn is the branch of another group, or the branch of the
+	// changesetTemplate, that the changeset of this group is based on.
+	DependsOn string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
 }
 
 type Mount struct {
@@ -166,6 +212,16 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 	}
 
 	for i, step := range spec.Steps {
+		if step.Timeout != "" {
+			if d, err := time.ParseDuration(step.Timeout); err != nil || d <= 0 {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d timeout must be a positive duration", i+1)))
+			}
+		}
+		if step.RetryBackoff != "" {
+			if d, err := time.ParseDuration(step.RetryBackoff); err != nil || d <= 0 {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d retryBackoff must be a positive duration", i+1)))
+			}
+		}
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
 				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d mount path contains invalid characters", i+1)))
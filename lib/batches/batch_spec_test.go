@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -210,6 +211,103 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("auto-merge policy", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: /tmp/sample.sh
+    container: alpine:3
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+autoMerge:
+  squash: true
+  maxPerHour: 5
+  respectRolloutWindows: true
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		assert.Equal(t, &AutoMerge{Squash: true, MaxPerHour: 5, RespectRolloutWindows: true}, batchSpec.AutoMerge)
+
+		_, err = ParseBatchSpec([]byte(spec + "  mergeMethod: rebase\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("step limits", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: /tmp/sample.sh
+    container: alpine:3
+    timeout: 10m
+    retries: 2
+    retryBackoff: 30s
+    continueOnError: true
+    cpus: 0.5
+    memory: 512m
+  - run: /tmp/sample.sh
+    container: alpine:3
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		step := batchSpec.Steps[0]
+		assert.Equal(t, 10*time.Minute, step.TimeoutDuration())
+		assert.Equal(t, 2, step.Retries)
+		assert.Equal(t, 30*time.Second, step.RetryBackoffDuration())
+		assert.True(t, step.ContinueOnError)
+		assert.Equal(t, 0.5, step.CPUs)
+		assert.Equal(t, "512m", step.Memory)
+
+		step = batchSpec.Steps[1]
+		assert.Equal(t, time.Duration(0), step.TimeoutDuration())
+		assert.Equal(t, DefaultRetryBackoff, step.RetryBackoffDuration())
+	})
+
+	t.Run("invalid step limits", func(t *testing.T) {
+		for name, limits := range map[string]string{
+			"timeout":      "timeout: forever",
+			"zero timeout": "timeout: 0s",
+			"retries":      "retries: -1",
+			"cpus":         "cpus: 0",
+			"memory":       "memory: 1 terabyte",
+		} {
+			t.Run(name, func(t *testing.T) {
+				spec := `
+name: test-spec
+description: A test spec
+steps:
+  - run: /tmp/sample.sh
+    container: alpine:3
+    ` + limits + `
+changesetTemplate:
+  title: Test
+  body: Test
+  branch: test
+  commit:
+    message: Test
+`
+				_, err := ParseBatchSpec([]byte(spec))
+				assert.Error(t, err)
+			})
+		}
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -80,6 +80,15 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	Reviewers []string `json:"reviewers,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+
+	// DependsOn is the head ref of the changeset in the same repository that
+	// this changeset is stacked on. If it's set, the changeset is based on
+	// the branch of that changeset until it's merged.
+	DependsOn string `json:"dependsOn,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -102,6 +111,10 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+		Reviewers      []string               `json:"reviewers,omitempty"`
+		Assignees      []string               `json:"assignees,omitempty"`
+		Labels         []string               `json:"labels,omitempty"`
+		DependsOn      string                 `json:"dependsOn,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -112,6 +125,10 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+		Reviewers:      c.Reviewers,
+		Assignees:      c.Assignees,
+		Labels:         c.Labels,
+		DependsOn:      c.DependsOn,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
@@ -2,6 +2,7 @@ package batches
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/sourcegraph/go-diff/diff"
@@ -94,6 +95,21 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 		return nil, err
 	}
 
+	reviewers, err := renderChangesetTemplateList("reviewers", input.Template.Reviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := renderChangesetTemplateList("assignees", input.Template.Assignees, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := renderChangesetTemplateList("labels", input.Template.Labels, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	newSpec := func(branch, diff string) (*ChangesetSpec, error) {
 		var published any = nil
 		if input.Template.Published != nil {
@@ -118,6 +134,9 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 				},
 			},
 			Published: PublishedValue{Val: published},
+			Reviewers: reviewers,
+			Assignees: assignees,
+			Labels:    labels,
 		}, nil
 	}
 
@@ -136,11 +155,26 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 			return specs, errors.Wrap(err, "grouping diffs failed")
 		}
 
+		dependencies := groupDependencies(input.Template.Branch, defaultBranch, groups)
+
 		for branch, diff := range diffsByBranch {
 			spec, err := newSpec(branch, diff)
 			if err != nil {
 				return nil, err
 			}
+
+			// If the branch a group depends on didn't get any changes, there's
+			// no changeset for it, so we stack the changeset on the next one
+			// up the chain instead.
+			dep := dependencies[branch]
+			for dep != "" {
+				if _, ok := diffsByBranch[dep]; ok {
+					spec.DependsOn = git.EnsureRefPrefix(dep)
+					break
+				}
+				dep = dependencies[dep]
+			}
+
 			specs = append(specs, spec)
 		}
 	} else {
@@ -154,6 +188,34 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 	return specs, nil
 }
 
+// renderChangesetTemplateList renders each of the given templates of the
+// changeset template field with the given name. Values that render to an empty
+// string, for example because they're conditional, are dropped, as are
+// duplicates.
+func renderChangesetTemplateList(name string, tmpls []string, tmplCtx *template.ChangesetTemplateContext) ([]string, error) {
+	if len(tmpls) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(tmpls))
+	seen := make(map[string]struct{}, len(tmpls))
+	for i, tmpl := range tmpls {
+		value, err := template.RenderChangesetTemplateField(fmt.Sprintf("%s[%d]", name, i), tmpl, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
@@ -228,9 +290,47 @@ func validateGroups(repoName, defaultBranch string, groups []Group) error {
 		}
 	}
 
+	for _, g := range groups {
+		if g.DependsOn == "" {
+			continue
+		}
+		if _, ok := uniqueBranches[g.DependsOn]; !ok && g.DependsOn != defaultBranch {
+			return NewValidationError(errors.Newf("transformChanges group branch %q in repository %s depends on unknown branch %q", g.Branch, repoName, g.DependsOn))
+		}
+	}
+
+	dependencies := groupDependencies(defaultBranch, defaultBranch, groups)
+	for _, g := range groups {
+		seen := map[string]struct{}{g.Branch: {}}
+		for dep := dependencies[g.Branch]; dep != ""; dep = dependencies[dep] {
+			if _, ok := seen[dep]; ok {
+				return NewValidationError(errors.Newf("transformChanges group branch %q in repository %s has a circular dependsOn", g.Branch, repoName))
+			}
+			seen[dep] = struct{}{}
+		}
+	}
+
 	return nil
 }
 
+// groupDependencies returns a map of the branch of each group that depends on
+// another branch to that branch. Dependencies on the branch of the changeset
+// template are mapped to the rendered defaultBranch.
+func groupDependencies(templateBranch, defaultBranch string, groups []Group) map[string]string {
+	dependencies := make(map[string]string, len(groups))
+	for _, g := range groups {
+		if g.DependsOn == "" {
+			continue
+		}
+		if g.DependsOn == templateBranch {
+			dependencies[g.Branch] = defaultBranch
+		} else {
+			dependencies[g.Branch] = g.DependsOn
+		}
+	}
+	return dependencies
+}
+
 func groupFileDiffs(completeDiff, defaultBranch string, groups []Group) (map[string]string, error) {
 	fileDiffs, err := diff.ParseMultiFileDiff([]byte(completeDiff))
 	if err != nil {
@@ -144,6 +144,31 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "reviewers, assignees and labels",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Reviewers = []string{"alice", "${{ outputs.owner }}", "${{ if eq repository.name \"github.com/sourcegraph/sourcegraph\" }}carol${{ end }}", "alice"}
+				input.Template.Assignees = []string{"${{ outputs.owner }}"}
+				input.Template.Labels = []string{"automated", "${{ repository.branch }}"}
+				input.Result.Outputs = map[string]any{"owner": "bob"}
+				input.Template.Published = parsePublishedFieldString(t, "false")
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Reviewers = []string{"alice", "bob"}
+					s.Assignees = []string{"bob"}
+					s.Labels = []string{"automated", "my-cool-base-ref"}
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid reviewer template",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Reviewers = []string{"${{ outputs.unknown }}"}
+			}),
+			wantErr: `template: reviewers[0]:1:4: executing "reviewers[0]" at <outputs>: map has no entry for key "unknown"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -167,6 +192,68 @@ func TestCreateChangesetSpecs(t *testing.T) {
 	}
 }
 
+func TestCreateChangesetSpecs_DependsOn(t *testing.T) {
+	diffA := `diff --git a/a.txt a/a.txt
+new file mode 100644
+index 0000000..19d6416
+--- /dev/null
++++ a/a.txt
+@@ -0,0 +1,1 @@
++this is a
+`
+	diffC := `diff --git c/c.txt c/c.txt
+new file mode 100644
+index 0000000..c825d65
+--- /dev/null
++++ c/c.txt
+@@ -0,0 +1,1 @@
++this is c
+`
+
+	input := &ChangesetSpecInput{
+		Repository: Repository{
+			ID:      "base-repo-id",
+			Name:    "github.com/sourcegraph/src-cli",
+			BaseRef: "refs/heads/main",
+			BaseRev: "f00b4r",
+		},
+		BatchChangeAttributes: &template.BatchChangeAttributes{Name: "the name"},
+		Template: &ChangesetTemplate{
+			Title:  "The title",
+			Branch: "my-branch",
+			Commit: ExpandedGitCommitDescription{Message: "git commit message"},
+		},
+		TransformChanges: &TransformChanges{
+			Group: []Group{
+				{Directory: "a", Branch: "my-branch-a", DependsOn: "my-branch"},
+				// There are no changes in b, so the changeset of c is stacked
+				// on the one of a.
+				{Directory: "b", Branch: "my-branch-b", DependsOn: "my-branch-a"},
+				{Directory: "c", Branch: "my-branch-c", DependsOn: "my-branch-b"},
+			},
+		},
+		Result: execution.AfterStepResult{Diff: diffA + diffC},
+	}
+
+	specs, err := BuildChangesetSpecs(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	have := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		have[spec.HeadRef] = spec.DependsOn
+	}
+	want := map[string]string{
+		"refs/heads/my-branch":   "",
+		"refs/heads/my-branch-a": "refs/heads/my-branch",
+		"refs/heads/my-branch-c": "refs/heads/my-branch-a",
+	}
+	if !cmp.Equal(want, have) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, have))
+	}
+}
+
 func TestGroupFileDiffs(t *testing.T) {
 	diff1 := `diff --git 1/1.txt 1/1.txt
 new file mode 100644
@@ -312,6 +399,34 @@ func TestValidateGroups(t *testing.T) {
 			},
 			wantErr: "transformChanges group branch for repository github.com/sourcegraph/src-cli is the same as branch \"my-batch-change\" in changesetTemplate",
 		},
+		{
+			groups: []Group{
+				{Directory: "a", Branch: "my-batch-change-a"},
+				{Directory: "b", Branch: "my-batch-change-b", DependsOn: "my-batch-change-a"},
+				{Directory: "c", Branch: "my-batch-change-c", DependsOn: defaultBranch},
+			},
+			wantErr: "",
+		},
+		{
+			groups: []Group{
+				{Directory: "a", Branch: "my-batch-change-a", DependsOn: "my-batch-change-unknown"},
+			},
+			wantErr: "transformChanges group branch \"my-batch-change-a\" in repository github.com/sourcegraph/src-cli depends on unknown branch \"my-batch-change-unknown\"",
+		},
+		{
+			groups: []Group{
+				{Directory: "a", Branch: "my-batch-change-a", DependsOn: "my-batch-change-a"},
+			},
+			wantErr: "transformChanges group branch \"my-batch-change-a\" in repository github.com/sourcegraph/src-cli has a circular dependsOn",
+		},
+		{
+			groups: []Group{
+				{Directory: "a", Branch: "my-batch-change-a", DependsOn: "my-batch-change-c"},
+				{Directory: "b", Branch: "my-batch-change-b", DependsOn: "my-batch-change-a"},
+				{Directory: "c", Branch: "my-batch-change-c", DependsOn: "my-batch-change-b"},
+			},
+			wantErr: "transformChanges group branch \"my-batch-change-a\" in repository github.com/sourcegraph/src-cli has a circular dependsOn",
+		},
 	}
 
 	for _, tc := range tests {
@@ -17,4 +17,12 @@ type AfterStepResult struct {
 	Diff string `json:"diff"`
 	// Outputs is a copy of the Outputs after executing the Step.
 	Outputs map[string]any `json:"outputs"`
+	// ExitCode is the exit code of the last run of the step, or -1 if it
+	// didn't finish, for example because it timed out.
+	ExitCode int `json:"exitCode,omitempty"`
+	// Attempts is the number of times the step was run, including retries.
+	Attempts int `json:"attempts,omitempty"`
+	// Failed is true if the step failed after all retries and execution
+	// continued because the step has continueOnError set.
+	Failed bool `json:"failed,omitempty"`
 }
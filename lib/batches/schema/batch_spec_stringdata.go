@@ -237,6 +237,43 @@ const BatchSpecJSON = `{
                 }
               }
             }
+          },
+          "timeout": {
+            "type": "string",
+            "description": "The maximum duration a single run of the step may take, as a duration string such as \"30s\", \"10m\" or \"1h30m\". The step fails if it exceeds the timeout.",
+            "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+            "examples": ["10m", "1h30m"]
+          },
+          "retries": {
+            "type": "integer",
+            "description": "The number of times the step is retried if it fails or times out.",
+            "minimum": 0,
+            "maximum": 10,
+            "default": 0
+          },
+          "retryBackoff": {
+            "type": "string",
+            "description": "The duration to wait before the first retry of the step, as a duration string. The duration is doubled for every following retry.",
+            "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+            "default": "10s",
+            "examples": ["10s", "1m"]
+          },
+          "continueOnError": {
+            "type": "boolean",
+            "description": "Continue with the next step if this step fails or times out after all retries. The failure is recorded in the step result.",
+            "default": false
+          },
+          "cpus": {
+            "type": "number",
+            "description": "The number of CPUs the Docker container of the step can use. It can't exceed the limit the executor is configured with.",
+            "exclusiveMinimum": 0,
+            "examples": [0.5, 2]
+          },
+          "memory": {
+            "type": "string",
+            "description": "The maximum amount of memory the Docker container of the step can use, as a number of bytes with an optional unit of b, k, m or g. It can't exceed the limit the executor is configured with.",
+            "pattern": "^[0-9]+[bkmgBKMG]?$",
+            "examples": ["512m", "4g"]
           }
         }
       }
@@ -269,6 +306,11 @@ const BatchSpecJSON = `{
                 "type": "string",
                 "description": "Only apply this transformation in the repository with this name (as it is known to Sourcegraph).",
                 "examples": ["github.com/foo/bar"]
+              },
+              "dependsOn": {
+                "type": "string",
+                "description": "The branch of another group, or the branch of the changeset template, that the changeset of this group is stacked on. The changeset is based on that branch until the changeset it depends on is merged, and is then rebased onto the base branch.",
+                "minLength": 1
               }
             }
           }
@@ -355,6 +397,27 @@ const BatchSpecJSON = `{
             }
           }
         },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review from on the changeset. Each username can be a template. Only supported on GitHub, GitLab, Bitbucket Server / Bitbucket Data Center and Gerrit.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign the changeset to. Each username can be a template. Only supported on GitHub and GitLab.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset. Each label can be a template. Only supported on GitHub and GitLab.",
+          "items": {
+            "type": "string"
+          }
+        },
         "published": {
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.",
           "oneOf": [
@@ -397,6 +460,26 @@ const BatchSpecJSON = `{
           ]
         }
       }
+    },
+    "autoMerge": {
+      "type": "object",
+      "description": "An optional policy to automatically merge the published changesets of the batch change once their checks have passed and they have been approved.",
+      "additionalProperties": false,
+      "properties": {
+        "squash": {
+          "type": "boolean",
+          "description": "Whether to squash the commits of a changeset when merging it. Not supported on Gerrit, where the submit type of the project is used."
+        },
+        "maxPerHour": {
+          "type": "integer",
+          "description": "The maximum number of changesets of the batch change to merge per hour. If omitted, there is no limit.",
+          "minimum": 1
+        },
+        "respectRolloutWindows": {
+          "type": "boolean",
+          "description": "Whether changesets are only merged while a rollout window configured on the site allows changesets to be published."
+        }
+      }
     }
   }
 }
@@ -92,6 +92,26 @@ const ChangesetSpecJSON = `{
             }
           }
         },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review from on the changeset.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign the changeset to.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset.",
+          "items": { "type": "string" }
+        },
+        "dependsOn": {
+          "type": "string",
+          "description": "The head ref of the changeset in the same repository that this changeset is stacked on. The changeset is based on the branch of that changeset until it is merged.",
+          "examples": ["refs/heads/batch-changes-part-1"]
+        },
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
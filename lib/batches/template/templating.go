@@ -203,6 +203,8 @@ func (stepCtx *StepContext) ToFuncMap() template.FuncMap {
 			"renamed_files":  "",
 			"stdout":         "",
 			"stderr":         "",
+			"exit_code":      0,
+			"failed":         false,
 		}
 		if res == nil {
 			return m
@@ -214,6 +216,8 @@ func (stepCtx *StepContext) ToFuncMap() template.FuncMap {
 		m["renamed_files"] = res.ChangedFiles.Renamed
 		m["stdout"] = res.Stdout
 		m["stderr"] = res.Stderr
+		m["exit_code"] = res.ExitCode
+		m["failed"] = res.Failed
 
 		return m
 	}
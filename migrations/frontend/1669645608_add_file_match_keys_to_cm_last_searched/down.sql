@@ -0,0 +1,2 @@
+ALTER TABLE cm_last_searched
+    DROP COLUMN IF EXISTS file_match_keys;
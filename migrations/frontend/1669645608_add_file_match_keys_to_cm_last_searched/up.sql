@@ -0,0 +1,4 @@
+ALTER TABLE cm_last_searched
+    ADD COLUMN IF NOT EXISTS file_match_keys TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN cm_last_searched.file_match_keys IS 'The set of file match keys found by the previous run of a content code monitor. Matches not in this set are reported as new on the next run';
@@ -0,0 +1,4 @@
+ALTER TABLE changeset_specs
+    DROP COLUMN IF EXISTS reviewers,
+    DROP COLUMN IF EXISTS assignees,
+    DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS changeset_auto_merges (
+    changeset_id bigint NOT NULL PRIMARY KEY REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    blocked_reason text,
+    merge_enqueued_at timestamp with time zone,
+    evaluated_at timestamp with time zone NOT NULL DEFAULT now(),
+    check_state text,
+    review_state text
+);
+
+CREATE INDEX IF NOT EXISTS changeset_auto_merges_batch_change_id_merge_enqueued_at ON changeset_auto_merges(batch_change_id, merge_enqueued_at);
+
+COMMENT ON TABLE changeset_auto_merges IS 'The latest evaluation of the auto-merge policy of a batch change for one of its changesets.';
+
+COMMENT ON COLUMN changeset_auto_merges.blocked_reason IS 'Why the changeset was not merged when the policy was last evaluated. NULL if a merge was enqueued.';
+
+COMMENT ON COLUMN changeset_auto_merges.merge_enqueued_at IS 'When a merge of the changeset was last enqueued by the policy.';
+
+COMMENT ON COLUMN changeset_auto_merges.check_state IS 'The check state of the changeset when the policy was last evaluated.';
+
+COMMENT ON COLUMN changeset_auto_merges.review_state IS 'The review state of the changeset when the policy was last evaluated.';
@@ -1075,6 +1075,10 @@ CREATE TABLE changeset_specs (
     commit_author_name text,
     commit_author_email text,
     type text NOT NULL,
+    reviewers text[] DEFAULT '{}'::text[] NOT NULL,
+    assignees text[] DEFAULT '{}'::text[] NOT NULL,
+    labels text[] DEFAULT '{}'::text[] NOT NULL,
+    depends_on text,
     CONSTRAINT changeset_specs_published_valid_values CHECK (((published = 'true'::text) OR (published = 'false'::text) OR (published = '"draft"'::text) OR (published IS NULL)))
 );
 
@@ -1169,6 +1173,26 @@ CREATE VIEW branch_changeset_specs_and_changesets AS
      JOIN repo ON ((changeset_specs.repo_id = repo.id)))
   WHERE ((changeset_specs.external_id IS NULL) AND (repo.deleted_at IS NULL));
 
+CREATE TABLE changeset_auto_merges (
+    changeset_id bigint NOT NULL,
+    batch_change_id bigint NOT NULL,
+    blocked_reason text,
+    merge_enqueued_at timestamp with time zone,
+    evaluated_at timestamp with time zone DEFAULT now() NOT NULL,
+    check_state text,
+    review_state text
+);
+
+COMMENT ON TABLE changeset_auto_merges IS 'The latest evaluation of the auto-merge policy of a batch change for one of its changesets.';
+
+COMMENT ON COLUMN changeset_auto_merges.blocked_reason IS 'Why the changeset was not merged when the policy was last evaluated. NULL if a merge was enqueued.';
+
+COMMENT ON COLUMN changeset_auto_merges.merge_enqueued_at IS 'When a merge of the changeset was last enqueued by the policy.';
+
+COMMENT ON COLUMN changeset_auto_merges.check_state IS 'The check state of the changeset when the policy was last evaluated.';
+
+COMMENT ON COLUMN changeset_auto_merges.review_state IS 'The review state of the changeset when the policy was last evaluated.';
+
 CREATE TABLE changeset_events (
     id bigint NOT NULL,
     changeset_id bigint NOT NULL,
@@ -1320,13 +1344,16 @@ ALTER SEQUENCE cm_emails_id_seq OWNED BY cm_emails.id;
 CREATE TABLE cm_last_searched (
     monitor_id bigint NOT NULL,
     commit_oids text[] NOT NULL,
-    repo_id integer NOT NULL
+    repo_id integer NOT NULL,
+    file_match_keys text[] DEFAULT '{}'::text[] NOT NULL
 );
 
 COMMENT ON TABLE cm_last_searched IS 'The last searched commit hashes for the given code monitor and unique set of search arguments';
 
 COMMENT ON COLUMN cm_last_searched.commit_oids IS 'The set of commit OIDs that was previously successfully searched and should be excluded on the next run';
 
+COMMENT ON COLUMN cm_last_searched.file_match_keys IS 'The set of file match keys found by the previous run of a content code monitor. Matches not in this set are reported as new on the next run';
+
 CREATE TABLE cm_monitors (
     id bigint NOT NULL,
     created_by integer NOT NULL,
@@ -3925,6 +3952,9 @@ ALTER TABLE ONLY batch_spec_workspaces
 ALTER TABLE ONLY batch_specs
     ADD CONSTRAINT batch_specs_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY changeset_auto_merges
+    ADD CONSTRAINT changeset_auto_merges_pkey PRIMARY KEY (changeset_id);
+
 ALTER TABLE ONLY changeset_events
     ADD CONSTRAINT changeset_events_changeset_id_kind_key_unique UNIQUE (changeset_id, kind, key);
 
@@ -4288,6 +4318,8 @@ CREATE INDEX batch_specs_rand_id ON batch_specs USING btree (rand_id);
 
 CREATE UNIQUE INDEX batch_specs_unique_rand_id ON batch_specs USING btree (rand_id);
 
+CREATE INDEX changeset_auto_merges_batch_change_id_merge_enqueued_at ON changeset_auto_merges USING btree (batch_change_id, merge_enqueued_at);
+
 CREATE INDEX changeset_jobs_bulk_group_idx ON changeset_jobs USING btree (bulk_group);
 
 CREATE INDEX changeset_jobs_state_idx ON changeset_jobs USING btree (state);
@@ -4730,6 +4762,12 @@ ALTER TABLE ONLY batch_specs
 ALTER TABLE ONLY batch_specs
     ADD CONSTRAINT batch_specs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
 
+ALTER TABLE ONLY changeset_auto_merges
+    ADD CONSTRAINT changeset_auto_merges_batch_change_id_fkey FOREIGN KEY (batch_change_id) REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE;
+
+ALTER TABLE ONLY changeset_auto_merges
+    ADD CONSTRAINT changeset_auto_merges_changeset_id_fkey FOREIGN KEY (changeset_id) REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE;
+
 ALTER TABLE ONLY changeset_events
     ADD CONSTRAINT changeset_events_changeset_id_fkey FOREIGN KEY (changeset_id) REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE;
 
@@ -118,6 +118,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -139,6 +140,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -157,12 +161,24 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
+}
+
+// AutoMerge description: An optional policy to automatically merge the published changesets of the batch change once their checks have passed and they have been approved.
+type AutoMerge struct {
+	// MaxPerHour description: The maximum number of changesets of the batch change to merge per hour. If omitted, there is no limit.
+	MaxPerHour int `json:"maxPerHour,omitempty"`
+	// RespectRolloutWindows description: Whether changesets are only merged while a rollout window configured on the site allows changesets to be published.
+	RespectRolloutWindows bool `json:"respectRolloutWindows,omitempty"`
+	// Squash description: Whether to squash the commits of a changeset when merging it. Not supported on Gerrit, where the submit type of the project is used.
+	Squash bool `json:"squash,omitempty"`
 }
 
 type BackendInsight struct {
@@ -195,6 +211,8 @@ type BatchChangeRolloutWindow struct {
 
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
+	// AutoMerge description: An optional policy to automatically merge the published changesets of the batch change once their checks have passed and they have been approved.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the batch change.
@@ -380,6 +398,8 @@ type BitbucketServerUsernameIdentity struct {
 	Type string `json:"type"`
 }
 type BranchChangesetSpec struct {
+	// Assignees description: The usernames of the users to assign the changeset to.
+	Assignees []string `json:"assignees,omitempty"`
 	// BaseRef description: The full name of the Git ref in the base repository that this changeset is based on (and is proposing to be merged into). This ref must exist on the base repository.
 	BaseRef string `json:"baseRef"`
 	// BaseRepository description: The GraphQL ID of the repository that this changeset spec is proposing to change.
@@ -394,8 +414,12 @@ type BranchChangesetSpec struct {
 	HeadRef string `json:"headRef"`
 	// HeadRepository description: The GraphQL ID of the repository that contains the branch with this changeset's changes. Fork repositories and cross-repository changesets are not yet supported. Therefore, headRepository must be equal to baseRepository.
 	HeadRepository string `json:"headRepository"`
+	// Labels description: The labels to add to the changeset.
+	Labels []string `json:"labels,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users to request a review from on the changeset.
+	Reviewers []string `json:"reviewers,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 }
@@ -431,14 +455,20 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign the changeset to. Each username can be a template. Only supported on GitHub and GitLab.
+	Assignees []string `json:"assignees,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// Labels description: The labels to add to the changeset. Each label can be a template. Only supported on GitHub and GitLab.
+	Labels []string `json:"labels,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Reviewers description: The usernames of the users to request a review from on the changeset. Each username can be a template. Only supported on GitHub, GitLab, Bitbucket Server / Bitbucket Data Center and Gerrit.
+	Reviewers []string `json:"reviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -1131,6 +1161,51 @@ type JVMPackagesConnection struct {
 	Maven *Maven `json:"maven,omitempty"`
 }
 
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which authenticates users with their username and password against an LDAP directory such as Active Directory.
+type LDAPAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// BindDN description: The DN of the service account used to search for users and groups. If not set, the directory is searched anonymously.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the service account specified in `bindDN`.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
+	ConfigID    string `json:"configID,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// DisplayNameAttribute description: The attribute of the user entry containing the display name of the user.
+	DisplayNameAttribute string `json:"displayNameAttribute,omitempty"`
+	// EmailAttribute description: The attribute of the user entry containing the email address of the user. Email addresses from the directory are considered verified.
+	EmailAttribute string         `json:"emailAttribute,omitempty"`
+	GroupSync      *LDAPGroupSync `json:"groupSync,omitempty"`
+	// SearchBase description: The DN of the subtree that users are searched in.
+	SearchBase string `json:"searchBase"`
+	// SearchFilter description: The filter used to search for the user signing in. `{username}` is replaced with the username entered by the user. The filter must match exactly one entry. For Active Directory, use `(&(objectClass=user)(sAMAccountName={username}))`.
+	SearchFilter string `json:"searchFilter,omitempty"`
+	// StartTLS description: Upgrade the connection to an ldap:// URL to TLS with the StartTLS operation before sending any credentials.
+	StartTLS bool `json:"startTLS,omitempty"`
+	// TlsCACertificate description: A PEM-encoded CA certificate used to verify the certificate of the LDAP server. This is only necessary if the certificate is signed by an internal CA.
+	TlsCACertificate string `json:"tlsCACertificate,omitempty"`
+	// TlsInsecureSkipVerify description: Skip verifying the certificate of the LDAP server. This should only be used for testing, as it allows the credentials of users to be intercepted.
+	TlsInsecureSkipVerify bool   `json:"tlsInsecureSkipVerify,omitempty"`
+	Type                  string `json:"type"`
+	// Url description: The URL of the LDAP server. Use the ldaps:// scheme to connect with TLS, or set `startTLS` to upgrade an ldap:// connection to TLS.
+	Url string `json:"url"`
+	// UsernameAttribute description: The attribute of the user entry that is normalized into the Sourcegraph username. For Active Directory, use `sAMAccountName`.
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
+// LDAPGroupSync description: Syncs the membership of LDAP groups to Sourcegraph organizations whenever users sign in.
+type LDAPGroupSync struct {
+	// NameAttribute description: The attribute of the group entries that is matched against the group names in `orgs`.
+	NameAttribute string `json:"nameAttribute,omitempty"`
+	// Orgs description: Maps the names of LDAP groups to the names of the Sourcegraph organizations that their members are added to. Users are removed from these organizations when they are no longer members of the mapped groups. Organizations that are not listed are not changed.
+	Orgs map[string]string `json:"orgs"`
+	// SearchBase description: The DN of the subtree that groups are searched in. Defaults to the `searchBase` of the provider.
+	SearchBase string `json:"searchBase,omitempty"`
+	// SearchFilter description: The filter used to search for the groups of the user signing in. `{dn}` is replaced with the DN of the user entry, and `{username}` with the value of the `usernameAttribute` of the user entry.
+	SearchFilter string `json:"searchFilter,omitempty"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
 	// AuditLog description: EXPERIMENTAL: Configuration for audit logging (specially formatted log entries for tracking sensitive events)
@@ -2228,6 +2303,8 @@ type SiteConfiguration struct {
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
 	// RepoPurgeWorker description: Configuration for repository purge worker.
 	RepoPurgeWorker *RepoPurgeWorker `json:"repoPurgeWorker,omitempty"`
+	// ScimAuthToken description: The bearer token that identity providers must send to provision users and groups via the SCIM 2.0 API at /.api/scim/v2. SCIM provisioning is disabled if unset.
+	ScimAuthToken string `json:"scim.authToken,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If : unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
@@ -2262,18 +2339,30 @@ type SrcCliVersionCache struct {
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
 	Container string `json:"container"`
+	// ContinueOnError description: Continue with the next step if this step fails or times out after all retries. The failure is recorded in the step result.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// Cpus description: The number of CPUs the Docker container of the step can use. It can't exceed the limit the executor is configured with.
+	Cpus float64 `json:"cpus,omitempty"`
 	// Env description: Environment variables to set in the step environment.
 	Env interface{} `json:"env,omitempty"`
 	// Files description: Files that should be mounted into or be created inside the Docker container.
 	Files map[string]string `json:"files,omitempty"`
 	// If description: A condition to check before executing steps. Supports templating. The value 'true' is interpreted as true.
 	If interface{} `json:"if,omitempty"`
+	// Memory description: The maximum amount of memory the Docker container of the step can use, as a number of bytes with an optional unit of b, k, m or g. It can't exceed the limit the executor is configured with.
+	Memory string `json:"memory,omitempty"`
 	// Mount description: Files that are mounted to the Docker container.
 	Mount []*Mount `json:"mount,omitempty"`
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
+	// Retries description: The number of times the step is retried if it fails or times out.
+	Retries int `json:"retries,omitempty"`
+	// RetryBackoff description: The duration to wait before the first retry of the step, as a duration string. The duration is doubled for every following retry.
+	RetryBackoff string `json:"retryBackoff,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
 	Run string `json:"run"`
+	// Timeout description: The maximum duration a single run of the step may take, as a duration string such as "30s", "10m" or "1h30m". The step fails if it exceeds the timeout.
+	Timeout string `json:"timeout,omitempty"`
 }
 type SubRepoPermissions struct {
 	// Enabled description: Enables sub-repo permission checking
@@ -2325,6 +2414,8 @@ type TransformChanges struct {
 type TransformChangesGroup struct {
 	// Branch description: The branch on the repository to propose changes to. If unset, the repository's default branch is used.
 	Branch string `json:"branch"`
+	// DependsOn description: The branch of another group, or the branch of the changeset template, that the changeset of this group is stacked on. The changeset is based on that branch until the changeset it depends on is merged, and is then rebased onto the base branch.
+	DependsOn string `json:"dependsOn,omitempty"`
 	// Directory description: The directory path (relative to the repository root) of the changes to include in this group.
 	Directory string `json:"directory"`
 	// Repository description: Only apply this transformation in the repository with this name (as it is known to Sourcegraph).